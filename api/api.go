@@ -23,8 +23,20 @@ type handler struct {
 func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	handler := &handler{store, pool, router}
 
-	sr := router.PathPrefix("/v1").Subrouter()
 	middleware := newMiddleware(store)
+
+	// The offline mode of the user interface replays the changes of entries with the session of the user,
+	// the session cookie is accepted only by these routes.
+	offlineRouter := router.PathPrefix("/v1").Subrouter()
+	offlineRouter.Use(middleware.handleCORS)
+	offlineRouter.Use(middleware.sessionAuth)
+	offlineRouter.Use(middleware.apiKeyAuth)
+	offlineRouter.Use(middleware.basicAuth)
+	offlineRouter.Methods(http.MethodOptions)
+	offlineRouter.HandleFunc("/entries", handler.setEntryStatus).Methods(http.MethodPut)
+	offlineRouter.HandleFunc("/entries/{entryID}/progress", handler.setEntryReadingProgress).Methods(http.MethodPut)
+
+	sr := router.PathPrefix("/v1").Subrouter()
 	sr.Use(middleware.handleCORS)
 	sr.Use(middleware.apiKeyAuth)
 	sr.Use(middleware.basicAuth)
@@ -51,6 +63,7 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	sr.HandleFunc("/feeds", handler.getFeeds).Methods(http.MethodGet)
 	sr.HandleFunc("/feeds/counters", handler.fetchCounters).Methods(http.MethodGet)
 	sr.HandleFunc("/feeds/refresh", handler.refreshAllFeeds).Methods(http.MethodPut)
+	sr.HandleFunc("/feeds/batch", handler.updateFeedsBatch).Methods(http.MethodPut)
 	sr.HandleFunc("/feeds/{feedID}/refresh", handler.refreshFeed).Methods(http.MethodPut)
 	sr.HandleFunc("/feeds/{feedID}", handler.getFeed).Methods(http.MethodGet)
 	sr.HandleFunc("/feeds/{feedID}", handler.updateFeed).Methods(http.MethodPut)
@@ -59,11 +72,17 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	sr.HandleFunc("/feeds/{feedID}/mark-all-as-read", handler.markFeedAsRead).Methods(http.MethodPut)
 	sr.HandleFunc("/export", handler.exportFeeds).Methods(http.MethodGet)
 	sr.HandleFunc("/import", handler.importFeeds).Methods(http.MethodPost)
+	sr.HandleFunc("/export/epub", handler.exportEPUB).Methods(http.MethodGet)
 	sr.HandleFunc("/feeds/{feedID}/entries", handler.getFeedEntries).Methods(http.MethodGet)
 	sr.HandleFunc("/feeds/{feedID}/entries/{entryID}", handler.getFeedEntry).Methods(http.MethodGet)
 	sr.HandleFunc("/entries", handler.getEntries).Methods(http.MethodGet)
-	sr.HandleFunc("/entries", handler.setEntryStatus).Methods(http.MethodPut)
+	sr.HandleFunc("/entries/batch", handler.updateEntriesBatch).Methods(http.MethodPut)
 	sr.HandleFunc("/entries/{entryID}", handler.getEntry).Methods(http.MethodGet)
+	sr.HandleFunc("/clusters", handler.getEntryClusters).Methods(http.MethodGet)
+	sr.HandleFunc("/clusters/{clusterID}/mark-all-as-read", handler.markClusterAsRead).Methods(http.MethodPut)
 	sr.HandleFunc("/entries/{entryID}/bookmark", handler.toggleBookmark).Methods(http.MethodPut)
 	sr.HandleFunc("/entries/{entryID}/fetch-content", handler.fetchContent).Methods(http.MethodGet)
+	sr.HandleFunc("/entries/{entryID}/deliveries", handler.getEntryIntegrationDeliveries).Methods(http.MethodGet)
+	sr.HandleFunc("/integrations/deliveries", handler.getIntegrationDeliveries).Methods(http.MethodGet)
+	sr.HandleFunc("/audit-logs", handler.getAuditLogs).Methods(http.MethodGet)
 }
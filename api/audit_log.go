@@ -0,0 +1,69 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package api // import "miniflux.app/api"
+
+import (
+	"net/http"
+	"time"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/json"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+	"miniflux.app/validator"
+)
+
+// apiKeyUseAuditInterval avoids one audit log entry per API request, only the first use in this interval is recorded.
+const apiKeyUseAuditInterval = time.Hour
+
+func (h *handler) getAuditLogs(w http.ResponseWriter, r *http.Request) {
+	if !request.HasPermission(r, model.PermissionManageServer) {
+		json.Forbidden(w, r)
+		return
+	}
+
+	filter := &model.AuditLogFilter{
+		UserID:   request.QueryInt64Param(r, "user_id", 0),
+		Username: request.QueryStringParam(r, "username", ""),
+		Source:   request.QueryStringParam(r, "source", ""),
+		Action:   request.QueryStringParam(r, "action", ""),
+		Offset:   request.QueryIntParam(r, "offset", 0),
+		Limit:    request.QueryIntParam(r, "limit", 100),
+	}
+
+	if err := validator.ValidateAuditLogFilter(filter); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	total, err := h.store.CountAuditLogs(filter)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	auditLogs, err := h.store.AuditLogs(filter)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	auditLogs.UseTimezone(request.UserTimezone(r))
+	json.OK(w, r, &auditLogsResponse{Total: total, AuditLogs: auditLogs})
+}
+
+// recordAuditLog adds an entry to the audit log, a failure is logged but does not interrupt the request.
+func recordAuditLog(store *storage.Storage, r *http.Request, action string, userID int64, username, target, details string) {
+	auditLog := model.NewAuditLog(model.AuditSourceAPI, action, userID, target)
+	auditLog.Username = username
+	auditLog.Details = details
+	auditLog.IP = request.ClientIP(r)
+	auditLog.UserAgent = r.UserAgent()
+
+	if err := store.CreateAuditLog(auditLog); err != nil {
+		logger.Error("[API:AuditLog] %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package api // import "miniflux.app/api"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+
+	"miniflux.app/batch"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/json"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func (h *handler) updateFeedsBatch(w http.ResponseWriter, r *http.Request) {
+	var feedBatchRequest model.FeedBatchRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&feedBatchRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if !request.HasPermission(r, model.PermissionManageSubscriptions) {
+		json.Forbidden(w, r)
+		return
+	}
+
+	userID := request.UserID(r)
+	if validationErr := validator.ValidateFeedBatchRequest(h.store, userID, &feedBatchRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	if err := batch.ApplyFeedAction(h.store, h.pool, userID, &feedBatchRequest); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
+func (h *handler) updateEntriesBatch(w http.ResponseWriter, r *http.Request) {
+	var entryBatchRequest model.EntryBatchRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&entryBatchRequest); err != nil {
+		json.BadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateEntryBatchRequest(&entryBatchRequest); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	if err := batch.ApplyEntryAction(h.store, request.UserID(r), &entryBatchRequest); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
@@ -16,6 +16,11 @@ import (
 )
 
 func (h *handler) createCategory(w http.ResponseWriter, r *http.Request) {
+	if !request.HasPermission(r, model.PermissionManageSubscriptions) {
+		json.Forbidden(w, r)
+		return
+	}
+
 	userID := request.UserID(r)
 
 	var categoryRequest model.CategoryRequest
@@ -39,6 +44,11 @@ func (h *handler) createCategory(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handler) updateCategory(w http.ResponseWriter, r *http.Request) {
+	if !request.HasPermission(r, model.PermissionManageSubscriptions) {
+		json.Forbidden(w, r)
+		return
+	}
+
 	userID := request.UserID(r)
 	categoryID := request.RouteInt64Param(r, "categoryID")
 
@@ -108,6 +118,11 @@ func (h *handler) getCategories(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handler) removeCategory(w http.ResponseWriter, r *http.Request) {
+	if !request.HasPermission(r, model.PermissionManageSubscriptions) {
+		json.Forbidden(w, r)
+		return
+	}
+
 	userID := request.UserID(r)
 	categoryID := request.RouteInt64Param(r, "categoryID")
 
@@ -125,6 +140,11 @@ func (h *handler) removeCategory(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handler) refreshCategory(w http.ResponseWriter, r *http.Request) {
+	if !request.HasPermission(r, model.PermissionManageSubscriptions) {
+		json.Forbidden(w, r)
+		return
+	}
+
 	userID := request.UserID(r)
 	categoryID := request.RouteInt64Param(r, "categoryID")
 
@@ -92,44 +92,125 @@ func (h *handler) getEntries(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int64, categoryID int64) {
+	builder := h.entryQueryBuilderFromRequest(w, r, feedID, categoryID)
+	if builder == nil {
+		return
+	}
+
+	entries, count, err := h.fetchEntries(r, builder)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, &entriesResponse{Total: count, Entries: entries})
+}
+
+func (h *handler) getEntryClusters(w http.ResponseWriter, r *http.Request) {
+	builder := h.entryQueryBuilderFromRequest(w, r, 0, 0)
+	if builder == nil {
+		return
+	}
+
+	entries, err := builder.GetStories()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	count, err := builder.CountStories()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	for i := range entries {
+		entries[i].Content = proxy.AbsoluteImageProxyRewriter(h.router, r.Host, entries[i].Content)
+	}
+
+	json.OK(w, r, &entryClustersResponse{Total: count, Clusters: model.GroupEntriesByCluster(entries)})
+}
+
+func (h *handler) fetchEntries(r *http.Request, builder *storage.EntryQueryBuilder) (model.Entries, int, error) {
+	entries, err := builder.GetEntries()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := builder.CountEntries()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range entries {
+		entries[i].Content = proxy.AbsoluteImageProxyRewriter(h.router, r.Host, entries[i].Content)
+	}
+
+	return entries, count, nil
+}
+
+func (h *handler) markClusterAsRead(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	clusterID := request.RouteInt64Param(r, "clusterID")
+
+	if !h.store.ClusterExists(userID, clusterID) {
+		json.NotFound(w, r)
+		return
+	}
+
+	if _, err := h.store.MarkClusterAsRead(userID, clusterID); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
+}
+
+// entryQueryBuilderFromRequest returns the builder matching the query parameters, or nil after sending an error.
+func (h *handler) entryQueryBuilderFromRequest(w http.ResponseWriter, r *http.Request, feedID int64, categoryID int64) *storage.EntryQueryBuilder {
 	statuses := request.QueryStringParamList(r, "status")
 	for _, status := range statuses {
 		if err := validator.ValidateEntryStatus(status); err != nil {
 			json.BadRequest(w, r, err)
-			return
+			return nil
 		}
 	}
 
 	order := request.QueryStringParam(r, "order", model.DefaultSortingOrder)
 	if err := validator.ValidateEntryOrder(order); err != nil {
 		json.BadRequest(w, r, err)
-		return
+		return nil
 	}
 
 	direction := request.QueryStringParam(r, "direction", model.DefaultSortingDirection)
 	if err := validator.ValidateDirection(direction); err != nil {
 		json.BadRequest(w, r, err)
-		return
+		return nil
 	}
 
 	limit := request.QueryIntParam(r, "limit", 100)
 	offset := request.QueryIntParam(r, "offset", 0)
 	if err := validator.ValidateRange(offset, limit); err != nil {
 		json.BadRequest(w, r, err)
-		return
+		return nil
+	}
+
+	if validationErr := validator.ValidateSearchQuery(request.QueryStringParam(r, "search", "")); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return nil
 	}
 
 	userID := request.UserID(r)
 	categoryID = request.QueryInt64Param(r, "category_id", categoryID)
 	if categoryID > 0 && !h.store.CategoryIDExists(userID, categoryID) {
 		json.BadRequest(w, r, errors.New("Invalid category ID"))
-		return
+		return nil
 	}
 
 	feedID = request.QueryInt64Param(r, "feed_id", feedID)
 	if feedID > 0 && !h.store.FeedExists(userID, feedID) {
 		json.BadRequest(w, r, errors.New("Invalid feed ID"))
-		return
+		return nil
 	}
 
 	builder := h.store.NewEntryQueryBuilder(userID)
@@ -142,38 +223,78 @@ func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int
 	builder.WithLimit(limit)
 	configureFilters(builder, r)
 
-	entries, err := builder.GetEntries()
-	if err != nil {
-		json.ServerError(w, r, err)
+	return builder
+}
+
+func (h *handler) setEntryStatus(w http.ResponseWriter, r *http.Request) {
+	var entriesStatusUpdateRequest model.EntriesStatusUpdateRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&entriesStatusUpdateRequest); err != nil {
+		json.BadRequest(w, r, err)
 		return
 	}
 
-	count, err := builder.CountEntries()
-	if err != nil {
-		json.ServerError(w, r, err)
+	if err := validator.ValidateEntriesUpdateRequest(&entriesStatusUpdateRequest); err != nil {
+		json.BadRequest(w, r, err)
 		return
 	}
 
-	for i := range entries {
-		entries[i].Content = proxy.AbsoluteImageProxyRewriter(h.router, r.Host, entries[i].Content)
+	// Offline clients replay their changes later, the server wins when the entry was modified after the change.
+	if entriesStatusUpdateRequest.Starred != nil || entriesStatusUpdateRequest.ChangedAt != nil {
+		_, err := h.store.UpdateEntriesState(
+			request.UserID(r),
+			entriesStatusUpdateRequest.EntryIDs,
+			entriesStatusUpdateRequest.Status,
+			entriesStatusUpdateRequest.Starred,
+			entriesStatusUpdateRequest.ChangedAt,
+		)
+		if err != nil {
+			json.ServerError(w, r, err)
+			return
+		}
+
+		json.NoContent(w, r)
+		return
 	}
 
-	json.OK(w, r, &entriesResponse{Total: count, Entries: entries})
+	if err := h.store.SetEntriesStatus(request.UserID(r), entriesStatusUpdateRequest.EntryIDs, entriesStatusUpdateRequest.Status); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.NoContent(w, r)
 }
 
-func (h *handler) setEntryStatus(w http.ResponseWriter, r *http.Request) {
-	var entriesStatusUpdateRequest model.EntriesStatusUpdateRequest
-	if err := json_parser.NewDecoder(r.Body).Decode(&entriesStatusUpdateRequest); err != nil {
+func (h *handler) setEntryReadingProgress(w http.ResponseWriter, r *http.Request) {
+	var progressRequest model.EntryReadingProgressRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&progressRequest); err != nil {
 		json.BadRequest(w, r, err)
 		return
 	}
 
-	if err := validator.ValidateEntriesStatusUpdateRequest(&entriesStatusUpdateRequest); err != nil {
+	if err := validator.ValidateEntryReadingProgressRequest(&progressRequest); err != nil {
 		json.BadRequest(w, r, err)
 		return
 	}
 
-	if err := h.store.SetEntriesStatus(request.UserID(r), entriesStatusUpdateRequest.EntryIDs, entriesStatusUpdateRequest.Status); err != nil {
+	loggedUserID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+
+	builder := h.store.NewEntryQueryBuilder(loggedUserID)
+	builder.WithEntryID(entryID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+
+	entry, err := builder.GetEntry()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	if err := h.store.SetEntryReadingProgress(loggedUserID, entryID, progressRequest.ReadingProgress, progressRequest.ScrollPosition); err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
@@ -275,8 +396,19 @@ func configureFilters(builder *storage.EntryQueryBuilder, r *http.Request) {
 		}
 	}
 
+	if request.HasQueryParam(r, "in_progress") {
+		inProgress, err := strconv.ParseBool(r.URL.Query().Get("in_progress"))
+		if err == nil && inProgress {
+			builder.WithReadingInProgress()
+		}
+	}
+
 	searchQuery := request.QueryStringParam(r, "search", "")
 	if searchQuery != "" {
 		builder.WithSearchQuery(searchQuery)
 	}
+	author := request.QueryStringParam(r, "author", "")
+	if author != "" {
+		builder.WithAuthor(author)
+	}
 }
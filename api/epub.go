@@ -0,0 +1,64 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package api // import "miniflux.app/api"
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+
+	"miniflux.app/epub"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response"
+	"miniflux.app/http/response/json"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func (h *handler) exportEPUB(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	if user == nil {
+		json.NotFound(w, r)
+		return
+	}
+
+	export := model.NewEPUBExport(user.ID, request.QueryStringParam(r, "title", "Miniflux"), request.QueryStringParam(r, "kind", ""))
+	switch export.Kind {
+	case model.EPUBExportKindCategory:
+		categoryID := request.QueryInt64Param(r, "category_id", 0)
+		export.CategoryID = &categoryID
+	case model.EPUBExportKindSearch:
+		export.SearchQuery = request.QueryStringParam(r, "search", "")
+	}
+
+	if validationErr := validator.ValidateEPUBExportSelection(h.store, user.ID, export); validationErr != nil {
+		json.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	entries, err := epub.Entries(h.store, export)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	var buffer bytes.Buffer
+	if err := epub.Write(&buffer, user, export, entries, epub.FetchImage); err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	response.New(w, r).
+		WithHeader("Content-Type", epub.ContentType).
+		WithAttachment(epub.Filename(export, time.Now())).
+		WithBody(buffer.Bytes()).
+		WithoutCompression().
+		Write()
+}
@@ -17,6 +17,11 @@ import (
 )
 
 func (h *handler) createFeed(w http.ResponseWriter, r *http.Request) {
+	if !request.HasPermission(r, model.PermissionManageSubscriptions) {
+		json.Forbidden(w, r)
+		return
+	}
+
 	userID := request.UserID(r)
 
 	var feedCreationRequest model.FeedCreationRequest
@@ -40,6 +45,11 @@ func (h *handler) createFeed(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handler) refreshFeed(w http.ResponseWriter, r *http.Request) {
+	if !request.HasPermission(r, model.PermissionManageSubscriptions) {
+		json.Forbidden(w, r)
+		return
+	}
+
 	feedID := request.RouteInt64Param(r, "feedID")
 	userID := request.UserID(r)
 
@@ -58,6 +68,11 @@ func (h *handler) refreshFeed(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handler) refreshAllFeeds(w http.ResponseWriter, r *http.Request) {
+	if !request.HasPermission(r, model.PermissionManageSubscriptions) {
+		json.Forbidden(w, r)
+		return
+	}
+
 	userID := request.UserID(r)
 	jobs, err := h.store.NewUserBatch(userID, h.store.CountFeeds(userID))
 	if err != nil {
@@ -73,6 +88,11 @@ func (h *handler) refreshAllFeeds(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
+	if !request.HasPermission(r, model.PermissionManageSubscriptions) {
+		json.Forbidden(w, r)
+		return
+	}
+
 	var feedModificationRequest model.FeedModificationRequest
 	if err := json_parser.NewDecoder(r.Body).Decode(&feedModificationRequest); err != nil {
 		json.BadRequest(w, r, err)
@@ -197,6 +217,11 @@ func (h *handler) getFeed(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handler) removeFeed(w http.ResponseWriter, r *http.Request) {
+	if !request.HasPermission(r, model.PermissionManageSubscriptions) {
+		json.Forbidden(w, r)
+		return
+	}
+
 	feedID := request.RouteInt64Param(r, "feedID")
 	userID := request.UserID(r)
 
@@ -7,10 +7,14 @@ package api // import "miniflux.app/api"
 import (
 	"context"
 	"net/http"
+	"time"
 
+	"miniflux.app/config"
+	"miniflux.app/http/cookie"
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/json"
 	"miniflux.app/logger"
+	"miniflux.app/model"
 	"miniflux.app/storage"
 )
 
@@ -35,6 +39,66 @@ func (m *middleware) handleCORS(next http.Handler) http.Handler {
 	})
 }
 
+// sessionAuth authenticates the requests sent by the web app with the session cookies, like the replay of offline changes.
+// The CSRF token must be sent in a header: browsers never add it to requests coming from other websites.
+func (m *middleware) sessionAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientIP := request.ClientIP(r)
+		csrfToken := r.Header.Get("X-Csrf-Token")
+		userSessionToken := request.CookieValue(r, cookie.CookieUserSessionID)
+		appSessionID := request.CookieValue(r, cookie.CookieAppSessionID)
+
+		if csrfToken == "" || userSessionToken == "" || appSessionID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		appSession, err := m.store.AppSession(appSessionID)
+		if err != nil || appSession.Data.CSRF != csrfToken {
+			logger.Error("[API][SessionAuth] [ClientIP=%s] Invalid or missing CSRF token", clientIP)
+			json.Unauthorized(w, r)
+			return
+		}
+
+		userSession, err := m.store.UserSessionByToken(userSessionToken)
+		if err != nil {
+			logger.Error("[API][SessionAuth] %v", err)
+			json.ServerError(w, r, err)
+			return
+		}
+
+		if userSession == nil || userSession.IsTwoFactorEnrollmentRequired(config.Opts.IsTwoFactorRequired()) {
+			logger.Error("[API][SessionAuth] [ClientIP=%s] No valid user session found", clientIP)
+			json.Unauthorized(w, r)
+			return
+		}
+
+		user, err := m.store.UserByID(userSession.UserID)
+		if err != nil {
+			logger.Error("[API][SessionAuth] %v", err)
+			json.ServerError(w, r, err)
+			return
+		}
+
+		if user == nil || !user.IsActive() {
+			logger.Error("[API][SessionAuth] [ClientIP=%s] User not found or inactive: #%d", clientIP, userSession.UserID)
+			json.Unauthorized(w, r)
+			return
+		}
+
+		logger.Debug("[API][SessionAuth] [ClientIP=%s] User authenticated: %s", clientIP, user.Username)
+
+		ctx := r.Context()
+		ctx = context.WithValue(ctx, request.UserIDContextKey, user.ID)
+		ctx = context.WithValue(ctx, request.UserTimezoneContextKey, user.Timezone)
+		ctx = context.WithValue(ctx, request.IsAdminUserContextKey, user.IsAdmin)
+		ctx = context.WithValue(ctx, request.UserRoleContextKey, user.Role)
+		ctx = context.WithValue(ctx, request.IsAuthenticatedContextKey, true)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 func (m *middleware) apiKeyAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		clientIP := request.ClientIP(r)
@@ -55,11 +119,19 @@ func (m *middleware) apiKeyAuth(next http.Handler) http.Handler {
 
 		if user == nil {
 			logger.Error("[API][TokenAuth] [ClientIP=%s] No user found with the given API key", clientIP)
+			recordAuditLog(m.store, r, model.AuditActionLoginFailed, 0, "", "", "api_key")
+			json.Unauthorized(w, r)
+			return
+		}
+
+		if !user.IsActive() {
+			logger.Error("[API][TokenAuth] [ClientIP=%s] The account %q is %s", clientIP, user.Username, user.Status)
 			json.Unauthorized(w, r)
 			return
 		}
 
 		logger.Info("[API][TokenAuth] [ClientIP=%s] User authenticated: %s", clientIP, user.Username)
+		m.recordAPIKeyUse(r, user, token)
 		m.store.SetLastLogin(user.ID)
 		m.store.SetAPIKeyUsedTimestamp(user.ID, token)
 
@@ -67,6 +139,7 @@ func (m *middleware) apiKeyAuth(next http.Handler) http.Handler {
 		ctx = context.WithValue(ctx, request.UserIDContextKey, user.ID)
 		ctx = context.WithValue(ctx, request.UserTimezoneContextKey, user.Timezone)
 		ctx = context.WithValue(ctx, request.IsAdminUserContextKey, user.IsAdmin)
+		ctx = context.WithValue(ctx, request.UserRoleContextKey, user.Role)
 		ctx = context.WithValue(ctx, request.IsAuthenticatedContextKey, true)
 
 		next.ServeHTTP(w, r.WithContext(ctx))
@@ -96,10 +169,14 @@ func (m *middleware) basicAuth(next http.Handler) http.Handler {
 			return
 		}
 
-		if err := m.store.CheckPassword(username, password); err != nil {
-			logger.Error("[API][BasicAuth] [ClientIP=%s] Invalid username or password: %s", clientIP, username)
-			json.Unauthorized(w, r)
-			return
+		// The account password is not enough once two-factor authentication is enabled, an app password must be used instead.
+		if err := m.store.CheckPassword(username, password); err != nil || m.store.HasTwoFactorEnabled(username) {
+			if !m.store.CheckAppPassword(username, password) {
+				logger.Error("[API][BasicAuth] [ClientIP=%s] Invalid username or password: %s", clientIP, username)
+				recordAuditLog(m.store, r, model.AuditActionLoginFailed, 0, username, "", "basic_auth")
+				json.Unauthorized(w, r)
+				return
+			}
 		}
 
 		user, err := m.store.UserByUsername(username)
@@ -109,8 +186,8 @@ func (m *middleware) basicAuth(next http.Handler) http.Handler {
 			return
 		}
 
-		if user == nil {
-			logger.Error("[API][BasicAuth] [ClientIP=%s] User not found: %s", clientIP, username)
+		if user == nil || !user.IsActive() {
+			logger.Error("[API][BasicAuth] [ClientIP=%s] User not found or inactive: %s", clientIP, username)
 			json.Unauthorized(w, r)
 			return
 		}
@@ -122,8 +199,24 @@ func (m *middleware) basicAuth(next http.Handler) http.Handler {
 		ctx = context.WithValue(ctx, request.UserIDContextKey, user.ID)
 		ctx = context.WithValue(ctx, request.UserTimezoneContextKey, user.Timezone)
 		ctx = context.WithValue(ctx, request.IsAdminUserContextKey, user.IsAdmin)
+		ctx = context.WithValue(ctx, request.UserRoleContextKey, user.Role)
 		ctx = context.WithValue(ctx, request.IsAuthenticatedContextKey, true)
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// recordAPIKeyUse adds the use of an API key to the audit log when it was not used recently.
+func (m *middleware) recordAPIKeyUse(r *http.Request, user *model.User, token string) {
+	apiKey, err := m.store.APIKeyByToken(user.ID, token)
+	if err != nil {
+		logger.Error("[API][TokenAuth] %v", err)
+		return
+	}
+
+	if apiKey == nil || (apiKey.LastUsedAt != nil && time.Since(*apiKey.LastUsedAt) < apiKeyUseAuditInterval) {
+		return
+	}
+
+	recordAuditLog(m.store, r, model.AuditActionAPIKeyUse, user.ID, "", "api_key:"+apiKey.Description, "")
+}
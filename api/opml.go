@@ -5,11 +5,13 @@
 package api // import "miniflux.app/api"
 
 import (
+	"fmt"
 	"net/http"
 
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/json"
 	"miniflux.app/http/response/xml"
+	"miniflux.app/model"
 	"miniflux.app/reader/opml"
 )
 
@@ -25,13 +27,26 @@ func (h *handler) exportFeeds(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handler) importFeeds(w http.ResponseWriter, r *http.Request) {
+	if !request.HasPermission(r, model.PermissionManageSubscriptions) {
+		json.Forbidden(w, r)
+		return
+	}
+
 	opmlHandler := opml.NewHandler(h.store)
-	err := opmlHandler.Import(request.UserID(r), r.Body)
+	report, err := opmlHandler.Import(request.UserID(r), r.Body)
 	defer r.Body.Close()
 	if err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
 
-	json.Created(w, r, map[string]string{"message": "Feeds imported successfully"})
+	recordAuditLog(h.store, r, model.AuditActionOPMLImport, request.UserID(r), "", "", fmt.Sprintf("created=%d duplicates=%d failures=%d", report.Count(opml.ImportStatusCreated), report.Count(opml.ImportStatusDuplicate), report.Count(opml.ImportStatusFailed)))
+
+	json.Created(w, r, &importResponse{
+		Message:    "Feeds imported successfully",
+		Created:    report.Count(opml.ImportStatusCreated),
+		Duplicates: report.Count(opml.ImportStatusDuplicate),
+		Failed:     report.Count(opml.ImportStatusFailed),
+		Results:    report,
+	})
 }
@@ -6,6 +6,7 @@ package api // import "miniflux.app/api"
 
 import (
 	"miniflux.app/model"
+	"miniflux.app/reader/opml"
 )
 
 type feedIconResponse struct {
@@ -19,6 +20,24 @@ type entriesResponse struct {
 	Entries model.Entries `json:"entries"`
 }
 
+type entryClustersResponse struct {
+	Total    int                 `json:"total"`
+	Clusters model.EntryClusters `json:"clusters"`
+}
+
+type auditLogsResponse struct {
+	Total     int             `json:"total"`
+	AuditLogs model.AuditLogs `json:"audit_logs"`
+}
+
 type feedCreationResponse struct {
 	FeedID int64 `json:"feed_id"`
 }
+
+type importResponse struct {
+	Message    string            `json:"message"`
+	Created    int               `json:"created"`
+	Duplicates int               `json:"duplicates"`
+	Failed     int               `json:"failed"`
+	Results    opml.ImportReport `json:"results"`
+}
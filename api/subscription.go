@@ -8,6 +8,7 @@ import (
 	json_parser "encoding/json"
 	"net/http"
 
+	"miniflux.app/http/request"
 	"miniflux.app/http/response/json"
 	"miniflux.app/model"
 	"miniflux.app/reader/subscription"
@@ -15,6 +16,11 @@ import (
 )
 
 func (h *handler) discoverSubscriptions(w http.ResponseWriter, r *http.Request) {
+	if !request.HasPermission(r, model.PermissionManageSubscriptions) {
+		json.Forbidden(w, r)
+		return
+	}
+
 	var subscriptionDiscoveryRequest model.SubscriptionDiscoveryRequest
 	if err := json_parser.NewDecoder(r.Body).Decode(&subscriptionDiscoveryRequest); err != nil {
 		json.BadRequest(w, r, err)
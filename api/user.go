@@ -7,6 +7,7 @@ package api // import "miniflux.app/api"
 import (
 	json_parser "encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"miniflux.app/http/request"
@@ -26,7 +27,7 @@ func (h *handler) currentUser(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handler) createUser(w http.ResponseWriter, r *http.Request) {
-	if !request.IsAdminUser(r) {
+	if !request.HasPermission(r, model.PermissionManageUsers) {
 		json.Forbidden(w, r)
 		return
 	}
@@ -42,12 +43,19 @@ func (h *handler) createUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !model.CanAssignRole(request.UserRole(r), userCreationRequest.UserRole()) {
+		json.Forbidden(w, r)
+		return
+	}
+
 	user, err := h.store.CreateUser(&userCreationRequest)
 	if err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
 
+	recordAuditLog(h.store, r, model.AuditActionUserCreate, request.UserID(r), "", "user:"+user.Username, "role="+user.Role)
+
 	json.Created(w, r, user)
 }
 
@@ -71,16 +79,14 @@ func (h *handler) updateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !request.IsAdminUser(r) {
-		if originalUser.ID != request.UserID(r) {
-			json.Forbidden(w, r)
-			return
-		}
+	if originalUser.ID != request.UserID(r) && !model.CanAssignRole(request.UserRole(r), originalUser.Role) {
+		json.Forbidden(w, r)
+		return
+	}
 
-		if userModificationRequest.IsAdmin != nil && *userModificationRequest.IsAdmin {
-			json.BadRequest(w, r, errors.New("Only administrators can change permissions of standard users"))
-			return
-		}
+	if userModificationRequest.ChangesRetentionCaps() && !request.HasPermission(r, model.PermissionManageUsers) {
+		json.Forbidden(w, r)
+		return
 	}
 
 	if validationErr := validator.ValidateUserModification(h.store, originalUser.ID, &userModificationRequest); validationErr != nil {
@@ -88,12 +94,31 @@ func (h *handler) updateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	previousRole := originalUser.Role
+	previousStatus := originalUser.Status
 	userModificationRequest.Patch(originalUser)
+	if originalUser.Role != previousRole && !model.CanAssignRole(request.UserRole(r), originalUser.Role) {
+		json.BadRequest(w, r, errors.New("You are not allowed to assign this role"))
+		return
+	}
+	if originalUser.Status != previousStatus && originalUser.ID == request.UserID(r) {
+		json.BadRequest(w, r, errors.New("You are not allowed to change your own status"))
+		return
+	}
 	if err = h.store.UpdateUser(originalUser); err != nil {
 		json.ServerError(w, r, err)
 		return
 	}
 
+	if originalUser.Status != previousStatus {
+		if err := h.store.SetUserStatus(originalUser.ID, originalUser.Status); err != nil {
+			json.ServerError(w, r, err)
+			return
+		}
+	}
+
+	recordAuditLog(h.store, r, model.AuditActionUserUpdate, request.UserID(r), "", "user:"+originalUser.Username, fmt.Sprintf("role=%s status=%s", originalUser.Role, originalUser.Status))
+
 	json.Created(w, r, originalUser)
 }
 
@@ -118,7 +143,7 @@ func (h *handler) markUserAsRead(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handler) users(w http.ResponseWriter, r *http.Request) {
-	if !request.IsAdminUser(r) {
+	if !request.HasPermission(r, model.PermissionManageUsers) {
 		json.Forbidden(w, r)
 		return
 	}
@@ -134,7 +159,7 @@ func (h *handler) users(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handler) userByID(w http.ResponseWriter, r *http.Request) {
-	if !request.IsAdminUser(r) {
+	if !request.HasPermission(r, model.PermissionManageUsers) {
 		json.Forbidden(w, r)
 		return
 	}
@@ -156,7 +181,7 @@ func (h *handler) userByID(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handler) userByUsername(w http.ResponseWriter, r *http.Request) {
-	if !request.IsAdminUser(r) {
+	if !request.HasPermission(r, model.PermissionManageUsers) {
 		json.Forbidden(w, r)
 		return
 	}
@@ -177,7 +202,7 @@ func (h *handler) userByUsername(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *handler) removeUser(w http.ResponseWriter, r *http.Request) {
-	if !request.IsAdminUser(r) {
+	if !request.HasPermission(r, model.PermissionManageUsers) {
 		json.Forbidden(w, r)
 		return
 	}
@@ -199,6 +224,12 @@ func (h *handler) removeUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !model.CanAssignRole(request.UserRole(r), user.Role) {
+		json.Forbidden(w, r)
+		return
+	}
+
 	h.store.RemoveUserAsync(user.ID)
+	recordAuditLog(h.store, r, model.AuditActionUserRemove, request.UserID(r), "", "user:"+user.Username, "")
 	json.NoContent(w, r)
 }
@@ -0,0 +1,107 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package batch // import "miniflux.app/batch"
+
+import (
+	"fmt"
+
+	"miniflux.app/integration"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+	"miniflux.app/worker"
+)
+
+// ApplyFeedAction applies the action of a validated request to the feeds of the user.
+// The feeds to refresh are queued in the worker pool instead of being refreshed right away.
+func ApplyFeedAction(store *storage.Storage, pool *worker.Pool, userID int64, request *model.FeedBatchRequest) (err error) {
+	var count int64
+
+	switch request.Action {
+	case model.FeedBatchActionMove:
+		count, err = store.MoveFeeds(userID, request.FeedIDs, request.CategoryID)
+	case model.FeedBatchActionEnable:
+		count, err = store.SetFeedsDisabled(userID, request.FeedIDs, false)
+	case model.FeedBatchActionDisable:
+		count, err = store.SetFeedsDisabled(userID, request.FeedIDs, true)
+	case model.FeedBatchActionRemove:
+		count, err = store.RemoveFeeds(userID, request.FeedIDs)
+	case model.FeedBatchActionApplyRules:
+		count, err = store.SetFeedsRules(userID, request.FeedIDs, request.ScraperRules, request.RewriteRules, request.BlocklistRules, request.KeeplistRules)
+	case model.FeedBatchActionSetUserAgent:
+		count, err = store.SetFeedsUserAgent(userID, request.FeedIDs, request.UserAgent)
+	case model.FeedBatchActionRefresh:
+		var jobs model.JobList
+		if jobs, err = store.NewFeedsBatch(userID, request.FeedIDs); err == nil {
+			count = int64(len(jobs))
+			go pool.Push(jobs)
+		}
+	default:
+		return fmt.Errorf("batch: unknown feed action %q", request.Action)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("[Batch] Action %q applied to %d feeds of User #%d", request.Action, count, userID)
+	return nil
+}
+
+// ApplyEntryAction applies the action of a validated request to the entries of the user.
+// The entries are sent to the integrations in the background.
+func ApplyEntryAction(store *storage.Storage, userID int64, request *model.EntryBatchRequest) (err error) {
+	var count int64
+	starred, unstarred := true, false
+
+	switch request.Action {
+	case model.EntryBatchActionStar:
+		count, err = store.UpdateEntriesState(userID, request.EntryIDs, "", &starred, nil)
+	case model.EntryBatchActionUnstar:
+		count, err = store.UpdateEntriesState(userID, request.EntryIDs, "", &unstarred, nil)
+	case model.EntryBatchActionRead:
+		count, err = store.UpdateEntriesState(userID, request.EntryIDs, model.EntryStatusRead, nil, nil)
+	case model.EntryBatchActionUnread:
+		count, err = store.UpdateEntriesState(userID, request.EntryIDs, model.EntryStatusUnread, nil, nil)
+	case model.EntryBatchActionShare:
+		err = store.ShareEntries(userID, request.EntryIDs)
+		count = int64(len(request.EntryIDs))
+	case model.EntryBatchActionSave:
+		count, err = saveEntries(store, userID, request.EntryIDs)
+	default:
+		return fmt.Errorf("batch: unknown entry action %q", request.Action)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("[Batch] Action %q applied to %d entries of User #%d", request.Action, count, userID)
+	return nil
+}
+
+func saveEntries(store *storage.Storage, userID int64, entryIDs []int64) (int64, error) {
+	builder := store.NewEntryQueryBuilder(userID)
+	builder.WithEntryIDs(entryIDs)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		return 0, err
+	}
+
+	settings, err := store.Integration(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	go func() {
+		for _, entry := range entries {
+			integration.SendEntry(store, entry, settings)
+		}
+	}()
+
+	return int64(len(entries)), nil
+}
@@ -0,0 +1,8 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+/*
+Package batch applies an action to several feeds or entries at once, from the user interface or the API.
+*/
+package batch // import "miniflux.app/batch"
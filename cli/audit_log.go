@@ -0,0 +1,21 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package cli // import "miniflux.app/cli"
+
+import (
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+)
+
+// recordAuditLog adds an entry to the audit log, the actor of command line actions is unknown.
+func recordAuditLog(store *storage.Storage, action, target, details string) {
+	auditLog := model.NewAuditLog(model.AuditSourceCLI, action, 0, target)
+	auditLog.Details = details
+
+	if err := store.CreateAuditLog(auditLog); err != nil {
+		logger.Error("[CLI:AuditLog] %v", err)
+	}
+}
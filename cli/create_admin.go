@@ -36,8 +36,11 @@ func createAdmin(store *storage.Storage) {
 		os.Exit(1)
 	}
 
-	if _, err := store.CreateUser(userCreationRequest); err != nil {
+	user, err := store.CreateUser(userCreationRequest)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
 		os.Exit(1)
 	}
+
+	recordAuditLog(store, model.AuditActionUserCreate, "user:"+user.Username, "role="+user.Role)
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 
+	"miniflux.app/model"
 	"miniflux.app/storage"
 )
 
@@ -17,4 +18,6 @@ func flushSessions(store *storage.Storage) {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
 		os.Exit(1)
 	}
+
+	recordAuditLog(store, model.AuditActionSessionRemove, "", "all")
 }
@@ -40,5 +40,6 @@ func resetPassword(store *storage.Storage) {
 		os.Exit(1)
 	}
 
+	recordAuditLog(store, model.AuditActionPasswordReset, "user:"+user.Username, "")
 	fmt.Println("Password changed!")
 }
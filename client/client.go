@@ -120,6 +120,27 @@ func (c *Client) CreateUser(username, password string, isAdmin bool) (*User, err
 	return user, nil
 }
 
+// CreateUserWithRole creates a new user with the given role: admin, user_manager, user or guest.
+func (c *Client) CreateUserWithRole(username, password, role string) (*User, error) {
+	body, err := c.request.Post("/v1/users", &UserCreationRequest{
+		Username: username,
+		Password: password,
+		Role:     role,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var user *User
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&user); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return user, nil
+}
+
 // UpdateUser updates a user in the system.
 func (c *Client) UpdateUser(userID int64, userChanges *UserModificationRequest) (*User, error) {
 	body, err := c.request.Put(fmt.Sprintf("/v1/users/%d", userID), userChanges)
@@ -287,12 +308,57 @@ func (c *Client) Export() ([]byte, error) {
 	return opml, nil
 }
 
+// ExportEPUB returns an EPUB book of the starred entries, the unread entries of a category or the result of a search.
+// The kind is "starred", "category" or "search", the category ID and the search query are used by the matching kind.
+func (c *Client) ExportEPUB(title, kind string, categoryID int64, search string) ([]byte, error) {
+	values := url.Values{}
+	values.Set("kind", kind)
+	if title != "" {
+		values.Set("title", title)
+	}
+	if categoryID > 0 {
+		values.Set("category_id", strconv.FormatInt(categoryID, 10))
+	}
+	if search != "" {
+		values.Set("search", search)
+	}
+
+	body, err := c.request.Get("/v1/export/epub?" + values.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	book, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return book, nil
+}
+
 // Import imports an OPML file.
 func (c *Client) Import(f io.ReadCloser) error {
 	_, err := c.request.PostFile("/v1/import", f)
 	return err
 }
 
+// ImportWithReport imports an OPML file and returns the outcome of each subscription.
+func (c *Client) ImportWithReport(f io.ReadCloser) (*ImportReport, error) {
+	body, err := c.request.PostFile("/v1/import", f)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var report *ImportReport
+	if err := json.NewDecoder(body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return report, nil
+}
+
 // Feed gets a feed.
 func (c *Client) Feed(feedID int64) (*Feed, error) {
 	body, err := c.request.Get(fmt.Sprintf("/v1/feeds/%d", feedID))
@@ -347,6 +413,12 @@ func (c *Client) UpdateFeed(feedID int64, feedChanges *FeedModificationRequest)
 	return f, nil
 }
 
+// UpdateFeeds applies an action to several feeds at once.
+func (c *Client) UpdateFeeds(feedBatch *FeedBatchRequest) error {
+	_, err := c.request.Put("/v1/feeds/batch", feedBatch)
+	return err
+}
+
 // MarkFeedAsRead marks all unread entries of the feed as read.
 func (c *Client) MarkFeedAsRead(feedID int64) error {
 	_, err := c.request.Put(fmt.Sprintf("/v1/feeds/%d/mark-all-as-read", feedID), nil)
@@ -457,6 +529,31 @@ func (c *Client) Entries(filter *Filter) (*EntryResultSet, error) {
 	return &result, nil
 }
 
+// EntryClusters fetch entries grouped by story, the limit and the offset apply to the stories.
+func (c *Client) EntryClusters(filter *Filter) (*EntryClusterResultSet, error) {
+	path := buildFilterQueryString("/v1/clusters", filter)
+
+	body, err := c.request.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result EntryClusterResultSet
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return &result, nil
+}
+
+// MarkClusterAsRead marks all unread entries of a story as read.
+func (c *Client) MarkClusterAsRead(clusterID int64) error {
+	_, err := c.request.Put(fmt.Sprintf("/v1/clusters/%d/mark-all-as-read", clusterID), nil)
+	return err
+}
+
 // FeedEntries fetch feed entries.
 func (c *Client) FeedEntries(feedID int64, filter *Filter) (*EntryResultSet, error) {
 	path := buildFilterQueryString(fmt.Sprintf("/v1/feeds/%d/entries", feedID), filter)
@@ -506,12 +603,129 @@ func (c *Client) UpdateEntries(entryIDs []int64, status string) error {
 	return err
 }
 
+// ApplyEntriesAction applies an action to several entries at once, see the EntryBatchAction constants.
+func (c *Client) ApplyEntriesAction(entryIDs []int64, action string) error {
+	type payload struct {
+		EntryIDs []int64 `json:"entry_ids"`
+		Action   string  `json:"action"`
+	}
+
+	_, err := c.request.Put("/v1/entries/batch", &payload{EntryIDs: entryIDs, Action: action})
+	return err
+}
+
 // ToggleBookmark toggles entry bookmark value.
 func (c *Client) ToggleBookmark(entryID int64) error {
 	_, err := c.request.Put(fmt.Sprintf("/v1/entries/%d/bookmark", entryID), nil)
 	return err
 }
 
+// UpdateEntryReadingProgress saves the reading progress (0 to 100) and the scroll position of an entry.
+func (c *Client) UpdateEntryReadingProgress(entryID int64, progress, scrollPosition int) error {
+	type payload struct {
+		ReadingProgress int `json:"reading_progress"`
+		ScrollPosition  int `json:"scroll_position"`
+	}
+
+	_, err := c.request.Put(fmt.Sprintf("/v1/entries/%d/progress", entryID), &payload{ReadingProgress: progress, ScrollPosition: scrollPosition})
+	return err
+}
+
+// EntryIntegrationDeliveries returns the deliveries of an entry to third-party services.
+func (c *Client) EntryIntegrationDeliveries(entryID int64) (IntegrationDeliveries, error) {
+	body, err := c.request.Get(fmt.Sprintf("/v1/entries/%d/deliveries", entryID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var deliveries IntegrationDeliveries
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&deliveries); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return deliveries, nil
+}
+
+// IntegrationDeliveries returns the most recent deliveries to third-party services.
+// All statuses are returned when the status is empty.
+func (c *Client) IntegrationDeliveries(status string, offset, limit int) (IntegrationDeliveries, error) {
+	values := url.Values{}
+	if status != "" {
+		values.Set("status", status)
+	}
+	if offset > 0 {
+		values.Set("offset", strconv.Itoa(offset))
+	}
+	if limit > 0 {
+		values.Set("limit", strconv.Itoa(limit))
+	}
+
+	path := "/v1/integrations/deliveries"
+	if len(values) > 0 {
+		path += "?" + values.Encode()
+	}
+
+	body, err := c.request.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var deliveries IntegrationDeliveries
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&deliveries); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return deliveries, nil
+}
+
+// AuditLogs returns the most recent audit log entries, only administrators are allowed to read them.
+func (c *Client) AuditLogs(filter *AuditLogFilter) (*AuditLogResultSet, error) {
+	values := url.Values{}
+	if filter != nil {
+		if filter.UserID > 0 {
+			values.Set("user_id", strconv.FormatInt(filter.UserID, 10))
+		}
+		if filter.Username != "" {
+			values.Set("username", filter.Username)
+		}
+		if filter.Source != "" {
+			values.Set("source", filter.Source)
+		}
+		if filter.Action != "" {
+			values.Set("action", filter.Action)
+		}
+		if filter.Offset > 0 {
+			values.Set("offset", strconv.Itoa(filter.Offset))
+		}
+		if filter.Limit > 0 {
+			values.Set("limit", strconv.Itoa(filter.Limit))
+		}
+	}
+
+	path := "/v1/audit-logs"
+	if len(values) > 0 {
+		path += "?" + values.Encode()
+	}
+
+	body, err := c.request.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result AuditLogResultSet
+	decoder := json.NewDecoder(body)
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return &result, nil
+}
+
 // FetchCounters
 func (c *Client) FetchCounters() (*FeedCounters, error) {
 	body, err := c.request.Get("/v1/feeds/counters")
@@ -577,6 +791,14 @@ func buildFilterQueryString(path string, filter *Filter) string {
 			values.Set("search", filter.Search)
 		}
 
+		if filter.InProgress {
+			values.Set("in_progress", "true")
+		}
+
+		if filter.Author != "" {
+			values.Set("author", filter.Author)
+		}
+
 		if filter.CategoryID > 0 {
 			values.Set("category_id", strconv.FormatInt(filter.CategoryID, 10))
 		}
@@ -9,6 +9,16 @@ import (
 	"time"
 )
 
+// Actions applied to several entries at once.
+const (
+	EntryBatchActionStar   = "star"
+	EntryBatchActionUnstar = "unstar"
+	EntryBatchActionRead   = "read"
+	EntryBatchActionUnread = "unread"
+	EntryBatchActionShare  = "share"
+	EntryBatchActionSave   = "save"
+)
+
 // Entry statuses.
 const (
 	EntryStatusUnread  = "unread"
@@ -22,6 +32,9 @@ type User struct {
 	Username               string     `json:"username"`
 	Password               string     `json:"password,omitempty"`
 	IsAdmin                bool       `json:"is_admin"`
+	Role                   string     `json:"role"`
+	Status                 string     `json:"status"`
+	Email                  string     `json:"email"`
 	Theme                  string     `json:"theme"`
 	Language               string     `json:"language"`
 	Timezone               string     `json:"timezone"`
@@ -40,10 +53,14 @@ type User struct {
 	CJKReadingSpeed        int        `json:"cjk_reading_speed"`
 	DefaultHomePage        string     `json:"default_home_page"`
 	CategoriesSortingOrder string     `json:"categories_sorting_order"`
+	MarkReadAtEnd          bool       `json:"mark_read_at_end"`
+	EntryScoring           bool       `json:"entry_scoring"`
+	RetentionMaxDays       int        `json:"retention_max_days"`
+	RetentionMaxEntries    int        `json:"retention_max_entries"`
 }
 
 func (u User) String() string {
-	return fmt.Sprintf("#%d - %s (admin=%v)", u.ID, u.Username, u.IsAdmin)
+	return fmt.Sprintf("#%d - %s (role=%s)", u.ID, u.Username, u.Role)
 }
 
 // UserCreationRequest represents the request to create a user.
@@ -51,6 +68,9 @@ type UserCreationRequest struct {
 	Username        string `json:"username"`
 	Password        string `json:"password"`
 	IsAdmin         bool   `json:"is_admin"`
+	Role            string `json:"role,omitempty"`
+	Status          string `json:"status,omitempty"`
+	Email           string `json:"email,omitempty"`
 	GoogleID        string `json:"google_id"`
 	OpenIDConnectID string `json:"openid_connect_id"`
 }
@@ -60,6 +80,9 @@ type UserModificationRequest struct {
 	Username               *string `json:"username"`
 	Password               *string `json:"password"`
 	IsAdmin                *bool   `json:"is_admin"`
+	Role                   *string `json:"role"`
+	Status                 *string `json:"status"`
+	Email                  *string `json:"email"`
 	Theme                  *string `json:"theme"`
 	Language               *string `json:"language"`
 	Timezone               *string `json:"timezone"`
@@ -77,6 +100,10 @@ type UserModificationRequest struct {
 	CJKReadingSpeed        *int    `json:"cjk_reading_speed"`
 	DefaultHomePage        *string `json:"default_home_page"`
 	CategoriesSortingOrder *string `json:"categories_sorting_order"`
+	MarkReadAtEnd          *bool   `json:"mark_read_at_end"`
+	EntryScoring           *bool   `json:"entry_scoring"`
+	RetentionMaxDays       *int    `json:"retention_max_days"`
+	RetentionMaxEntries    *int    `json:"retention_max_entries"`
 }
 
 // Users represents a list of users.
@@ -84,9 +111,12 @@ type Users []User
 
 // Category represents a feed category.
 type Category struct {
-	ID     int64  `json:"id,omitempty"`
-	Title  string `json:"title,omitempty"`
-	UserID int64  `json:"user_id,omitempty"`
+	ID              int64  `json:"id,omitempty"`
+	Title           string `json:"title,omitempty"`
+	UserID          int64  `json:"user_id,omitempty"`
+	RetentionMode   string `json:"retention_mode,omitempty"`
+	RetentionValue  int    `json:"retention_value,omitempty"`
+	RetentionDelete bool   `json:"retention_delete,omitempty"`
 }
 
 func (c Category) String() string {
@@ -137,6 +167,10 @@ type Feed struct {
 	Password                    string    `json:"password"`
 	Category                    *Category `json:"category,omitempty"`
 	HideGlobally                bool      `json:"hide_globally"`
+	Notify                      bool      `json:"notify"`
+	RetentionMode               string    `json:"retention_mode"`
+	RetentionValue              int       `json:"retention_value"`
+	RetentionDelete             bool      `json:"retention_delete"`
 }
 
 // FeedCreationRequest represents the request to create a feed.
@@ -179,6 +213,33 @@ type FeedModificationRequest struct {
 	AllowSelfSignedCertificates *bool   `json:"allow_self_signed_certificates"`
 	FetchViaProxy               *bool   `json:"fetch_via_proxy"`
 	HideGlobally                *bool   `json:"hide_globally"`
+	Notify                      *bool   `json:"notify"`
+	RetentionMode               *string `json:"retention_mode"`
+	RetentionValue              *int    `json:"retention_value"`
+	RetentionDelete             *bool   `json:"retention_delete"`
+}
+
+// Actions applied to several feeds at once.
+const (
+	FeedBatchActionMove         = "move"
+	FeedBatchActionEnable       = "enable"
+	FeedBatchActionDisable      = "disable"
+	FeedBatchActionRemove       = "remove"
+	FeedBatchActionRefresh      = "refresh"
+	FeedBatchActionApplyRules   = "apply_rules"
+	FeedBatchActionSetUserAgent = "set_user_agent"
+)
+
+// FeedBatchRequest represents an action applied to a list of feeds.
+type FeedBatchRequest struct {
+	FeedIDs        []int64 `json:"feed_ids"`
+	Action         string  `json:"action"`
+	CategoryID     int64   `json:"category_id,omitempty"`
+	UserAgent      string  `json:"user_agent,omitempty"`
+	ScraperRules   *string `json:"scraper_rules,omitempty"`
+	RewriteRules   *string `json:"rewrite_rules,omitempty"`
+	BlocklistRules *string `json:"blocklist_rules,omitempty"`
+	KeeplistRules  *string `json:"keeplist_rules,omitempty"`
 }
 
 // FeedIcon represents the feed icon.
@@ -198,24 +259,29 @@ type Feeds []*Feed
 
 // Entry represents a subscription item in the system.
 type Entry struct {
-	ID          int64      `json:"id"`
-	UserID      int64      `json:"user_id"`
-	FeedID      int64      `json:"feed_id"`
-	Status      string     `json:"status"`
-	Hash        string     `json:"hash"`
-	Title       string     `json:"title"`
-	URL         string     `json:"url"`
-	CommentsURL string     `json:"comments_url"`
-	Date        time.Time  `json:"published_at"`
-	CreatedAt   time.Time  `json:"created_at"`
-	ChangedAt   time.Time  `json:"changed_at"`
-	Content     string     `json:"content"`
-	Author      string     `json:"author"`
-	ShareCode   string     `json:"share_code"`
-	Starred     bool       `json:"starred"`
-	ReadingTime int        `json:"reading_time"`
-	Enclosures  Enclosures `json:"enclosures,omitempty"`
-	Feed        *Feed      `json:"feed,omitempty"`
+	ID              int64      `json:"id"`
+	UserID          int64      `json:"user_id"`
+	FeedID          int64      `json:"feed_id"`
+	Status          string     `json:"status"`
+	Hash            string     `json:"hash"`
+	Title           string     `json:"title"`
+	URL             string     `json:"url"`
+	CommentsURL     string     `json:"comments_url"`
+	Date            time.Time  `json:"published_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	ChangedAt       time.Time  `json:"changed_at"`
+	Content         string     `json:"content"`
+	Author          string     `json:"author"`
+	AuthorStatus    string     `json:"author_status"`
+	ShareCode       string     `json:"share_code"`
+	Starred         bool       `json:"starred"`
+	ReadingTime     int        `json:"reading_time"`
+	ReadingProgress int        `json:"reading_progress"`
+	ScrollPosition  int        `json:"scroll_position"`
+	ClusterID       int64      `json:"cluster_id"`
+	Score           float64    `json:"score"`
+	Enclosures      Enclosures `json:"enclosures,omitempty"`
+	Feed            *Feed      `json:"feed,omitempty"`
 }
 
 // Entries represents a list of entries.
@@ -255,6 +321,8 @@ type Filter struct {
 	CategoryID    int64
 	FeedID        int64
 	Statuses      []string
+	InProgress    bool
+	Author        string
 }
 
 // EntryResultSet represents the response when fetching entries.
@@ -262,3 +330,98 @@ type EntryResultSet struct {
 	Total   int     `json:"total"`
 	Entries Entries `json:"entries"`
 }
+
+// EntryCluster represents the entries telling the same story, the ID is 0 for entries without similar ones.
+type EntryCluster struct {
+	ID      int64   `json:"id"`
+	Entries Entries `json:"entries"`
+}
+
+// EntryClusterResultSet represents the response when fetching entries grouped by story, the total is the number of stories.
+type EntryClusterResultSet struct {
+	Total    int             `json:"total"`
+	Clusters []*EntryCluster `json:"clusters"`
+}
+
+// OPML import statuses.
+const (
+	ImportStatusCreated   = "created"
+	ImportStatusDuplicate = "duplicate"
+	ImportStatusFailed    = "failed"
+)
+
+// ImportResult represents the outcome of the import of an OPML outline.
+type ImportResult struct {
+	Title    string `json:"title"`
+	FeedURL  string `json:"feed_url"`
+	Category string `json:"category"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ImportReport represents the response of an OPML import.
+type ImportReport struct {
+	Message    string          `json:"message"`
+	Created    int             `json:"created"`
+	Duplicates int             `json:"duplicates"`
+	Failed     int             `json:"failed"`
+	Results    []*ImportResult `json:"results"`
+}
+
+// Integration delivery statuses.
+const (
+	DeliveryStatusSuccess  = "success"
+	DeliveryStatusRetrying = "retrying"
+	DeliveryStatusFailed   = "failed"
+)
+
+// IntegrationDelivery represents the delivery of an entry to a third-party service.
+type IntegrationDelivery struct {
+	ID            int64      `json:"id"`
+	UserID        int64      `json:"user_id"`
+	EntryID       int64      `json:"entry_id"`
+	Provider      string     `json:"provider"`
+	Action        string     `json:"action"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error"`
+	NextAttemptAt *time.Time `json:"next_attempt_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// IntegrationDeliveries represents a list of deliveries.
+type IntegrationDeliveries []*IntegrationDelivery
+
+// AuditLog represents a security-relevant or administrative action.
+type AuditLog struct {
+	ID        int64     `json:"id"`
+	UserID    *int64    `json:"user_id"`
+	Username  string    `json:"username"`
+	Source    string    `json:"source"`
+	Action    string    `json:"action"`
+	Target    string    `json:"target"`
+	Details   string    `json:"details"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuditLogs represents a list of audit log entries.
+type AuditLogs []*AuditLog
+
+// AuditLogFilter is used to filter the audit log, empty fields are ignored.
+type AuditLogFilter struct {
+	UserID   int64
+	Username string
+	Source   string
+	Action   string
+	Offset   int
+	Limit    int
+}
+
+// AuditLogResultSet represents the response when fetching the audit log.
+type AuditLogResultSet struct {
+	Total     int       `json:"total"`
+	AuditLogs AuditLogs `json:"audit_logs"`
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package cluster // import "miniflux.app/cluster"
+
+import (
+	"time"
+
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/reader/sanitizer"
+	"miniflux.app/storage"
+)
+
+// Threshold is the minimum similarity of two entries telling the same story.
+const Threshold = 0.5
+
+// Document is an entry prepared for the comparison.
+type Document struct {
+	ID        int64
+	ClusterID int64
+	Shingles  Shingles
+}
+
+// NewDocument returns the document of an entry, the title is compared with the text of the content.
+func NewDocument(entry *model.Entry) *Document {
+	return &Document{
+		ID:        entry.ID,
+		ClusterID: entry.ClusterID,
+		Shingles:  NewShingles(entry.Title + " " + sanitizer.StripTags(entry.Content)),
+	}
+}
+
+// Assign groups the similar documents and returns the cluster ID of each document, 0 for documents without similar ones.
+// A cluster keeps its previous ID when it grows, otherwise the smallest document ID is used.
+func Assign(documents []*Document, threshold float64) map[int64]int64 {
+	parents := make([]int, len(documents))
+	for i := range parents {
+		parents[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+
+	// Only the documents sharing at least one shingle are compared.
+	index := make(map[uint64][]int)
+	for i, document := range documents {
+		candidates := make(map[int]bool)
+		for shingle := range document.Shingles {
+			for _, j := range index[shingle] {
+				candidates[j] = true
+			}
+			index[shingle] = append(index[shingle], i)
+		}
+
+		for j := range candidates {
+			if document.Shingles.Similarity(documents[j].Shingles) >= threshold {
+				parents[find(i)] = find(j)
+			}
+		}
+	}
+
+	groups := make(map[int][]*Document)
+	for i, document := range documents {
+		root := find(i)
+		groups[root] = append(groups[root], document)
+	}
+
+	clusters := make(map[int64]int64, len(documents))
+	for _, group := range groups {
+		if len(group) < 2 {
+			clusters[group[0].ID] = 0
+			continue
+		}
+
+		var clusterID int64
+		for _, document := range group {
+			if document.ClusterID > 0 && (clusterID == 0 || document.ClusterID < clusterID) {
+				clusterID = document.ClusterID
+			}
+		}
+
+		if clusterID == 0 {
+			for _, document := range group {
+				if clusterID == 0 || document.ID < clusterID {
+					clusterID = document.ID
+				}
+			}
+		}
+
+		for _, document := range group {
+			clusters[document.ID] = clusterID
+		}
+	}
+
+	return clusters
+}
+
+// ClusterEntries groups the entries of all users published during the last hours.
+func ClusterEntries(store *storage.Storage, windowHours int) {
+	since := time.Now().Add(-time.Duration(windowHours) * time.Hour)
+	userIDs, err := store.UserIDsWithEntriesSince(since)
+	if err != nil {
+		logger.Error("[Cluster] %v", err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		if err := ClusterUserEntries(store, userID, since); err != nil {
+			logger.Error("[Cluster] User #%d: %v", userID, err)
+		}
+	}
+}
+
+// ClusterUserEntries groups the entries of a user published after the given date.
+func ClusterUserEntries(store *storage.Storage, userID int64, since time.Time) error {
+	entries, err := store.EntriesForClustering(userID, since)
+	if err != nil {
+		return err
+	}
+
+	documents := make([]*Document, 0, len(entries))
+	for _, entry := range entries {
+		documents = append(documents, NewDocument(entry))
+	}
+
+	clusters := Assign(documents, Threshold)
+	logger.Debug("[Cluster] Compared %d entries of User #%d", len(documents), userID)
+
+	return store.UpdateEntryClusters(userID, clusters)
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package cluster // import "miniflux.app/cluster"
+
+import (
+	"testing"
+
+	"miniflux.app/model"
+)
+
+const story = `<p>The city council approved on Monday the construction of a new tramway line
+connecting the airport to the central station, with works starting next spring and
+an opening planned for the end of the decade.</p>`
+
+func TestShinglesSimilarity(t *testing.T) {
+	a := NewShingles("The quick brown fox jumps over the lazy dog")
+	b := NewShingles("the QUICK brown fox, jumps over the lazy dog!")
+	if similarity := a.Similarity(b); similarity != 1 {
+		t.Errorf(`The case and the punctuation should be ignored, got %v`, similarity)
+	}
+
+	c := NewShingles("A completely different sentence about the weather today")
+	if similarity := a.Similarity(c); similarity != 0 {
+		t.Errorf(`Different texts should not be similar, got %v`, similarity)
+	}
+
+	if similarity := a.Similarity(NewShingles("")); similarity != 0 {
+		t.Errorf(`An empty text should not be similar to anything, got %v`, similarity)
+	}
+}
+
+func TestShortText(t *testing.T) {
+	if shingles := NewShingles("Breaking news"); len(shingles) != 1 {
+		t.Errorf(`A text shorter than a shingle should have one shingle, got %d`, len(shingles))
+	}
+}
+
+func TestAssign(t *testing.T) {
+	documents := []*Document{
+		NewDocument(&model.Entry{ID: 10, Title: "New tramway line approved", Content: story}),
+		NewDocument(&model.Entry{ID: 11, Title: "Football: the final is postponed", Content: "<p>The final will be played next week because of the storm.</p>"}),
+		NewDocument(&model.Entry{ID: 12, Title: "City approves a new tramway line", Content: story + "<p>Read more on our website.</p>"}),
+	}
+
+	clusters := Assign(documents, Threshold)
+	if clusters[10] != 10 || clusters[12] != 10 {
+		t.Errorf(`The entries telling the same story should be in the cluster of the oldest one, got %v`, clusters)
+	}
+
+	if clusters[11] != 0 {
+		t.Errorf(`An entry without similar ones should not be in a cluster, got %d`, clusters[11])
+	}
+}
+
+func TestAssignKeepsClusterID(t *testing.T) {
+	documents := []*Document{
+		NewDocument(&model.Entry{ID: 20, ClusterID: 5, Title: "New tramway line approved", Content: story}),
+		NewDocument(&model.Entry{ID: 21, Title: "City approves a new tramway line", Content: story}),
+	}
+
+	clusters := Assign(documents, Threshold)
+	if clusters[20] != 5 || clusters[21] != 5 {
+		t.Errorf(`A growing cluster should keep its ID, got %v`, clusters)
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+/*
+Package cluster groups the recent entries telling the same story, like the articles of news agencies republished by many websites.
+*/
+package cluster // import "miniflux.app/cluster"
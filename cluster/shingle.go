@@ -0,0 +1,74 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package cluster // import "miniflux.app/cluster"
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// shingleSize is the number of consecutive words of a shingle.
+	shingleSize = 3
+
+	// maxWords limits the comparison to the beginning of long articles.
+	maxWords = 300
+)
+
+// Shingles is the set of hashed word sequences of a text.
+type Shingles map[uint64]struct{}
+
+// NewShingles returns the shingles of a plain text, the case and the punctuation are ignored.
+func NewShingles(text string) Shingles {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	if len(words) > maxWords {
+		words = words[:maxWords]
+	}
+
+	shingles := make(Shingles)
+	if len(words) == 0 {
+		return shingles
+	}
+
+	if len(words) < shingleSize {
+		shingles[hash(words)] = struct{}{}
+		return shingles
+	}
+
+	for i := 0; i+shingleSize <= len(words); i++ {
+		shingles[hash(words[i:i+shingleSize])] = struct{}{}
+	}
+
+	return shingles
+}
+
+// Similarity returns the Jaccard index of both sets: 0 when nothing is shared, 1 for identical texts.
+func (s Shingles) Similarity(other Shingles) float64 {
+	if len(s) == 0 || len(other) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for shingle := range s {
+		if _, found := other[shingle]; found {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(s)+len(other)-shared)
+}
+
+func hash(words []string) uint64 {
+	h := fnv.New64a()
+	for _, word := range words {
+		h.Write([]byte(word))
+		h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
@@ -6,6 +6,7 @@ package config // import "miniflux.app/config"
 
 import (
 	"os"
+	"reflect"
 	"testing"
 )
 
@@ -1432,6 +1433,1126 @@ func TestFetchYouTubeWatchTime(t *testing.T) {
 	}
 }
 
+func TestDefaultCleanupRemoveDeliveriesDaysValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultCleanupRemoveDeliveriesDays
+	result := opts.CleanupRemoveDeliveriesDays()
+
+	if result != expected {
+		t.Fatalf(`Unexpected CLEANUP_REMOVE_DELIVERIES_DAYS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestCleanupRemoveDeliveriesDays(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("CLEANUP_REMOVE_DELIVERIES_DAYS", "7")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 7
+	result := opts.CleanupRemoveDeliveriesDays()
+
+	if result != expected {
+		t.Fatalf(`Unexpected CLEANUP_REMOVE_DELIVERIES_DAYS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultIntegrationRetryFrequencyValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultIntegrationRetryFrequency
+	result := opts.IntegrationRetryFrequency()
+
+	if result != expected {
+		t.Fatalf(`Unexpected INTEGRATION_RETRY_FREQUENCY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestIntegrationRetryFrequency(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("INTEGRATION_RETRY_FREQUENCY", "15")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 15
+	result := opts.IntegrationRetryFrequency()
+
+	if result != expected {
+		t.Fatalf(`Unexpected INTEGRATION_RETRY_FREQUENCY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultIntegrationRetryMaxAttemptsValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultIntegrationRetryMaxAttempts
+	result := opts.IntegrationRetryMaxAttempts()
+
+	if result != expected {
+		t.Fatalf(`Unexpected INTEGRATION_RETRY_MAX_ATTEMPTS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestIntegrationRetryMaxAttempts(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("INTEGRATION_RETRY_MAX_ATTEMPTS", "10")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 10
+	result := opts.IntegrationRetryMaxAttempts()
+
+	if result != expected {
+		t.Fatalf(`Unexpected INTEGRATION_RETRY_MAX_ATTEMPTS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultNotificationFrequencyValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultNotificationFrequency
+	result := opts.NotificationFrequency()
+
+	if result != expected {
+		t.Fatalf(`Unexpected NOTIFICATION_FREQUENCY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestNotificationFrequency(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("NOTIFICATION_FREQUENCY", "30")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 30
+	result := opts.NotificationFrequency()
+
+	if result != expected {
+		t.Fatalf(`Unexpected NOTIFICATION_FREQUENCY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultSMTPHostValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultSMTPHost
+	result := opts.SMTPHost()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SMTP_HOST value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestSMTPHost(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SMTP_HOST", "smtp.example.org")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := "smtp.example.org"
+	result := opts.SMTPHost()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SMTP_HOST value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultSMTPPortValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultSMTPPort
+	result := opts.SMTPPort()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SMTP_PORT value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestSMTPPort(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SMTP_PORT", "25")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 25
+	result := opts.SMTPPort()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SMTP_PORT value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultSMTPUsernameValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultSMTPUsername
+	result := opts.SMTPUsername()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SMTP_USERNAME value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestSMTPUsername(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SMTP_USERNAME", "miniflux")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := "miniflux"
+	result := opts.SMTPUsername()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SMTP_USERNAME value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultSMTPPasswordValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultSMTPPassword
+	result := opts.SMTPPassword()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SMTP_PASSWORD value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestSMTPPassword(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SMTP_PASSWORD", "secret")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := "secret"
+	result := opts.SMTPPassword()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SMTP_PASSWORD value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultSMTPFromValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultSMTPFrom
+	result := opts.SMTPFrom()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SMTP_FROM value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestSMTPFrom(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("SMTP_FROM", "miniflux@example.org")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := "miniflux@example.org"
+	result := opts.SMTPFrom()
+
+	if result != expected {
+		t.Fatalf(`Unexpected SMTP_FROM value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultEmailDigestFrequencyValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultEmailDigestFrequency
+	result := opts.EmailDigestFrequency()
+
+	if result != expected {
+		t.Fatalf(`Unexpected EMAIL_DIGEST_FREQUENCY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestEmailDigestFrequency(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("EMAIL_DIGEST_FREQUENCY", "60")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 60
+	result := opts.EmailDigestFrequency()
+
+	if result != expected {
+		t.Fatalf(`Unexpected EMAIL_DIGEST_FREQUENCY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultMediaProxyCacheDirValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultMediaProxyCacheDir
+	result := opts.MediaProxyCacheDir()
+
+	if result != expected {
+		t.Fatalf(`Unexpected MEDIA_PROXY_CACHE_DIR value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestMediaProxyCacheDir(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("MEDIA_PROXY_CACHE_DIR", "/var/cache/miniflux")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := "/var/cache/miniflux"
+	result := opts.MediaProxyCacheDir()
+
+	if result != expected {
+		t.Fatalf(`Unexpected MEDIA_PROXY_CACHE_DIR value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultMediaProxyCacheSizeValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultMediaProxyCacheSize
+	result := opts.MediaProxyCacheSize()
+
+	if result != expected {
+		t.Fatalf(`Unexpected MEDIA_PROXY_CACHE_SIZE value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestMediaProxyCacheSize(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("MEDIA_PROXY_CACHE_SIZE", "500")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 500
+	result := opts.MediaProxyCacheSize()
+
+	if result != expected {
+		t.Fatalf(`Unexpected MEDIA_PROXY_CACHE_SIZE value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultMediaProxyCacheMaxAgeValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultMediaProxyCacheMaxAge
+	result := opts.MediaProxyCacheMaxAge()
+
+	if result != expected {
+		t.Fatalf(`Unexpected MEDIA_PROXY_CACHE_MAX_AGE value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestMediaProxyCacheMaxAge(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("MEDIA_PROXY_CACHE_MAX_AGE", "24")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 24
+	result := opts.MediaProxyCacheMaxAge()
+
+	if result != expected {
+		t.Fatalf(`Unexpected MEDIA_PROXY_CACHE_MAX_AGE value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultMediaProxyAllowPrivateNetworksValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultMediaProxyAllowPrivateNetworks
+	result := opts.MediaProxyAllowPrivateNetworks()
+
+	if result != expected {
+		t.Fatalf(`Unexpected MEDIA_PROXY_ALLOW_PRIVATE_NETWORKS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestMediaProxyAllowPrivateNetworks(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("MEDIA_PROXY_ALLOW_PRIVATE_NETWORKS", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := true
+	result := opts.MediaProxyAllowPrivateNetworks()
+
+	if result != expected {
+		t.Fatalf(`Unexpected MEDIA_PROXY_ALLOW_PRIVATE_NETWORKS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultMediaProxyResizeImagesValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultMediaProxyResizeImages
+	result := opts.MediaProxyResizeImages()
+
+	if result != expected {
+		t.Fatalf(`Unexpected MEDIA_PROXY_RESIZE_IMAGES value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestMediaProxyResizeImages(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("MEDIA_PROXY_RESIZE_IMAGES", "0")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := false
+	result := opts.MediaProxyResizeImages()
+
+	if result != expected {
+		t.Fatalf(`Unexpected MEDIA_PROXY_RESIZE_IMAGES value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultOPMLSubscriptionFrequencyValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultOPMLSubscriptionFrequency
+	result := opts.OPMLSubscriptionFrequency()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OPML_SUBSCRIPTION_FREQUENCY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestOPMLSubscriptionFrequency(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("OPML_SUBSCRIPTION_FREQUENCY", "30")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 30
+	result := opts.OPMLSubscriptionFrequency()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OPML_SUBSCRIPTION_FREQUENCY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultPollingSharedFetchIntervalValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultPollingSharedFetchInterval
+	result := opts.PollingSharedFetchInterval()
+
+	if result != expected {
+		t.Fatalf(`Unexpected POLLING_SHARED_FETCH_INTERVAL value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestPollingSharedFetchInterval(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("POLLING_SHARED_FETCH_INTERVAL", "30")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 30
+	result := opts.PollingSharedFetchInterval()
+
+	if result != expected {
+		t.Fatalf(`Unexpected POLLING_SHARED_FETCH_INTERVAL value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultIsRegistrationEnabledValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultRegistrationEnabled
+	result := opts.IsRegistrationEnabled()
+
+	if result != expected {
+		t.Fatalf(`Unexpected REGISTRATION_ENABLED value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestIsRegistrationEnabled(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("REGISTRATION_ENABLED", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := true
+	result := opts.IsRegistrationEnabled()
+
+	if result != expected {
+		t.Fatalf(`Unexpected REGISTRATION_ENABLED value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultIsRegistrationApprovalRequiredValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultRegistrationApprovalRequired
+	result := opts.IsRegistrationApprovalRequired()
+
+	if result != expected {
+		t.Fatalf(`Unexpected REGISTRATION_APPROVAL_REQUIRED value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestIsRegistrationApprovalRequired(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("REGISTRATION_APPROVAL_REQUIRED", "0")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := false
+	result := opts.IsRegistrationApprovalRequired()
+
+	if result != expected {
+		t.Fatalf(`Unexpected REGISTRATION_APPROVAL_REQUIRED value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultInvitationExpirationHoursValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultInvitationExpirationHours
+	result := opts.InvitationExpirationHours()
+
+	if result != expected {
+		t.Fatalf(`Unexpected INVITATION_EXPIRATION_HOURS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestInvitationExpirationHours(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("INVITATION_EXPIRATION_HOURS", "24")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 24
+	result := opts.InvitationExpirationHours()
+
+	if result != expected {
+		t.Fatalf(`Unexpected INVITATION_EXPIRATION_HOURS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultIsTwoFactorRequiredValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultTwoFactorRequired
+	result := opts.IsTwoFactorRequired()
+
+	if result != expected {
+		t.Fatalf(`Unexpected TWO_FACTOR_REQUIRED value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestIsTwoFactorRequired(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("TWO_FACTOR_REQUIRED", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := true
+	result := opts.IsTwoFactorRequired()
+
+	if result != expected {
+		t.Fatalf(`Unexpected TWO_FACTOR_REQUIRED value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultOAuth2UserDefaultCategoryValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultOAuth2UserDefaultCategory
+	result := opts.OAuth2UserDefaultCategory()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OAUTH2_USER_DEFAULT_CATEGORY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestOAuth2UserDefaultCategory(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("OAUTH2_USER_DEFAULT_CATEGORY", "Feeds")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := "Feeds"
+	result := opts.OAuth2UserDefaultCategory()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OAUTH2_USER_DEFAULT_CATEGORY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultOAuth2UserDefaultThemeValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultOAuth2UserDefaultTheme
+	result := opts.OAuth2UserDefaultTheme()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OAUTH2_USER_DEFAULT_THEME value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestOAuth2UserDefaultTheme(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("OAUTH2_USER_DEFAULT_THEME", "dark_serif")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := "dark_serif"
+	result := opts.OAuth2UserDefaultTheme()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OAUTH2_USER_DEFAULT_THEME value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultCleanupRemoveAuditLogsDaysValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultCleanupRemoveAuditLogsDays
+	result := opts.CleanupRemoveAuditLogsDays()
+
+	if result != expected {
+		t.Fatalf(`Unexpected CLEANUP_REMOVE_AUDIT_LOGS_DAYS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestCleanupRemoveAuditLogsDays(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("CLEANUP_REMOVE_AUDIT_LOGS_DAYS", "30")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 30
+	result := opts.CleanupRemoveAuditLogsDays()
+
+	if result != expected {
+		t.Fatalf(`Unexpected CLEANUP_REMOVE_AUDIT_LOGS_DAYS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultOfflineEntriesValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultOfflineEntries
+	result := opts.OfflineEntries()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OFFLINE_ENTRIES value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestOfflineEntries(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("OFFLINE_ENTRIES", "20")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 20
+	result := opts.OfflineEntries()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OFFLINE_ENTRIES value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultOfflineImagesValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultOfflineImages
+	result := opts.OfflineImages()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OFFLINE_IMAGES value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestOfflineImages(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("OFFLINE_IMAGES", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := true
+	result := opts.OfflineImages()
+
+	if result != expected {
+		t.Fatalf(`Unexpected OFFLINE_IMAGES value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultStoryClusteringValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultStoryClustering
+	result := opts.StoryClustering()
+
+	if result != expected {
+		t.Fatalf(`Unexpected STORY_CLUSTERING value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestStoryClustering(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("STORY_CLUSTERING", "1")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := true
+	result := opts.StoryClustering()
+
+	if result != expected {
+		t.Fatalf(`Unexpected STORY_CLUSTERING value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultStoryClusteringWindowHoursValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultStoryClusteringWindowHours
+	result := opts.StoryClusteringWindowHours()
+
+	if result != expected {
+		t.Fatalf(`Unexpected STORY_CLUSTERING_WINDOW_HOURS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestStoryClusteringWindowHours(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("STORY_CLUSTERING_WINDOW_HOURS", "48")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 48
+	result := opts.StoryClusteringWindowHours()
+
+	if result != expected {
+		t.Fatalf(`Unexpected STORY_CLUSTERING_WINDOW_HOURS value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultStoryClusteringFrequencyValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultStoryClusteringFrequency
+	result := opts.StoryClusteringFrequency()
+
+	if result != expected {
+		t.Fatalf(`Unexpected STORY_CLUSTERING_FREQUENCY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestStoryClusteringFrequency(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("STORY_CLUSTERING_FREQUENCY", "30")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 30
+	result := opts.StoryClusteringFrequency()
+
+	if result != expected {
+		t.Fatalf(`Unexpected STORY_CLUSTERING_FREQUENCY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultEntryScoringFrequencyValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultEntryScoringFrequency
+	result := opts.EntryScoringFrequency()
+
+	if result != expected {
+		t.Fatalf(`Unexpected ENTRY_SCORING_FREQUENCY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestEntryScoringFrequency(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("ENTRY_SCORING_FREQUENCY", "15")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 15
+	result := opts.EntryScoringFrequency()
+
+	if result != expected {
+		t.Fatalf(`Unexpected ENTRY_SCORING_FREQUENCY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultEPUBExportDirectoryValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultEPUBExportDirectory
+	result := opts.EPUBExportDirectory()
+
+	if result != expected {
+		t.Fatalf(`Unexpected EPUB_EXPORT_DIRECTORY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestEPUBExportDirectory(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("EPUB_EXPORT_DIRECTORY", "/var/lib/miniflux/epub")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := "/var/lib/miniflux/epub"
+	result := opts.EPUBExportDirectory()
+
+	if result != expected {
+		t.Fatalf(`Unexpected EPUB_EXPORT_DIRECTORY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestDefaultEPUBExportFrequencyValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := defaultEPUBExportFrequency
+	result := opts.EPUBExportFrequency()
+
+	if result != expected {
+		t.Fatalf(`Unexpected EPUB_EXPORT_FREQUENCY value, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestEPUBExportFrequency(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("EPUB_EXPORT_FREQUENCY", "15")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	expected := 15
+	result := opts.EPUBExportFrequency()
+
+	if result != expected {
+		t.Fatalf(`Unexpected EPUB_EXPORT_FREQUENCY value, got %v instead of %v`, result, expected)
+	}
+}
+
 func TestParseConfigDumpOutput(t *testing.T) {
 	os.Clearenv()
 
@@ -1466,3 +2587,98 @@ func TestParseConfigDumpOutput(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestOAuth2ProvidersWithLegacyProvider(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("BASE_URL", "https://reader.example.org/")
+	os.Setenv("OAUTH2_PROVIDER", "oidc")
+	os.Setenv("OAUTH2_CLIENT_ID", "client")
+	os.Setenv("OAUTH2_CLIENT_SECRET", "secret")
+	os.Setenv("OAUTH2_REDIRECT_URL", "https://reader.example.org/oauth2/oidc/callback")
+	os.Setenv("OAUTH2_OIDC_DISCOVERY_ENDPOINT", "https://id.example.org")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	providers := opts.OAuth2Providers()
+	if len(providers) != 1 {
+		t.Fatalf(`Unexpected number of providers, got %d instead of 1`, len(providers))
+	}
+
+	expected := OAuth2ProviderOptions{
+		Name:              "oidc",
+		ClientID:          "client",
+		ClientSecret:      "secret",
+		RedirectURL:       "https://reader.example.org/oauth2/oidc/callback",
+		DiscoveryEndpoint: "https://id.example.org",
+		UsernameClaim:     "email",
+		GroupsClaim:       "groups",
+	}
+
+	if !reflect.DeepEqual(*providers[0], expected) {
+		t.Fatalf(`Unexpected provider, got %+v instead of %+v`, *providers[0], expected)
+	}
+}
+
+func TestOAuth2ProvidersWithNamedProviders(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("BASE_URL", "https://reader.example.org/")
+	os.Setenv("OAUTH2_PROVIDER", "google")
+	os.Setenv("OAUTH2_CLIENT_ID", "google-client")
+	os.Setenv("OAUTH2_PROVIDERS", "Keycloak, google")
+	os.Setenv("OAUTH2_KEYCLOAK_LABEL", "Company SSO")
+	os.Setenv("OAUTH2_KEYCLOAK_CLIENT_ID", "miniflux")
+	os.Setenv("OAUTH2_KEYCLOAK_OIDC_DISCOVERY_ENDPOINT", "https://sso.example.org/realms/main")
+	os.Setenv("OAUTH2_KEYCLOAK_USERNAME_CLAIM", "preferred_username")
+	os.Setenv("OAUTH2_KEYCLOAK_ROLE_MAPPING", "ops=admin, friends = guest, invalid")
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	providers := opts.OAuth2Providers()
+	if len(providers) != 2 {
+		t.Fatalf(`Unexpected number of providers, got %d instead of 2`, len(providers))
+	}
+
+	if providers[0].Name != "google" || providers[0].ClientID != "google-client" {
+		t.Fatalf(`Unexpected first provider: %+v`, *providers[0])
+	}
+
+	expected := OAuth2ProviderOptions{
+		Name:              "keycloak",
+		Label:             "Company SSO",
+		ClientID:          "miniflux",
+		RedirectURL:       "https://reader.example.org/oauth2/keycloak/callback",
+		DiscoveryEndpoint: "https://sso.example.org/realms/main",
+		UsernameClaim:     "preferred_username",
+		GroupsClaim:       "groups",
+		RoleMapping: []OAuth2RoleMapping{
+			{Group: "ops", Role: "admin"},
+			{Group: "friends", Role: "guest"},
+		},
+	}
+
+	if !reflect.DeepEqual(*providers[1], expected) {
+		t.Fatalf(`Unexpected provider, got %+v instead of %+v`, *providers[1], expected)
+	}
+}
+
+func TestDefaultOAuth2ProvidersValue(t *testing.T) {
+	os.Clearenv()
+
+	parser := NewParser()
+	opts, err := parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if providers := opts.OAuth2Providers(); len(providers) != 0 {
+		t.Fatalf(`No provider should be enabled by default, got %d`, len(providers))
+	}
+}
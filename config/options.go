@@ -22,16 +22,27 @@ const (
 	defaultSchedulerService                   = true
 	defaultDebug                              = false
 	defaultTiming                             = false
+	defaultSMTPHost                           = ""
+	defaultSMTPPort                           = 587
+	defaultSMTPUsername                       = ""
+	defaultSMTPPassword                       = ""
+	defaultSMTPFrom                           = ""
 	defaultBaseURL                            = "http://localhost"
 	defaultRootURL                            = "http://localhost"
 	defaultBasePath                           = ""
 	defaultWorkerPoolSize                     = 5
 	defaultPollingFrequency                   = 60
 	defaultBatchSize                          = 100
+	defaultIntegrationRetryFrequency          = 5
+	defaultIntegrationRetryMaxAttempts        = 5
+	defaultNotificationFrequency              = 5
+	defaultEmailDigestFrequency               = 15
+	defaultOPMLSubscriptionFrequency          = 60
 	defaultPollingScheduler                   = "round_robin"
 	defaultSchedulerEntryFrequencyMinInterval = 5
 	defaultSchedulerEntryFrequencyMaxInterval = 24 * 60
 	defaultPollingParsingErrorLimit           = 3
+	defaultPollingSharedFetchInterval         = 10
 	defaultRunMigrations                      = false
 	defaultDatabaseURL                        = "user=postgres password=postgres dbname=miniflux2 sslmode=disable"
 	defaultDatabaseMaxConns                   = 20
@@ -46,18 +57,41 @@ const (
 	defaultCleanupArchiveUnreadDays           = 180
 	defaultCleanupArchiveBatchSize            = 10000
 	defaultCleanupRemoveSessionsDays          = 30
+	defaultCleanupRemoveDeliveriesDays        = 30
+	defaultCleanupRemoveAuditLogsDays         = 180
 	defaultProxyImages                        = "http-only"
 	defaultProxyImageUrl                      = ""
+	defaultMediaProxyCacheDir                 = ""
+	defaultMediaProxyCacheSize                = 100
+	defaultMediaProxyCacheMaxAge              = 72
+	defaultMediaProxyAllowPrivateNetworks     = false
+	defaultMediaProxyResizeImages             = true
+	defaultOfflineEntries                     = 100
+	defaultOfflineImages                      = false
+	defaultStoryClustering                    = false
+	defaultStoryClusteringWindowHours         = 24
+	defaultStoryClusteringFrequency           = 15
+	defaultEntryScoringFrequency              = 60
+	defaultEPUBExportDirectory                = ""
+	defaultEPUBExportFrequency                = 60
 	defaultFetchYouTubeWatchTime              = false
 	defaultCreateAdmin                        = false
 	defaultAdminUsername                      = ""
 	defaultAdminPassword                      = ""
 	defaultOAuth2UserCreation                 = false
+	defaultOAuth2UserDefaultCategory          = "All"
+	defaultOAuth2UserDefaultTheme             = ""
+	defaultRegistrationEnabled                = false
+	defaultRegistrationApprovalRequired       = true
+	defaultInvitationExpirationHours          = 168
+	defaultTwoFactorRequired                  = false
 	defaultOAuth2ClientID                     = ""
 	defaultOAuth2ClientSecret                 = ""
 	defaultOAuth2RedirectURL                  = ""
 	defaultOAuth2OidcDiscoveryEndpoint        = ""
 	defaultOAuth2Provider                     = ""
+	defaultOAuth2UsernameClaim                = "email"
+	defaultOAuth2GroupsClaim                  = "groups"
 	defaultPocketConsumerKey                  = ""
 	defaultHTTPClientTimeout                  = 20
 	defaultHTTPClientMaxBodySize              = 15
@@ -90,6 +124,11 @@ type Options struct {
 	schedulerService                   bool
 	debug                              bool
 	serverTimingHeader                 bool
+	smtpHost                           string
+	smtpPort                           int
+	smtpUsername                       string
+	smtpPassword                       string
+	smtpFrom                           string
 	baseURL                            string
 	rootURL                            string
 	basePath                           string
@@ -107,25 +146,54 @@ type Options struct {
 	cleanupArchiveUnreadDays           int
 	cleanupArchiveBatchSize            int
 	cleanupRemoveSessionsDays          int
+	cleanupRemoveDeliveriesDays        int
+	cleanupRemoveAuditLogsDays         int
 	pollingFrequency                   int
 	batchSize                          int
+	integrationRetryFrequency          int
+	integrationRetryMaxAttempts        int
+	notificationFrequency              int
+	emailDigestFrequency               int
+	opmlSubscriptionFrequency          int
 	pollingScheduler                   string
 	schedulerEntryFrequencyMinInterval int
 	schedulerEntryFrequencyMaxInterval int
 	pollingParsingErrorLimit           int
+	pollingSharedFetchInterval         int
 	workerPoolSize                     int
 	createAdmin                        bool
 	adminUsername                      string
 	adminPassword                      string
 	proxyImages                        string
 	proxyImageUrl                      string
+	mediaProxyCacheDir                 string
+	mediaProxyCacheSize                int
+	mediaProxyCacheMaxAge              int
+	mediaProxyAllowPrivateNetworks     bool
+	mediaProxyResizeImages             bool
+	offlineEntries                     int
+	offlineImages                      bool
+	storyClustering                    bool
+	storyClusteringWindowHours         int
+	storyClusteringFrequency           int
+	entryScoringFrequency              int
+	epubExportDirectory                string
+	epubExportFrequency                int
 	fetchYouTubeWatchTime              bool
 	oauth2UserCreationAllowed          bool
+	oauth2UserDefaultCategory          string
+	oauth2UserDefaultTheme             string
+	registrationEnabled                bool
+	registrationApprovalRequired       bool
+	invitationExpirationHours          int
+	twoFactorRequired                  bool
 	oauth2ClientID                     string
 	oauth2ClientSecret                 string
 	oauth2RedirectURL                  string
 	oauth2OidcDiscoveryEndpoint        string
 	oauth2Provider                     string
+	oauth2Providers                    []string
+	oauth2ProviderOptions              map[string]*OAuth2ProviderOptions
 	pocketConsumerKey                  string
 	httpClientTimeout                  int
 	httpClientMaxBodySize              int64
@@ -156,6 +224,11 @@ func NewOptions() *Options {
 		schedulerService:                   defaultSchedulerService,
 		debug:                              defaultDebug,
 		serverTimingHeader:                 defaultTiming,
+		smtpHost:                           defaultSMTPHost,
+		smtpPort:                           defaultSMTPPort,
+		smtpUsername:                       defaultSMTPUsername,
+		smtpPassword:                       defaultSMTPPassword,
+		smtpFrom:                           defaultSMTPFrom,
 		baseURL:                            defaultBaseURL,
 		rootURL:                            defaultRootURL,
 		basePath:                           defaultBasePath,
@@ -173,23 +246,51 @@ func NewOptions() *Options {
 		cleanupArchiveUnreadDays:           defaultCleanupArchiveUnreadDays,
 		cleanupArchiveBatchSize:            defaultCleanupArchiveBatchSize,
 		cleanupRemoveSessionsDays:          defaultCleanupRemoveSessionsDays,
+		cleanupRemoveDeliveriesDays:        defaultCleanupRemoveDeliveriesDays,
+		cleanupRemoveAuditLogsDays:         defaultCleanupRemoveAuditLogsDays,
 		pollingFrequency:                   defaultPollingFrequency,
 		batchSize:                          defaultBatchSize,
+		integrationRetryFrequency:          defaultIntegrationRetryFrequency,
+		integrationRetryMaxAttempts:        defaultIntegrationRetryMaxAttempts,
+		notificationFrequency:              defaultNotificationFrequency,
+		emailDigestFrequency:               defaultEmailDigestFrequency,
+		opmlSubscriptionFrequency:          defaultOPMLSubscriptionFrequency,
 		pollingScheduler:                   defaultPollingScheduler,
 		schedulerEntryFrequencyMinInterval: defaultSchedulerEntryFrequencyMinInterval,
 		schedulerEntryFrequencyMaxInterval: defaultSchedulerEntryFrequencyMaxInterval,
 		pollingParsingErrorLimit:           defaultPollingParsingErrorLimit,
+		pollingSharedFetchInterval:         defaultPollingSharedFetchInterval,
 		workerPoolSize:                     defaultWorkerPoolSize,
 		createAdmin:                        defaultCreateAdmin,
 		proxyImages:                        defaultProxyImages,
 		proxyImageUrl:                      defaultProxyImageUrl,
+		mediaProxyCacheDir:                 defaultMediaProxyCacheDir,
+		mediaProxyCacheSize:                defaultMediaProxyCacheSize,
+		mediaProxyCacheMaxAge:              defaultMediaProxyCacheMaxAge,
+		mediaProxyAllowPrivateNetworks:     defaultMediaProxyAllowPrivateNetworks,
+		mediaProxyResizeImages:             defaultMediaProxyResizeImages,
+		offlineEntries:                     defaultOfflineEntries,
+		offlineImages:                      defaultOfflineImages,
+		storyClustering:                    defaultStoryClustering,
+		storyClusteringWindowHours:         defaultStoryClusteringWindowHours,
+		storyClusteringFrequency:           defaultStoryClusteringFrequency,
+		entryScoringFrequency:              defaultEntryScoringFrequency,
+		epubExportDirectory:                defaultEPUBExportDirectory,
+		epubExportFrequency:                defaultEPUBExportFrequency,
 		fetchYouTubeWatchTime:              defaultFetchYouTubeWatchTime,
 		oauth2UserCreationAllowed:          defaultOAuth2UserCreation,
+		oauth2UserDefaultCategory:          defaultOAuth2UserDefaultCategory,
+		oauth2UserDefaultTheme:             defaultOAuth2UserDefaultTheme,
+		registrationEnabled:                defaultRegistrationEnabled,
+		registrationApprovalRequired:       defaultRegistrationApprovalRequired,
+		invitationExpirationHours:          defaultInvitationExpirationHours,
+		twoFactorRequired:                  defaultTwoFactorRequired,
 		oauth2ClientID:                     defaultOAuth2ClientID,
 		oauth2ClientSecret:                 defaultOAuth2ClientSecret,
 		oauth2RedirectURL:                  defaultOAuth2RedirectURL,
 		oauth2OidcDiscoveryEndpoint:        defaultOAuth2OidcDiscoveryEndpoint,
 		oauth2Provider:                     defaultOAuth2Provider,
+		oauth2ProviderOptions:              make(map[string]*OAuth2ProviderOptions),
 		pocketConsumerKey:                  defaultPocketConsumerKey,
 		httpClientTimeout:                  defaultHTTPClientTimeout,
 		httpClientMaxBodySize:              defaultHTTPClientMaxBodySize * 1024 * 1024,
@@ -233,6 +334,31 @@ func (o *Options) HasServerTimingHeader() bool {
 	return o.serverTimingHeader
 }
 
+// SMTPHost returns the hostname of the SMTP server used to send emails.
+func (o *Options) SMTPHost() string {
+	return o.smtpHost
+}
+
+// SMTPPort returns the port of the SMTP server.
+func (o *Options) SMTPPort() int {
+	return o.smtpPort
+}
+
+// SMTPUsername returns the username used to authenticate to the SMTP server.
+func (o *Options) SMTPUsername() string {
+	return o.smtpUsername
+}
+
+// SMTPPassword returns the password used to authenticate to the SMTP server.
+func (o *Options) SMTPPassword() string {
+	return o.smtpPassword
+}
+
+// SMTPFrom returns the sender address of emails.
+func (o *Options) SMTPFrom() string {
+	return o.smtpFrom
+}
+
 // BaseURL returns the application base URL with path.
 func (o *Options) BaseURL() string {
 	return o.baseURL
@@ -318,6 +444,16 @@ func (o *Options) CleanupRemoveSessionsDays() int {
 	return o.cleanupRemoveSessionsDays
 }
 
+// CleanupRemoveDeliveriesDays returns the number of days after which to remove completed integration deliveries.
+func (o *Options) CleanupRemoveDeliveriesDays() int {
+	return o.cleanupRemoveDeliveriesDays
+}
+
+// CleanupRemoveAuditLogsDays returns the number of days after which to remove audit log entries.
+func (o *Options) CleanupRemoveAuditLogsDays() int {
+	return o.cleanupRemoveAuditLogsDays
+}
+
 // WorkerPoolSize returns the number of background worker.
 func (o *Options) WorkerPoolSize() int {
 	return o.workerPoolSize
@@ -333,6 +469,31 @@ func (o *Options) BatchSize() int {
 	return o.batchSize
 }
 
+// IntegrationRetryFrequency returns the interval in minutes to retry failed integration deliveries.
+func (o *Options) IntegrationRetryFrequency() int {
+	return o.integrationRetryFrequency
+}
+
+// IntegrationRetryMaxAttempts returns the maximum number of attempts to deliver an entry to an integration.
+func (o *Options) IntegrationRetryMaxAttempts() int {
+	return o.integrationRetryMaxAttempts
+}
+
+// NotificationFrequency returns the interval in minutes to send the queued notifications.
+func (o *Options) NotificationFrequency() int {
+	return o.notificationFrequency
+}
+
+// EmailDigestFrequency returns the interval in minutes to check for email digests to send.
+func (o *Options) EmailDigestFrequency() int {
+	return o.emailDigestFrequency
+}
+
+// OPMLSubscriptionFrequency returns the interval in minutes to synchronize remote OPML files.
+func (o *Options) OPMLSubscriptionFrequency() int {
+	return o.opmlSubscriptionFrequency
+}
+
 // PollingScheduler returns the scheduler used for polling feeds.
 func (o *Options) PollingScheduler() string {
 	return o.pollingScheduler
@@ -353,11 +514,46 @@ func (o *Options) PollingParsingErrorLimit() int {
 	return o.pollingParsingErrorLimit
 }
 
+// PollingSharedFetchInterval returns the interval in minutes during which a download is shared by all subscribers of a feed.
+func (o *Options) PollingSharedFetchInterval() int {
+	return o.pollingSharedFetchInterval
+}
+
 // IsOAuth2UserCreationAllowed returns true if user creation is allowed for OAuth2 users.
 func (o *Options) IsOAuth2UserCreationAllowed() bool {
 	return o.oauth2UserCreationAllowed
 }
 
+// OAuth2UserDefaultCategory returns the title of the first category of the users created by OAuth2.
+func (o *Options) OAuth2UserDefaultCategory() string {
+	return o.oauth2UserDefaultCategory
+}
+
+// OAuth2UserDefaultTheme returns the theme of the users created by OAuth2, the default theme is used when empty.
+func (o *Options) OAuth2UserDefaultTheme() string {
+	return o.oauth2UserDefaultTheme
+}
+
+// IsRegistrationEnabled returns true if visitors can create an account from the login page.
+func (o *Options) IsRegistrationEnabled() bool {
+	return o.registrationEnabled
+}
+
+// IsRegistrationApprovalRequired returns true if the accounts created by visitors must be approved by a user manager.
+func (o *Options) IsRegistrationApprovalRequired() bool {
+	return o.registrationApprovalRequired
+}
+
+// InvitationExpirationHours returns the number of hours during which an invitation link can be used.
+func (o *Options) InvitationExpirationHours() int {
+	return o.invitationExpirationHours
+}
+
+// IsTwoFactorRequired returns true if every user must set up two-factor authentication.
+func (o *Options) IsTwoFactorRequired() bool {
+	return o.twoFactorRequired
+}
+
 // OAuth2ClientID returns the OAuth2 Client ID.
 func (o *Options) OAuth2ClientID() string {
 	return o.oauth2ClientID
@@ -383,6 +579,83 @@ func (o *Options) OAuth2Provider() string {
 	return o.oauth2Provider
 }
 
+// OAuth2ProviderNames returns the names of the OAuth2 providers enabled in addition to OAUTH2_PROVIDER.
+func (o *Options) OAuth2ProviderNames() []string {
+	return o.oauth2Providers
+}
+
+// OAuth2Providers returns the settings of the enabled OAuth2 providers, OAUTH2_PROVIDER comes first.
+func (o *Options) OAuth2Providers() []*OAuth2ProviderOptions {
+	names := o.oauth2Providers
+	if o.oauth2Provider != "" {
+		names = append([]string{o.oauth2Provider}, names...)
+	}
+
+	var providers []*OAuth2ProviderOptions
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.ToLower(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		provider := OAuth2ProviderOptions{Name: name}
+		if options, found := o.oauth2ProviderOptions[name]; found {
+			provider = *options
+		}
+
+		// The provider of OAUTH2_PROVIDER keeps using the historical variables.
+		if name == strings.ToLower(o.oauth2Provider) {
+			provider.ClientID = fallbackString(provider.ClientID, o.oauth2ClientID)
+			provider.ClientSecret = fallbackString(provider.ClientSecret, o.oauth2ClientSecret)
+			provider.RedirectURL = fallbackString(provider.RedirectURL, o.oauth2RedirectURL)
+			provider.DiscoveryEndpoint = fallbackString(provider.DiscoveryEndpoint, o.oauth2OidcDiscoveryEndpoint)
+		}
+
+		provider.RedirectURL = fallbackString(provider.RedirectURL, o.baseURL+"/oauth2/"+name+"/callback")
+		provider.UsernameClaim = fallbackString(provider.UsernameClaim, defaultOAuth2UsernameClaim)
+		provider.GroupsClaim = fallbackString(provider.GroupsClaim, defaultOAuth2GroupsClaim)
+		providers = append(providers, &provider)
+	}
+
+	return providers
+}
+
+// OAuth2ProviderOptions holds the settings of an OAuth2 provider.
+type OAuth2ProviderOptions struct {
+	Name              string
+	Label             string
+	ClientID          string
+	ClientSecret      string
+	RedirectURL       string
+	DiscoveryEndpoint string
+	UsernameClaim     string
+	GroupsClaim       string
+	RoleMapping       []OAuth2RoleMapping
+}
+
+// OAuth2RoleMapping gives a role to the members of an OpenID Connect group.
+type OAuth2RoleMapping struct {
+	Group string
+	Role  string
+}
+
+func (o *Options) oauth2ProviderOption(name string) *OAuth2ProviderOptions {
+	name = strings.ToLower(name)
+	if _, found := o.oauth2ProviderOptions[name]; !found {
+		o.oauth2ProviderOptions[name] = &OAuth2ProviderOptions{Name: name}
+	}
+	return o.oauth2ProviderOptions[name]
+}
+
+func fallbackString(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
 // HasHSTS returns true if HTTP Strict Transport Security is enabled.
 func (o *Options) HasHSTS() bool {
 	return o.hsts
@@ -424,6 +697,71 @@ func (o *Options) ProxyImageUrl() string {
 	return o.proxyImageUrl
 }
 
+// MediaProxyCacheDir returns the directory where proxied media files are cached.
+func (o *Options) MediaProxyCacheDir() string {
+	return o.mediaProxyCacheDir
+}
+
+// MediaProxyCacheSize returns the maximum size of the media cache in megabytes.
+func (o *Options) MediaProxyCacheSize() int {
+	return o.mediaProxyCacheSize
+}
+
+// MediaProxyCacheMaxAge returns the number of hours a proxied media file is kept in the cache.
+func (o *Options) MediaProxyCacheMaxAge() int {
+	return o.mediaProxyCacheMaxAge
+}
+
+// MediaProxyAllowPrivateNetworks returns true if the media proxy can fetch files from private networks.
+func (o *Options) MediaProxyAllowPrivateNetworks() bool {
+	return o.mediaProxyAllowPrivateNetworks
+}
+
+// MediaProxyResizeImages returns true if the media proxy offers downscaled variants of images.
+func (o *Options) MediaProxyResizeImages() bool {
+	return o.mediaProxyResizeImages
+}
+
+// OfflineEntries returns the number of unread entries downloaded by the web app for offline reading.
+func (o *Options) OfflineEntries() int {
+	return o.offlineEntries
+}
+
+// OfflineImages returns true if the images of offline entries are downloaded through the media proxy.
+func (o *Options) OfflineImages() bool {
+	return o.offlineImages
+}
+
+// StoryClustering returns true if similar entries are grouped by story.
+func (o *Options) StoryClustering() bool {
+	return o.storyClustering
+}
+
+// StoryClusteringWindowHours returns the age in hours of the oldest entries compared when grouping stories.
+func (o *Options) StoryClusteringWindowHours() int {
+	return o.storyClusteringWindowHours
+}
+
+// StoryClusteringFrequency returns the interval in minutes between two story clustering runs.
+func (o *Options) StoryClusteringFrequency() int {
+	return o.storyClusteringFrequency
+}
+
+// EntryScoringFrequency returns the interval in minutes between two rankings of the unread entries.
+func (o *Options) EntryScoringFrequency() int {
+	return o.entryScoringFrequency
+}
+
+// EPUBExportDirectory returns the directory where the scheduled EPUB exports are written, an empty value disables this delivery.
+func (o *Options) EPUBExportDirectory() string {
+	return o.epubExportDirectory
+}
+
+// EPUBExportFrequency returns the interval in minutes to check for EPUB exports to deliver.
+func (o *Options) EPUBExportFrequency() int {
+	return o.epubExportFrequency
+}
+
 // HasHTTPService returns true if the HTTP service is enabled.
 func (o *Options) HasHTTPService() bool {
 	return o.httpService
@@ -519,6 +857,11 @@ func (o *Options) SortedOptions(redactSecret bool) []*Option {
 		"BASE_PATH":                              o.basePath,
 		"BASE_URL":                               o.baseURL,
 		"BATCH_SIZE":                             o.batchSize,
+		"INTEGRATION_RETRY_FREQUENCY":            o.integrationRetryFrequency,
+		"INTEGRATION_RETRY_MAX_ATTEMPTS":         o.integrationRetryMaxAttempts,
+		"NOTIFICATION_FREQUENCY":                 o.notificationFrequency,
+		"EMAIL_DIGEST_FREQUENCY":                 o.emailDigestFrequency,
+		"OPML_SUBSCRIPTION_FREQUENCY":            o.opmlSubscriptionFrequency,
 		"CERT_DOMAIN":                            o.certDomain,
 		"CERT_FILE":                              o.certFile,
 		"CLEANUP_ARCHIVE_READ_DAYS":              o.cleanupArchiveReadDays,
@@ -526,6 +869,8 @@ func (o *Options) SortedOptions(redactSecret bool) []*Option {
 		"CLEANUP_ARCHIVE_BATCH_SIZE":             o.cleanupArchiveBatchSize,
 		"CLEANUP_FREQUENCY_HOURS":                o.cleanupFrequencyHours,
 		"CLEANUP_REMOVE_SESSIONS_DAYS":           o.cleanupRemoveSessionsDays,
+		"CLEANUP_REMOVE_DELIVERIES_DAYS":         o.cleanupRemoveDeliveriesDays,
+		"CLEANUP_REMOVE_AUDIT_LOGS_DAYS":         o.cleanupRemoveAuditLogsDays,
 		"CREATE_ADMIN":                           o.createAdmin,
 		"DATABASE_MAX_CONNS":                     o.databaseMaxConns,
 		"DATABASE_MIN_CONNS":                     o.databaseMinConns,
@@ -555,14 +900,35 @@ func (o *Options) SortedOptions(redactSecret bool) []*Option {
 		"OAUTH2_CLIENT_SECRET":                   redactSecretValue(o.oauth2ClientSecret, redactSecret),
 		"OAUTH2_OIDC_DISCOVERY_ENDPOINT":         o.oauth2OidcDiscoveryEndpoint,
 		"OAUTH2_PROVIDER":                        o.oauth2Provider,
+		"OAUTH2_PROVIDERS":                       strings.Join(o.oauth2Providers, ","),
 		"OAUTH2_REDIRECT_URL":                    o.oauth2RedirectURL,
 		"OAUTH2_USER_CREATION":                   o.oauth2UserCreationAllowed,
+		"OAUTH2_USER_DEFAULT_CATEGORY":           o.oauth2UserDefaultCategory,
+		"OAUTH2_USER_DEFAULT_THEME":              o.oauth2UserDefaultTheme,
+		"REGISTRATION_ENABLED":                   o.registrationEnabled,
+		"REGISTRATION_APPROVAL_REQUIRED":         o.registrationApprovalRequired,
+		"INVITATION_EXPIRATION_HOURS":            o.invitationExpirationHours,
+		"TWO_FACTOR_REQUIRED":                    o.twoFactorRequired,
 		"POCKET_CONSUMER_KEY":                    redactSecretValue(o.pocketConsumerKey, redactSecret),
 		"POLLING_FREQUENCY":                      o.pollingFrequency,
 		"POLLING_PARSING_ERROR_LIMIT":            o.pollingParsingErrorLimit,
+		"POLLING_SHARED_FETCH_INTERVAL":          o.pollingSharedFetchInterval,
 		"POLLING_SCHEDULER":                      o.pollingScheduler,
 		"PROXY_IMAGES":                           o.proxyImages,
 		"PROXY_IMAGE_URL":                        o.proxyImageUrl,
+		"MEDIA_PROXY_CACHE_DIR":                  o.mediaProxyCacheDir,
+		"MEDIA_PROXY_CACHE_SIZE":                 o.mediaProxyCacheSize,
+		"MEDIA_PROXY_CACHE_MAX_AGE":              o.mediaProxyCacheMaxAge,
+		"MEDIA_PROXY_ALLOW_PRIVATE_NETWORKS":     o.mediaProxyAllowPrivateNetworks,
+		"MEDIA_PROXY_RESIZE_IMAGES":              o.mediaProxyResizeImages,
+		"OFFLINE_ENTRIES":                        o.offlineEntries,
+		"OFFLINE_IMAGES":                         o.offlineImages,
+		"STORY_CLUSTERING":                       o.storyClustering,
+		"STORY_CLUSTERING_WINDOW_HOURS":          o.storyClusteringWindowHours,
+		"STORY_CLUSTERING_FREQUENCY":             o.storyClusteringFrequency,
+		"ENTRY_SCORING_FREQUENCY":                o.entryScoringFrequency,
+		"EPUB_EXPORT_DIRECTORY":                  o.epubExportDirectory,
+		"EPUB_EXPORT_FREQUENCY":                  o.epubExportFrequency,
 		"PROXY_PRIVATE_KEY":                      redactSecretValue(string(o.proxyPrivateKey), redactSecret),
 		"ROOT_URL":                               o.rootURL,
 		"RUN_MIGRATIONS":                         o.runMigrations,
@@ -570,6 +936,11 @@ func (o *Options) SortedOptions(redactSecret bool) []*Option {
 		"SCHEDULER_ENTRY_FREQUENCY_MIN_INTERVAL": o.schedulerEntryFrequencyMinInterval,
 		"SCHEDULER_SERVICE":                      o.schedulerService,
 		"SERVER_TIMING_HEADER":                   o.serverTimingHeader,
+		"SMTP_HOST":                              o.smtpHost,
+		"SMTP_PORT":                              o.smtpPort,
+		"SMTP_USERNAME":                          o.smtpUsername,
+		"SMTP_PASSWORD":                          redactSecretValue(o.smtpPassword, redactSecret),
+		"SMTP_FROM":                              o.smtpFrom,
 		"WORKER_POOL_SIZE":                       o.workerPoolSize,
 		"WATCHDOG":                               o.watchdog,
 	}
@@ -13,6 +13,7 @@ import (
 	"io"
 	url_parser "net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -79,6 +80,16 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.debug = parseBool(value, defaultDebug)
 		case "SERVER_TIMING_HEADER":
 			p.opts.serverTimingHeader = parseBool(value, defaultTiming)
+		case "SMTP_HOST":
+			p.opts.smtpHost = parseString(value, defaultSMTPHost)
+		case "SMTP_PORT":
+			p.opts.smtpPort = parseInt(value, defaultSMTPPort)
+		case "SMTP_USERNAME":
+			p.opts.smtpUsername = parseString(value, defaultSMTPUsername)
+		case "SMTP_PASSWORD":
+			p.opts.smtpPassword = parseString(value, defaultSMTPPassword)
+		case "SMTP_FROM":
+			p.opts.smtpFrom = parseString(value, defaultSMTPFrom)
 		case "BASE_URL":
 			p.opts.baseURL, p.opts.rootURL, p.opts.basePath, err = parseBaseURL(value)
 			if err != nil {
@@ -124,12 +135,26 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.cleanupArchiveBatchSize = parseInt(value, defaultCleanupArchiveBatchSize)
 		case "CLEANUP_REMOVE_SESSIONS_DAYS":
 			p.opts.cleanupRemoveSessionsDays = parseInt(value, defaultCleanupRemoveSessionsDays)
+		case "CLEANUP_REMOVE_DELIVERIES_DAYS":
+			p.opts.cleanupRemoveDeliveriesDays = parseInt(value, defaultCleanupRemoveDeliveriesDays)
+		case "CLEANUP_REMOVE_AUDIT_LOGS_DAYS":
+			p.opts.cleanupRemoveAuditLogsDays = parseInt(value, defaultCleanupRemoveAuditLogsDays)
 		case "WORKER_POOL_SIZE":
 			p.opts.workerPoolSize = parseInt(value, defaultWorkerPoolSize)
 		case "POLLING_FREQUENCY":
 			p.opts.pollingFrequency = parseInt(value, defaultPollingFrequency)
 		case "BATCH_SIZE":
 			p.opts.batchSize = parseInt(value, defaultBatchSize)
+		case "INTEGRATION_RETRY_FREQUENCY":
+			p.opts.integrationRetryFrequency = parseInt(value, defaultIntegrationRetryFrequency)
+		case "INTEGRATION_RETRY_MAX_ATTEMPTS":
+			p.opts.integrationRetryMaxAttempts = parseInt(value, defaultIntegrationRetryMaxAttempts)
+		case "NOTIFICATION_FREQUENCY":
+			p.opts.notificationFrequency = parseInt(value, defaultNotificationFrequency)
+		case "EMAIL_DIGEST_FREQUENCY":
+			p.opts.emailDigestFrequency = parseInt(value, defaultEmailDigestFrequency)
+		case "OPML_SUBSCRIPTION_FREQUENCY":
+			p.opts.opmlSubscriptionFrequency = parseInt(value, defaultOPMLSubscriptionFrequency)
 		case "POLLING_SCHEDULER":
 			p.opts.pollingScheduler = strings.ToLower(parseString(value, defaultPollingScheduler))
 		case "SCHEDULER_ENTRY_FREQUENCY_MAX_INTERVAL":
@@ -138,10 +163,38 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.schedulerEntryFrequencyMinInterval = parseInt(value, defaultSchedulerEntryFrequencyMinInterval)
 		case "POLLING_PARSING_ERROR_LIMIT":
 			p.opts.pollingParsingErrorLimit = parseInt(value, defaultPollingParsingErrorLimit)
+		case "POLLING_SHARED_FETCH_INTERVAL":
+			p.opts.pollingSharedFetchInterval = parseInt(value, defaultPollingSharedFetchInterval)
 		case "PROXY_IMAGES":
 			p.opts.proxyImages = parseString(value, defaultProxyImages)
 		case "PROXY_IMAGE_URL":
 			p.opts.proxyImageUrl = parseString(value, defaultProxyImageUrl)
+		case "MEDIA_PROXY_CACHE_DIR":
+			p.opts.mediaProxyCacheDir = parseString(value, defaultMediaProxyCacheDir)
+		case "MEDIA_PROXY_CACHE_SIZE":
+			p.opts.mediaProxyCacheSize = parseInt(value, defaultMediaProxyCacheSize)
+		case "MEDIA_PROXY_CACHE_MAX_AGE":
+			p.opts.mediaProxyCacheMaxAge = parseInt(value, defaultMediaProxyCacheMaxAge)
+		case "MEDIA_PROXY_ALLOW_PRIVATE_NETWORKS":
+			p.opts.mediaProxyAllowPrivateNetworks = parseBool(value, defaultMediaProxyAllowPrivateNetworks)
+		case "MEDIA_PROXY_RESIZE_IMAGES":
+			p.opts.mediaProxyResizeImages = parseBool(value, defaultMediaProxyResizeImages)
+		case "OFFLINE_ENTRIES":
+			p.opts.offlineEntries = parseInt(value, defaultOfflineEntries)
+		case "OFFLINE_IMAGES":
+			p.opts.offlineImages = parseBool(value, defaultOfflineImages)
+		case "STORY_CLUSTERING":
+			p.opts.storyClustering = parseBool(value, defaultStoryClustering)
+		case "STORY_CLUSTERING_WINDOW_HOURS":
+			p.opts.storyClusteringWindowHours = parseInt(value, defaultStoryClusteringWindowHours)
+		case "STORY_CLUSTERING_FREQUENCY":
+			p.opts.storyClusteringFrequency = parseInt(value, defaultStoryClusteringFrequency)
+		case "ENTRY_SCORING_FREQUENCY":
+			p.opts.entryScoringFrequency = parseInt(value, defaultEntryScoringFrequency)
+		case "EPUB_EXPORT_DIRECTORY":
+			p.opts.epubExportDirectory = parseString(value, defaultEPUBExportDirectory)
+		case "EPUB_EXPORT_FREQUENCY":
+			p.opts.epubExportFrequency = parseInt(value, defaultEPUBExportFrequency)
 		case "CREATE_ADMIN":
 			p.opts.createAdmin = parseBool(value, defaultCreateAdmin)
 		case "ADMIN_USERNAME":
@@ -158,6 +211,18 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.pocketConsumerKey = readSecretFile(value, defaultPocketConsumerKey)
 		case "OAUTH2_USER_CREATION":
 			p.opts.oauth2UserCreationAllowed = parseBool(value, defaultOAuth2UserCreation)
+		case "OAUTH2_USER_DEFAULT_CATEGORY":
+			p.opts.oauth2UserDefaultCategory = parseString(value, defaultOAuth2UserDefaultCategory)
+		case "OAUTH2_USER_DEFAULT_THEME":
+			p.opts.oauth2UserDefaultTheme = parseString(value, defaultOAuth2UserDefaultTheme)
+		case "REGISTRATION_ENABLED":
+			p.opts.registrationEnabled = parseBool(value, defaultRegistrationEnabled)
+		case "REGISTRATION_APPROVAL_REQUIRED":
+			p.opts.registrationApprovalRequired = parseBool(value, defaultRegistrationApprovalRequired)
+		case "INVITATION_EXPIRATION_HOURS":
+			p.opts.invitationExpirationHours = parseInt(value, defaultInvitationExpirationHours)
+		case "TWO_FACTOR_REQUIRED":
+			p.opts.twoFactorRequired = parseBool(value, defaultTwoFactorRequired)
 		case "OAUTH2_CLIENT_ID":
 			p.opts.oauth2ClientID = parseString(value, defaultOAuth2ClientID)
 		case "OAUTH2_CLIENT_ID_FILE":
@@ -172,6 +237,8 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			p.opts.oauth2OidcDiscoveryEndpoint = parseString(value, defaultOAuth2OidcDiscoveryEndpoint)
 		case "OAUTH2_PROVIDER":
 			p.opts.oauth2Provider = parseString(value, defaultOAuth2Provider)
+		case "OAUTH2_PROVIDERS":
+			p.opts.oauth2Providers = parseStringList(value, nil)
 		case "HTTP_CLIENT_TIMEOUT":
 			p.opts.httpClientTimeout = parseInt(value, defaultHTTPClientTimeout)
 		case "HTTP_CLIENT_MAX_BODY_SIZE":
@@ -204,6 +271,8 @@ func (p *Parser) parseLines(lines []string) (err error) {
 			randomKey := make([]byte, 16)
 			rand.Read(randomKey)
 			p.opts.proxyPrivateKey = parseBytes(value, randomKey)
+		default:
+			p.parseOAuth2ProviderOption(key, value)
 		}
 	}
 
@@ -213,6 +282,52 @@ func (p *Parser) parseLines(lines []string) (err error) {
 	return nil
 }
 
+var oauth2ProviderOptionPattern = regexp.MustCompile(`^OAUTH2_([A-Z0-9]+)_(CLIENT_ID|CLIENT_SECRET|CLIENT_SECRET_FILE|REDIRECT_URL|OIDC_DISCOVERY_ENDPOINT|LABEL|USERNAME_CLAIM|GROUPS_CLAIM|ROLE_MAPPING)$`)
+
+// parseOAuth2ProviderOption handles the variables of the providers listed in OAUTH2_PROVIDERS, for example OAUTH2_KEYCLOAK_CLIENT_ID.
+func (p *Parser) parseOAuth2ProviderOption(key, value string) {
+	matches := oauth2ProviderOptionPattern.FindStringSubmatch(key)
+	if matches == nil {
+		return
+	}
+
+	provider := p.opts.oauth2ProviderOption(matches[1])
+	switch matches[2] {
+	case "CLIENT_ID":
+		provider.ClientID = parseString(value, "")
+	case "CLIENT_SECRET":
+		provider.ClientSecret = parseString(value, "")
+	case "CLIENT_SECRET_FILE":
+		provider.ClientSecret = readSecretFile(value, "")
+	case "REDIRECT_URL":
+		provider.RedirectURL = parseString(value, "")
+	case "OIDC_DISCOVERY_ENDPOINT":
+		provider.DiscoveryEndpoint = parseString(value, "")
+	case "LABEL":
+		provider.Label = parseString(value, "")
+	case "USERNAME_CLAIM":
+		provider.UsernameClaim = parseString(value, "")
+	case "GROUPS_CLAIM":
+		provider.GroupsClaim = parseString(value, "")
+	case "ROLE_MAPPING":
+		provider.RoleMapping = parseOAuth2RoleMapping(value)
+	}
+}
+
+// parseOAuth2RoleMapping parses a list like "ops=admin,friends=guest", the order gives the precedence.
+func parseOAuth2RoleMapping(value string) []OAuth2RoleMapping {
+	var mapping []OAuth2RoleMapping
+	for _, item := range parseStringList(value, nil) {
+		group, role, found := strings.Cut(item, "=")
+		group = strings.TrimSpace(group)
+		role = strings.TrimSpace(role)
+		if found && group != "" && role != "" {
+			mapping = append(mapping, OAuth2RoleMapping{Group: group, Role: role})
+		}
+	}
+	return mapping
+}
+
 func parseBaseURL(value string) (string, string, string, error) {
 	if value == "" {
 		return defaultBaseURL, defaultRootURL, "", nil
@@ -656,4 +656,503 @@ var migrations = []func(tx *sql.Tx) error{
 		_, err = tx.Exec(sql)
 		return
 	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE integration_providers (
+				user_id int not null references users(id) on delete cascade,
+				provider text not null,
+				enabled bool not null default 'f',
+				settings jsonb not null default '{}',
+				primary key (user_id, provider)
+			);
+
+			INSERT INTO integration_providers (user_id, provider, enabled, settings)
+				SELECT
+					user_id,
+					'pinboard',
+					coalesce(pinboard_enabled, 'f'),
+					jsonb_build_object(
+						'token', coalesce(pinboard_token, ''),
+						'tags', coalesce(pinboard_tags, ''),
+						'mark_as_unread', coalesce(pinboard_mark_as_unread, 'f')::text
+					)
+				FROM integrations;
+
+			INSERT INTO integration_providers (user_id, provider, enabled, settings)
+				SELECT
+					user_id,
+					'wallabag',
+					coalesce(wallabag_enabled, 'f'),
+					jsonb_build_object(
+						'only_url', coalesce(wallabag_only_url, 'f')::text,
+						'url', coalesce(wallabag_url, ''),
+						'client_id', coalesce(wallabag_client_id, ''),
+						'client_secret', coalesce(wallabag_client_secret, ''),
+						'username', coalesce(wallabag_username, ''),
+						'password', coalesce(wallabag_password, '')
+					)
+				FROM integrations;
+
+			INSERT INTO integration_providers (user_id, provider, enabled, settings)
+				SELECT
+					user_id,
+					'pocket',
+					coalesce(pocket_enabled, 'f'),
+					jsonb_build_object(
+						'access_token', coalesce(pocket_access_token, ''),
+						'consumer_key', coalesce(pocket_consumer_key, '')
+					)
+				FROM integrations;
+
+			INSERT INTO integration_providers (user_id, provider, enabled, settings)
+				SELECT
+					user_id,
+					'telegram_bot',
+					coalesce(telegram_bot_enabled, 'f'),
+					jsonb_build_object(
+						'token', coalesce(telegram_bot_token, ''),
+						'chat_id', coalesce(telegram_bot_chat_id, '')
+					)
+				FROM integrations;
+
+			INSERT INTO integration_providers (user_id, provider, enabled, settings)
+				SELECT
+					user_id,
+					'matrix_bot',
+					coalesce(matrix_bot_enabled, 'f'),
+					jsonb_build_object(
+						'user', coalesce(matrix_bot_user, ''),
+						'password', coalesce(matrix_bot_password, ''),
+						'url', coalesce(matrix_bot_url, ''),
+						'chat_id', coalesce(matrix_bot_chat_id, '')
+					)
+				FROM integrations;
+
+			ALTER TABLE integrations
+				DROP COLUMN pinboard_enabled,
+				DROP COLUMN pinboard_token,
+				DROP COLUMN pinboard_tags,
+				DROP COLUMN pinboard_mark_as_unread,
+				DROP COLUMN wallabag_enabled,
+				DROP COLUMN wallabag_only_url,
+				DROP COLUMN wallabag_url,
+				DROP COLUMN wallabag_client_id,
+				DROP COLUMN wallabag_client_secret,
+				DROP COLUMN wallabag_username,
+				DROP COLUMN wallabag_password,
+				DROP COLUMN pocket_enabled,
+				DROP COLUMN pocket_access_token,
+				DROP COLUMN pocket_consumer_key,
+				DROP COLUMN telegram_bot_enabled,
+				DROP COLUMN telegram_bot_token,
+				DROP COLUMN telegram_bot_chat_id,
+				DROP COLUMN matrix_bot_enabled,
+				DROP COLUMN matrix_bot_user,
+				DROP COLUMN matrix_bot_password,
+				DROP COLUMN matrix_bot_url,
+				DROP COLUMN matrix_bot_chat_id;
+		`
+		_, err = tx.Exec(sql)
+		return
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			INSERT INTO integration_providers (user_id, provider, enabled, settings)
+				SELECT
+					user_id,
+					'instapaper',
+					coalesce(instapaper_enabled, 'f'),
+					jsonb_build_object(
+						'username', coalesce(instapaper_username, ''),
+						'password', coalesce(instapaper_password, '')
+					)
+				FROM integrations;
+
+			INSERT INTO integration_providers (user_id, provider, enabled, settings)
+				SELECT
+					user_id,
+					'nunux_keeper',
+					coalesce(nunux_keeper_enabled, 'f'),
+					jsonb_build_object(
+						'url', coalesce(nunux_keeper_url, ''),
+						'api_key', coalesce(nunux_keeper_api_key, '')
+					)
+				FROM integrations;
+
+			INSERT INTO integration_providers (user_id, provider, enabled, settings)
+				SELECT
+					user_id,
+					'espial',
+					coalesce(espial_enabled, 'f'),
+					jsonb_build_object(
+						'url', coalesce(espial_url, ''),
+						'api_key', coalesce(espial_api_key, ''),
+						'tags', coalesce(espial_tags, '')
+					)
+				FROM integrations;
+
+			INSERT INTO integration_providers (user_id, provider, enabled, settings)
+				SELECT
+					user_id,
+					'linkding',
+					coalesce(linkding_enabled, 'f'),
+					jsonb_build_object(
+						'url', coalesce(linkding_url, ''),
+						'api_key', coalesce(linkding_api_key, '')
+					)
+				FROM integrations;
+
+			ALTER TABLE integrations
+				DROP COLUMN instapaper_enabled,
+				DROP COLUMN instapaper_username,
+				DROP COLUMN instapaper_password,
+				DROP COLUMN nunux_keeper_enabled,
+				DROP COLUMN nunux_keeper_url,
+				DROP COLUMN nunux_keeper_api_key,
+				DROP COLUMN espial_enabled,
+				DROP COLUMN espial_url,
+				DROP COLUMN espial_api_key,
+				DROP COLUMN espial_tags,
+				DROP COLUMN linkding_enabled,
+				DROP COLUMN linkding_url,
+				DROP COLUMN linkding_api_key;
+
+			CREATE TABLE integration_deliveries (
+				id bigserial not null,
+				user_id int not null,
+				entry_id bigint not null,
+				provider text not null,
+				action text not null,
+				status text not null,
+				attempts int not null default 0,
+				last_error text not null default '',
+				next_attempt_at timestamp with time zone,
+				created_at timestamp with time zone not null default now(),
+				updated_at timestamp with time zone not null default now(),
+				primary key (id),
+				unique (entry_id, provider, action),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (entry_id) references entries(id) on delete cascade
+			);
+
+			CREATE INDEX integration_deliveries_status_idx ON integration_deliveries(status, next_attempt_at);
+			CREATE INDEX integration_deliveries_user_idx ON integration_deliveries(user_id, updated_at);
+		`
+		_, err = tx.Exec(sql)
+		return
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN notify bool not null default 'f';
+			ALTER TABLE categories ADD COLUMN notify bool not null default 'f';
+
+			ALTER TABLE integrations ADD COLUMN notification_rules text not null default '';
+			ALTER TABLE integrations ADD COLUMN notification_quiet_hours_start int not null default 0;
+			ALTER TABLE integrations ADD COLUMN notification_quiet_hours_end int not null default 0;
+			ALTER TABLE integrations ADD COLUMN notification_digest_interval int not null default 0;
+			ALTER TABLE integrations ADD COLUMN notification_digest_sent_at timestamp with time zone;
+
+			CREATE TABLE notification_queue (
+				user_id int not null,
+				entry_id bigint not null,
+				created_at timestamp with time zone not null default now(),
+				primary key (user_id, entry_id),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (entry_id) references entries(id) on delete cascade
+			);
+
+			-- New entries were pushed for all feeds before, keep this behavior for existing users.
+			UPDATE feeds SET notify='t' WHERE user_id IN (
+				SELECT user_id FROM integration_providers WHERE provider IN ('telegram_bot', 'matrix_bot', 'apprise') AND enabled='t'
+			);
+		`
+		_, err = tx.Exec(sql)
+		return
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE email_digests (
+				user_id int not null primary key,
+				enabled bool not null default 'f',
+				email text not null default '',
+				frequency text not null default 'daily',
+				hour int not null default 8,
+				weekday int not null default 1,
+				category_ids bigint[] not null default '{}',
+				token text not null unique,
+				sent_at timestamp with time zone,
+				foreign key (user_id) references users(id) on delete cascade
+			);
+		`
+		_, err = tx.Exec(sql)
+		return
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE opml_subscriptions (
+				id bigserial not null,
+				user_id int not null,
+				url text not null,
+				category_id int,
+				removal_policy text not null default 'keep',
+				etag_header text not null default '',
+				last_modified_header text not null default '',
+				checked_at timestamp with time zone,
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				unique (user_id, url),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (category_id) references categories(id) on delete set null
+			);
+
+			CREATE TABLE opml_subscription_feeds (
+				subscription_id bigint not null,
+				feed_id bigint not null,
+				disabled bool not null default 'f',
+				primary key (subscription_id, feed_id),
+				foreign key (subscription_id) references opml_subscriptions(id) on delete cascade,
+				foreign key (feed_id) references feeds(id) on delete cascade
+			);
+
+			CREATE TABLE opml_subscription_syncs (
+				id bigserial not null,
+				subscription_id bigint not null,
+				created int not null default 0,
+				disabled int not null default 0,
+				removed int not null default 0,
+				failed int not null default 0,
+				error_msg text not null default '',
+				synced_at timestamp with time zone not null default now(),
+				primary key (id),
+				foreign key (subscription_id) references opml_subscriptions(id) on delete cascade
+			);
+
+			CREATE INDEX opml_subscription_syncs_subscription_idx ON opml_subscription_syncs(subscription_id, synced_at);
+		`
+		_, err = tx.Exec(sql)
+		return
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN role text not null default 'user';
+			UPDATE users SET role='admin' WHERE is_admin='t';
+
+			CREATE TABLE guest_categories (
+				user_id bigint not null,
+				category_id bigint not null,
+				primary key (user_id, category_id),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (category_id) references categories(id) on delete cascade
+			);
+
+			ALTER TABLE feeds ADD COLUMN shared_feed_id bigint references feeds(id) on delete cascade;
+			CREATE INDEX feeds_shared_feed_idx ON feeds(shared_feed_id) WHERE shared_feed_id IS NOT NULL;
+		`
+		_, err = tx.Exec(sql)
+		return
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN status text not null default 'active';
+			ALTER TABLE users ADD COLUMN email text not null default '';
+			CREATE UNIQUE INDEX users_email_idx ON users(lower(email)) WHERE email <> '';
+
+			CREATE TABLE user_invitations (
+				id bigserial not null,
+				token text not null,
+				email text not null default '',
+				role text not null default 'user',
+				created_by bigint not null,
+				expires_at timestamp with time zone not null,
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				unique (token),
+				foreign key (created_by) references users(id) on delete cascade
+			);
+
+			CREATE TABLE password_reset_tokens (
+				token text not null,
+				user_id bigint not null,
+				expires_at timestamp with time zone not null,
+				created_at timestamp with time zone not null default now(),
+				primary key (token),
+				foreign key (user_id) references users(id) on delete cascade
+			);
+		`
+		_, err = tx.Exec(sql)
+		return
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE users ADD COLUMN totp_secret text not null default '';
+			ALTER TABLE users ADD COLUMN totp_enabled bool not null default 'f';
+			ALTER TABLE users ADD COLUMN totp_last_counter bigint not null default 0;
+			ALTER TABLE users ADD COLUMN two_factor_required bool not null default 'f';
+
+			CREATE TABLE recovery_codes (
+				id bigserial not null,
+				user_id bigint not null,
+				code_hash text not null,
+				used_at timestamp with time zone,
+				primary key (id),
+				unique (user_id, code_hash),
+				foreign key (user_id) references users(id) on delete cascade
+			);
+
+			CREATE TABLE app_passwords (
+				id bigserial not null,
+				user_id bigint not null,
+				description text not null,
+				password_hash text not null,
+				last_used_at timestamp with time zone,
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				unique (user_id, description),
+				foreign key (user_id) references users(id) on delete cascade
+			);
+		`
+		_, err = tx.Exec(sql)
+		return
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE user_sessions ADD COLUMN idp_session_id text not null default '';
+			CREATE INDEX user_sessions_idp_session_id_idx ON user_sessions(idp_session_id) WHERE idp_session_id <> '';
+		`
+		_, err = tx.Exec(sql)
+		return
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE audit_logs (
+				id bigserial not null,
+				user_id bigint,
+				username text not null default '',
+				source text not null,
+				action text not null,
+				target text not null default '',
+				details text not null default '',
+				ip text not null default '',
+				user_agent text not null default '',
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				foreign key (user_id) references users(id) on delete set null
+			);
+
+			CREATE INDEX audit_logs_created_at_idx ON audit_logs(created_at);
+			CREATE INDEX audit_logs_user_id_idx ON audit_logs(user_id);
+			CREATE INDEX audit_logs_action_idx ON audit_logs(action);
+		`
+		_, err = tx.Exec(sql)
+		return
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE published_feeds (
+				id bigserial not null,
+				user_id bigint not null,
+				title text not null,
+				kind text not null,
+				token text not null,
+				category_id bigint,
+				feed_id bigint,
+				search_query text not null default '',
+				unread_only bool not null default 'f',
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				unique (token),
+				unique (user_id, title),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (category_id) references categories(id) on delete cascade,
+				foreign key (feed_id) references feeds(id) on delete cascade
+			);
+		`
+		_, err = tx.Exec(sql)
+		return
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN reading_progress smallint not null default 0;
+			ALTER TABLE entries ADD COLUMN scroll_position int not null default 0;
+			CREATE INDEX entries_user_reading_progress_idx ON entries(user_id) WHERE reading_progress > 0 AND reading_progress < 100;
+			ALTER TABLE users ADD COLUMN mark_read_at_end bool not null default 'f';
+		`
+		_, err = tx.Exec(sql)
+		return
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN cluster_id bigint;
+			CREATE INDEX entries_user_cluster_idx ON entries(user_id, cluster_id) WHERE cluster_id IS NOT NULL;
+		`
+		_, err = tx.Exec(sql)
+		return
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN score real not null default 0;
+			ALTER TABLE users ADD COLUMN entry_scoring bool not null default 'f';
+		`
+		_, err = tx.Exec(sql)
+		return
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE epub_exports (
+				id bigserial not null,
+				user_id bigint not null,
+				title text not null,
+				kind text not null,
+				category_id bigint,
+				search_query text not null default '',
+				delivery text not null default '',
+				email text not null default '',
+				frequency text not null default 'daily',
+				delivered_at timestamp with time zone,
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				unique (user_id, title),
+				foreign key (user_id) references users(id) on delete cascade,
+				foreign key (category_id) references categories(id) on delete cascade
+			);
+		`
+		_, err = tx.Exec(sql)
+		return
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			CREATE TABLE author_preferences (
+				id bigserial not null,
+				user_id bigint not null,
+				author text not null,
+				status text not null,
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				foreign key (user_id) references users(id) on delete cascade
+			);
+			CREATE UNIQUE INDEX author_preferences_user_author_idx ON author_preferences(user_id, lower(author));
+			CREATE INDEX entries_user_author_idx ON entries(user_id, lower(author));
+		`
+		_, err = tx.Exec(sql)
+		return
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN retention_mode text not null default '';
+			ALTER TABLE feeds ADD COLUMN retention_value int not null default 0;
+			ALTER TABLE feeds ADD COLUMN retention_delete bool not null default 'f';
+			ALTER TABLE categories ADD COLUMN retention_mode text not null default '';
+			ALTER TABLE categories ADD COLUMN retention_value int not null default 0;
+			ALTER TABLE categories ADD COLUMN retention_delete bool not null default 'f';
+			ALTER TABLE users ADD COLUMN retention_max_days int not null default 0;
+			ALTER TABLE users ADD COLUMN retention_max_entries int not null default 0;
+		`
+		_, err = tx.Exec(sql)
+		return
+	},
+	func(tx *sql.Tx) (err error) {
+		// The feeds shared with guests use the credentials of their owner when refreshed.
+		_, err = tx.Exec(`UPDATE feeds SET username='', password='', cookie='' WHERE shared_feed_id IS NOT NULL`)
+		return
+	},
 }
@@ -0,0 +1,137 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package digest // import "miniflux.app/digest"
+
+import (
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/locale"
+	"miniflux.app/logger"
+	"miniflux.app/mail"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+	"miniflux.app/template"
+	"miniflux.app/timezone"
+)
+
+// maxEntries is the maximum number of entries included in a digest.
+const maxEntries = 100
+
+// UnsubscribePath is the path of the page that disables the digest without being logged in.
+const UnsubscribePath = "/digest/unsubscribe/"
+
+// SendDueDigests sends the digests that are due according to the user preferences, inactive users are skipped.
+func SendDueDigests(store *storage.Storage, tpl *template.Engine) {
+	digests, err := store.EnabledEmailDigests()
+	if err != nil {
+		logger.Error("[Digest] %v", err)
+		return
+	}
+
+	for _, digest := range digests {
+		user, err := store.UserByID(digest.UserID)
+		if err != nil {
+			logger.Error("[Digest] %v", err)
+			continue
+		}
+
+		if user == nil || !user.IsActive() || !IsDue(timezone.Now(user.Timezone), digest) {
+			continue
+		}
+
+		if err := Send(store, tpl, user, digest); err != nil {
+			logger.Error("[Digest] User #%d: %v", user.ID, err)
+		}
+	}
+}
+
+// Send renders and sends the digest of a user.
+// Nothing is sent when there is no new unread entry.
+func Send(store *storage.Storage, tpl *template.Engine, user *model.User, digest *model.EmailDigest) error {
+	entries, err := Entries(store, digest, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if len(entries) > 0 {
+		logger.Debug("[Digest] Sending %d entries to User #%d", len(entries), user.ID)
+
+		printer := locale.NewPrinter(user.Language)
+		err = mail.Send(&mail.Message{
+			To:      digest.Email,
+			Subject: printer.Plural("email.digest.subject", len(entries), len(entries)),
+			Body:    Render(tpl, user, digest, entries),
+			Headers: map[string]string{"List-Unsubscribe": "<" + UnsubscribeURL(digest.Token) + ">"},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return store.UpdateEmailDigestSentAt(user.ID)
+}
+
+// Entries returns the unread entries created since the previous digest.
+func Entries(store *storage.Storage, digest *model.EmailDigest, now time.Time) (model.Entries, error) {
+	builder := store.NewEntryQueryBuilder(digest.UserID)
+	builder.WithStatus(model.EntryStatusUnread)
+	builder.WithCategoryIDs(digest.CategoryIDs)
+	builder.CreatedAfter(since(now, digest))
+	builder.WithOrder("published_at")
+	builder.WithDirection("desc")
+	builder.WithLimit(maxEntries)
+	return builder.GetEntries()
+}
+
+// Render returns the HTML body of the digest email.
+func Render(tpl *template.Engine, user *model.User, digest *model.EmailDigest, entries model.Entries) []byte {
+	return tpl.Render("digest_email.html", map[string]interface{}{
+		"language":       user.Language,
+		"theme":          user.Theme,
+		"user":           user,
+		"digest":         digest,
+		"entries":        entries,
+		"unsubscribeURL": UnsubscribeURL(digest.Token),
+		"baseURL":        config.Opts.BaseURL(),
+	})
+}
+
+// UnsubscribeURL returns the absolute URL to disable the digest.
+func UnsubscribeURL(token string) string {
+	return config.Opts.BaseURL() + UnsubscribePath + token
+}
+
+// IsDue returns true if the digest must be sent at the given time, expressed in the user timezone.
+// A digest is sent at most once a day, after the chosen hour, and only on the chosen weekday for weekly digests.
+func IsDue(now time.Time, digest *model.EmailDigest) bool {
+	if now.Hour() < digest.Hour {
+		return false
+	}
+
+	if digest.Frequency == model.DigestFrequencyWeekly && int(now.Weekday()) != digest.Weekday {
+		return false
+	}
+
+	if digest.SentAt == nil {
+		return true
+	}
+
+	sentAt := digest.SentAt.In(now.Location())
+	return sentAt.Year() != now.Year() || sentAt.YearDay() != now.YearDay()
+}
+
+// since returns the date of the previous digest, or the beginning of the period for the first one.
+func since(now time.Time, digest *model.EmailDigest) time.Time {
+	if digest.SentAt != nil {
+		return *digest.SentAt
+	}
+
+	if digest.Frequency == model.DigestFrequencyWeekly {
+		return now.AddDate(0, 0, -7)
+	}
+
+	return now.AddDate(0, 0, -1)
+}
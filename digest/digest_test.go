@@ -0,0 +1,79 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package digest // import "miniflux.app/digest"
+
+import (
+	"testing"
+	"time"
+
+	"miniflux.app/model"
+)
+
+func TestIsDueDaily(t *testing.T) {
+	digest := &model.EmailDigest{Frequency: model.DigestFrequencyDaily, Hour: 8}
+
+	if IsDue(time.Date(2023, 3, 1, 7, 59, 0, 0, time.UTC), digest) {
+		t.Error(`The digest should not be sent before the chosen hour`)
+	}
+
+	if !IsDue(time.Date(2023, 3, 1, 8, 0, 0, 0, time.UTC), digest) {
+		t.Error(`The first digest should be sent at the chosen hour`)
+	}
+
+	sentAt := time.Date(2023, 3, 1, 8, 5, 0, 0, time.UTC)
+	digest.SentAt = &sentAt
+
+	if IsDue(time.Date(2023, 3, 1, 20, 0, 0, 0, time.UTC), digest) {
+		t.Error(`The digest should be sent only once a day`)
+	}
+
+	if !IsDue(time.Date(2023, 3, 2, 8, 15, 0, 0, time.UTC), digest) {
+		t.Error(`The digest should be sent the next day`)
+	}
+}
+
+func TestIsDueWeekly(t *testing.T) {
+	digest := &model.EmailDigest{Frequency: model.DigestFrequencyWeekly, Hour: 9, Weekday: int(time.Monday)}
+
+	if IsDue(time.Date(2023, 3, 7, 10, 0, 0, 0, time.UTC), digest) {
+		t.Error(`The weekly digest should not be sent on another weekday`)
+	}
+
+	if !IsDue(time.Date(2023, 3, 6, 10, 0, 0, 0, time.UTC), digest) {
+		t.Error(`The weekly digest should be sent on the chosen weekday`)
+	}
+}
+
+func TestIsDueUsesTheUserTimezone(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(`Timezone database not available`)
+	}
+
+	// 2023-03-02 01:00 UTC is still March 1st in New York.
+	sentAt := time.Date(2023, 3, 2, 1, 0, 0, 0, time.UTC)
+	digest := &model.EmailDigest{Frequency: model.DigestFrequencyDaily, Hour: 8, SentAt: &sentAt}
+
+	if !IsDue(time.Date(2023, 3, 2, 8, 30, 0, 0, location), digest) {
+		t.Error(`The digest should be due on the next day in the user timezone`)
+	}
+}
+
+func TestSince(t *testing.T) {
+	now := time.Date(2023, 3, 8, 8, 0, 0, 0, time.UTC)
+
+	if result := since(now, &model.EmailDigest{Frequency: model.DigestFrequencyDaily}); !result.Equal(now.AddDate(0, 0, -1)) {
+		t.Errorf(`Unexpected start of the daily period: %v`, result)
+	}
+
+	if result := since(now, &model.EmailDigest{Frequency: model.DigestFrequencyWeekly}); !result.Equal(now.AddDate(0, 0, -7)) {
+		t.Errorf(`Unexpected start of the weekly period: %v`, result)
+	}
+
+	sentAt := now.Add(-36 * time.Hour)
+	if result := since(now, &model.EmailDigest{Frequency: model.DigestFrequencyDaily, SentAt: &sentAt}); !result.Equal(sentAt) {
+		t.Errorf(`The period should start at the previous digest, got %v`, result)
+	}
+}
@@ -0,0 +1,349 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package epub // import "miniflux.app/epub"
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"miniflux.app/crypto"
+)
+
+// ContentType is the media type of EPUB files.
+const ContentType = "application/epub+zip"
+
+// maxImages limits the size of books made of entries with large galleries.
+const maxImages = 300
+
+// imageExtensions are the image formats supported by EPUB reading systems.
+var imageExtensions = map[string]string{
+	"image/gif":  "gif",
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/webp": "webp",
+}
+
+// ImageFetcher returns the content and the content type of a remote image.
+type ImageFetcher func(imageURL string) ([]byte, string, error)
+
+// Book is an EPUB 3 publication, each entry is a chapter.
+type Book struct {
+	Identifier string
+	Title      string
+	Language   string
+	Modified   time.Time
+
+	// TableOfContents is the heading of the table of contents, in the language of the book.
+	TableOfContents string
+
+	Chapters []*Chapter
+}
+
+// Chapter is the content of an entry with its metadata.
+type Chapter struct {
+	Title     string
+	Author    string
+	FeedTitle string
+	URL       string
+	Published time.Time
+	Content   string
+}
+
+// NewBook returns an empty book with a random identifier.
+func NewBook(title, language string, modified time.Time) *Book {
+	return &Book{
+		Identifier:      "urn:miniflux:epub:" + crypto.GenerateRandomStringHex(16),
+		Title:           title,
+		Language:        language,
+		Modified:        modified,
+		TableOfContents: title,
+	}
+}
+
+// AddChapter appends a chapter to the book.
+func (b *Book) AddChapter(chapter *Chapter) {
+	b.Chapters = append(b.Chapters, chapter)
+}
+
+type bookImage struct {
+	id          string
+	path        string
+	contentType string
+	data        []byte
+}
+
+type bookFile struct {
+	name string
+	data string
+}
+
+// Write writes the book as an EPUB archive, the images are downloaded with the fetcher and embedded in the book.
+// The images that cannot be fetched are replaced by their alternative text.
+func (b *Book) Write(w io.Writer, fetchImage ImageFetcher) error {
+	var images []*bookImage
+	paths := make(map[string]string)
+
+	rewriteImage := func(src string) string {
+		if path, found := paths[src]; found {
+			return path
+		}
+
+		paths[src] = ""
+		if src == "" || len(images) >= maxImages {
+			return ""
+		}
+
+		data, contentType, err := fetchImage(src)
+		if err != nil {
+			return ""
+		}
+
+		mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+		extension, found := imageExtensions[mediaType]
+		if !found {
+			return ""
+		}
+
+		image := &bookImage{
+			id:          fmt.Sprintf("image-%04d", len(images)+1),
+			contentType: mediaType,
+			data:        data,
+		}
+		image.path = "images/" + image.id + "." + extension
+		images = append(images, image)
+		paths[src] = image.path
+		return image.path
+	}
+
+	var files []bookFile
+	for i, chapter := range b.Chapters {
+		content, err := toXHTML(chapter.Content, rewriteImage)
+		if err != nil {
+			return fmt.Errorf("epub: unable to convert the entry %q: %v", chapter.Title, err)
+		}
+		files = append(files, bookFile{"OEBPS/" + chapterPath(i), b.chapterDocument(chapter, content)})
+	}
+
+	files = append([]bookFile{
+		{"META-INF/container.xml", containerDocument},
+		{"OEBPS/content.opf", b.packageDocument(images)},
+		{"OEBPS/nav.xhtml", b.navigationDocument()},
+		{"OEBPS/toc.ncx", b.ncxDocument()},
+		{"OEBPS/style.css", stylesheet},
+	}, files...)
+
+	archive := zip.NewWriter(w)
+
+	// The mimetype must be the first file of the archive, without compression.
+	writer, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store, Modified: b.Modified})
+	if err != nil {
+		return fmt.Errorf("epub: unable to write the archive: %v", err)
+	}
+	if _, err := io.WriteString(writer, ContentType); err != nil {
+		return fmt.Errorf("epub: unable to write the archive: %v", err)
+	}
+
+	for _, file := range files {
+		if err := writeFile(archive, file.name, []byte(file.data), b.Modified); err != nil {
+			return err
+		}
+	}
+
+	for _, image := range images {
+		if err := writeFile(archive, "OEBPS/"+image.path, image.data, b.Modified); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("epub: unable to write the archive: %v", err)
+	}
+
+	return nil
+}
+
+func writeFile(archive *zip.Writer, name string, data []byte, modified time.Time) error {
+	writer, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return fmt.Errorf("epub: unable to write %s: %v", name, err)
+	}
+
+	if _, err := writer.Write(data); err != nil {
+		return fmt.Errorf("epub: unable to write %s: %v", name, err)
+	}
+
+	return nil
+}
+
+func chapterPath(index int) string {
+	return fmt.Sprintf("chapter-%04d.xhtml", index+1)
+}
+
+// language returns the language of the book as a BCP 47 tag.
+func (b *Book) language() string {
+	if b.Language == "" {
+		return "en"
+	}
+	return strings.ReplaceAll(b.Language, "_", "-")
+}
+
+func (c *Chapter) title() string {
+	if c.Title != "" {
+		return c.Title
+	}
+	return c.URL
+}
+
+const containerDocument = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+const stylesheet = `body { font-family: serif; line-height: 1.5; margin: 0 0.5em; }
+h1 { font-size: 1.4em; line-height: 1.3; margin: 0 0 0.5em; }
+img { max-width: 100%; height: auto; }
+pre { white-space: pre-wrap; }
+blockquote { margin: 1em 0; padding-left: 1em; border-left: 2px solid #999; }
+.entry-meta { font-size: 0.85em; color: #555; margin: 0 0 0.3em; }
+.entry-meta a { word-break: break-all; }
+nav ol { padding-left: 1.2em; }
+`
+
+func (b *Book) packageDocument(images []*bookImage) string {
+	var s strings.Builder
+
+	s.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(&s, "<package xmlns=\"http://www.idpf.org/2007/opf\" version=\"3.0\" unique-identifier=\"book-id\" xml:lang=\"%s\">\n", escape(b.language()))
+	s.WriteString("  <metadata xmlns:dc=\"http://purl.org/dc/elements/1.1/\">\n")
+	fmt.Fprintf(&s, "    <dc:identifier id=\"book-id\">%s</dc:identifier>\n", escape(b.Identifier))
+	fmt.Fprintf(&s, "    <dc:title>%s</dc:title>\n", escape(b.Title))
+	fmt.Fprintf(&s, "    <dc:language>%s</dc:language>\n", escape(b.language()))
+	s.WriteString("    <dc:publisher>Miniflux</dc:publisher>\n")
+	fmt.Fprintf(&s, "    <dc:date>%s</dc:date>\n", b.Modified.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&s, "    <meta property=\"dcterms:modified\">%s</meta>\n", b.Modified.UTC().Format("2006-01-02T15:04:05Z"))
+
+	// Accessibility metadata used by reading systems and text-to-speech engines.
+	s.WriteString("    <meta property=\"schema:accessMode\">textual</meta>\n")
+	if len(images) > 0 {
+		s.WriteString("    <meta property=\"schema:accessMode\">visual</meta>\n")
+	}
+	s.WriteString("    <meta property=\"schema:accessModeSufficient\">textual</meta>\n")
+	s.WriteString("    <meta property=\"schema:accessibilityFeature\">tableOfContents</meta>\n")
+	s.WriteString("    <meta property=\"schema:accessibilityFeature\">readingOrder</meta>\n")
+	s.WriteString("    <meta property=\"schema:accessibilityHazard\">none</meta>\n")
+	s.WriteString("  </metadata>\n")
+
+	s.WriteString("  <manifest>\n")
+	s.WriteString("    <item id=\"nav\" href=\"nav.xhtml\" media-type=\"application/xhtml+xml\" properties=\"nav\"/>\n")
+	s.WriteString("    <item id=\"ncx\" href=\"toc.ncx\" media-type=\"application/x-dtbncx+xml\"/>\n")
+	s.WriteString("    <item id=\"style\" href=\"style.css\" media-type=\"text/css\"/>\n")
+	for i := range b.Chapters {
+		fmt.Fprintf(&s, "    <item id=\"chapter-%04d\" href=\"%s\" media-type=\"application/xhtml+xml\"/>\n", i+1, chapterPath(i))
+	}
+	for _, image := range images {
+		fmt.Fprintf(&s, "    <item id=\"%s\" href=\"%s\" media-type=\"%s\"/>\n", image.id, image.path, image.contentType)
+	}
+	s.WriteString("  </manifest>\n")
+
+	s.WriteString("  <spine toc=\"ncx\">\n")
+	s.WriteString("    <itemref idref=\"nav\"/>\n")
+	for i := range b.Chapters {
+		fmt.Fprintf(&s, "    <itemref idref=\"chapter-%04d\"/>\n", i+1)
+	}
+	s.WriteString("  </spine>\n")
+	s.WriteString("</package>\n")
+
+	return s.String()
+}
+
+func (b *Book) navigationDocument() string {
+	var s strings.Builder
+
+	s.WriteString(b.documentHeader(b.TableOfContents))
+	s.WriteString("<nav epub:type=\"toc\" role=\"doc-toc\" id=\"toc\">\n")
+	fmt.Fprintf(&s, "<h1>%s</h1>\n", escape(b.TableOfContents))
+	s.WriteString("<ol>\n")
+	for i, chapter := range b.Chapters {
+		fmt.Fprintf(&s, "<li><a href=\"%s\">%s</a></li>\n", chapterPath(i), escape(chapter.title()))
+	}
+	s.WriteString("</ol>\n")
+	s.WriteString("</nav>\n")
+	s.WriteString("</body>\n</html>\n")
+
+	return s.String()
+}
+
+// ncxDocument returns the table of contents of EPUB 2, still used by older e-readers.
+func (b *Book) ncxDocument() string {
+	var s strings.Builder
+
+	s.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	s.WriteString("<ncx xmlns=\"http://www.daisy.org/z3986/2005/ncx/\" version=\"2005-1\">\n")
+	fmt.Fprintf(&s, "<head><meta name=\"dtb:uid\" content=\"%s\"/></head>\n", escape(b.Identifier))
+	fmt.Fprintf(&s, "<docTitle><text>%s</text></docTitle>\n", escape(b.Title))
+	s.WriteString("<navMap>\n")
+	for i, chapter := range b.Chapters {
+		fmt.Fprintf(&s, "<navPoint id=\"nav-%d\" playOrder=\"%d\"><navLabel><text>%s</text></navLabel><content src=\"%s\"/></navPoint>\n", i+1, i+1, escape(chapter.title()), chapterPath(i))
+	}
+	s.WriteString("</navMap>\n")
+	s.WriteString("</ncx>\n")
+
+	return s.String()
+}
+
+func (b *Book) chapterDocument(chapter *Chapter, content string) string {
+	var s strings.Builder
+
+	s.WriteString(b.documentHeader(chapter.title()))
+	s.WriteString("<section epub:type=\"chapter\" role=\"doc-chapter\" aria-labelledby=\"entry-title\">\n")
+	s.WriteString("<header>\n")
+	fmt.Fprintf(&s, "<h1 id=\"entry-title\">%s</h1>\n", escape(chapter.title()))
+
+	var metadata []string
+	if chapter.FeedTitle != "" {
+		metadata = append(metadata, escape(chapter.FeedTitle))
+	}
+	if chapter.Author != "" {
+		metadata = append(metadata, escape(chapter.Author))
+	}
+	if !chapter.Published.IsZero() {
+		metadata = append(metadata, fmt.Sprintf("<time datetime=\"%s\">%s</time>", chapter.Published.Format(time.RFC3339), chapter.Published.Format("2006-01-02 15:04")))
+	}
+	if len(metadata) > 0 {
+		fmt.Fprintf(&s, "<p class=\"entry-meta\">%s</p>\n", strings.Join(metadata, " · "))
+	}
+	if chapter.URL != "" {
+		fmt.Fprintf(&s, "<p class=\"entry-meta\"><a href=\"%s\">%s</a></p>\n", escape(chapter.URL), escape(chapter.URL))
+	}
+
+	s.WriteString("</header>\n")
+	s.WriteString(content)
+	s.WriteString("\n</section>\n")
+	s.WriteString("</body>\n</html>\n")
+
+	return s.String()
+}
+
+func (b *Book) documentHeader(title string) string {
+	var s strings.Builder
+
+	s.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	s.WriteString("<!DOCTYPE html>\n")
+	fmt.Fprintf(&s, "<html xmlns=\"http://www.w3.org/1999/xhtml\" xmlns:epub=\"http://www.idpf.org/2007/ops\" xml:lang=\"%s\" lang=\"%s\">\n", escape(b.language()), escape(b.language()))
+	s.WriteString("<head>\n")
+	s.WriteString("<meta charset=\"utf-8\"/>\n")
+	fmt.Fprintf(&s, "<title>%s</title>\n", escape(title))
+	s.WriteString("<link rel=\"stylesheet\" type=\"text/css\" href=\"style.css\"/>\n")
+	s.WriteString("</head>\n")
+	s.WriteString("<body>\n")
+
+	return s.String()
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package epub // import "miniflux.app/epub"
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeTestBook(t *testing.T, book *Book, fetchImage ImageFetcher) map[string]string {
+	var buffer bytes.Buffer
+	if err := book.Write(&buffer, fetchImage); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(archive.File) == 0 || archive.File[0].Name != "mimetype" || archive.File[0].Method != zip.Store {
+		t.Fatal(`The mimetype must be the first file of the archive, without compression`)
+	}
+
+	files := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[file.Name] = string(data)
+	}
+
+	return files
+}
+
+func checkWellFormed(t *testing.T, name, document string) {
+	decoder := xml.NewDecoder(strings.NewReader(document))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatalf(`%s is not well-formed: %v`, name, err)
+		}
+	}
+}
+
+func TestWriteBook(t *testing.T) {
+	book := NewBook("Starred & saved", "pt_BR", time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC))
+	book.TableOfContents = "Sumário"
+	book.AddChapter(&Chapter{
+		Title:     "First <entry>",
+		Author:    "Jane",
+		FeedTitle: "Example",
+		URL:       "https://example.org/1?a=1&b=2",
+		Published: time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC),
+		Content:   `<p>Hello<br>world <img src="https://example.org/a.png" alt="A"> <img src="https://example.org/missing.png" alt="B"></p>`,
+	})
+	book.AddChapter(&Chapter{
+		URL:     "https://example.org/2",
+		Content: `<p><img src="https://example.org/a.png"></p>`,
+	})
+
+	fetchCount := 0
+	files := writeTestBook(t, book, func(imageURL string) ([]byte, string, error) {
+		fetchCount++
+		if imageURL == "https://example.org/a.png" {
+			return []byte("png"), "image/png; charset=binary", nil
+		}
+		return nil, "", errors.New("not found")
+	})
+
+	if files["mimetype"] != ContentType {
+		t.Errorf(`Unexpected mimetype: %q`, files["mimetype"])
+	}
+
+	if fetchCount != 2 {
+		t.Errorf(`Each image should be fetched once, got %d requests`, fetchCount)
+	}
+
+	if files["OEBPS/images/image-0001.png"] != "png" {
+		t.Error(`The image should be embedded in the book`)
+	}
+
+	for _, name := range []string{"META-INF/container.xml", "OEBPS/content.opf", "OEBPS/nav.xhtml", "OEBPS/toc.ncx", "OEBPS/chapter-0001.xhtml", "OEBPS/chapter-0002.xhtml"} {
+		document, found := files[name]
+		if !found {
+			t.Fatalf(`The book should contain %s`, name)
+		}
+		checkWellFormed(t, name, document)
+	}
+
+	for _, expected := range []string{
+		`<dc:title>Starred &amp; saved</dc:title>`,
+		`<dc:language>pt-BR</dc:language>`,
+		`<meta property="dcterms:modified">2023-01-02T03:04:05Z</meta>`,
+		`<meta property="schema:accessMode">visual</meta>`,
+		`<item id="image-0001" href="images/image-0001.png" media-type="image/png"/>`,
+		`<itemref idref="chapter-0002"/>`,
+	} {
+		if !strings.Contains(files["OEBPS/content.opf"], expected) {
+			t.Errorf(`The package document should contain %q`, expected)
+		}
+	}
+
+	for _, expected := range []string{
+		`<h1>Sumário</h1>`,
+		`<a href="chapter-0001.xhtml">First &lt;entry&gt;</a>`,
+		`<a href="chapter-0002.xhtml">https://example.org/2</a>`,
+	} {
+		if !strings.Contains(files["OEBPS/nav.xhtml"], expected) {
+			t.Errorf(`The navigation document should contain %q`, expected)
+		}
+	}
+
+	for _, expected := range []string{
+		`xml:lang="pt-BR"`,
+		`<p class="entry-meta">Example · Jane · <time datetime="2023-01-01T10:30:00Z">2023-01-01 10:30</time></p>`,
+		`<a href="https://example.org/1?a=1&amp;b=2">`,
+		`<img src="images/image-0001.png" alt="A"/> B</p>`,
+	} {
+		if !strings.Contains(files["OEBPS/chapter-0001.xhtml"], expected) {
+			t.Errorf(`The chapter should contain %q`, expected)
+		}
+	}
+
+	if !strings.Contains(files["OEBPS/chapter-0002.xhtml"], `<img src="images/image-0001.png" alt=""/>`) {
+		t.Error(`The image should be shared by both chapters`)
+	}
+}
+
+func TestWriteBookWithoutImages(t *testing.T) {
+	book := NewBook("Empty", "", time.Now())
+	book.AddChapter(&Chapter{Title: "Text", Content: `<p><img src="https://example.org/a.svg" alt="Logo"></p>`})
+
+	files := writeTestBook(t, book, func(imageURL string) ([]byte, string, error) {
+		return []byte("<svg/>"), "image/svg+xml", nil
+	})
+
+	if strings.Contains(files["OEBPS/content.opf"], "image-0001") {
+		t.Error(`Unsupported image formats should not be embedded`)
+	}
+
+	if strings.Contains(files["OEBPS/content.opf"], `schema:accessMode">visual`) {
+		t.Error(`A book without images is only textual`)
+	}
+
+	if !strings.Contains(files["OEBPS/chapter-0001.xhtml"], `<p>Logo</p>`) {
+		t.Error(`The alternative text should replace the image`)
+	}
+
+	if !strings.Contains(files["OEBPS/content.opf"], `<dc:language>en</dc:language>`) {
+		t.Error(`The default language should be English`)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package epub // import "miniflux.app/epub"
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/locale"
+	"miniflux.app/logger"
+	"miniflux.app/mail"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+)
+
+// deliveryTolerance keeps the delivery time stable despite the interval of the scheduler.
+const deliveryTolerance = 10 * time.Minute
+
+// DeliverDueExports writes or sends the scheduled exports that are due.
+func DeliverDueExports(store *storage.Storage) {
+	exports, err := store.ScheduledEPUBExports()
+	if err != nil {
+		logger.Error("[EPUB] %v", err)
+		return
+	}
+
+	for _, export := range exports {
+		if !IsDue(time.Now(), export) {
+			continue
+		}
+
+		user, err := store.UserByID(export.UserID)
+		if err != nil {
+			logger.Error("[EPUB] %v", err)
+			continue
+		}
+
+		if user == nil || !user.IsActive() {
+			continue
+		}
+
+		if err := Deliver(store, user, export); err != nil {
+			logger.Error("[EPUB] User #%d: %v", user.ID, err)
+		}
+	}
+}
+
+// IsDue returns true if the scheduled export must be delivered at the given time.
+func IsDue(now time.Time, export *model.EPUBExport) bool {
+	if !export.IsScheduled() {
+		return false
+	}
+
+	if export.DeliveredAt == nil {
+		return true
+	}
+
+	period := 24 * time.Hour
+	if export.Frequency == model.EPUBExportFrequencyWeekly {
+		period = 7 * 24 * time.Hour
+	}
+
+	return now.Sub(*export.DeliveredAt) >= period-deliveryTolerance
+}
+
+// Deliver writes the book of the export to the export directory or sends it by email.
+// Nothing is delivered when no entry is selected, for example when all entries of the category are read.
+func Deliver(store *storage.Storage, user *model.User, export *model.EPUBExport) error {
+	entries, err := Entries(store, export)
+	if err != nil {
+		return err
+	}
+
+	if len(entries) > 0 {
+		logger.Debug("[EPUB] Delivering %d entries of export #%d to User #%d", len(entries), export.ID, user.ID)
+
+		var buffer bytes.Buffer
+		if err := Write(&buffer, user, export, entries, FetchImage); err != nil {
+			return err
+		}
+
+		filename := Filename(export, time.Now())
+
+		switch export.Delivery {
+		case model.EPUBExportDeliveryDirectory:
+			err = writeToDirectory(user, filename, buffer.Bytes())
+		case model.EPUBExportDeliveryEmail:
+			err = sendByEmail(user, export, filename, len(entries), buffer.Bytes())
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return store.UpdateEPUBExportDeliveredAt(user.ID, export.ID)
+}
+
+// UserDirectory returns the directory where the exports of the user are written.
+// The user ID keeps the directories of users with similar names apart.
+func UserDirectory(user *model.User) string {
+	return filepath.Join(config.Opts.EPUBExportDirectory(), fmt.Sprintf("%s-%d", slug(user.Username), user.ID))
+}
+
+func writeToDirectory(user *model.User, filename string, data []byte) error {
+	if config.Opts.EPUBExportDirectory() == "" {
+		return fmt.Errorf("epub: no export directory configured")
+	}
+
+	directory := UserDirectory(user)
+	if err := os.MkdirAll(directory, 0o750); err != nil {
+		return fmt.Errorf("epub: unable to create %q: %v", directory, err)
+	}
+
+	// The book is renamed once complete, synchronization tools never copy a partial file.
+	file, err := os.CreateTemp(directory, ".tmp-*.epub")
+	if err != nil {
+		return fmt.Errorf("epub: unable to create a file in %q: %v", directory, err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("epub: unable to write %q: %v", file.Name(), err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("epub: unable to write %q: %v", file.Name(), err)
+	}
+
+	if err := os.Rename(file.Name(), filepath.Join(directory, filename)); err != nil {
+		return fmt.Errorf("epub: unable to write %q: %v", filename, err)
+	}
+
+	return nil
+}
+
+func sendByEmail(user *model.User, export *model.EPUBExport, filename string, count int, data []byte) error {
+	printer := locale.NewPrinter(user.Language)
+	return mail.Send(&mail.Message{
+		To:      export.Email,
+		Subject: printer.Printf("email.epub_export.subject", export.Title),
+		Body:    []byte("<p>" + html.EscapeString(printer.Plural("email.epub_export.body", count, count)) + "</p>"),
+		Attachments: []*mail.Attachment{
+			{Filename: filename, ContentType: ContentType, Data: data},
+		},
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package epub // import "miniflux.app/epub"
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/model"
+)
+
+func TestIsDue(t *testing.T) {
+	export := &model.EPUBExport{Frequency: model.EPUBExportFrequencyDaily}
+	now := time.Date(2023, 3, 2, 8, 0, 0, 0, time.UTC)
+
+	if IsDue(now, export) {
+		t.Error(`An export without delivery should never be due`)
+	}
+
+	export.Delivery = model.EPUBExportDeliveryEmail
+	if !IsDue(now, export) {
+		t.Error(`The first delivery should be due right away`)
+	}
+
+	deliveredAt := time.Date(2023, 3, 1, 8, 5, 0, 0, time.UTC)
+	export.DeliveredAt = &deliveredAt
+
+	if IsDue(time.Date(2023, 3, 1, 20, 0, 0, 0, time.UTC), export) {
+		t.Error(`A daily export should be delivered once a day`)
+	}
+
+	if !IsDue(now, export) {
+		t.Error(`A daily export should be due a little before the same time the next day`)
+	}
+
+	export.Frequency = model.EPUBExportFrequencyWeekly
+	if IsDue(now, export) {
+		t.Error(`A weekly export should not be due the next day`)
+	}
+
+	if !IsDue(time.Date(2023, 3, 8, 8, 5, 0, 0, time.UTC), export) {
+		t.Error(`A weekly export should be due after a week`)
+	}
+}
+
+func TestFilename(t *testing.T) {
+	now := time.Date(2023, 3, 2, 8, 0, 0, 0, time.UTC)
+	scenarios := map[string]string{
+		"Starred":             "starred-2023-03-02.epub",
+		"Tech / Unread (all)": "tech-unread-all-2023-03-02.epub",
+		"Café Québec":         "café-québec-2023-03-02.epub",
+		"../..":               "miniflux-2023-03-02.epub",
+	}
+
+	for title, expected := range scenarios {
+		if result := Filename(&model.EPUBExport{Title: title}, now); result != expected {
+			t.Errorf(`Unexpected filename for %q, got %q instead of %q`, title, result, expected)
+		}
+	}
+}
+
+func TestWriteToDirectory(t *testing.T) {
+	directory := t.TempDir()
+
+	os.Clearenv()
+	os.Setenv("EPUB_EXPORT_DIRECTORY", directory)
+
+	var err error
+	config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	user := &model.User{ID: 42, Username: "jane@example.org"}
+	if err := writeToDirectory(user, "starred-2023-03-02.epub", []byte("book")); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(directory, "jane-example-org-42", "starred-2023-03-02.epub"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "book" {
+		t.Errorf(`Unexpected content: %q`, data)
+	}
+
+	files, _ := os.ReadDir(UserDirectory(user))
+	if len(files) != 1 {
+		t.Errorf(`The temporary file should be removed, got %d files`, len(files))
+	}
+}
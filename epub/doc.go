@@ -0,0 +1,8 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+/*
+Package epub bundles entries into EPUB 3 books for e-readers and delivers the scheduled exports.
+*/
+package epub // import "miniflux.app/epub"
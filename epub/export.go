@@ -0,0 +1,127 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package epub // import "miniflux.app/epub"
+
+import (
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"miniflux.app/locale"
+	"miniflux.app/model"
+	"miniflux.app/proxy"
+	"miniflux.app/storage"
+)
+
+const (
+	// maxEntries is the maximum number of entries included in a book.
+	maxEntries = 100
+
+	// imageWidth fits the screens of e-readers, larger images are downscaled.
+	imageWidth = 1200
+)
+
+// Entries returns the entries selected by the export, the oldest first to follow the reading order.
+func Entries(store *storage.Storage, export *model.EPUBExport) (model.Entries, error) {
+	builder := store.NewEntryQueryBuilder(export.UserID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+
+	switch export.Kind {
+	case model.EPUBExportKindStarred:
+		builder.WithStarred(true)
+	case model.EPUBExportKindCategory:
+		if export.CategoryID != nil {
+			builder.WithCategoryID(*export.CategoryID)
+		}
+		builder.WithStatus(model.EntryStatusUnread)
+	case model.EPUBExportKindSearch:
+		builder.WithSearchQuery(export.SearchQuery)
+	}
+
+	builder.WithOrder("published_at")
+	builder.WithDirection("desc")
+	builder.WithLimit(maxEntries)
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+
+	return entries, nil
+}
+
+// Write writes the book of the entries in the language of the user.
+func Write(w io.Writer, user *model.User, export *model.EPUBExport, entries model.Entries, fetchImage ImageFetcher) error {
+	book := NewBook(export.Title, user.Language, time.Now())
+	book.TableOfContents = locale.NewPrinter(user.Language).Printf("epub.table_of_contents")
+
+	for _, entry := range entries {
+		chapter := &Chapter{
+			Title:     entry.Title,
+			Author:    entry.Author,
+			URL:       entry.URL,
+			Published: entry.Date,
+			Content:   entry.Content,
+		}
+		if entry.Feed != nil {
+			chapter.FeedTitle = entry.Feed.Title
+		}
+		book.AddChapter(chapter)
+	}
+
+	return book.Write(w, fetchImage)
+}
+
+// FetchImage downloads an image like the media proxy does and downscales it for e-readers.
+func FetchImage(imageURL string) ([]byte, string, error) {
+	data, contentType, err := proxy.FetchImage(imageURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data, contentType = ShrinkImage(data, contentType)
+	return data, contentType, nil
+}
+
+// ShrinkImage downscales the images wider than e-reader screens, other images are returned unchanged.
+func ShrinkImage(data []byte, contentType string) ([]byte, string) {
+	resizedImage, err := proxy.ResizeImage(data, imageWidth, true)
+	if err != nil {
+		return data, contentType
+	}
+
+	return resizedImage.Data, resizedImage.ContentType
+}
+
+// Filename returns the name of the file of an export made at the given date.
+func Filename(export *model.EPUBExport, now time.Time) string {
+	return slug(export.Title) + "-" + now.Format("2006-01-02") + ".epub"
+}
+
+// slug returns a name made of lowercase letters, digits and dashes, usable on all file systems.
+func slug(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+
+	if name := strings.TrimSuffix(b.String(), "-"); name != "" {
+		return name
+	}
+
+	return "miniflux"
+}
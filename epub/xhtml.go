@@ -0,0 +1,177 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package epub // import "miniflux.app/epub"
+
+import (
+	"html"
+	"strings"
+
+	nethtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// droppedElements are removed with their content, e-readers cannot play or run them.
+var droppedElements = map[string]bool{
+	"audio":    true,
+	"button":   true,
+	"canvas":   true,
+	"embed":    true,
+	"form":     true,
+	"iframe":   true,
+	"input":    true,
+	"noscript": true,
+	"object":   true,
+	"script":   true,
+	"select":   true,
+	"source":   true,
+	"style":    true,
+	"template": true,
+	"textarea": true,
+	"track":    true,
+	"video":    true,
+}
+
+// droppedAttributes are only useful to web browsers.
+var droppedAttributes = map[string]bool{
+	"crossorigin":    true,
+	"decoding":       true,
+	"fetchpriority":  true,
+	"loading":        true,
+	"referrerpolicy": true,
+	"sizes":          true,
+	"srcset":         true,
+}
+
+var voidElements = map[string]bool{
+	"area": true,
+	"br":   true,
+	"col":  true,
+	"hr":   true,
+	"img":  true,
+	"wbr":  true,
+}
+
+// imageRewriter returns the path of an image inside the book, an empty path removes the image.
+type imageRewriter func(src string) string
+
+// toXHTML converts a sanitized HTML fragment to well-formed XHTML.
+// Images are replaced by their alternative text when they are not available.
+func toXHTML(content string, rewriteImage imageRewriter) (string, error) {
+	context := &nethtml.Node{Type: nethtml.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := nethtml.ParseFragment(strings.NewReader(content), context)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, node := range nodes {
+		writeNode(&b, node, rewriteImage)
+	}
+
+	return b.String(), nil
+}
+
+func writeNode(b *strings.Builder, n *nethtml.Node, rewriteImage imageRewriter) {
+	switch n.Type {
+	case nethtml.TextNode:
+		b.WriteString(escape(n.Data))
+	case nethtml.ElementNode:
+		writeElement(b, n, rewriteImage)
+	}
+}
+
+func writeElement(b *strings.Builder, n *nethtml.Node, rewriteImage imageRewriter) {
+	// Inline SVG and MathML are rarely supported by e-readers.
+	if n.Namespace != "" || droppedElements[n.Data] {
+		return
+	}
+
+	if n.Data == "picture" || !isValidName(n.Data) {
+		writeChildren(b, n, rewriteImage)
+		return
+	}
+
+	attributes := n.Attr
+	if n.Data == "img" {
+		alt := attributeValue(n, "alt")
+		src := rewriteImage(attributeValue(n, "src"))
+		if src == "" {
+			b.WriteString(escape(alt))
+			return
+		}
+
+		// The alternative text is required by EPUB and read aloud by text-to-speech engines.
+		attributes = []nethtml.Attribute{{Key: "src", Val: src}, {Key: "alt", Val: alt}}
+		for _, attribute := range n.Attr {
+			if attribute.Key != "src" && attribute.Key != "alt" {
+				attributes = append(attributes, attribute)
+			}
+		}
+	}
+
+	b.WriteString("<" + n.Data)
+	seen := make(map[string]bool)
+	for _, attribute := range attributes {
+		if attribute.Namespace != "" || droppedAttributes[attribute.Key] || !isValidName(attribute.Key) || seen[attribute.Key] {
+			continue
+		}
+		seen[attribute.Key] = true
+		b.WriteString(" " + attribute.Key + `="` + escape(attribute.Val) + `"`)
+	}
+
+	if voidElements[n.Data] {
+		b.WriteString("/>")
+		return
+	}
+
+	b.WriteString(">")
+	writeChildren(b, n, rewriteImage)
+	b.WriteString("</" + n.Data + ">")
+}
+
+func writeChildren(b *strings.Builder, n *nethtml.Node, rewriteImage imageRewriter) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		writeNode(b, child, rewriteImage)
+	}
+}
+
+func attributeValue(n *nethtml.Node, key string) string {
+	for _, attribute := range n.Attr {
+		if attribute.Key == key {
+			return attribute.Val
+		}
+	}
+	return ""
+}
+
+// isValidName returns true if the element or attribute name can be written without namespace in XML.
+func isValidName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+		case i > 0 && (r >= '0' && r <= '9' || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// escape returns the text escaped for XML, without the characters forbidden by XML 1.0.
+func escape(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' || (r >= 0x20 && r != 0xFFFE && r != 0xFFFF) {
+			return r
+		}
+		return -1
+	}, text)
+
+	return html.EscapeString(text)
+}
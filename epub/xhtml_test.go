@@ -0,0 +1,104 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package epub // import "miniflux.app/epub"
+
+import "testing"
+
+func keepImages(src string) string {
+	return "images/" + src
+}
+
+func TestToXHTMLClosesVoidElements(t *testing.T) {
+	output, err := toXHTML(`<p>Line 1<br>Line 2</p><hr><img src="a.png" alt="A">`, keepImages)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<p>Line 1<br/>Line 2</p><hr/><img src="images/a.png" alt="A"/>`
+	if output != expected {
+		t.Errorf(`Unexpected output, got %q instead of %q`, output, expected)
+	}
+}
+
+func TestToXHTMLEscapesText(t *testing.T) {
+	output, err := toXHTML(`<p title="a &quot;b&quot;">Fish &amp; chips &lt;3&#1;</p>`, keepImages)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<p title="a &#34;b&#34;">Fish &amp; chips &lt;3</p>`
+	if output != expected {
+		t.Errorf(`Unexpected output, got %q instead of %q`, output, expected)
+	}
+}
+
+func TestToXHTMLRemovesUnsupportedElements(t *testing.T) {
+	input := `<p>Text</p><iframe src="https://example.org/embed"></iframe><video src="a.mp4"><p>Fallback</p></video><svg><circle r="1"></circle></svg>`
+	output, err := toXHTML(input, keepImages)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if output != `<p>Text</p>` {
+		t.Errorf(`Unexpected output, got %q`, output)
+	}
+}
+
+func TestToXHTMLUnwrapsPictures(t *testing.T) {
+	input := `<picture><source srcset="a.webp" type="image/webp"><img src="a.jpg" srcset="a-2x.jpg 2x" loading="lazy" alt=""></picture>`
+	output, err := toXHTML(input, keepImages)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<img src="images/a.jpg" alt=""/>`
+	if output != expected {
+		t.Errorf(`Unexpected output, got %q instead of %q`, output, expected)
+	}
+}
+
+func TestToXHTMLReplacesMissingImagesWithAlternativeText(t *testing.T) {
+	removeImages := func(src string) string { return "" }
+
+	output, err := toXHTML(`<p><img src="a.png" alt="A chart">Text<img src="b.png"></p>`, removeImages)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if output != `<p>A chartText</p>` {
+		t.Errorf(`Unexpected output, got %q`, output)
+	}
+}
+
+func TestToXHTMLAddsMissingAlternativeText(t *testing.T) {
+	output, err := toXHTML(`<img src="a.png" width="10">`, keepImages)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<img src="images/a.png" alt="" width="10"/>`
+	if output != expected {
+		t.Errorf(`Unexpected output, got %q instead of %q`, output, expected)
+	}
+}
+
+func TestIsValidName(t *testing.T) {
+	scenarios := map[string]bool{
+		"p":          true,
+		"data-id":    true,
+		"h1":         true,
+		"":           false,
+		"1a":         false,
+		"-a":         false,
+		"xlink:href": false,
+		`a"b`:        false,
+	}
+
+	for name, expected := range scenarios {
+		if result := isValidName(name); result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, name, result, expected)
+		}
+	}
+}
@@ -435,7 +435,7 @@ func (h *handler) handleWriteItems(w http.ResponseWriter, r *http.Request) {
 		}
 
 		go func() {
-			integration.SendEntry(entry, settings)
+			integration.SendEntry(h.store, entry, settings)
 		}()
 	case "unsaved":
 		logger.Debug("[Fever] Mark entry #%d as unsaved for user #%d", entryID, userID)
@@ -45,6 +45,12 @@ func (m *middleware) serve(next http.Handler) http.Handler {
 			return
 		}
 
+		if !user.IsActive() {
+			logger.Info("[Fever] [ClientIP=%s] The account of user #%d is %s", clientIP, user.ID, user.Status)
+			json.OK(w, r, newAuthFailureResponse())
+			return
+		}
+
 		logger.Info("[Fever] [ClientIP=%s] User #%d is authenticated with user agent %q", clientIP, user.ID, r.UserAgent())
 		m.store.SetLastLogin(user.ID)
 
@@ -52,6 +58,7 @@ func (m *middleware) serve(next http.Handler) http.Handler {
 		ctx = context.WithValue(ctx, request.UserIDContextKey, user.ID)
 		ctx = context.WithValue(ctx, request.UserTimezoneContextKey, user.Timezone)
 		ctx = context.WithValue(ctx, request.IsAdminUserContextKey, user.IsAdmin)
+		ctx = context.WithValue(ctx, request.UserRoleContextKey, user.Role)
 		ctx = context.WithValue(ctx, request.IsAuthenticatedContextKey, true)
 
 		next.ServeHTTP(w, r.WithContext(ctx))
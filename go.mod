@@ -18,6 +18,7 @@ require (
 	golang.org/x/net v0.4.0
 	golang.org/x/oauth2 v0.3.0
 	golang.org/x/term v0.3.0
+	gopkg.in/square/go-jose.v2 v2.6.0
 	mvdan.cc/xurls/v2 v2.4.0
 )
 
@@ -44,7 +45,6 @@ require (
 	golang.org/x/text v0.5.0 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/protobuf v1.28.1 // indirect
-	gopkg.in/square/go-jose.v2 v2.6.0 // indirect
 )
 
 go 1.19
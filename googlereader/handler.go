@@ -522,7 +522,7 @@ func (h *handler) editTag(w http.ResponseWriter, r *http.Request) {
 		for _, entry := range entries {
 			e := entry
 			go func() {
-				integration.SendEntry(e, settings)
+				integration.SendEntry(h.store, e, settings)
 			}()
 		}
 	}
@@ -536,6 +536,11 @@ func (h *handler) quickAdd(w http.ResponseWriter, r *http.Request) {
 
 	logger.Info("[GoogleReader][/subscription/quickadd][ClientIP=%s] Incoming Request for userID  #%d", clientIP, userID)
 
+	if !request.HasPermission(r, model.PermissionManageSubscriptions) {
+		json.Forbidden(w, r)
+		return
+	}
+
 	err := r.ParseForm()
 	if err != nil {
 		logger.Error("[GoogleReader][/subscription/quickadd] [ClientIP=%s] %v", clientIP, err)
@@ -682,6 +687,11 @@ func (h *handler) editSubscription(w http.ResponseWriter, r *http.Request) {
 
 	logger.Info("[GoogleReader][/subscription/edit][ClientIP=%s] Incoming Request for userID #%d", clientIP, userID)
 
+	if !request.HasPermission(r, model.PermissionManageSubscriptions) {
+		json.Forbidden(w, r)
+		return
+	}
+
 	err := r.ParseForm()
 	if err != nil {
 		logger.Error("[GoogleReader][/subscription/edit] [ClientIP=%s] %v", clientIP, err)
@@ -896,6 +906,11 @@ func (h *handler) disableTag(w http.ResponseWriter, r *http.Request) {
 
 	logger.Info("[GoogleReader][/disable-tag][ClientIP=%s] Incoming Request for userID #%d", clientIP, userID)
 
+	if !request.HasPermission(r, model.PermissionManageSubscriptions) {
+		json.Forbidden(w, r)
+		return
+	}
+
 	err := r.ParseForm()
 	if err != nil {
 		logger.Error("[GoogleReader][/disable-tag] [ClientIP=%s] %v", clientIP, err)
@@ -933,6 +948,11 @@ func (h *handler) renameTag(w http.ResponseWriter, r *http.Request) {
 
 	logger.Info("[GoogleReader][/rename-tag][ClientIP=%s] Incoming Request for userID #%d", clientIP, userID)
 
+	if !request.HasPermission(r, model.PermissionManageSubscriptions) {
+		json.Forbidden(w, r)
+		return
+	}
+
 	err := r.ParseForm()
 	if err != nil {
 		logger.Error("[GoogleReader][/rename-tag] [ClientIP=%s] %v", clientIP, err)
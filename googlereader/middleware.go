@@ -182,18 +182,25 @@ func (m *middleware) apiKeyAuth(next http.Handler) http.Handler {
 			Unauthorized(w, r)
 			return
 		}
-		if user, err = m.store.UserByID(integration.UserID); err != nil {
+		if user, err = m.store.UserByID(integration.UserID); err != nil || user == nil {
 			logger.Error("[GoogleReader][Auth] [ClientIP=%s] No user found with the userID: %d", clientIP, integration.UserID)
 			Unauthorized(w, r)
 			return
 		}
 
+		if !user.IsActive() {
+			logger.Error("[GoogleReader][Auth] [ClientIP=%s] The account of user #%d is %s", clientIP, user.ID, user.Status)
+			Unauthorized(w, r)
+			return
+		}
+
 		m.store.SetLastLogin(integration.UserID)
 
 		ctx := r.Context()
 		ctx = context.WithValue(ctx, request.UserIDContextKey, user.ID)
 		ctx = context.WithValue(ctx, request.UserTimezoneContextKey, user.Timezone)
 		ctx = context.WithValue(ctx, request.IsAdminUserContextKey, user.IsAdmin)
+		ctx = context.WithValue(ctx, request.UserRoleContextKey, user.Role)
 		ctx = context.WithValue(ctx, request.IsAuthenticatedContextKey, true)
 		ctx = context.WithValue(ctx, request.GoogleReaderToken, token)
 
@@ -8,6 +8,8 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"miniflux.app/model"
+
 	"github.com/go-webauthn/webauthn/webauthn"
 )
 
@@ -32,6 +34,8 @@ const (
 	WebAuthnStateContextKey
 	ClientIPContextKey
 	GoogleReaderToken
+	UserRoleContextKey
+	TwoFactorStateContextKey
 )
 
 // GoolgeReaderToken returns the google reader token if it exists.
@@ -44,6 +48,16 @@ func IsAdminUser(r *http.Request) bool {
 	return getContextBoolValue(r, IsAdminUserContextKey)
 }
 
+// UserRole returns the role of the logged user.
+func UserRole(r *http.Request) string {
+	return getContextStringValue(r, UserRoleContextKey)
+}
+
+// HasPermission checks if the role of the logged user grants the permission.
+func HasPermission(r *http.Request, permission string) bool {
+	return model.RoleHasPermission(UserRole(r), permission)
+}
+
 // IsAuthenticated returns a boolean if the user is authenticated.
 func IsAuthenticated(r *http.Request) bool {
 	return getContextBoolValue(r, IsAuthenticatedContextKey)
@@ -131,6 +145,20 @@ func WebAuthnState(r *http.Request) webauthn.SessionData {
 	return sessionData
 }
 
+// TwoFactorLoginState returns the login waiting for a second factor if any.
+func TwoFactorLoginState(r *http.Request) *model.TwoFactorLoginState {
+	value := getContextStringValue(r, TwoFactorStateContextKey)
+	if value == "" {
+		return nil
+	}
+
+	var state model.TwoFactorLoginState
+	if err := json.Unmarshal([]byte(value), &state); err != nil {
+		return nil
+	}
+	return &state
+}
+
 func getContextStringValue(r *http.Request, key ContextKey) string {
 	if v := r.Context().Value(key); v != nil {
 		value, valid := v.(string)
@@ -8,6 +8,8 @@ import (
 	"context"
 	"net/http"
 	"testing"
+
+	"miniflux.app/model"
 )
 
 func TestContextStringValue(t *testing.T) {
@@ -434,3 +436,52 @@ func TestClientIP(t *testing.T) {
 		t.Errorf(`Unexpected context value, got %q instead of %q`, result, expected)
 	}
 }
+
+func TestUserRole(t *testing.T) {
+	r, _ := http.NewRequest("GET", "http://example.org", nil)
+
+	result := UserRole(r)
+	expected := ""
+
+	if result != expected {
+		t.Errorf(`Unexpected context value, got %q instead of %q`, result, expected)
+	}
+
+	ctx := r.Context()
+	ctx = context.WithValue(ctx, UserRoleContextKey, model.RoleUserManager)
+	r = r.WithContext(ctx)
+
+	result = UserRole(r)
+	expected = model.RoleUserManager
+
+	if result != expected {
+		t.Errorf(`Unexpected context value, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestHasPermission(t *testing.T) {
+	r, _ := http.NewRequest("GET", "http://example.org", nil)
+
+	if HasPermission(r, model.PermissionManageSubscriptions) {
+		t.Error(`A request without role should not have any permission`)
+	}
+
+	ctx := r.Context()
+	ctx = context.WithValue(ctx, UserRoleContextKey, model.RoleGuest)
+	r = r.WithContext(ctx)
+
+	if HasPermission(r, model.PermissionManageSubscriptions) {
+		t.Error(`A guest should not be able to manage subscriptions`)
+	}
+
+	ctx = context.WithValue(ctx, UserRoleContextKey, model.RoleUser)
+	r = r.WithContext(ctx)
+
+	if !HasPermission(r, model.PermissionManageSubscriptions) {
+		t.Error(`A regular user should be able to manage subscriptions`)
+	}
+
+	if HasPermission(r, model.PermissionManageUsers) {
+		t.Error(`A regular user should not be able to manage users`)
+	}
+}
@@ -7,8 +7,8 @@ package response // import "miniflux.app/http/response"
 import (
 	"compress/flate"
 	"compress/gzip"
-	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"strings"
 	"time"
@@ -45,8 +45,9 @@ func (b *Builder) WithBody(body interface{}) *Builder {
 }
 
 // WithAttachment forces the document to be downloaded by the web browser.
+// Filenames with spaces or non-ASCII characters are quoted or encoded.
 func (b *Builder) WithAttachment(filename string) *Builder {
-	b.headers["Content-Disposition"] = fmt.Sprintf("attachment; filename=%s", filename)
+	b.headers["Content-Disposition"] = mime.FormatMediaType("attachment", map[string]string{"filename": filename})
 	return b
 }
 
@@ -0,0 +1,54 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package apprise // import "miniflux.app/integration/apprise"
+
+import (
+	"fmt"
+	"strings"
+
+	"miniflux.app/http/client"
+)
+
+// Notification represents a notification sent to the Apprise API.
+type Notification struct {
+	URLs  string `json:"urls,omitempty"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// Client represents an Apprise API client.
+type Client struct {
+	baseURL     string
+	servicesURL string
+}
+
+// NewClient returns a new Apprise client.
+// The services URL is a comma separated list of Apprise URLs, the server defaults are used when it's empty.
+func NewClient(baseURL, servicesURL string) *Client {
+	return &Client{baseURL: baseURL, servicesURL: servicesURL}
+}
+
+// Notify sends a notification to all services.
+func (c *Client) Notify(title, body string) error {
+	if c.baseURL == "" {
+		return fmt.Errorf("apprise: missing API endpoint")
+	}
+
+	clt := client.New(strings.TrimSuffix(c.baseURL, "/") + "/notify/")
+	response, err := clt.PostJSON(&Notification{
+		URLs:  c.servicesURL,
+		Title: title,
+		Body:  body,
+	})
+	if err != nil {
+		return fmt.Errorf("apprise: unable to send notification: %v", err)
+	}
+
+	if response.HasServerFailure() {
+		return fmt.Errorf("apprise: unable to send notification, status=%d", response.StatusCode)
+	}
+
+	return nil
+}
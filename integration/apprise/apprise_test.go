@@ -0,0 +1,58 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package apprise // import "miniflux.app/integration/apprise"
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNotify(t *testing.T) {
+	var notification Notification
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/notify/" {
+			t.Errorf(`Unexpected request: %s %s`, r.Method, r.URL.Path)
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&notification); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(ts.URL+"/", "tgram://bottoken/ChatID")
+	if err := client.Notify("Example", "https://example.org/"); err != nil {
+		t.Fatal(err)
+	}
+
+	if notification.URLs != "tgram://bottoken/ChatID" {
+		t.Errorf(`Unexpected services, got %q`, notification.URLs)
+	}
+
+	if notification.Title != "Example" || notification.Body != "https://example.org/" {
+		t.Errorf(`Unexpected notification: %+v`, notification)
+	}
+}
+
+func TestNotifyWithServerFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusFailedDependency)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ts.URL, "")
+	if err := client.Notify("Example", "https://example.org/"); err == nil {
+		t.Fatal(`A server failure should return an error`)
+	}
+}
+
+func TestNotifyWithMissingEndpoint(t *testing.T) {
+	client := NewClient("", "")
+	if err := client.Notify("Example", "https://example.org/"); err == nil {
+		t.Fatal(`A missing endpoint should return an error`)
+	}
+}
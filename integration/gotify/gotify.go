@@ -0,0 +1,72 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package gotify // import "miniflux.app/integration/gotify"
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"miniflux.app/http/client"
+)
+
+// DefaultPriority is the priority used when the user doesn't choose one.
+const DefaultPriority = 5
+
+// Message represents a Gotify message.
+type Message struct {
+	Title    string                 `json:"title"`
+	Message  string                 `json:"message"`
+	Priority int                    `json:"priority"`
+	Extras   map[string]interface{} `json:"extras,omitempty"`
+}
+
+// Client represents a Gotify client.
+type Client struct {
+	serverURL string
+	appToken  string
+	priority  int
+}
+
+// NewClient returns a new Gotify client.
+func NewClient(serverURL, appToken string, priority int) *Client {
+	return &Client{serverURL: serverURL, appToken: appToken, priority: priority}
+}
+
+// SendMessage sends a message to the Gotify application.
+func (c *Client) SendMessage(title, message, link string) error {
+	if c.serverURL == "" || c.appToken == "" {
+		return fmt.Errorf("gotify: missing credentials")
+	}
+
+	values := url.Values{}
+	values.Set("token", c.appToken)
+
+	msg := &Message{
+		Title:    title,
+		Message:  message,
+		Priority: c.priority,
+	}
+
+	if link != "" {
+		msg.Extras = map[string]interface{}{
+			"client::notification": map[string]interface{}{
+				"click": map[string]string{"url": link},
+			},
+		}
+	}
+
+	clt := client.New(strings.TrimSuffix(c.serverURL, "/") + "/message?" + values.Encode())
+	response, err := clt.PostJSON(msg)
+	if err != nil {
+		return fmt.Errorf("gotify: unable to send message: %v", err)
+	}
+
+	if response.HasServerFailure() {
+		return fmt.Errorf("gotify: unable to send message, status=%d", response.StatusCode)
+	}
+
+	return nil
+}
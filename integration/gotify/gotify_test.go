@@ -0,0 +1,62 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package gotify // import "miniflux.app/integration/gotify"
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendMessage(t *testing.T) {
+	var message Message
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/gotify/message" {
+			t.Errorf(`Unexpected request: %s %s`, r.Method, r.URL.Path)
+		}
+
+		if r.URL.Query().Get("token") != "secret" {
+			t.Errorf(`Unexpected token, got %q`, r.URL.Query().Get("token"))
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(ts.URL+"/gotify/", "secret", 8)
+	if err := client.SendMessage("Example", "New entry", "https://example.org/"); err != nil {
+		t.Fatal(err)
+	}
+
+	if message.Title != "Example" || message.Message != "New entry" || message.Priority != 8 {
+		t.Errorf(`Unexpected message: %+v`, message)
+	}
+
+	if _, found := message.Extras["client::notification"]; !found {
+		t.Errorf(`The click action is missing: %+v`, message.Extras)
+	}
+}
+
+func TestSendMessageWithServerFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ts.URL, "secret", DefaultPriority)
+	if err := client.SendMessage("Example", "New entry", ""); err == nil {
+		t.Fatal(`A server failure should return an error`)
+	}
+}
+
+func TestSendMessageWithMissingCredentials(t *testing.T) {
+	client := NewClient("", "", DefaultPriority)
+	if err := client.SendMessage("Example", "New entry", ""); err == nil {
+		t.Fatal(`Missing credentials should return an error`)
+	}
+}
@@ -5,132 +5,191 @@
 package integration // import "miniflux.app/integration"
 
 import (
+	"errors"
+	"time"
+
 	"miniflux.app/config"
-	"miniflux.app/integration/espial"
-	"miniflux.app/integration/instapaper"
-	"miniflux.app/integration/linkding"
-	"miniflux.app/integration/matrixbot"
-	"miniflux.app/integration/nunuxkeeper"
-	"miniflux.app/integration/pinboard"
-	"miniflux.app/integration/pocket"
-	"miniflux.app/integration/telegrambot"
-	"miniflux.app/integration/wallabag"
 	"miniflux.app/logger"
+	"miniflux.app/metric"
 	"miniflux.app/model"
+	"miniflux.app/storage"
+)
+
+const (
+	minRetryDelay = time.Minute
+	maxRetryDelay = 6 * time.Hour
 )
 
 // SendEntry sends the entry to third-party providers when the user click on "Save".
-func SendEntry(entry *model.Entry, integration *model.Integration) {
-	if integration.PinboardEnabled {
-		logger.Debug("[Integration] Sending Entry #%d %q for User #%d to Pinboard", entry.ID, entry.URL, integration.UserID)
-
-		client := pinboard.NewClient(integration.PinboardToken)
-		err := client.AddBookmark(
-			entry.URL,
-			entry.Title,
-			integration.PinboardTags,
-			integration.PinboardMarkAsUnread,
-		)
-
-		if err != nil {
-			logger.Error("[Integration] UserID #%d: %v", integration.UserID, err)
+func SendEntry(store *storage.Storage, entry *model.Entry, integration *model.Integration) {
+	for _, provider := range Providers() {
+		sender, ok := provider.(EntrySender)
+		settings := integration.Provider(provider.Name())
+		if !ok || !settings.Enabled {
+			continue
 		}
-	}
 
-	if integration.InstapaperEnabled {
-		logger.Debug("[Integration] Sending Entry #%d %q for User #%d to Instapaper", entry.ID, entry.URL, integration.UserID)
+		logger.Debug("[Integration] Sending Entry #%d %q for User #%d to %s", entry.ID, entry.URL, integration.UserID, provider.Title())
 
-		client := instapaper.NewClient(integration.InstapaperUsername, integration.InstapaperPassword)
-		if err := client.AddURL(entry.URL, entry.Title); err != nil {
-			logger.Error("[Integration] UserID #%d: %v", integration.UserID, err)
+		delivery := &model.IntegrationDelivery{
+			UserID:   integration.UserID,
+			EntryID:  entry.ID,
+			Provider: provider.Name(),
+			Action:   model.DeliveryActionSave,
 		}
+
+		err := sender.SendEntry(entry, settings)
+		saveDeliveryAttempt(store, delivery, err)
 	}
+}
 
-	if integration.WallabagEnabled {
-		logger.Debug("[Integration] Sending Entry #%d %q for User #%d to Wallabag", entry.ID, entry.URL, integration.UserID)
+// PushEntries pushes an entry array to third-party providers during feed refreshes.
+func PushEntries(store *storage.Storage, entries model.Entries, integration *model.Integration) {
+	pushEntries(store, entries, integration, false)
+}
 
-		client := wallabag.NewClient(
-			integration.WallabagURL,
-			integration.WallabagClientID,
-			integration.WallabagClientSecret,
-			integration.WallabagUsername,
-			integration.WallabagPassword,
-			integration.WallabagOnlyURL,
-		)
+// pushEntries sends the entries one by one, or as a single message when
+// a digest is requested and the provider supports it.
+// The delivery of each entry is recorded with the result of the message that contained it.
+func pushEntries(store *storage.Storage, entries model.Entries, integration *model.Integration, digest bool) {
+	for _, provider := range Providers() {
+		settings := integration.Provider(provider.Name())
+		if !settings.Enabled {
+			continue
+		}
 
-		if err := client.AddEntry(entry.URL, entry.Title, entry.Content); err != nil {
-			logger.Error("[Integration] UserID #%d: %v", integration.UserID, err)
+		digestPusher, isDigestPusher := provider.(DigestPusher)
+		entryPusher, isEntryPusher := provider.(EntryPusher)
+		entriesPusher, isEntriesPusher := provider.(EntriesPusher)
+
+		switch {
+		case isDigestPusher && digest && len(entries) > 1:
+			logger.Debug("[Integration] Sending a digest of %d entries for User #%d to %s", len(entries), integration.UserID, provider.Title())
+			saveDeliveryAttempts(store, integration.UserID, provider, entries, digestPusher.PushDigest(entries, settings))
+		case isEntryPusher:
+			logger.Debug("[Integration] Sending %d entries for User #%d to %s", len(entries), integration.UserID, provider.Title())
+			for _, entry := range entries {
+				saveDeliveryAttempts(store, integration.UserID, provider, model.Entries{entry}, entryPusher.PushEntry(entry, settings))
+			}
+		case isEntriesPusher:
+			logger.Debug("[Integration] Sending %d entries for User #%d to %s", len(entries), integration.UserID, provider.Title())
+			saveDeliveryAttempts(store, integration.UserID, provider, entries, entriesPusher.PushEntries(entries, settings))
 		}
 	}
+}
+
+func saveDeliveryAttempts(store *storage.Storage, userID int64, provider Provider, entries model.Entries, err error) {
+	for _, entry := range entries {
+		delivery := &model.IntegrationDelivery{
+			UserID:   userID,
+			EntryID:  entry.ID,
+			Provider: provider.Name(),
+			Action:   model.DeliveryActionPush,
+		}
 
-	if integration.NunuxKeeperEnabled {
-		logger.Debug("[Integration] Sending Entry #%d %q for User #%d to NunuxKeeper", entry.ID, entry.URL, integration.UserID)
+		saveDeliveryAttempt(store, delivery, err)
+	}
+}
 
-		client := nunuxkeeper.NewClient(
-			integration.NunuxKeeperURL,
-			integration.NunuxKeeperAPIKey,
-		)
+// RetryDeliveries sends again the failed deliveries that are due for a new attempt.
+func RetryDeliveries(store *storage.Storage, limit int) {
+	deliveries, err := store.IntegrationDeliveriesToRetry(limit)
+	if err != nil {
+		logger.Error("[Integration] %v", err)
+		return
+	}
 
-		if err := client.AddEntry(entry.URL, entry.Title, entry.Content); err != nil {
-			logger.Error("[Integration] UserID #%d: %v", integration.UserID, err)
-		}
+	for _, delivery := range deliveries {
+		logger.Debug("[Integration] Retrying delivery #%d of Entry #%d for User #%d to %s (attempt %d)",
+			delivery.ID, delivery.EntryID, delivery.UserID, delivery.Provider, delivery.Attempts+1)
+
+		saveDeliveryAttempt(store, delivery, retryDelivery(store, delivery))
 	}
+}
 
-	if integration.EspialEnabled {
-		logger.Debug("[Integration] Sending Entry #%d %q for User #%d to Espial", entry.ID, entry.URL, integration.UserID)
+func retryDelivery(store *storage.Storage, delivery *model.IntegrationDelivery) error {
+	provider := ProviderByName(delivery.Provider)
+	if provider == nil {
+		return errors.New("the provider does not exist anymore")
+	}
 
-		client := espial.NewClient(
-			integration.EspialURL,
-			integration.EspialAPIKey,
-		)
+	integration, err := store.Integration(delivery.UserID)
+	if err != nil {
+		return err
+	}
 
-		if err := client.AddEntry(entry.URL, entry.Title, entry.Content, integration.EspialTags); err != nil {
-			logger.Error("[Integration] UserID #%d: %v", integration.UserID, err)
-		}
+	settings := integration.Provider(provider.Name())
+	if !settings.Enabled {
+		return errors.New("the provider has been disabled")
 	}
 
-	if integration.PocketEnabled {
-		logger.Debug("[Integration] Sending Entry #%d %q for User #%d to Pocket", entry.ID, entry.URL, integration.UserID)
+	builder := store.NewEntryQueryBuilder(delivery.UserID)
+	builder.WithEntryID(delivery.EntryID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
 
-		client := pocket.NewClient(config.Opts.PocketConsumerKey(integration.PocketConsumerKey), integration.PocketAccessToken)
-		if err := client.AddURL(entry.URL, entry.Title); err != nil {
-			logger.Error("[Integration] UserID #%d: %v", integration.UserID, err)
-		}
+	entry, err := builder.GetEntry()
+	if err != nil {
+		return err
 	}
 
-	if integration.LinkdingEnabled {
-		logger.Debug("[Integration] Sending Entry #%d %q for User #%d to Linkding", entry.ID, entry.URL, integration.UserID)
+	if entry == nil {
+		return errors.New("the entry does not exist anymore")
+	}
 
-		client := linkding.NewClient(
-			integration.LinkdingURL,
-			integration.LinkdingAPIKey,
-		)
-		if err := client.AddEntry(entry.Title, entry.URL); err != nil {
-			logger.Error("[Integration] UserID #%d: %v", integration.UserID, err)
+	switch delivery.Action {
+	case model.DeliveryActionSave:
+		if sender, ok := provider.(EntrySender); ok {
+			return sender.SendEntry(entry, settings)
+		}
+	case model.DeliveryActionPush:
+		if pusher, ok := provider.(EntryPusher); ok {
+			return pusher.PushEntry(entry, settings)
+		}
+		if pusher, ok := provider.(EntriesPusher); ok {
+			return pusher.PushEntries(model.Entries{entry}, settings)
 		}
 	}
+
+	return errors.New("the provider does not support this action")
 }
 
-// PushEntries pushes an entry array to third-party providers during feed refreshes.
-func PushEntries(entries model.Entries, integration *model.Integration) {
-	if integration.MatrixBotEnabled {
-		logger.Debug("[Integration] Sending %d entries for User #%d to Matrix", len(entries), integration.UserID)
+func saveDeliveryAttempt(store *storage.Storage, delivery *model.IntegrationDelivery, err error) {
+	delivery.Attempts++
+	delivery.NextAttemptAt = nil
+
+	switch {
+	case err == nil:
+		delivery.Status = model.DeliveryStatusSuccess
+		delivery.LastError = ""
+	case delivery.Attempts < config.Opts.IntegrationRetryMaxAttempts():
+		logger.Error("[Integration] UserID #%d: %s: %v", delivery.UserID, delivery.Provider, err)
+		nextAttemptAt := time.Now().Add(retryDelay(delivery.Attempts))
+		delivery.Status = model.DeliveryStatusRetrying
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = &nextAttemptAt
+	default:
+		logger.Error("[Integration] UserID #%d: %s: %v (giving up after %d attempts)", delivery.UserID, delivery.Provider, err, delivery.Attempts)
+		delivery.Status = model.DeliveryStatusFailed
+		delivery.LastError = err.Error()
+	}
 
-		err := matrixbot.PushEntries(entries, integration.MatrixBotURL, integration.MatrixBotUser, integration.MatrixBotPassword, integration.MatrixBotChatID)
-		if err != nil {
-			logger.Error("[Integration] push entries to matrix bot failed: %v", err)
-		}
+	if config.Opts.HasMetricsCollector() {
+		metric.IntegrationDeliveries.WithLabelValues(delivery.Provider, delivery.Status).Inc()
 	}
-}
 
-// PushEntry pushes an entry to third-party providers during feed refreshes.
-func PushEntry(entry *model.Entry, integration *model.Integration) {
-	if integration.TelegramBotEnabled {
-		logger.Debug("[Integration] Sending Entry %q for User #%d to Telegram", entry.URL, integration.UserID)
+	if err := store.SaveIntegrationDelivery(delivery); err != nil {
+		logger.Error("[Integration] %v", err)
+	}
+}
 
-		err := telegrambot.PushEntry(entry, integration.TelegramBotToken, integration.TelegramBotChatID)
-		if err != nil {
-			logger.Error("[Integration] push entry to telegram bot failed: %v", err)
+// retryDelay returns the waiting time before the next attempt, doubled after each failure.
+func retryDelay(attempts int) time.Duration {
+	delay := minRetryDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxRetryDelay {
+			return maxRetryDelay
 		}
 	}
+	return delay
 }
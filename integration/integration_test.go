@@ -0,0 +1,28 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	scenarios := map[int]time.Duration{
+		1:  time.Minute,
+		2:  2 * time.Minute,
+		3:  4 * time.Minute,
+		5:  16 * time.Minute,
+		9:  256 * time.Minute,
+		10: 6 * time.Hour,
+		50: 6 * time.Hour,
+	}
+
+	for attempts, expected := range scenarios {
+		if result := retryDelay(attempts); result != expected {
+			t.Errorf(`Unexpected delay after %d attempts, got %v instead of %v`, attempts, result, expected)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+	"miniflux.app/timezone"
+	"miniflux.app/validator"
+)
+
+// maxDigestEntries is the maximum number of entries listed in the body of a digest.
+const maxDigestEntries = 20
+
+// NotifyEntries sends the new entries of a feed to the notification providers.
+// Entries are queued during quiet hours or when the user prefers digests.
+func NotifyEntries(store *storage.Storage, feed *model.Feed, entries model.Entries, user *model.User) {
+	integration, err := store.Integration(user.ID)
+	if err != nil {
+		logger.Error("[Integration] Unable to fetch integrations of User #%d: %v", user.ID, err)
+		return
+	}
+
+	authorStatuses := make(map[string]string)
+	if preferences, err := store.AuthorPreferences(user.ID); err != nil {
+		logger.Error("[Integration] %v", err)
+	} else {
+		authorStatuses = preferences.Statuses()
+	}
+
+	var selectedEntries model.Entries
+	for _, entry := range entries {
+		if entry.Feed == nil {
+			entry.Feed = feed
+		}
+
+		if shouldNotify(feed, entry, integration.NotificationRules, authorStatuses) {
+			selectedEntries = append(selectedEntries, entry)
+		}
+	}
+
+	if len(selectedEntries) == 0 {
+		return
+	}
+
+	now := timezone.Now(user.Timezone)
+	if integration.NotificationDigestInterval > 0 || isQuietHours(now, integration.NotificationQuietHoursStart, integration.NotificationQuietHoursEnd) {
+		entryIDs := make([]int64, 0, len(selectedEntries))
+		for _, entry := range selectedEntries {
+			entryIDs = append(entryIDs, entry.ID)
+		}
+
+		if err := store.QueueNotifications(user.ID, entryIDs); err != nil {
+			logger.Error("[Integration] %v", err)
+		}
+		return
+	}
+
+	PushEntries(store, selectedEntries, integration)
+}
+
+// SendQueuedNotifications sends the postponed notifications of all users
+// outside of their quiet hours and when their digest is due.
+func SendQueuedNotifications(store *storage.Storage) {
+	userIDs, err := store.UsersWithQueuedNotifications()
+	if err != nil {
+		logger.Error("[Integration] %v", err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		if err := sendQueuedNotifications(store, userID); err != nil {
+			logger.Error("[Integration] User #%d: %v", userID, err)
+		}
+	}
+}
+
+func sendQueuedNotifications(store *storage.Storage, userID int64) error {
+	user, err := store.UserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	integration, err := store.Integration(userID)
+	if err != nil {
+		return err
+	}
+
+	if isQuietHours(timezone.Now(user.Timezone), integration.NotificationQuietHoursStart, integration.NotificationQuietHoursEnd) {
+		return nil
+	}
+
+	if !isDigestDue(time.Now(), integration.NotificationDigestSentAt, integration.NotificationDigestInterval) {
+		return nil
+	}
+
+	entryIDs, err := store.QueuedNotifications(userID)
+	if err != nil {
+		return err
+	}
+
+	builder := store.NewEntryQueryBuilder(userID)
+	builder.WithEntryIDs(entryIDs)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	builder.WithOrder(model.DefaultSortingOrder)
+	builder.WithDirection("asc")
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("[Integration] Sending %d queued notifications for User #%d", len(entries), userID)
+
+	if len(entries) > 0 {
+		pushEntries(store, entries, integration, true)
+	}
+
+	if err := store.RemoveQueuedNotifications(userID, entryIDs); err != nil {
+		return err
+	}
+
+	if integration.NotificationDigestInterval > 0 {
+		return store.UpdateNotificationDigestSentAt(userID)
+	}
+
+	return nil
+}
+
+// ValidateNotificationSettings checks the notification settings before they are saved.
+func ValidateNotificationSettings(integration *model.Integration) *validator.ValidationError {
+	if integration.NotificationRules != "" && !validator.IsValidRegex(integration.NotificationRules) {
+		return validator.NewValidationError("error.notification_invalid_rules")
+	}
+
+	if !isValidHour(integration.NotificationQuietHoursStart) || !isValidHour(integration.NotificationQuietHoursEnd) {
+		return validator.NewValidationError("error.notification_invalid_quiet_hours")
+	}
+
+	if integration.NotificationDigestInterval < 0 {
+		return validator.NewValidationError("error.notification_invalid_digest_interval")
+	}
+
+	return nil
+}
+
+// shouldNotify returns true when the user opted in for notifications on the feed,
+// on its category, or when the entry title matches the notification rules.
+// Entries of followed authors are always notified, entries of muted authors never.
+func shouldNotify(feed *model.Feed, entry *model.Entry, rules string, authorStatuses map[string]string) bool {
+	if entry.Author != "" {
+		switch authorStatuses[model.NormalizeAuthor(entry.Author)] {
+		case model.AuthorStatusFollowed:
+			return true
+		case model.AuthorStatusMuted:
+			return false
+		}
+	}
+
+	if feed.Notify || (feed.Category != nil && feed.Category.Notify) {
+		return true
+	}
+
+	if rules != "" {
+		match, _ := regexp.MatchString(rules, entry.Title)
+		return match
+	}
+
+	return false
+}
+
+// isQuietHours returns true if the hour of the given time is between the start (inclusive)
+// and the end (exclusive). The range can span midnight, e.g. from 22 to 7.
+func isQuietHours(now time.Time, start, end int) bool {
+	if start == end {
+		return false
+	}
+
+	hour := now.Hour()
+	if start < end {
+		return hour >= start && hour < end
+	}
+
+	return hour >= start || hour < end
+}
+
+// isDigestDue returns true if the digest interval (in minutes) has elapsed since the last digest.
+func isDigestDue(now time.Time, sentAt *time.Time, interval int) bool {
+	if interval <= 0 || sentAt == nil {
+		return true
+	}
+
+	return !now.Before(sentAt.Add(time.Duration(interval) * time.Minute))
+}
+
+func isValidHour(hour int) bool {
+	return hour >= 0 && hour <= 23
+}
+
+func notificationTitle(entry *model.Entry) string {
+	if entry.Feed != nil {
+		return entry.Feed.Title + ": " + entry.Title
+	}
+	return entry.Title
+}
+
+// digestMessage returns the title and the body of a notification that lists several entries.
+func digestMessage(entries model.Entries) (string, string) {
+	var lines []string
+	for i, entry := range entries {
+		if i == maxDigestEntries {
+			lines = append(lines, fmt.Sprintf("… (+%d)", len(entries)-maxDigestEntries))
+			break
+		}
+
+		lines = append(lines, "• "+notificationTitle(entry))
+	}
+
+	return fmt.Sprintf("%d new entries", len(entries)), strings.Join(lines, "\n")
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/model"
+)
+
+func TestIsQuietHours(t *testing.T) {
+	scenarios := []struct {
+		hour     int
+		start    int
+		end      int
+		expected bool
+	}{
+		{10, 0, 0, false},
+		{10, 9, 17, true},
+		{9, 9, 17, true},
+		{17, 9, 17, false},
+		{8, 9, 17, false},
+		{23, 22, 7, true},
+		{3, 22, 7, true},
+		{7, 22, 7, false},
+		{12, 22, 7, false},
+	}
+
+	for _, scenario := range scenarios {
+		now := time.Date(2023, 1, 1, scenario.hour, 30, 0, 0, time.UTC)
+		if result := isQuietHours(now, scenario.start, scenario.end); result != scenario.expected {
+			t.Errorf(`Unexpected result for %02d:30 between %d and %d, got %v instead of %v`, scenario.hour, scenario.start, scenario.end, result, scenario.expected)
+		}
+	}
+}
+
+func TestIsDigestDue(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	recently := now.Add(-10 * time.Minute)
+	longAgo := now.Add(-2 * time.Hour)
+
+	if !isDigestDue(now, nil, 60) {
+		t.Error(`The first digest should be sent immediately`)
+	}
+
+	if !isDigestDue(now, &recently, 0) {
+		t.Error(`Notifications should be sent when the digest mode is disabled`)
+	}
+
+	if isDigestDue(now, &recently, 60) {
+		t.Error(`The digest should not be sent before the end of the interval`)
+	}
+
+	if !isDigestDue(now, &longAgo, 60) {
+		t.Error(`The digest should be sent after the end of the interval`)
+	}
+}
+
+func TestShouldNotify(t *testing.T) {
+	entry := &model.Entry{Title: "Release 2.0 is out"}
+
+	if shouldNotify(&model.Feed{Category: &model.Category{}}, entry, "", nil) {
+		t.Error(`Entries should not be notified without opt-in`)
+	}
+
+	if !shouldNotify(&model.Feed{Notify: true, Category: &model.Category{}}, entry, "", nil) {
+		t.Error(`Entries should be notified when the feed has notifications enabled`)
+	}
+
+	if !shouldNotify(&model.Feed{Category: &model.Category{Notify: true}}, entry, "", nil) {
+		t.Error(`Entries should be notified when the category has notifications enabled`)
+	}
+
+	if !shouldNotify(&model.Feed{Category: &model.Category{}}, entry, "(?i)release", nil) {
+		t.Error(`Entries matching the rules should be notified`)
+	}
+
+	if shouldNotify(&model.Feed{Category: &model.Category{}}, entry, "(?i)security", nil) {
+		t.Error(`Entries not matching the rules should not be notified`)
+	}
+
+	authorStatuses := map[string]string{
+		"jane doe": model.AuthorStatusFollowed,
+		"john doe": model.AuthorStatusMuted,
+	}
+
+	if !shouldNotify(&model.Feed{Category: &model.Category{}}, &model.Entry{Title: "Hello", Author: "Jane Doe"}, "", authorStatuses) {
+		t.Error(`Entries of followed authors should be notified`)
+	}
+
+	if shouldNotify(&model.Feed{Notify: true, Category: &model.Category{}}, &model.Entry{Title: "Hello", Author: "John Doe"}, "", authorStatuses) {
+		t.Error(`Entries of muted authors should not be notified`)
+	}
+}
+
+func TestValidateNotificationSettings(t *testing.T) {
+	scenarios := []struct {
+		integration *model.Integration
+		expected    string
+	}{
+		{&model.Integration{NotificationRules: "(?i)golang", NotificationQuietHoursStart: 22, NotificationQuietHoursEnd: 7, NotificationDigestInterval: 60}, ""},
+		{&model.Integration{NotificationRules: "[a-z"}, "error.notification_invalid_rules"},
+		{&model.Integration{NotificationQuietHoursStart: 24}, "error.notification_invalid_quiet_hours"},
+		{&model.Integration{NotificationQuietHoursEnd: -1}, "error.notification_invalid_quiet_hours"},
+		{&model.Integration{NotificationDigestInterval: -5}, "error.notification_invalid_digest_interval"},
+	}
+
+	for _, scenario := range scenarios {
+		err := ValidateNotificationSettings(scenario.integration)
+		switch {
+		case scenario.expected == "" && err != nil:
+			t.Errorf(`Unexpected validation error: %v`, err)
+		case scenario.expected != "" && (err == nil || err.TranslationKey != scenario.expected):
+			t.Errorf(`Expected validation error %q, got %v`, scenario.expected, err)
+		}
+	}
+}
+
+func TestDigestMessage(t *testing.T) {
+	feed := &model.Feed{Title: "Blog"}
+	entries := model.Entries{
+		{Title: "First", Feed: feed},
+		{Title: "Second", Feed: feed},
+	}
+
+	title, message := digestMessage(entries)
+	if title != "2 new entries" {
+		t.Errorf(`Unexpected digest title: %q`, title)
+	}
+
+	if message != "• Blog: First\n• Blog: Second" {
+		t.Errorf(`Unexpected digest message: %q`, message)
+	}
+}
+
+func TestDigestMessageIsTruncated(t *testing.T) {
+	var entries model.Entries
+	for i := 0; i < maxDigestEntries+5; i++ {
+		entries = append(entries, &model.Entry{Title: "Entry"})
+	}
+
+	_, message := digestMessage(entries)
+	lines := strings.Split(message, "\n")
+	if len(lines) != maxDigestEntries+1 {
+		t.Fatalf(`Unexpected number of lines: %d`, len(lines))
+	}
+
+	if lines[maxDigestEntries] != "… (+5)" {
+		t.Errorf(`Unexpected last line: %q`, lines[maxDigestEntries])
+	}
+}
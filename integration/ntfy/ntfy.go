@@ -0,0 +1,70 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ntfy // import "miniflux.app/integration/ntfy"
+
+import (
+	"fmt"
+	"strings"
+
+	"miniflux.app/http/client"
+)
+
+// DefaultServerURL is the public ntfy server.
+const DefaultServerURL = "https://ntfy.sh"
+
+// Message represents a message published to a ntfy topic.
+type Message struct {
+	Topic   string   `json:"topic"`
+	Title   string   `json:"title"`
+	Message string   `json:"message"`
+	Click   string   `json:"click,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// Client represents a ntfy client.
+type Client struct {
+	serverURL   string
+	topic       string
+	accessToken string
+}
+
+// NewClient returns a new ntfy client.
+// The access token is only required by protected topics.
+func NewClient(serverURL, topic, accessToken string) *Client {
+	if serverURL == "" {
+		serverURL = DefaultServerURL
+	}
+	return &Client{serverURL: serverURL, topic: topic, accessToken: accessToken}
+}
+
+// Publish sends a message to the topic.
+func (c *Client) Publish(title, message, link string) error {
+	if c.topic == "" {
+		return fmt.Errorf("ntfy: missing topic")
+	}
+
+	// Publishing as JSON requires to post the message to the root URL.
+	clt := client.New(strings.TrimSuffix(c.serverURL, "/") + "/")
+	if c.accessToken != "" {
+		clt.WithAuthorization("Bearer " + c.accessToken)
+	}
+
+	response, err := clt.PostJSON(&Message{
+		Topic:   c.topic,
+		Title:   title,
+		Message: message,
+		Click:   link,
+		Tags:    []string{"newspaper"},
+	})
+	if err != nil {
+		return fmt.Errorf("ntfy: unable to publish message: %v", err)
+	}
+
+	if response.HasServerFailure() {
+		return fmt.Errorf("ntfy: unable to publish message, status=%d", response.StatusCode)
+	}
+
+	return nil
+}
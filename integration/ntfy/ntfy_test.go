@@ -0,0 +1,72 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ntfy // import "miniflux.app/integration/ntfy"
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPublish(t *testing.T) {
+	var message Message
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/" {
+			t.Errorf(`Unexpected request: %s %s`, r.Method, r.URL.Path)
+		}
+
+		if r.Header.Get("Authorization") != "Bearer tk_secret" {
+			t.Errorf(`Unexpected authorization header, got %q`, r.Header.Get("Authorization"))
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(ts.URL, "miniflux", "tk_secret")
+	if err := client.Publish("Example", "New entry", "https://example.org/"); err != nil {
+		t.Fatal(err)
+	}
+
+	if message.Topic != "miniflux" || message.Title != "Example" || message.Message != "New entry" || message.Click != "https://example.org/" {
+		t.Errorf(`Unexpected message: %+v`, message)
+	}
+}
+
+func TestPublishWithoutAccessToken(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf(`No authorization header should be sent, got %q`, r.Header.Get("Authorization"))
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(ts.URL, "miniflux", "")
+	if err := client.Publish("Example", "New entry", ""); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPublishWithServerFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ts.URL, "miniflux", "")
+	if err := client.Publish("Example", "New entry", ""); err == nil {
+		t.Fatal(`A server failure should return an error`)
+	}
+}
+
+func TestDefaultServerURL(t *testing.T) {
+	client := NewClient("", "miniflux", "")
+	if client.serverURL != DefaultServerURL {
+		t.Errorf(`Unexpected server URL, got %q`, client.serverURL)
+	}
+}
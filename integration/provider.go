@@ -0,0 +1,182 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"sort"
+	"sync"
+
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+// Field types used to render the settings form of a provider.
+const (
+	FieldTypeText     = "text"
+	FieldTypePassword = "password"
+	FieldTypeURL      = "url"
+	FieldTypeBool     = "bool"
+)
+
+// Field describes a setting of an integration provider.
+type Field struct {
+	// Key is the name of the setting, the form input is named "<provider>_<key>".
+	Key string
+
+	// Type is one of the FieldType constants.
+	Type string
+
+	// Label is the translation key of the field label.
+	Label string
+
+	Placeholder string
+	Required    bool
+}
+
+// Provider is a third-party service that receives entries.
+type Provider interface {
+	// Name returns the unique identifier of the provider.
+	Name() string
+
+	// Title returns the human readable name of the provider.
+	Title() string
+
+	// Fields returns the configuration schema of the provider.
+	Fields() []Field
+
+	// Validate checks the user settings before they are saved.
+	Validate(settings *model.ProviderSettings) *validator.ValidationError
+}
+
+// EntrySender is implemented by providers that save entries when the user click on "Save".
+type EntrySender interface {
+	SendEntry(entry *model.Entry, settings *model.ProviderSettings) error
+}
+
+// EntriesPusher is implemented by providers that receive new entries during feed refreshes in a single message.
+type EntriesPusher interface {
+	PushEntries(entries model.Entries, settings *model.ProviderSettings) error
+}
+
+// EntryPusher is implemented by providers that receive each new entry in its own message during feed refreshes.
+type EntryPusher interface {
+	PushEntry(entry *model.Entry, settings *model.ProviderSettings) error
+}
+
+// DigestPusher is implemented by providers that can send several entries in a single notification.
+type DigestPusher interface {
+	PushDigest(entries model.Entries, settings *model.ProviderSettings) error
+}
+
+// Authorizer is implemented by providers that obtain their credentials through an authorization flow.
+type Authorizer interface {
+	// AuthorizeRoute returns the name of the route that starts the authorization flow.
+	AuthorizeRoute() string
+
+	// IsAuthorized returns true if the settings already contain the credentials.
+	IsAuthorized(settings *model.ProviderSettings) bool
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Provider)
+)
+
+// Register makes a provider available to all users.
+// It panics if a provider with the same name is already registered.
+func Register(provider Provider) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, found := registry[provider.Name()]; found {
+		panic("integration: provider registered twice: " + provider.Name())
+	}
+
+	registry[provider.Name()] = provider
+}
+
+// Providers returns all registered providers sorted by title.
+func Providers() []Provider {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	providers := make([]Provider, 0, len(registry))
+	for _, provider := range registry {
+		providers = append(providers, provider)
+	}
+
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].Title() < providers[j].Title()
+	})
+
+	return providers
+}
+
+// ProviderByName returns a registered provider or nil.
+func ProviderByName(name string) Provider {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	return registry[name]
+}
+
+// EntrySenderNames returns the name of all providers able to save entries.
+func EntrySenderNames() []string {
+	var names []string
+	for _, provider := range Providers() {
+		if _, ok := provider.(EntrySender); ok {
+			names = append(names, provider.Name())
+		}
+	}
+	return names
+}
+
+// ProviderTitles returns the title of all providers indexed by name.
+func ProviderTitles() map[string]string {
+	titles := make(map[string]string)
+	for _, provider := range Providers() {
+		titles[provider.Name()] = provider.Title()
+	}
+	return titles
+}
+
+// ValidateProviderSettings checks the settings of all enabled providers.
+func ValidateProviderSettings(integration *model.Integration) *validator.ValidationError {
+	for _, provider := range Providers() {
+		settings := integration.Provider(provider.Name())
+		if !settings.Enabled {
+			continue
+		}
+
+		if err := validateRequiredFields(provider, settings); err != nil {
+			return err
+		}
+
+		if err := provider.Validate(settings); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateRequiredFields(provider Provider, settings *model.ProviderSettings) *validator.ValidationError {
+	for _, field := range provider.Fields() {
+		if !field.Required {
+			continue
+		}
+
+		value := settings.Get(field.Key)
+		if value == "" {
+			return validator.NewValidationError("error.integration_mandatory_fields")
+		}
+
+		if field.Type == FieldTypeURL && !validator.IsValidURL(value) {
+			return validator.NewValidationError("error.integration_invalid_url")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"miniflux.app/integration/apprise"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func init() {
+	Register(&appriseProvider{})
+}
+
+type appriseProvider struct{}
+
+func (p *appriseProvider) Name() string {
+	return "apprise"
+}
+
+func (p *appriseProvider) Title() string {
+	return "Apprise"
+}
+
+func (p *appriseProvider) Fields() []Field {
+	return []Field{
+		{Key: "url", Type: FieldTypeURL, Label: "form.integration.apprise_url", Placeholder: "http://apprise:8000", Required: true},
+		{Key: "services_url", Type: FieldTypeText, Label: "form.integration.apprise_services_url", Placeholder: "tgram://bottoken/ChatID"},
+	}
+}
+
+func (p *appriseProvider) Validate(settings *model.ProviderSettings) *validator.ValidationError {
+	return nil
+}
+
+func (p *appriseProvider) PushEntry(entry *model.Entry, settings *model.ProviderSettings) error {
+	return p.client(settings).Notify(notificationTitle(entry), entry.URL)
+}
+
+func (p *appriseProvider) PushDigest(entries model.Entries, settings *model.ProviderSettings) error {
+	title, message := digestMessage(entries)
+	return p.client(settings).Notify(title, message)
+}
+
+func (p *appriseProvider) client(settings *model.ProviderSettings) *apprise.Client {
+	return apprise.NewClient(settings.Get("url"), settings.Get("services_url"))
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"miniflux.app/integration/espial"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func init() {
+	Register(&espialProvider{})
+}
+
+type espialProvider struct{}
+
+func (p *espialProvider) Name() string {
+	return "espial"
+}
+
+func (p *espialProvider) Title() string {
+	return "Espial"
+}
+
+func (p *espialProvider) Fields() []Field {
+	return []Field{
+		{Key: "url", Type: FieldTypeURL, Label: "form.integration.espial_endpoint", Placeholder: "https://esp.ae8.org", Required: true},
+		{Key: "api_key", Type: FieldTypeText, Label: "form.integration.espial_api_key", Required: true},
+		{Key: "tags", Type: FieldTypeText, Label: "form.integration.espial_tags"},
+	}
+}
+
+func (p *espialProvider) Validate(settings *model.ProviderSettings) *validator.ValidationError {
+	return nil
+}
+
+func (p *espialProvider) SendEntry(entry *model.Entry, settings *model.ProviderSettings) error {
+	client := espial.NewClient(settings.Get("url"), settings.Get("api_key"))
+	return client.AddEntry(entry.URL, entry.Title, entry.Content, settings.Get("tags"))
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"strconv"
+
+	"miniflux.app/integration/gotify"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func init() {
+	Register(&gotifyProvider{})
+}
+
+type gotifyProvider struct{}
+
+func (p *gotifyProvider) Name() string {
+	return "gotify"
+}
+
+func (p *gotifyProvider) Title() string {
+	return "Gotify"
+}
+
+func (p *gotifyProvider) Fields() []Field {
+	return []Field{
+		{Key: "url", Type: FieldTypeURL, Label: "form.integration.gotify_url", Placeholder: "https://gotify.example.org", Required: true},
+		{Key: "token", Type: FieldTypePassword, Label: "form.integration.gotify_token", Required: true},
+		{Key: "priority", Type: FieldTypeText, Label: "form.integration.gotify_priority", Placeholder: strconv.Itoa(gotify.DefaultPriority)},
+	}
+}
+
+func (p *gotifyProvider) Validate(settings *model.ProviderSettings) *validator.ValidationError {
+	if value := settings.Get("priority"); value != "" {
+		if priority, err := strconv.Atoi(value); err != nil || priority < 0 || priority > 10 {
+			return validator.NewValidationError("error.gotify_invalid_priority")
+		}
+	}
+	return nil
+}
+
+func (p *gotifyProvider) PushEntry(entry *model.Entry, settings *model.ProviderSettings) error {
+	return p.client(settings).SendMessage(notificationTitle(entry), entry.URL, entry.URL)
+}
+
+func (p *gotifyProvider) PushDigest(entries model.Entries, settings *model.ProviderSettings) error {
+	title, message := digestMessage(entries)
+	return p.client(settings).SendMessage(title, message, "")
+}
+
+func (p *gotifyProvider) client(settings *model.ProviderSettings) *gotify.Client {
+	priority, err := strconv.Atoi(settings.Get("priority"))
+	if err != nil {
+		priority = gotify.DefaultPriority
+	}
+	return gotify.NewClient(settings.Get("url"), settings.Get("token"), priority)
+}
@@ -0,0 +1,41 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"miniflux.app/integration/instapaper"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func init() {
+	Register(&instapaperProvider{})
+}
+
+type instapaperProvider struct{}
+
+func (p *instapaperProvider) Name() string {
+	return "instapaper"
+}
+
+func (p *instapaperProvider) Title() string {
+	return "Instapaper"
+}
+
+func (p *instapaperProvider) Fields() []Field {
+	return []Field{
+		{Key: "username", Type: FieldTypeText, Label: "form.integration.instapaper_username", Required: true},
+		{Key: "password", Type: FieldTypePassword, Label: "form.integration.instapaper_password", Required: true},
+	}
+}
+
+func (p *instapaperProvider) Validate(settings *model.ProviderSettings) *validator.ValidationError {
+	return nil
+}
+
+func (p *instapaperProvider) SendEntry(entry *model.Entry, settings *model.ProviderSettings) error {
+	client := instapaper.NewClient(settings.Get("username"), settings.Get("password"))
+	return client.AddURL(entry.URL, entry.Title)
+}
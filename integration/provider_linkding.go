@@ -0,0 +1,41 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"miniflux.app/integration/linkding"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func init() {
+	Register(&linkdingProvider{})
+}
+
+type linkdingProvider struct{}
+
+func (p *linkdingProvider) Name() string {
+	return "linkding"
+}
+
+func (p *linkdingProvider) Title() string {
+	return "Linkding"
+}
+
+func (p *linkdingProvider) Fields() []Field {
+	return []Field{
+		{Key: "url", Type: FieldTypeURL, Label: "form.integration.linkding_endpoint", Placeholder: "https://linkding.com", Required: true},
+		{Key: "api_key", Type: FieldTypeText, Label: "form.integration.linkding_api_key", Required: true},
+	}
+}
+
+func (p *linkdingProvider) Validate(settings *model.ProviderSettings) *validator.ValidationError {
+	return nil
+}
+
+func (p *linkdingProvider) SendEntry(entry *model.Entry, settings *model.ProviderSettings) error {
+	client := linkding.NewClient(settings.Get("url"), settings.Get("api_key"))
+	return client.AddEntry(entry.Title, entry.URL)
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"miniflux.app/integration/matrixbot"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func init() {
+	Register(&matrixBotProvider{})
+}
+
+type matrixBotProvider struct{}
+
+func (p *matrixBotProvider) Name() string {
+	return "matrix_bot"
+}
+
+func (p *matrixBotProvider) Title() string {
+	return "Matrix Bot"
+}
+
+func (p *matrixBotProvider) Fields() []Field {
+	return []Field{
+		{Key: "user", Type: FieldTypeText, Label: "form.integration.matrix_bot_user", Required: true},
+		{Key: "password", Type: FieldTypePassword, Label: "form.integration.matrix_bot_password", Required: true},
+		{Key: "url", Type: FieldTypeURL, Label: "form.integration.matrix_bot_url", Required: true},
+		{Key: "chat_id", Type: FieldTypeText, Label: "form.integration.matrix_bot_chat_id", Required: true},
+	}
+}
+
+func (p *matrixBotProvider) Validate(settings *model.ProviderSettings) *validator.ValidationError {
+	return nil
+}
+
+func (p *matrixBotProvider) PushEntries(entries model.Entries, settings *model.ProviderSettings) error {
+	return matrixbot.PushEntries(
+		entries,
+		settings.Get("url"),
+		settings.Get("user"),
+		settings.Get("password"),
+		settings.Get("chat_id"),
+	)
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"miniflux.app/integration/ntfy"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func init() {
+	Register(&ntfyProvider{})
+}
+
+type ntfyProvider struct{}
+
+func (p *ntfyProvider) Name() string {
+	return "ntfy"
+}
+
+func (p *ntfyProvider) Title() string {
+	return "ntfy"
+}
+
+func (p *ntfyProvider) Fields() []Field {
+	return []Field{
+		{Key: "url", Type: FieldTypeURL, Label: "form.integration.ntfy_url", Placeholder: ntfy.DefaultServerURL},
+		{Key: "topic", Type: FieldTypeText, Label: "form.integration.ntfy_topic", Required: true},
+		{Key: "access_token", Type: FieldTypePassword, Label: "form.integration.ntfy_access_token"},
+	}
+}
+
+func (p *ntfyProvider) Validate(settings *model.ProviderSettings) *validator.ValidationError {
+	if url := settings.Get("url"); url != "" && !validator.IsValidURL(url) {
+		return validator.NewValidationError("error.integration_invalid_url")
+	}
+	return nil
+}
+
+func (p *ntfyProvider) PushEntry(entry *model.Entry, settings *model.ProviderSettings) error {
+	return p.client(settings).Publish(notificationTitle(entry), entry.URL, entry.URL)
+}
+
+func (p *ntfyProvider) PushDigest(entries model.Entries, settings *model.ProviderSettings) error {
+	title, message := digestMessage(entries)
+	return p.client(settings).Publish(title, message, "")
+}
+
+func (p *ntfyProvider) client(settings *model.ProviderSettings) *ntfy.Client {
+	return ntfy.NewClient(settings.Get("url"), settings.Get("topic"), settings.Get("access_token"))
+}
@@ -0,0 +1,41 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"miniflux.app/integration/nunuxkeeper"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func init() {
+	Register(&nunuxKeeperProvider{})
+}
+
+type nunuxKeeperProvider struct{}
+
+func (p *nunuxKeeperProvider) Name() string {
+	return "nunux_keeper"
+}
+
+func (p *nunuxKeeperProvider) Title() string {
+	return "Nunux Keeper"
+}
+
+func (p *nunuxKeeperProvider) Fields() []Field {
+	return []Field{
+		{Key: "url", Type: FieldTypeURL, Label: "form.integration.nunux_keeper_endpoint", Placeholder: "https://api.nunux.org/keeper", Required: true},
+		{Key: "api_key", Type: FieldTypeText, Label: "form.integration.nunux_keeper_api_key", Required: true},
+	}
+}
+
+func (p *nunuxKeeperProvider) Validate(settings *model.ProviderSettings) *validator.ValidationError {
+	return nil
+}
+
+func (p *nunuxKeeperProvider) SendEntry(entry *model.Entry, settings *model.ProviderSettings) error {
+	client := nunuxkeeper.NewClient(settings.Get("url"), settings.Get("api_key"))
+	return client.AddEntry(entry.URL, entry.Title, entry.Content)
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"miniflux.app/integration/pinboard"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func init() {
+	Register(&pinboardProvider{})
+}
+
+type pinboardProvider struct{}
+
+func (p *pinboardProvider) Name() string {
+	return "pinboard"
+}
+
+func (p *pinboardProvider) Title() string {
+	return "Pinboard"
+}
+
+func (p *pinboardProvider) Fields() []Field {
+	return []Field{
+		{Key: "token", Type: FieldTypePassword, Label: "form.integration.pinboard_token", Required: true},
+		{Key: "tags", Type: FieldTypeText, Label: "form.integration.pinboard_tags"},
+		{Key: "mark_as_unread", Type: FieldTypeBool, Label: "form.integration.pinboard_bookmark"},
+	}
+}
+
+func (p *pinboardProvider) Validate(settings *model.ProviderSettings) *validator.ValidationError {
+	return nil
+}
+
+func (p *pinboardProvider) SendEntry(entry *model.Entry, settings *model.ProviderSettings) error {
+	client := pinboard.NewClient(settings.Get("token"))
+	return client.AddBookmark(
+		entry.URL,
+		entry.Title,
+		settings.Get("tags"),
+		settings.Bool("mark_as_unread"),
+	)
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"miniflux.app/config"
+	"miniflux.app/integration/pocket"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func init() {
+	Register(&pocketProvider{})
+}
+
+type pocketProvider struct{}
+
+func (p *pocketProvider) Name() string {
+	return "pocket"
+}
+
+func (p *pocketProvider) Title() string {
+	return "Pocket"
+}
+
+func (p *pocketProvider) Fields() []Field {
+	var fields []Field
+
+	// The consumer key is configurable only when the administrator didn't define one globally.
+	if config.Opts.PocketConsumerKey("") == "" {
+		fields = append(fields, Field{Key: "consumer_key", Type: FieldTypeText, Label: "form.integration.pocket_consumer_key"})
+	}
+
+	return append(fields, Field{Key: "access_token", Type: FieldTypePassword, Label: "form.integration.pocket_access_token"})
+}
+
+func (p *pocketProvider) Validate(settings *model.ProviderSettings) *validator.ValidationError {
+	if PocketConsumerKey(settings) == "" {
+		return validator.NewValidationError("error.integration_mandatory_fields")
+	}
+	return nil
+}
+
+func (p *pocketProvider) SendEntry(entry *model.Entry, settings *model.ProviderSettings) error {
+	client := pocket.NewClient(PocketConsumerKey(settings), settings.Get("access_token"))
+	return client.AddURL(entry.URL, entry.Title)
+}
+
+func (p *pocketProvider) AuthorizeRoute() string {
+	return "pocketAuthorize"
+}
+
+func (p *pocketProvider) IsAuthorized(settings *model.ProviderSettings) bool {
+	return settings.Get("access_token") != ""
+}
+
+// PocketConsumerKey returns the consumer key defined globally or by the user.
+func PocketConsumerKey(settings *model.ProviderSettings) string {
+	return config.Opts.PocketConsumerKey(settings.Get("consumer_key"))
+}
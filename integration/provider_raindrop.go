@@ -0,0 +1,61 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"strconv"
+
+	"miniflux.app/integration/raindrop"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func init() {
+	Register(&raindropProvider{})
+}
+
+type raindropProvider struct{}
+
+func (p *raindropProvider) Name() string {
+	return "raindrop"
+}
+
+func (p *raindropProvider) Title() string {
+	return "Raindrop.io"
+}
+
+func (p *raindropProvider) Fields() []Field {
+	return []Field{
+		{Key: "token", Type: FieldTypePassword, Label: "form.integration.raindrop_token", Required: true},
+		{Key: "collection_id", Type: FieldTypeText, Label: "form.integration.raindrop_collection_id", Placeholder: "-1"},
+		{Key: "tags", Type: FieldTypeText, Label: "form.integration.raindrop_tags"},
+	}
+}
+
+func (p *raindropProvider) Validate(settings *model.ProviderSettings) *validator.ValidationError {
+	if _, err := raindropCollectionID(settings); err != nil {
+		return validator.NewValidationError("error.raindrop_invalid_collection_id")
+	}
+	return nil
+}
+
+func (p *raindropProvider) SendEntry(entry *model.Entry, settings *model.ProviderSettings) error {
+	collectionID, err := raindropCollectionID(settings)
+	if err != nil {
+		return err
+	}
+
+	client := raindrop.NewClient(settings.Get("token"))
+	return client.AddBookmark(entry.URL, entry.Title, settings.Get("tags"), collectionID)
+}
+
+// raindropCollectionID returns the configured collection or the "Unsorted" collection.
+func raindropCollectionID(settings *model.ProviderSettings) (int64, error) {
+	value := settings.Get("collection_id")
+	if value == "" {
+		return raindrop.UnsortedCollectionID, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
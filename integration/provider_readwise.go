@@ -0,0 +1,41 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"miniflux.app/integration/readwise"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func init() {
+	Register(&readwiseProvider{})
+}
+
+type readwiseProvider struct{}
+
+func (p *readwiseProvider) Name() string {
+	return "readwise"
+}
+
+func (p *readwiseProvider) Title() string {
+	return "Readwise Reader"
+}
+
+func (p *readwiseProvider) Fields() []Field {
+	return []Field{
+		{Key: "api_key", Type: FieldTypePassword, Label: "form.integration.readwise_api_key", Required: true},
+		{Key: "tags", Type: FieldTypeText, Label: "form.integration.readwise_tags"},
+	}
+}
+
+func (p *readwiseProvider) Validate(settings *model.ProviderSettings) *validator.ValidationError {
+	return nil
+}
+
+func (p *readwiseProvider) SendEntry(entry *model.Entry, settings *model.ProviderSettings) error {
+	client := readwise.NewClient(settings.Get("api_key"))
+	return client.AddEntry(entry.URL, entry.Title, entry.Content, settings.Get("tags"))
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"miniflux.app/integration/shaarli"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func init() {
+	Register(&shaarliProvider{})
+}
+
+type shaarliProvider struct{}
+
+func (p *shaarliProvider) Name() string {
+	return "shaarli"
+}
+
+func (p *shaarliProvider) Title() string {
+	return "Shaarli"
+}
+
+func (p *shaarliProvider) Fields() []Field {
+	return []Field{
+		{Key: "url", Type: FieldTypeURL, Label: "form.integration.shaarli_endpoint", Placeholder: "https://example.org/shaarli/", Required: true},
+		{Key: "api_secret", Type: FieldTypePassword, Label: "form.integration.shaarli_api_secret", Required: true},
+		{Key: "tags", Type: FieldTypeText, Label: "form.integration.shaarli_tags"},
+		{Key: "private", Type: FieldTypeBool, Label: "form.integration.shaarli_private"},
+	}
+}
+
+func (p *shaarliProvider) Validate(settings *model.ProviderSettings) *validator.ValidationError {
+	return nil
+}
+
+func (p *shaarliProvider) SendEntry(entry *model.Entry, settings *model.ProviderSettings) error {
+	client := shaarli.NewClient(settings.Get("url"), settings.Get("api_secret"))
+	return client.AddLink(entry.URL, entry.Title, settings.Get("tags"), settings.Bool("private"))
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"strconv"
+
+	"miniflux.app/integration/telegrambot"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func init() {
+	Register(&telegramBotProvider{})
+}
+
+type telegramBotProvider struct{}
+
+func (p *telegramBotProvider) Name() string {
+	return "telegram_bot"
+}
+
+func (p *telegramBotProvider) Title() string {
+	return "Telegram Bot"
+}
+
+func (p *telegramBotProvider) Fields() []Field {
+	return []Field{
+		{Key: "token", Type: FieldTypeText, Label: "form.integration.telegram_bot_token", Placeholder: "bot123456:Abcdefg", Required: true},
+		{Key: "chat_id", Type: FieldTypeText, Label: "form.integration.telegram_chat_id", Required: true},
+	}
+}
+
+func (p *telegramBotProvider) Validate(settings *model.ProviderSettings) *validator.ValidationError {
+	if _, err := strconv.ParseInt(settings.Get("chat_id"), 10, 64); err != nil {
+		return validator.NewValidationError("error.telegram_invalid_chat_id")
+	}
+	return nil
+}
+
+func (p *telegramBotProvider) PushEntry(entry *model.Entry, settings *model.ProviderSettings) error {
+	return telegrambot.PushEntry(entry, settings.Get("token"), settings.Get("chat_id"))
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"testing"
+
+	"miniflux.app/config"
+	"miniflux.app/model"
+)
+
+func TestProviderByName(t *testing.T) {
+	for _, name := range []string{"pinboard", "wallabag", "pocket", "telegram_bot", "matrix_bot", "instapaper", "nunux_keeper", "espial", "linkding", "shaarli", "readwise", "raindrop", "apprise", "ntfy", "gotify"} {
+		if provider := ProviderByName(name); provider == nil || provider.Name() != name {
+			t.Errorf(`The provider %q should be registered`, name)
+		}
+	}
+
+	if provider := ProviderByName("unknown"); provider != nil {
+		t.Error(`An unknown provider should not be returned`)
+	}
+}
+
+func TestEntrySenderNames(t *testing.T) {
+	names := EntrySenderNames()
+	expected := []string{"espial", "instapaper", "linkding", "nunux_keeper", "pinboard", "pocket", "raindrop", "readwise", "shaarli", "wallabag"}
+
+	if len(names) != len(expected) {
+		t.Fatalf(`Unexpected providers, got %v instead of %v`, names, expected)
+	}
+
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf(`Unexpected provider, got %q instead of %q`, names[i], expected[i])
+		}
+	}
+}
+
+func TestEntryPushers(t *testing.T) {
+	for _, name := range []string{"apprise", "gotify", "ntfy", "telegram_bot"} {
+		provider := ProviderByName(name)
+		if _, ok := provider.(EntryPusher); !ok {
+			t.Errorf(`The provider %q should push each entry in its own message`, name)
+		}
+		if _, ok := provider.(EntriesPusher); ok {
+			t.Errorf(`The provider %q should not push the entries in a single message`, name)
+		}
+	}
+}
+
+func TestValidateProviderSettingsIgnoresDisabledProviders(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	integration := &model.Integration{}
+	integration.SetProvider(&model.ProviderSettings{Name: "wallabag", Enabled: false})
+
+	if err := ValidateProviderSettings(integration); err != nil {
+		t.Errorf(`A disabled provider should not be validated, got %q`, err.TranslationKey)
+	}
+}
+
+func TestValidateProviderSettingsWithMissingFields(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	integration := &model.Integration{}
+	integration.SetProvider(&model.ProviderSettings{
+		Name:    "pinboard",
+		Enabled: true,
+		Values:  map[string]string{"tags": "miniflux"},
+	})
+
+	err := ValidateProviderSettings(integration)
+	if err == nil {
+		t.Fatal(`A missing token should generate an error`)
+	}
+
+	if err.TranslationKey != "error.integration_mandatory_fields" {
+		t.Errorf(`Unexpected error, got %q`, err.TranslationKey)
+	}
+}
+
+func TestValidateProviderSettingsWithInvalidURL(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	integration := &model.Integration{}
+	integration.SetProvider(&model.ProviderSettings{
+		Name:    "matrix_bot",
+		Enabled: true,
+		Values: map[string]string{
+			"user":     "bot",
+			"password": "secret",
+			"url":      "matrix.example.org",
+			"chat_id":  "!room:example.org",
+		},
+	})
+
+	err := ValidateProviderSettings(integration)
+	if err == nil {
+		t.Fatal(`An invalid URL should generate an error`)
+	}
+
+	if err.TranslationKey != "error.integration_invalid_url" {
+		t.Errorf(`Unexpected error, got %q`, err.TranslationKey)
+	}
+}
+
+func TestValidateTelegramChatID(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	integration := &model.Integration{}
+	integration.SetProvider(&model.ProviderSettings{
+		Name:    "telegram_bot",
+		Enabled: true,
+		Values:  map[string]string{"token": "bot123456:Abcdefg", "chat_id": "not a number"},
+	})
+
+	err := ValidateProviderSettings(integration)
+	if err == nil || err.TranslationKey != "error.telegram_invalid_chat_id" {
+		t.Error(`An invalid chat ID should generate an error`)
+	}
+
+	integration.Provider("telegram_bot").Set("chat_id", "-100123456")
+	if err := ValidateProviderSettings(integration); err != nil {
+		t.Errorf(`A valid chat ID should not generate any error, got %q`, err.TranslationKey)
+	}
+}
+
+func TestValidateRaindropCollectionID(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	integration := &model.Integration{}
+	integration.SetProvider(&model.ProviderSettings{
+		Name:    "raindrop",
+		Enabled: true,
+		Values:  map[string]string{"token": "secret", "collection_id": "unsorted"},
+	})
+
+	err := ValidateProviderSettings(integration)
+	if err == nil || err.TranslationKey != "error.raindrop_invalid_collection_id" {
+		t.Error(`An invalid collection ID should generate an error`)
+	}
+
+	integration.Provider("raindrop").Set("collection_id", "")
+	if err := ValidateProviderSettings(integration); err != nil {
+		t.Errorf(`An empty collection ID should not generate any error, got %q`, err.TranslationKey)
+	}
+}
+
+func TestValidateGotifyPriority(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	integration := &model.Integration{}
+	integration.SetProvider(&model.ProviderSettings{
+		Name:    "gotify",
+		Enabled: true,
+		Values:  map[string]string{"url": "https://gotify.example.org", "token": "secret", "priority": "42"},
+	})
+
+	err := ValidateProviderSettings(integration)
+	if err == nil || err.TranslationKey != "error.gotify_invalid_priority" {
+		t.Error(`An invalid priority should generate an error`)
+	}
+
+	integration.Provider("gotify").Set("priority", "8")
+	if err := ValidateProviderSettings(integration); err != nil {
+		t.Errorf(`A valid priority should not generate any error, got %q`, err.TranslationKey)
+	}
+}
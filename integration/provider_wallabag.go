@@ -0,0 +1,52 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package integration // import "miniflux.app/integration"
+
+import (
+	"miniflux.app/integration/wallabag"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func init() {
+	Register(&wallabagProvider{})
+}
+
+type wallabagProvider struct{}
+
+func (p *wallabagProvider) Name() string {
+	return "wallabag"
+}
+
+func (p *wallabagProvider) Title() string {
+	return "Wallabag"
+}
+
+func (p *wallabagProvider) Fields() []Field {
+	return []Field{
+		{Key: "only_url", Type: FieldTypeBool, Label: "form.integration.wallabag_only_url"},
+		{Key: "url", Type: FieldTypeURL, Label: "form.integration.wallabag_endpoint", Placeholder: "http://v2.wallabag.org/", Required: true},
+		{Key: "client_id", Type: FieldTypeText, Label: "form.integration.wallabag_client_id", Required: true},
+		{Key: "client_secret", Type: FieldTypePassword, Label: "form.integration.wallabag_client_secret", Required: true},
+		{Key: "username", Type: FieldTypeText, Label: "form.integration.wallabag_username", Required: true},
+		{Key: "password", Type: FieldTypePassword, Label: "form.integration.wallabag_password", Required: true},
+	}
+}
+
+func (p *wallabagProvider) Validate(settings *model.ProviderSettings) *validator.ValidationError {
+	return nil
+}
+
+func (p *wallabagProvider) SendEntry(entry *model.Entry, settings *model.ProviderSettings) error {
+	client := wallabag.NewClient(
+		settings.Get("url"),
+		settings.Get("client_id"),
+		settings.Get("client_secret"),
+		settings.Get("username"),
+		settings.Get("password"),
+		settings.Bool("only_url"),
+	)
+	return client.AddEntry(entry.URL, entry.Title, entry.Content)
+}
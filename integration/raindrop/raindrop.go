@@ -0,0 +1,76 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package raindrop // import "miniflux.app/integration/raindrop"
+
+import (
+	"fmt"
+	"strings"
+
+	"miniflux.app/http/client"
+)
+
+const defaultAPIEndpoint = "https://api.raindrop.io/rest/v1/raindrop"
+
+// UnsortedCollectionID is the identifier of the default Raindrop collection.
+const UnsortedCollectionID = -1
+
+// Collection represents a reference to a Raindrop collection.
+type Collection struct {
+	ID int64 `json:"$id"`
+}
+
+// Raindrop represents a Raindrop bookmark.
+type Raindrop struct {
+	Link       string      `json:"link"`
+	Title      string      `json:"title"`
+	Tags       []string    `json:"tags,omitempty"`
+	Collection *Collection `json:"collection"`
+
+	// PleaseParse asks Raindrop to fetch the cover and the excerpt of the page.
+	PleaseParse struct{} `json:"pleaseParse"`
+}
+
+// Client represents a Raindrop client.
+type Client struct {
+	apiEndpoint string
+	token       string
+}
+
+// NewClient returns a new Raindrop client.
+func NewClient(token string) *Client {
+	return &Client{apiEndpoint: defaultAPIEndpoint, token: token}
+}
+
+// AddBookmark sends a link to a Raindrop collection.
+func (c *Client) AddBookmark(link, title, tags string, collectionID int64) error {
+	if c.token == "" {
+		return fmt.Errorf("raindrop: missing credentials")
+	}
+
+	raindrop := &Raindrop{
+		Link:       link,
+		Title:      title,
+		Collection: &Collection{ID: collectionID},
+	}
+
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			raindrop.Tags = append(raindrop.Tags, tag)
+		}
+	}
+
+	clt := client.New(c.apiEndpoint)
+	clt.WithAuthorization("Bearer " + c.token)
+	response, err := clt.PostJSON(raindrop)
+	if err != nil {
+		return fmt.Errorf("raindrop: unable to send bookmark: %v", err)
+	}
+
+	if response.HasServerFailure() {
+		return fmt.Errorf("raindrop: unable to send bookmark, status=%d", response.StatusCode)
+	}
+
+	return nil
+}
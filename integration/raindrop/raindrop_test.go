@@ -0,0 +1,72 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package raindrop // import "miniflux.app/integration/raindrop"
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAddBookmark(t *testing.T) {
+	var raindrop Raindrop
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf(`Unexpected method, got %s`, r.Method)
+		}
+
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf(`Unexpected authorization header, got %q`, r.Header.Get("Authorization"))
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&raindrop); err != nil {
+			t.Error(err)
+		}
+
+		w.Write([]byte(`{"result": true}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient("secret")
+	client.apiEndpoint = ts.URL
+
+	if err := client.AddBookmark("https://example.org/", "Example", "news,tech", 42); err != nil {
+		t.Fatal(err)
+	}
+
+	if raindrop.Link != "https://example.org/" || raindrop.Title != "Example" {
+		t.Errorf(`Unexpected bookmark: %+v`, raindrop)
+	}
+
+	if raindrop.Collection == nil || raindrop.Collection.ID != 42 {
+		t.Errorf(`Unexpected collection: %+v`, raindrop.Collection)
+	}
+
+	if len(raindrop.Tags) != 2 || raindrop.Tags[0] != "news" || raindrop.Tags[1] != "tech" {
+		t.Errorf(`Unexpected tags: %v`, raindrop.Tags)
+	}
+}
+
+func TestAddBookmarkWithServerFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	client := NewClient("secret")
+	client.apiEndpoint = ts.URL
+
+	if err := client.AddBookmark("https://example.org/", "Example", "", UnsortedCollectionID); err == nil {
+		t.Fatal(`A server failure should return an error`)
+	}
+}
+
+func TestAddBookmarkWithMissingCredentials(t *testing.T) {
+	client := NewClient("")
+	if err := client.AddBookmark("https://example.org/", "Example", "", UnsortedCollectionID); err == nil {
+		t.Fatal(`Missing credentials should return an error`)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package readwise // import "miniflux.app/integration/readwise"
+
+import (
+	"fmt"
+	"strings"
+
+	"miniflux.app/http/client"
+)
+
+const defaultAPIEndpoint = "https://readwise.io/api/v3/save/"
+
+// Document represents a Readwise Reader document.
+type Document struct {
+	URL   string   `json:"url"`
+	Title string   `json:"title,omitempty"`
+	HTML  string   `json:"html,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+// Client represents a Readwise Reader client.
+type Client struct {
+	apiEndpoint string
+	apiKey      string
+}
+
+// NewClient returns a new Readwise Reader client.
+func NewClient(apiKey string) *Client {
+	return &Client{apiEndpoint: defaultAPIEndpoint, apiKey: apiKey}
+}
+
+// AddEntry sends an entry to Readwise Reader.
+func (c *Client) AddEntry(link, title, content, tags string) error {
+	if c.apiKey == "" {
+		return fmt.Errorf("readwise: missing credentials")
+	}
+
+	doc := &Document{
+		URL:   link,
+		Title: title,
+		HTML:  content,
+	}
+
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			doc.Tags = append(doc.Tags, tag)
+		}
+	}
+
+	clt := client.New(c.apiEndpoint)
+	clt.WithAuthorization("Token " + c.apiKey)
+	response, err := clt.PostJSON(doc)
+	if err != nil {
+		return fmt.Errorf("readwise: unable to send entry: %v", err)
+	}
+
+	if response.HasServerFailure() {
+		return fmt.Errorf("readwise: unable to send entry, status=%d", response.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package readwise // import "miniflux.app/integration/readwise"
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAddEntry(t *testing.T) {
+	var doc Document
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf(`Unexpected method, got %s`, r.Method)
+		}
+
+		if r.Header.Get("Authorization") != "Token secret" {
+			t.Errorf(`Unexpected authorization header, got %q`, r.Header.Get("Authorization"))
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&doc); err != nil {
+			t.Error(err)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	client := NewClient("secret")
+	client.apiEndpoint = ts.URL
+
+	if err := client.AddEntry("https://example.org/", "Example", "<p>Content</p>", "miniflux, news"); err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.URL != "https://example.org/" || doc.Title != "Example" || doc.HTML != "<p>Content</p>" {
+		t.Errorf(`Unexpected document: %+v`, doc)
+	}
+
+	if len(doc.Tags) != 2 || doc.Tags[0] != "miniflux" || doc.Tags[1] != "news" {
+		t.Errorf(`Unexpected tags: %v`, doc.Tags)
+	}
+}
+
+func TestAddEntryWithServerFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	client := NewClient("secret")
+	client.apiEndpoint = ts.URL
+
+	if err := client.AddEntry("https://example.org/", "Example", "", ""); err == nil {
+		t.Fatal(`A server failure should return an error`)
+	}
+}
+
+func TestAddEntryWithMissingCredentials(t *testing.T) {
+	client := NewClient("")
+	if err := client.AddEntry("https://example.org/", "Example", "", ""); err == nil {
+		t.Fatal(`Missing credentials should return an error`)
+	}
+}
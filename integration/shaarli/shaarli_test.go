@@ -0,0 +1,96 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package shaarli // import "miniflux.app/integration/shaarli"
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateToken(t *testing.T) {
+	token, err := generateToken("secret", time.Unix(1672531200, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf(`The token should have 3 parts, got %q`, token)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(payload) != `{"iat":1672531200}` {
+		t.Errorf(`Unexpected token payload, got %s`, payload)
+	}
+
+	mac := hmac.New(sha512.New, []byte("secret"))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if parts[2] != base64.RawURLEncoding.EncodeToString(mac.Sum(nil)) {
+		t.Error(`The token signature is invalid`)
+	}
+}
+
+func TestAddLink(t *testing.T) {
+	var link Link
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/shaarli/api/v1/links" {
+			t.Errorf(`Unexpected request: %s %s`, r.Method, r.URL.Path)
+		}
+
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			t.Errorf(`Missing bearer token, got %q`, r.Header.Get("Authorization"))
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&link); err != nil {
+			t.Error(err)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ts.URL+"/shaarli/", "secret")
+	if err := client.AddLink("https://example.org/", "Example", "news, tech", true); err != nil {
+		t.Fatal(err)
+	}
+
+	if link.URL != "https://example.org/" || link.Title != "Example" || !link.Private {
+		t.Errorf(`Unexpected link: %+v`, link)
+	}
+
+	if len(link.Tags) != 2 || link.Tags[0] != "news" || link.Tags[1] != "tech" {
+		t.Errorf(`Unexpected tags: %v`, link.Tags)
+	}
+}
+
+func TestAddLinkWithServerFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ts.URL, "secret")
+	if err := client.AddLink("https://example.org/", "Example", "", false); err == nil {
+		t.Fatal(`A server failure should return an error`)
+	}
+}
+
+func TestAddLinkWithMissingCredentials(t *testing.T) {
+	client := NewClient("", "")
+	if err := client.AddLink("https://example.org/", "Example", "", false); err == nil {
+		t.Fatal(`Missing credentials should return an error`)
+	}
+}
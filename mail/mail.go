@@ -0,0 +1,120 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package mail // import "miniflux.app/mail"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"miniflux.app/config"
+)
+
+// Message represents an HTML email.
+type Message struct {
+	To      string
+	Subject string
+	Body    []byte
+
+	// Headers contains additional headers, like List-Unsubscribe.
+	Headers map[string]string
+
+	Attachments []*Attachment
+}
+
+// Attachment represents a file attached to an email.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// IsEnabled returns true if an SMTP server is configured.
+func IsEnabled() bool {
+	return config.Opts.SMTPHost() != ""
+}
+
+// Send delivers the message through the configured SMTP server.
+// The connection is upgraded with STARTTLS when the server supports it.
+func Send(message *Message) error {
+	if !IsEnabled() {
+		return errors.New("mail: no SMTP server configured")
+	}
+
+	var auth smtp.Auth
+	if config.Opts.SMTPUsername() != "" {
+		auth = smtp.PlainAuth("", config.Opts.SMTPUsername(), config.Opts.SMTPPassword(), config.Opts.SMTPHost())
+	}
+
+	addr := net.JoinHostPort(config.Opts.SMTPHost(), strconv.Itoa(config.Opts.SMTPPort()))
+	from := config.Opts.SMTPFrom()
+
+	if err := smtp.SendMail(addr, auth, from, []string{message.To}, message.bytes(from, time.Now())); err != nil {
+		return fmt.Errorf("mail: unable to send email to %q: %v", message.To, err)
+	}
+
+	return nil
+}
+
+func (m *Message) bytes(from string, date time.Time) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", m.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	for name, value := range m.Headers {
+		fmt.Fprintf(&b, "%s: %s\r\n", name, value)
+	}
+	b.WriteString("MIME-Version: 1.0\r\n")
+
+	if len(m.Attachments) == 0 {
+		b.WriteString("Content-Type: text/html; charset=utf-8\r\n")
+		b.WriteString("\r\n")
+		b.Write(m.Body)
+		return b.Bytes()
+	}
+
+	var parts bytes.Buffer
+	writer := multipart.NewWriter(&parts)
+	fmt.Fprintf(&b, "Content-Type: multipart/mixed; boundary=%q\r\n", writer.Boundary())
+	b.WriteString("\r\n")
+
+	body, _ := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/html; charset=utf-8"}})
+	body.Write(m.Body)
+
+	for _, attachment := range m.Attachments {
+		part, _ := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {attachment.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+		})
+		writeBase64(part, attachment.Data)
+	}
+
+	writer.Close()
+	b.Write(parts.Bytes())
+
+	return b.Bytes()
+}
+
+// writeBase64 encodes the data in lines of 76 characters, as required by RFC 2045.
+func writeBase64(w io.Writer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		io.WriteString(w, encoded[:76]+"\r\n")
+		encoded = encoded[76:]
+	}
+	io.WriteString(w, encoded+"\r\n")
+}
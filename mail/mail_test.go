@@ -0,0 +1,76 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package mail // import "miniflux.app/mail"
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/config"
+)
+
+func TestMessageBytes(t *testing.T) {
+	message := &Message{
+		To:      "user@example.org",
+		Subject: "Résumé",
+		Body:    []byte("<p>Hello</p>"),
+		Headers: map[string]string{"List-Unsubscribe": "<https://example.org/unsubscribe>"},
+	}
+
+	date := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	result := string(message.bytes("miniflux@example.org", date))
+
+	for _, expected := range []string{
+		"From: miniflux@example.org\r\n",
+		"To: user@example.org\r\n",
+		"Subject: =?utf-8?q?R=C3=A9sum=C3=A9?=\r\n",
+		"Date: Mon, 02 Jan 2023 03:04:05 +0000\r\n",
+		"List-Unsubscribe: <https://example.org/unsubscribe>\r\n",
+		"Content-Type: text/html; charset=utf-8\r\n\r\n<p>Hello</p>",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf(`The message should contain %q, got %q`, expected, result)
+		}
+	}
+}
+
+func TestSendWithoutServer(t *testing.T) {
+	config.Opts = config.NewOptions()
+
+	if IsEnabled() {
+		t.Fatal(`Emails should be disabled by default`)
+	}
+
+	if err := Send(&Message{To: "user@example.org"}); err == nil {
+		t.Error(`Sending an email without SMTP server should fail`)
+	}
+}
+
+func TestMessageBytesWithAttachment(t *testing.T) {
+	data := []byte(strings.Repeat("0123456789", 10))
+	message := &Message{
+		To:      "user@example.org",
+		Subject: "Export",
+		Body:    []byte("<p>Attached</p>"),
+		Attachments: []*Attachment{
+			{Filename: "starred 2023-01-02.epub", ContentType: "application/epub+zip", Data: data},
+		},
+	}
+
+	result := string(message.bytes("miniflux@example.org", time.Now()))
+
+	for _, expected := range []string{
+		"Content-Type: multipart/mixed; boundary=",
+		"Content-Type: text/html; charset=utf-8\r\n\r\n<p>Attached</p>",
+		"Content-Disposition: attachment; filename=\"starred 2023-01-02.epub\"\r\n",
+		"Content-Transfer-Encoding: base64\r\n",
+		"MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2Nzg5MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTIzNDU2\r\n",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf(`The message should contain %q, got %q`, expected, result)
+		}
+	}
+}
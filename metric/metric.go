@@ -45,6 +45,42 @@ var (
 		[]string{"status"},
 	)
 
+	ArchivedEntries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "miniflux",
+			Name:      "archived_entries_total",
+			Help:      "Number of entries archived by the cleanup scheduler by policy and action",
+		},
+		[]string{"policy", "action"},
+	)
+
+	IntegrationDeliveries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "miniflux",
+			Name:      "integration_deliveries_total",
+			Help:      "Number of delivery attempts to third-party services",
+		},
+		[]string{"provider", "status"},
+	)
+
+	MediaProxyCacheRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "miniflux",
+			Name:      "media_proxy_cache_requests_total",
+			Help:      "Number of media proxy cache lookups by result",
+		},
+		[]string{"result"},
+	)
+
+	SharedFeedFetches = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "miniflux",
+			Name:      "shared_feed_fetches_total",
+			Help:      "Number of feed refreshes by shared download result",
+		},
+		[]string{"result"},
+	)
+
 	usersGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "miniflux",
@@ -79,6 +115,15 @@ var (
 		[]string{"status"},
 	)
 
+	integrationDeliveriesGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "integration_deliveries",
+			Help:      "Number of integration deliveries by status",
+		},
+		[]string{"status"},
+	)
+
 	dbOpenConnectionsGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "miniflux",
@@ -147,10 +192,15 @@ func NewCollector(store *storage.Storage, refreshInterval int) *Collector {
 	prometheus.MustRegister(BackgroundFeedRefreshDuration)
 	prometheus.MustRegister(ScraperRequestDuration)
 	prometheus.MustRegister(ArchiveEntriesDuration)
+	prometheus.MustRegister(ArchivedEntries)
+	prometheus.MustRegister(IntegrationDeliveries)
+	prometheus.MustRegister(MediaProxyCacheRequests)
+	prometheus.MustRegister(SharedFeedFetches)
 	prometheus.MustRegister(usersGauge)
 	prometheus.MustRegister(feedsGauge)
 	prometheus.MustRegister(brokenFeedsGauge)
 	prometheus.MustRegister(entriesGauge)
+	prometheus.MustRegister(integrationDeliveriesGauge)
 	prometheus.MustRegister(dbOpenConnectionsGauge)
 	prometheus.MustRegister(dbConnectionsInUseGauge)
 	prometheus.MustRegister(dbConnectionsIdleGauge)
@@ -180,6 +230,11 @@ func (c *Collector) GatherStorageMetrics() {
 			entriesGauge.WithLabelValues(status).Set(float64(count))
 		}
 
+		deliveriesCount := c.store.CountAllIntegrationDeliveries()
+		for status, count := range deliveriesCount {
+			integrationDeliveriesGauge.WithLabelValues(status).Set(float64(count))
+		}
+
 		dbStats := c.store.DBStats()
 		dbOpenConnectionsGauge.Set(float64(dbStats.OpenConnections))
 		dbConnectionsInUseGauge.Set(float64(dbStats.InUse))
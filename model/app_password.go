@@ -0,0 +1,29 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"time"
+
+	"miniflux.app/crypto"
+)
+
+// AppPassword is a password dedicated to an application using the HTTP Basic authentication,
+// it is the only password accepted by the API once two-factor authentication is enabled.
+type AppPassword struct {
+	ID          int64
+	UserID      int64
+	Description string
+	LastUsedAt  *time.Time
+	CreatedAt   time.Time
+}
+
+// GenerateAppPassword returns a new random password, it is displayed only once.
+func GenerateAppPassword() string {
+	return crypto.GenerateRandomStringHex(16)
+}
+
+// AppPasswords represents a collection of app passwords.
+type AppPasswords []*AppPassword
@@ -0,0 +1,128 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"time"
+
+	"miniflux.app/timezone"
+)
+
+// Audit log sources.
+const (
+	AuditSourceUI  = "ui"
+	AuditSourceAPI = "api"
+	AuditSourceCLI = "cli"
+)
+
+// Audit log actions.
+const (
+	AuditActionLogin             = "auth.login"
+	AuditActionLoginFailed       = "auth.login_failed"
+	AuditActionLogout            = "auth.logout"
+	AuditActionSessionRemove     = "session.remove"
+	AuditActionPasswordReset     = "password.reset"
+	AuditActionTwoFactorEnable   = "two_factor.enable"
+	AuditActionTwoFactorDisable  = "two_factor.disable"
+	AuditActionAPIKeyCreate      = "api_key.create"
+	AuditActionAPIKeyRemove      = "api_key.remove"
+	AuditActionAPIKeyUse         = "api_key.use"
+	AuditActionUserCreate        = "user.create"
+	AuditActionUserUpdate        = "user.update"
+	AuditActionUserRemove        = "user.remove"
+	AuditActionOPMLImport        = "opml.import"
+	AuditActionIntegrationUpdate = "integration.update"
+)
+
+// AuditActions returns the list of audit log actions.
+func AuditActions() []string {
+	return []string{
+		AuditActionLogin,
+		AuditActionLoginFailed,
+		AuditActionLogout,
+		AuditActionSessionRemove,
+		AuditActionPasswordReset,
+		AuditActionTwoFactorEnable,
+		AuditActionTwoFactorDisable,
+		AuditActionAPIKeyCreate,
+		AuditActionAPIKeyRemove,
+		AuditActionAPIKeyUse,
+		AuditActionUserCreate,
+		AuditActionUserUpdate,
+		AuditActionUserRemove,
+		AuditActionOPMLImport,
+		AuditActionIntegrationUpdate,
+	}
+}
+
+// AuditSources returns the list of audit log sources.
+func AuditSources() []string {
+	return []string{AuditSourceUI, AuditSourceAPI, AuditSourceCLI}
+}
+
+// IsValidAuditAction returns true if the audit log action exists.
+func IsValidAuditAction(action string) bool {
+	for _, validAction := range AuditActions() {
+		if action == validAction {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidAuditSource returns true if the audit log source exists.
+func IsValidAuditSource(source string) bool {
+	for _, validSource := range AuditSources() {
+		if source == validSource {
+			return true
+		}
+	}
+	return false
+}
+
+// AuditLog records a security-relevant or administrative action.
+// The username is kept when the user is removed, the user ID is cleared.
+type AuditLog struct {
+	ID        int64     `json:"id"`
+	UserID    *int64    `json:"user_id"`
+	Username  string    `json:"username"`
+	Source    string    `json:"source"`
+	Action    string    `json:"action"`
+	Target    string    `json:"target"`
+	Details   string    `json:"details"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewAuditLog initializes an audit log entry for the given user, the ID is zero when the user is unknown.
+// The target describes the object of the action, like "user:bob".
+func NewAuditLog(source, action string, userID int64, target string) *AuditLog {
+	auditLog := &AuditLog{Source: source, Action: action, Target: target}
+	if userID > 0 {
+		auditLog.UserID = &userID
+	}
+	return auditLog
+}
+
+// AuditLogs represents a list of audit log entries.
+type AuditLogs []*AuditLog
+
+// UseTimezone converts creation dates to the given timezone.
+func (a AuditLogs) UseTimezone(tz string) {
+	for _, auditLog := range a {
+		auditLog.CreatedAt = timezone.Convert(tz, auditLog.CreatedAt)
+	}
+}
+
+// AuditLogFilter restricts the audit log entries returned by the storage, empty fields are ignored.
+type AuditLogFilter struct {
+	UserID   int64
+	Username string
+	Source   string
+	Action   string
+	Offset   int
+	Limit    int
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"strings"
+	"time"
+)
+
+// Statuses of the authors followed or muted by a user.
+const (
+	AuthorStatusFollowed = "followed"
+	AuthorStatusMuted    = "muted"
+)
+
+// AuthorPreference represents an author followed or muted by a user.
+// Authors are matched without regard to case, the name is kept as first seen.
+type AuthorPreference struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Author    string    `json:"author"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IsFollowed returns true if the author is followed.
+func (a *AuthorPreference) IsFollowed() bool {
+	return a.Status == AuthorStatusFollowed
+}
+
+// IsMuted returns true if the author is muted.
+func (a *AuthorPreference) IsMuted() bool {
+	return a.Status == AuthorStatusMuted
+}
+
+// IsValidAuthorStatus returns true if the author status exists.
+func IsValidAuthorStatus(status string) bool {
+	return status == AuthorStatusFollowed || status == AuthorStatusMuted
+}
+
+// AuthorPreferences represents a list of author preferences.
+type AuthorPreferences []*AuthorPreference
+
+// Statuses returns the status of each author, the names are lowercased.
+func (a AuthorPreferences) Statuses() map[string]string {
+	statuses := make(map[string]string, len(a))
+	for _, preference := range a {
+		statuses[NormalizeAuthor(preference.Author)] = preference.Status
+	}
+	return statuses
+}
+
+// NormalizeAuthor returns the author name used to compare authors.
+func NormalizeAuthor(author string) string {
+	return strings.ToLower(strings.TrimSpace(author))
+}
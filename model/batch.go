@@ -0,0 +1,73 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+// MaxBatchSize is the maximum number of feeds or entries changed by a batch operation.
+const MaxBatchSize = 1000
+
+// Actions applied to several feeds at once.
+const (
+	FeedBatchActionMove         = "move"
+	FeedBatchActionEnable       = "enable"
+	FeedBatchActionDisable      = "disable"
+	FeedBatchActionRemove       = "remove"
+	FeedBatchActionRefresh      = "refresh"
+	FeedBatchActionApplyRules   = "apply_rules"
+	FeedBatchActionSetUserAgent = "set_user_agent"
+)
+
+// Actions applied to several entries at once.
+const (
+	EntryBatchActionStar   = "star"
+	EntryBatchActionUnstar = "unstar"
+	EntryBatchActionRead   = "read"
+	EntryBatchActionUnread = "unread"
+	EntryBatchActionShare  = "share"
+	EntryBatchActionSave   = "save"
+)
+
+// FeedBatchRequest represents an action applied to a list of feeds.
+// Only the rules given are replaced by the "apply_rules" action.
+type FeedBatchRequest struct {
+	FeedIDs        []int64 `json:"feed_ids"`
+	Action         string  `json:"action"`
+	CategoryID     int64   `json:"category_id,omitempty"`
+	UserAgent      string  `json:"user_agent,omitempty"`
+	ScraperRules   *string `json:"scraper_rules,omitempty"`
+	RewriteRules   *string `json:"rewrite_rules,omitempty"`
+	BlocklistRules *string `json:"blocklist_rules,omitempty"`
+	KeeplistRules  *string `json:"keeplist_rules,omitempty"`
+}
+
+// EntryBatchRequest represents an action applied to a list of entries.
+type EntryBatchRequest struct {
+	EntryIDs []int64 `json:"entry_ids"`
+	Action   string  `json:"action"`
+}
+
+// FeedBatchActions returns the list of actions applied to several feeds at once.
+func FeedBatchActions() []string {
+	return []string{
+		FeedBatchActionMove,
+		FeedBatchActionEnable,
+		FeedBatchActionDisable,
+		FeedBatchActionRemove,
+		FeedBatchActionRefresh,
+		FeedBatchActionApplyRules,
+		FeedBatchActionSetUserAgent,
+	}
+}
+
+// EntryBatchActions returns the list of actions applied to several entries at once.
+func EntryBatchActions() []string {
+	return []string{
+		EntryBatchActionStar,
+		EntryBatchActionUnstar,
+		EntryBatchActionRead,
+		EntryBatchActionUnread,
+		EntryBatchActionShare,
+		EntryBatchActionSave,
+	}
+}
@@ -12,8 +12,10 @@ type Category struct {
 	Title        string `json:"title"`
 	UserID       int64  `json:"user_id"`
 	HideGlobally bool   `json:"hide_globally"`
+	Notify       bool   `json:"notify"`
 	FeedCount    int    `json:"-"`
 	TotalUnread  int    `json:"-"`
+	RetentionPolicy
 }
 
 func (c *Category) String() string {
@@ -24,12 +26,33 @@ func (c *Category) String() string {
 type CategoryRequest struct {
 	Title        string `json:"title"`
 	HideGlobally string `json:"hide_globally"`
+	Notify       string `json:"notify"`
+
+	// The retention policy is left untouched when not given.
+	RetentionMode   *string `json:"retention_mode,omitempty"`
+	RetentionValue  *int    `json:"retention_value,omitempty"`
+	RetentionDelete *bool   `json:"retention_delete,omitempty"`
 }
 
 // Patch updates category fields.
 func (cr *CategoryRequest) Patch(category *Category) {
 	category.Title = cr.Title
 	category.HideGlobally = cr.HideGlobally != ""
+	category.Notify = cr.Notify != ""
+
+	if cr.RetentionMode != nil {
+		category.RetentionMode = *cr.RetentionMode
+	}
+
+	if cr.RetentionValue != nil {
+		category.RetentionValue = *cr.RetentionValue
+	}
+
+	if cr.RetentionDelete != nil {
+		category.RetentionDelete = *cr.RetentionDelete
+	}
+
+	category.Normalize()
 }
 
 // Categories represents a list of categories.
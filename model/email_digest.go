@@ -0,0 +1,60 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"time"
+
+	"miniflux.app/crypto"
+)
+
+// Email digest frequencies.
+const (
+	DigestFrequencyDaily  = "daily"
+	DigestFrequencyWeekly = "weekly"
+)
+
+// EmailDigest represents the email digest preferences of a user.
+type EmailDigest struct {
+	UserID      int64
+	Enabled     bool
+	Email       string
+	Frequency   string
+	Hour        int
+	Weekday     int
+	CategoryIDs []int64
+	Token       string
+	SentAt      *time.Time
+}
+
+// NewEmailDigest returns the default digest preferences of a user.
+func NewEmailDigest(userID int64) *EmailDigest {
+	return &EmailDigest{
+		UserID:    userID,
+		Frequency: DigestFrequencyDaily,
+		Hour:      8,
+		Weekday:   int(time.Monday),
+		Token:     crypto.GenerateRandomString(32),
+	}
+}
+
+// HasCategory returns true if the digest includes the given category.
+// All categories are included when none is selected.
+func (d *EmailDigest) HasCategory(categoryID int64) bool {
+	if len(d.CategoryIDs) == 0 {
+		return true
+	}
+
+	for _, id := range d.CategoryIDs {
+		if id == categoryID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EmailDigests represents a list of email digest preferences.
+type EmailDigests []*EmailDigest
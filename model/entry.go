@@ -19,31 +19,46 @@ const (
 
 // Entry represents a feed item in the system.
 type Entry struct {
-	ID          int64         `json:"id"`
-	UserID      int64         `json:"user_id"`
-	FeedID      int64         `json:"feed_id"`
-	Status      string        `json:"status"`
-	Hash        string        `json:"hash"`
-	Title       string        `json:"title"`
-	URL         string        `json:"url"`
-	CommentsURL string        `json:"comments_url"`
-	Date        time.Time     `json:"published_at"`
-	CreatedAt   time.Time     `json:"created_at"`
-	ChangedAt   time.Time     `json:"changed_at"`
-	Content     string        `json:"content"`
-	Author      string        `json:"author"`
-	ShareCode   string        `json:"share_code"`
-	Starred     bool          `json:"starred"`
-	ReadingTime int           `json:"reading_time"`
-	Enclosures  EnclosureList `json:"enclosures"`
-	Feed        *Feed         `json:"feed,omitempty"`
+	ID              int64         `json:"id"`
+	UserID          int64         `json:"user_id"`
+	FeedID          int64         `json:"feed_id"`
+	Status          string        `json:"status"`
+	Hash            string        `json:"hash"`
+	Title           string        `json:"title"`
+	URL             string        `json:"url"`
+	CommentsURL     string        `json:"comments_url"`
+	Date            time.Time     `json:"published_at"`
+	CreatedAt       time.Time     `json:"created_at"`
+	ChangedAt       time.Time     `json:"changed_at"`
+	Content         string        `json:"content"`
+	Author          string        `json:"author"`
+	AuthorStatus    string        `json:"author_status"`
+	ShareCode       string        `json:"share_code"`
+	Starred         bool          `json:"starred"`
+	ReadingTime     int           `json:"reading_time"`
+	ReadingProgress int           `json:"reading_progress"`
+	ScrollPosition  int           `json:"scroll_position"`
+	ClusterID       int64         `json:"cluster_id"`
+	Score           float64       `json:"score"`
+	Enclosures      EnclosureList `json:"enclosures"`
+	Feed            *Feed         `json:"feed,omitempty"`
 }
 
 // Entries represents a list of entries.
 type Entries []*Entry
 
+// EntryReadingProgressRequest represents a request to save how far an entry has been read.
+type EntryReadingProgressRequest struct {
+	ReadingProgress int `json:"reading_progress"`
+	ScrollPosition  int `json:"scroll_position"`
+}
+
 // EntriesStatusUpdateRequest represents a request to change entries status.
+// The API also accepts a bookmark flag without status, and a change time
+// to skip the entries modified more recently on the server.
 type EntriesStatusUpdateRequest struct {
-	EntryIDs []int64 `json:"entry_ids"`
-	Status   string  `json:"status"`
+	EntryIDs  []int64    `json:"entry_ids"`
+	Status    string     `json:"status"`
+	Starred   *bool      `json:"starred,omitempty"`
+	ChangedAt *time.Time `json:"changed_at,omitempty"`
 }
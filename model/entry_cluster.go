@@ -0,0 +1,38 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+// EntryCluster represents the entries telling the same story.
+// Entries without similar ones are alone in a cluster with the ID 0.
+type EntryCluster struct {
+	ID      int64   `json:"id"`
+	Entries Entries `json:"entries"`
+}
+
+// EntryClusters represents a list of clusters.
+type EntryClusters []*EntryCluster
+
+// GroupEntriesByCluster groups the entries of the same cluster, the order of the first entry of each cluster is kept.
+func GroupEntriesByCluster(entries Entries) EntryClusters {
+	clusters := make(EntryClusters, 0, len(entries))
+	positions := make(map[int64]int)
+
+	for _, entry := range entries {
+		if entry.ClusterID == 0 {
+			clusters = append(clusters, &EntryCluster{Entries: Entries{entry}})
+			continue
+		}
+
+		if position, found := positions[entry.ClusterID]; found {
+			clusters[position].Entries = append(clusters[position].Entries, entry)
+			continue
+		}
+
+		positions[entry.ClusterID] = len(clusters)
+		clusters = append(clusters, &EntryCluster{ID: entry.ClusterID, Entries: Entries{entry}})
+	}
+
+	return clusters
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import "testing"
+
+func TestGroupEntriesByCluster(t *testing.T) {
+	entries := Entries{
+		{ID: 1, ClusterID: 1},
+		{ID: 2},
+		{ID: 3, ClusterID: 1},
+		{ID: 4, ClusterID: 4},
+		{ID: 5, ClusterID: 4},
+	}
+
+	clusters := GroupEntriesByCluster(entries)
+	if len(clusters) != 3 {
+		t.Fatalf(`Unexpected number of clusters, got %d instead of 3`, len(clusters))
+	}
+
+	expected := [][]int64{{1, 3}, {2}, {4, 5}}
+	for i, cluster := range clusters {
+		if len(cluster.Entries) != len(expected[i]) {
+			t.Fatalf(`Unexpected number of entries in cluster #%d, got %d`, i, len(cluster.Entries))
+		}
+
+		for j, entry := range cluster.Entries {
+			if entry.ID != expected[i][j] {
+				t.Errorf(`Unexpected entry in cluster #%d, got %d instead of %d`, i, entry.ID, expected[i][j])
+			}
+		}
+	}
+
+	if clusters[1].ID != 0 {
+		t.Errorf(`An entry without similar ones should have the cluster ID 0, got %d`, clusters[1].ID)
+	}
+}
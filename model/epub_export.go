@@ -0,0 +1,71 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import "time"
+
+// Kinds of entries bundled in an EPUB export.
+const (
+	EPUBExportKindStarred  = "starred"
+	EPUBExportKindCategory = "category"
+	EPUBExportKindSearch   = "search"
+)
+
+// Deliveries of the scheduled EPUB exports, exports without delivery are only downloaded.
+const (
+	EPUBExportDeliveryNone      = ""
+	EPUBExportDeliveryDirectory = "directory"
+	EPUBExportDeliveryEmail     = "email"
+)
+
+// Frequencies of the scheduled EPUB exports.
+const (
+	EPUBExportFrequencyDaily  = "daily"
+	EPUBExportFrequencyWeekly = "weekly"
+)
+
+// EPUBExport bundles a selection of entries into an EPUB book: the starred entries,
+// the unread entries of a category or the result of a search query.
+type EPUBExport struct {
+	ID            int64
+	UserID        int64
+	Title         string
+	Kind          string
+	CategoryID    *int64
+	CategoryTitle string
+	SearchQuery   string
+	Delivery      string
+	Email         string
+	Frequency     string
+	DeliveredAt   *time.Time
+	CreatedAt     time.Time
+}
+
+// NewEPUBExport returns an export without scheduled delivery.
+func NewEPUBExport(userID int64, title, kind string) *EPUBExport {
+	return &EPUBExport{
+		UserID:    userID,
+		Title:     title,
+		Kind:      kind,
+		Frequency: EPUBExportFrequencyDaily,
+	}
+}
+
+// IsValidEPUBExportKind returns true if the kind of export exists.
+func IsValidEPUBExportKind(kind string) bool {
+	switch kind {
+	case EPUBExportKindStarred, EPUBExportKindCategory, EPUBExportKindSearch:
+		return true
+	}
+	return false
+}
+
+// IsScheduled returns true if the export is delivered periodically.
+func (e *EPUBExport) IsScheduled() bool {
+	return e.Delivery != EPUBExportDeliveryNone
+}
+
+// EPUBExports represents a list of EPUB exports.
+type EPUBExports []*EPUBExport
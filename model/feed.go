@@ -53,8 +53,10 @@ type Feed struct {
 	Entries                     Entries   `json:"entries,omitempty"`
 	Icon                        *FeedIcon `json:"icon"`
 	HideGlobally                bool      `json:"hide_globally"`
+	Notify                      bool      `json:"notify"`
 	UnreadCount                 int       `json:"-"`
 	ReadCount                   int       `json:"-"`
+	RetentionPolicy
 }
 
 type FeedCounters struct {
@@ -166,6 +168,10 @@ type FeedModificationRequest struct {
 	AllowSelfSignedCertificates *bool   `json:"allow_self_signed_certificates"`
 	FetchViaProxy               *bool   `json:"fetch_via_proxy"`
 	HideGlobally                *bool   `json:"hide_globally"`
+	Notify                      *bool   `json:"notify"`
+	RetentionMode               *string `json:"retention_mode"`
+	RetentionValue              *int    `json:"retention_value"`
+	RetentionDelete             *bool   `json:"retention_delete"`
 }
 
 // Patch updates a feed with modified values.
@@ -245,6 +251,24 @@ func (f *FeedModificationRequest) Patch(feed *Feed) {
 	if f.HideGlobally != nil {
 		feed.HideGlobally = *f.HideGlobally
 	}
+
+	if f.Notify != nil {
+		feed.Notify = *f.Notify
+	}
+
+	if f.RetentionMode != nil {
+		feed.RetentionMode = *f.RetentionMode
+	}
+
+	if f.RetentionValue != nil {
+		feed.RetentionValue = *f.RetentionValue
+	}
+
+	if f.RetentionDelete != nil {
+		feed.RetentionDelete = *f.RetentionDelete
+	}
+
+	feed.Normalize()
 }
 
 // Feeds is a list of feed
@@ -4,48 +4,84 @@
 
 package model // import "miniflux.app/model"
 
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
 // Integration represents user integration settings.
 type Integration struct {
 	UserID               int64
-	PinboardEnabled      bool
-	PinboardToken        string
-	PinboardTags         string
-	PinboardMarkAsUnread bool
-	InstapaperEnabled    bool
-	InstapaperUsername   string
-	InstapaperPassword   string
 	FeverEnabled         bool
 	FeverUsername        string
 	FeverToken           string
 	GoogleReaderEnabled  bool
 	GoogleReaderUsername string
 	GoogleReaderPassword string
-	WallabagEnabled      bool
-	WallabagOnlyURL      bool
-	WallabagURL          string
-	WallabagClientID     string
-	WallabagClientSecret string
-	WallabagUsername     string
-	WallabagPassword     string
-	NunuxKeeperEnabled   bool
-	NunuxKeeperURL       string
-	NunuxKeeperAPIKey    string
-	EspialEnabled        bool
-	EspialURL            string
-	EspialAPIKey         string
-	EspialTags           string
-	PocketEnabled        bool
-	PocketAccessToken    string
-	PocketConsumerKey    string
-	TelegramBotEnabled   bool
-	TelegramBotToken     string
-	TelegramBotChatID    string
-	LinkdingEnabled      bool
-	LinkdingURL          string
-	LinkdingAPIKey       string
-	MatrixBotEnabled     bool
-	MatrixBotUser        string
-	MatrixBotPassword    string
-	MatrixBotURL         string
-	MatrixBotChatID      string
+	Providers            map[string]*ProviderSettings
+
+	// Notification settings, the quiet hours are disabled when the start and the end are equal.
+	NotificationRules           string
+	NotificationQuietHoursStart int
+	NotificationQuietHoursEnd   int
+	NotificationDigestInterval  int
+	NotificationDigestSentAt    *time.Time
+}
+
+// Provider returns the settings of the given integration provider.
+// A disabled and empty configuration is returned when the user never configured the provider.
+func (i *Integration) Provider(name string) *ProviderSettings {
+	if settings, found := i.Providers[name]; found {
+		return settings
+	}
+
+	return &ProviderSettings{Name: name, Values: make(map[string]string)}
+}
+
+// SetProvider replaces the settings of an integration provider.
+func (i *Integration) SetProvider(settings *ProviderSettings) {
+	if i.Providers == nil {
+		i.Providers = make(map[string]*ProviderSettings)
+	}
+
+	i.Providers[settings.Name] = settings
+}
+
+// EnabledProviders returns the sorted names of the enabled integration providers.
+func (i *Integration) EnabledProviders() []string {
+	var names []string
+	for name, settings := range i.Providers {
+		if settings.Enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ProviderSettings represents the configuration of an integration provider for a user.
+type ProviderSettings struct {
+	Name    string
+	Enabled bool
+	Values  map[string]string
+}
+
+// Get returns the value of a setting.
+func (p *ProviderSettings) Get(key string) string {
+	return p.Values[key]
+}
+
+// Bool returns the value of a boolean setting.
+func (p *ProviderSettings) Bool(key string) bool {
+	value, _ := strconv.ParseBool(p.Values[key])
+	return value
+}
+
+// Set changes the value of a setting.
+func (p *ProviderSettings) Set(key, value string) {
+	if p.Values == nil {
+		p.Values = make(map[string]string)
+	}
+	p.Values[key] = value
 }
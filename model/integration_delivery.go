@@ -0,0 +1,38 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import "time"
+
+// Integration delivery statuses.
+const (
+	DeliveryStatusSuccess  = "success"
+	DeliveryStatusRetrying = "retrying"
+	DeliveryStatusFailed   = "failed"
+)
+
+// Integration delivery actions.
+const (
+	DeliveryActionSave = "save"
+	DeliveryActionPush = "push"
+)
+
+// IntegrationDelivery represents the delivery of an entry to an integration provider.
+type IntegrationDelivery struct {
+	ID            int64      `json:"id"`
+	UserID        int64      `json:"user_id"`
+	EntryID       int64      `json:"entry_id"`
+	Provider      string     `json:"provider"`
+	Action        string     `json:"action"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error"`
+	NextAttemptAt *time.Time `json:"next_attempt_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// IntegrationDeliveries represents a list of deliveries.
+type IntegrationDeliveries []*IntegrationDelivery
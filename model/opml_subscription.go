@@ -0,0 +1,63 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import "time"
+
+// Policies applied to the feeds removed from a remote OPML file.
+const (
+	OPMLRemovalPolicyKeep    = "keep"
+	OPMLRemovalPolicyDisable = "disable"
+	OPMLRemovalPolicyRemove  = "remove"
+)
+
+// OPMLSubscription represents a remote OPML file kept in sync with the feeds of a user.
+type OPMLSubscription struct {
+	ID                 int64
+	UserID             int64
+	URL                string
+	CategoryID         *int64
+	CategoryTitle      string
+	RemovalPolicy      string
+	EtagHeader         string
+	LastModifiedHeader string
+	CheckedAt          *time.Time
+	CreatedAt          time.Time
+	FeedCount          int
+}
+
+// IsValidOPMLRemovalPolicy returns true if the policy is supported.
+func IsValidOPMLRemovalPolicy(policy string) bool {
+	switch policy {
+	case OPMLRemovalPolicyKeep, OPMLRemovalPolicyDisable, OPMLRemovalPolicyRemove:
+		return true
+	}
+	return false
+}
+
+// OPMLSubscriptions represents a list of remote OPML subscriptions.
+type OPMLSubscriptions []*OPMLSubscription
+
+// OPMLSubscriptionFeed represents a feed created from a remote OPML file.
+type OPMLSubscriptionFeed struct {
+	FeedID   int64
+	FeedURL  string
+	Disabled bool
+}
+
+// OPMLSubscriptionSync represents the outcome of the synchronization of a remote OPML file.
+type OPMLSubscriptionSync struct {
+	ID             int64
+	SubscriptionID int64
+	Created        int
+	Disabled       int
+	Removed        int
+	Failed         int
+	ErrorMsg       string
+	SyncedAt       time.Time
+}
+
+// OPMLSubscriptionSyncs represents the synchronization history of a remote OPML file.
+type OPMLSubscriptionSyncs []*OPMLSubscriptionSync
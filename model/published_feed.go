@@ -0,0 +1,71 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"time"
+
+	"miniflux.app/crypto"
+)
+
+// Kinds of entries exposed by a published feed.
+const (
+	PublishedFeedKindStarred  = "starred"
+	PublishedFeedKindShared   = "shared"
+	PublishedFeedKindCategory = "category"
+	PublishedFeedKindFeed     = "feed"
+	PublishedFeedKindSearch   = "search"
+)
+
+// PublishedFeed exposes a selection of entries as Atom and JSON Feed documents protected by a secret token.
+// The search query filters the entries of every kind, it is required by the "search" kind.
+type PublishedFeed struct {
+	ID            int64
+	UserID        int64
+	Title         string
+	Kind          string
+	Token         string
+	CategoryID    *int64
+	CategoryTitle string
+	FeedID        *int64
+	FeedTitle     string
+	SearchQuery   string
+	UnreadOnly    bool
+	CreatedAt     time.Time
+}
+
+// NewPublishedFeed initializes a published feed with a new token.
+func NewPublishedFeed(userID int64, title, kind string) *PublishedFeed {
+	return &PublishedFeed{
+		UserID: userID,
+		Title:  title,
+		Kind:   kind,
+		Token:  crypto.GenerateRandomString(32),
+	}
+}
+
+// PublishedFeedKinds returns the list of kinds of published feeds.
+func PublishedFeedKinds() []string {
+	return []string{
+		PublishedFeedKindStarred,
+		PublishedFeedKindShared,
+		PublishedFeedKindCategory,
+		PublishedFeedKindFeed,
+		PublishedFeedKindSearch,
+	}
+}
+
+// IsValidPublishedFeedKind returns true if the kind of published feed exists.
+func IsValidPublishedFeedKind(kind string) bool {
+	for _, validKind := range PublishedFeedKinds() {
+		if kind == validKind {
+			return true
+		}
+	}
+	return false
+}
+
+// PublishedFeeds represents a list of published feeds.
+type PublishedFeeds []*PublishedFeed
@@ -0,0 +1,51 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+// Retention modes of feeds and categories.
+// Without mode, the feed follows the policy of its category, then the global cleanup settings.
+const (
+	RetentionModeDefault = ""
+	RetentionModeDays    = "days"
+	RetentionModeCount   = "count"
+	RetentionModeNever   = "never"
+)
+
+// RetentionModes returns the list of retention modes, the default one first.
+func RetentionModes() []string {
+	return []string{RetentionModeDefault, RetentionModeDays, RetentionModeCount, RetentionModeNever}
+}
+
+// IsValidRetentionMode returns true if the retention mode exists.
+func IsValidRetentionMode(mode string) bool {
+	for _, retentionMode := range RetentionModes() {
+		if mode == retentionMode {
+			return true
+		}
+	}
+	return false
+}
+
+// RetentionPolicy is the retention of the entries of a feed or a category, embedded in both.
+// The value is the number of days or of entries to keep. Deleted entries lose their content and enclosures
+// instead of only being marked as removed.
+type RetentionPolicy struct {
+	RetentionMode   string `json:"retention_mode"`
+	RetentionValue  int    `json:"retention_value"`
+	RetentionDelete bool   `json:"retention_delete"`
+}
+
+// HasValue returns true if the mode keeps a number of days or of entries.
+func (r *RetentionPolicy) HasValue() bool {
+	return r.RetentionMode == RetentionModeDays || r.RetentionMode == RetentionModeCount
+}
+
+// Normalize clears the value and the deletion flag of the modes which do not use them.
+func (r *RetentionPolicy) Normalize() {
+	if !r.HasValue() {
+		r.RetentionValue = 0
+		r.RetentionDelete = false
+	}
+}
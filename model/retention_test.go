@@ -0,0 +1,52 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import "testing"
+
+func TestIsValidRetentionMode(t *testing.T) {
+	for _, mode := range RetentionModes() {
+		if !IsValidRetentionMode(mode) {
+			t.Errorf(`The mode %q should be valid`, mode)
+		}
+	}
+
+	if IsValidRetentionMode("forever") {
+		t.Error(`An unknown mode should be invalid`)
+	}
+}
+
+func TestRetentionPolicyNormalize(t *testing.T) {
+	scenarios := []struct {
+		policy   RetentionPolicy
+		expected RetentionPolicy
+	}{
+		{RetentionPolicy{RetentionModeDays, 30, true}, RetentionPolicy{RetentionModeDays, 30, true}},
+		{RetentionPolicy{RetentionModeCount, 200, false}, RetentionPolicy{RetentionModeCount, 200, false}},
+		{RetentionPolicy{RetentionModeNever, 30, true}, RetentionPolicy{RetentionModeNever, 0, false}},
+		{RetentionPolicy{RetentionModeDefault, 10, true}, RetentionPolicy{RetentionModeDefault, 0, false}},
+	}
+
+	for _, scenario := range scenarios {
+		policy := scenario.policy
+		policy.Normalize()
+
+		if policy != scenario.expected {
+			t.Errorf(`Unexpected policy %+v for %+v`, policy, scenario.policy)
+		}
+	}
+}
+
+func TestFeedModificationRequestPatchRetention(t *testing.T) {
+	mode := RetentionModeNever
+	feed := &Feed{RetentionPolicy: RetentionPolicy{RetentionModeCount, 50, true}}
+
+	request := &FeedModificationRequest{RetentionMode: &mode}
+	request.Patch(feed)
+
+	if feed.RetentionMode != RetentionModeNever || feed.RetentionValue != 0 || feed.RetentionDelete {
+		t.Errorf(`Unexpected retention policy %+v`, feed.RetentionPolicy)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+// User roles.
+const (
+	RoleAdmin       = "admin"
+	RoleUserManager = "user_manager"
+	RoleUser        = "user"
+	RoleGuest       = "guest"
+)
+
+// Permissions granted by the roles.
+const (
+	// PermissionManageServer allows to see the server configuration and to manage administrators.
+	PermissionManageServer = "manage_server"
+
+	// PermissionManageUsers allows to create, update and remove regular users and guests.
+	PermissionManageUsers = "manage_users"
+
+	// PermissionManageSubscriptions allows to add, update and remove feeds and categories.
+	PermissionManageSubscriptions = "manage_subscriptions"
+)
+
+var rolePermissions = map[string][]string{
+	RoleAdmin:       {PermissionManageServer, PermissionManageUsers, PermissionManageSubscriptions},
+	RoleUserManager: {PermissionManageUsers, PermissionManageSubscriptions},
+	RoleUser:        {PermissionManageSubscriptions},
+	RoleGuest:       {},
+}
+
+// Roles returns the list of roles, the most privileged first.
+func Roles() []string {
+	return []string{RoleAdmin, RoleUserManager, RoleUser, RoleGuest}
+}
+
+// IsValidRole returns true if the role exists.
+func IsValidRole(role string) bool {
+	_, found := rolePermissions[role]
+	return found
+}
+
+// RoleHasPermission returns true if the role grants the permission.
+func RoleHasPermission(role, permission string) bool {
+	for _, rolePermission := range rolePermissions[role] {
+		if rolePermission == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAssignRole returns true if a user with the role actorRole can create or update a user with the role targetRole.
+// Only administrators can manage administrators and user managers.
+func CanAssignRole(actorRole, targetRole string) bool {
+	if !RoleHasPermission(actorRole, PermissionManageUsers) {
+		return false
+	}
+
+	switch targetRole {
+	case RoleAdmin, RoleUserManager:
+		return RoleHasPermission(actorRole, PermissionManageServer)
+	default:
+		return IsValidRole(targetRole)
+	}
+}
+
+// AssignableRoles returns the roles that a user with the role actorRole can give to other users.
+func AssignableRoles(actorRole string) []string {
+	var roles []string
+	for _, role := range Roles() {
+		if CanAssignRole(actorRole, role) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// RoleFromAdminFlag returns the role matching the legacy administrator flag.
+func RoleFromAdminFlag(isAdmin bool) string {
+	if isAdmin {
+		return RoleAdmin
+	}
+	return RoleUser
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRolePermissions(t *testing.T) {
+	scenarios := []struct {
+		role                  string
+		canManageServer       bool
+		canManageUsers        bool
+		canManageSubscription bool
+	}{
+		{RoleAdmin, true, true, true},
+		{RoleUserManager, false, true, true},
+		{RoleUser, false, false, true},
+		{RoleGuest, false, false, false},
+		{"unknown", false, false, false},
+	}
+
+	for _, scenario := range scenarios {
+		user := &User{Role: scenario.role}
+
+		if user.CanManageServer() != scenario.canManageServer {
+			t.Errorf(`Unexpected server permission for the role %q`, scenario.role)
+		}
+
+		if user.CanManageUsers() != scenario.canManageUsers {
+			t.Errorf(`Unexpected users permission for the role %q`, scenario.role)
+		}
+
+		if user.CanManageSubscriptions() != scenario.canManageSubscription {
+			t.Errorf(`Unexpected subscriptions permission for the role %q`, scenario.role)
+		}
+	}
+}
+
+func TestCanAssignRole(t *testing.T) {
+	scenarios := []struct {
+		actorRole  string
+		targetRole string
+		expected   bool
+	}{
+		{RoleAdmin, RoleAdmin, true},
+		{RoleAdmin, RoleUserManager, true},
+		{RoleAdmin, RoleGuest, true},
+		{RoleAdmin, "unknown", false},
+		{RoleUserManager, RoleAdmin, false},
+		{RoleUserManager, RoleUserManager, false},
+		{RoleUserManager, RoleUser, true},
+		{RoleUserManager, RoleGuest, true},
+		{RoleUser, RoleUser, false},
+		{RoleUser, RoleGuest, false},
+		{RoleGuest, RoleGuest, false},
+	}
+
+	for _, scenario := range scenarios {
+		if result := CanAssignRole(scenario.actorRole, scenario.targetRole); result != scenario.expected {
+			t.Errorf(`Unexpected result for %q assigning %q, got %v instead of %v`, scenario.actorRole, scenario.targetRole, result, scenario.expected)
+		}
+	}
+}
+
+func TestAssignableRoles(t *testing.T) {
+	if roles := AssignableRoles(RoleUserManager); !reflect.DeepEqual(roles, []string{RoleUser, RoleGuest}) {
+		t.Errorf(`Unexpected roles for a user manager: %v`, roles)
+	}
+
+	if roles := AssignableRoles(RoleUser); len(roles) != 0 {
+		t.Errorf(`A regular user should not assign any role: %v`, roles)
+	}
+}
+
+func TestUserCreationRequestRole(t *testing.T) {
+	if role := (&UserCreationRequest{IsAdmin: true}).UserRole(); role != RoleAdmin {
+		t.Errorf(`The administrator flag should be used when the role is missing, got %q`, role)
+	}
+
+	if role := (&UserCreationRequest{}).UserRole(); role != RoleUser {
+		t.Errorf(`Users should be regular users by default, got %q`, role)
+	}
+
+	if role := (&UserCreationRequest{IsAdmin: true, Role: RoleGuest}).UserRole(); role != RoleGuest {
+		t.Errorf(`The role should have precedence over the administrator flag, got %q`, role)
+	}
+}
+
+func TestUserModificationRequestRole(t *testing.T) {
+	isAdmin := true
+	user := &User{Role: RoleUser}
+	(&UserModificationRequest{IsAdmin: &isAdmin}).Patch(user)
+	if user.Role != RoleAdmin || !user.IsAdmin {
+		t.Errorf(`The administrator flag should promote the user, got %q`, user.Role)
+	}
+
+	isAdmin = false
+	user = &User{Role: RoleUserManager}
+	(&UserModificationRequest{IsAdmin: &isAdmin}).Patch(user)
+	if user.Role != RoleUserManager {
+		t.Errorf(`An unchanged administrator flag should keep the role, got %q`, user.Role)
+	}
+
+	role := RoleGuest
+	user = &User{Role: RoleAdmin, IsAdmin: true}
+	(&UserModificationRequest{Role: &role}).Patch(user)
+	if user.Role != RoleGuest || user.IsAdmin {
+		t.Errorf(`The role should be updated, got %q`, user.Role)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"strings"
+	"time"
+
+	"miniflux.app/crypto"
+)
+
+// RecoveryCodeCount is the number of recovery codes generated at once.
+const RecoveryCodeCount = 10
+
+// TwoFactorLoginTimeout is the time given to enter the second factor once the password is verified.
+const TwoFactorLoginTimeout = 5 * time.Minute
+
+// TwoFactorMaxAttempts is the number of invalid codes accepted before the password must be entered again.
+const TwoFactorMaxAttempts = 5
+
+// TwoFactor represents the two-factor authentication settings of a user.
+type TwoFactor struct {
+	UserID int64
+
+	// Secret is set during the enrollment, the second factor is enabled once a first code is verified.
+	Secret      string
+	Enabled     bool
+	LastCounter int64
+
+	// Required is set by a user manager to force the enrollment.
+	Required bool
+}
+
+// IsEnrollmentRequired returns true if the user must set up a second factor before using the application.
+func (t *TwoFactor) IsEnrollmentRequired(requiredForEveryone bool) bool {
+	return (requiredForEveryone || t.Required) && !t.Enabled
+}
+
+// GenerateRecoveryCodes returns new single-use recovery codes.
+func GenerateRecoveryCodes() []string {
+	codes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		code := crypto.GenerateRandomStringHex(5)
+		codes[i] = code[:5] + "-" + code[5:]
+	}
+	return codes
+}
+
+// HashRecoveryCode returns the stored form of a recovery code.
+// Recovery codes are random, a fast hash is enough.
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return crypto.Hash(strings.ReplaceAll(code, " ", ""))
+}
+
+// TwoFactorLoginState is kept in the application session between the password and the second factor.
+type TwoFactorLoginState struct {
+	UserID    int64     `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Attempts  int       `json:"attempts"`
+
+	// IdPSessionID is the session of the identity provider when the login started with OAuth2.
+	IdPSessionID string `json:"idp_session_id,omitempty"`
+}
+
+// NewTwoFactorLoginState initializes the state of a login waiting for the second factor.
+func NewTwoFactorLoginState(userID int64) *TwoFactorLoginState {
+	return &TwoFactorLoginState{UserID: userID, ExpiresAt: time.Now().Add(TwoFactorLoginTimeout)}
+}
+
+// IsValid returns true if the second factor can still be entered.
+func (s *TwoFactorLoginState) IsValid() bool {
+	return s.UserID > 0 && s.Attempts < TwoFactorMaxAttempts && time.Now().Before(s.ExpiresAt)
+}
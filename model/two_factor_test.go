@@ -0,0 +1,92 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes := GenerateRecoveryCodes()
+	if len(codes) != RecoveryCodeCount {
+		t.Fatalf(`Unexpected number of recovery codes, got %d instead of %d`, len(codes), RecoveryCodeCount)
+	}
+
+	format := regexp.MustCompile(`^[0-9a-f]{5}-[0-9a-f]{5}$`)
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if !format.MatchString(code) {
+			t.Errorf(`Unexpected recovery code format: %q`, code)
+		}
+
+		if seen[code] {
+			t.Errorf(`Duplicate recovery code: %q`, code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	expected := HashRecoveryCode("abcde-12345")
+
+	for _, input := range []string{"ABCDE-12345", " abcde-12345 ", "abcde - 12345"} {
+		if result := HashRecoveryCode(input); result != expected {
+			t.Errorf(`The recovery code %q should be normalized`, input)
+		}
+	}
+
+	if HashRecoveryCode("abcde-12346") == expected {
+		t.Error(`Different recovery codes should have different hashes`)
+	}
+}
+
+func TestTwoFactorIsEnrollmentRequired(t *testing.T) {
+	scenarios := []struct {
+		twoFactor           TwoFactor
+		requiredForEveryone bool
+		expected            bool
+	}{
+		{TwoFactor{}, false, false},
+		{TwoFactor{}, true, true},
+		{TwoFactor{Required: true}, false, true},
+		{TwoFactor{Required: true, Enabled: true}, false, false},
+		{TwoFactor{Enabled: true}, true, false},
+	}
+
+	for _, scenario := range scenarios {
+		if result := scenario.twoFactor.IsEnrollmentRequired(scenario.requiredForEveryone); result != scenario.expected {
+			t.Errorf(`Unexpected result for %+v (required for everyone: %v), got %v instead of %v`, scenario.twoFactor, scenario.requiredForEveryone, result, scenario.expected)
+		}
+
+		session := &UserSession{TwoFactorEnabled: scenario.twoFactor.Enabled, TwoFactorRequired: scenario.twoFactor.Required}
+		if result := session.IsTwoFactorEnrollmentRequired(scenario.requiredForEveryone); result != scenario.expected {
+			t.Errorf(`Unexpected session result for %+v (required for everyone: %v), got %v instead of %v`, scenario.twoFactor, scenario.requiredForEveryone, result, scenario.expected)
+		}
+	}
+}
+
+func TestTwoFactorLoginState(t *testing.T) {
+	state := NewTwoFactorLoginState(1)
+	if !state.IsValid() {
+		t.Fatal(`A new state should be valid`)
+	}
+
+	state.Attempts = TwoFactorMaxAttempts
+	if state.IsValid() {
+		t.Error(`The state should be invalid after too many attempts`)
+	}
+
+	state = NewTwoFactorLoginState(1)
+	state.ExpiresAt = time.Now().Add(-time.Second)
+	if state.IsValid() {
+		t.Error(`An expired state should be invalid`)
+	}
+
+	if (&TwoFactorLoginState{ExpiresAt: time.Now().Add(time.Minute)}).IsValid() {
+		t.Error(`A state without user should be invalid`)
+	}
+}
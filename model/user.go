@@ -10,12 +10,27 @@ import (
 	"miniflux.app/timezone"
 )
 
+// User statuses.
+const (
+	UserStatusActive   = "active"
+	UserStatusDisabled = "disabled"
+	UserStatusPending  = "pending"
+)
+
+// UserStatuses returns the list of user statuses.
+func UserStatuses() []string {
+	return []string{UserStatusActive, UserStatusDisabled, UserStatusPending}
+}
+
 // User represents a user in the system.
 type User struct {
 	ID                     int64      `json:"id"`
 	Username               string     `json:"username"`
 	Password               string     `json:"-"`
 	IsAdmin                bool       `json:"is_admin"`
+	Role                   string     `json:"role"`
+	Status                 string     `json:"status"`
+	Email                  string     `json:"email"`
 	Theme                  string     `json:"theme"`
 	Language               string     `json:"language"`
 	Timezone               string     `json:"timezone"`
@@ -34,6 +49,10 @@ type User struct {
 	CJKReadingSpeed        int        `json:"cjk_reading_speed"`
 	DefaultHomePage        string     `json:"default_home_page"`
 	CategoriesSortingOrder string     `json:"categories_sorting_order"`
+	MarkReadAtEnd          bool       `json:"mark_read_at_end"`
+	EntryScoring           bool       `json:"entry_scoring"`
+	RetentionMaxDays       int        `json:"retention_max_days"`
+	RetentionMaxEntries    int        `json:"retention_max_entries"`
 }
 
 // UserCreationRequest represents the request to create a user.
@@ -41,10 +60,29 @@ type UserCreationRequest struct {
 	Username        string `json:"username"`
 	Password        string `json:"password"`
 	IsAdmin         bool   `json:"is_admin"`
+	Role            string `json:"role"`
+	Status          string `json:"status"`
+	Email           string `json:"email"`
 	GoogleID        string `json:"google_id"`
 	OpenIDConnectID string `json:"openid_connect_id"`
 }
 
+// UserRole returns the requested role, the administrator flag is used when the role is not specified.
+func (u *UserCreationRequest) UserRole() string {
+	if u.Role == "" {
+		return RoleFromAdminFlag(u.IsAdmin)
+	}
+	return u.Role
+}
+
+// UserStatus returns the requested status, accounts are active by default.
+func (u *UserCreationRequest) UserStatus() string {
+	if u.Status == "" {
+		return UserStatusActive
+	}
+	return u.Status
+}
+
 // UserModificationRequest represents the request to update a user.
 type UserModificationRequest struct {
 	Username               *string `json:"username"`
@@ -59,6 +97,9 @@ type UserModificationRequest struct {
 	OpenIDConnectID        *string `json:"openid_connect_id"`
 	EntriesPerPage         *int    `json:"entries_per_page"`
 	IsAdmin                *bool   `json:"is_admin"`
+	Role                   *string `json:"role"`
+	Status                 *string `json:"status"`
+	Email                  *string `json:"email"`
 	KeyboardShortcuts      *bool   `json:"keyboard_shortcuts"`
 	ShowReadingTime        *bool   `json:"show_reading_time"`
 	EntrySwipe             *bool   `json:"entry_swipe"`
@@ -67,6 +108,15 @@ type UserModificationRequest struct {
 	CJKReadingSpeed        *int    `json:"cjk_reading_speed"`
 	DefaultHomePage        *string `json:"default_home_page"`
 	CategoriesSortingOrder *string `json:"categories_sorting_order"`
+	MarkReadAtEnd          *bool   `json:"mark_read_at_end"`
+	EntryScoring           *bool   `json:"entry_scoring"`
+	RetentionMaxDays       *int    `json:"retention_max_days"`
+	RetentionMaxEntries    *int    `json:"retention_max_entries"`
+}
+
+// ChangesRetentionCaps returns true if the request changes the retention caps, only allowed to user managers.
+func (u *UserModificationRequest) ChangesRetentionCaps() bool {
+	return u.RetentionMaxDays != nil || u.RetentionMaxEntries != nil
 }
 
 // Patch updates the User object with the modification request.
@@ -79,8 +129,19 @@ func (u *UserModificationRequest) Patch(user *User) {
 		user.Password = *u.Password
 	}
 
-	if u.IsAdmin != nil {
-		user.IsAdmin = *u.IsAdmin
+	if u.Role != nil {
+		user.Role = *u.Role
+	} else if u.IsAdmin != nil && *u.IsAdmin != user.IsAdmin {
+		user.Role = RoleFromAdminFlag(*u.IsAdmin)
+	}
+	user.IsAdmin = user.Role == RoleAdmin
+
+	if u.Status != nil {
+		user.Status = *u.Status
+	}
+
+	if u.Email != nil {
+		user.Email = *u.Email
 	}
 
 	if u.Theme != nil {
@@ -150,6 +211,62 @@ func (u *UserModificationRequest) Patch(user *User) {
 	if u.CategoriesSortingOrder != nil {
 		user.CategoriesSortingOrder = *u.CategoriesSortingOrder
 	}
+
+	if u.MarkReadAtEnd != nil {
+		user.MarkReadAtEnd = *u.MarkReadAtEnd
+	}
+
+	if u.EntryScoring != nil {
+		user.EntryScoring = *u.EntryScoring
+	}
+
+	if u.RetentionMaxDays != nil {
+		user.RetentionMaxDays = *u.RetentionMaxDays
+	}
+
+	if u.RetentionMaxEntries != nil {
+		user.RetentionMaxEntries = *u.RetentionMaxEntries
+	}
+}
+
+// HasPermission returns true if the role of the user grants the permission.
+func (u *User) HasPermission(permission string) bool {
+	return RoleHasPermission(u.Role, permission)
+}
+
+// CanManageServer returns true if the user can see the server configuration and manage administrators.
+func (u *User) CanManageServer() bool {
+	return u.HasPermission(PermissionManageServer)
+}
+
+// CanManageUsers returns true if the user can manage regular users and guests.
+func (u *User) CanManageUsers() bool {
+	return u.HasPermission(PermissionManageUsers)
+}
+
+// CanManageSubscriptions returns true if the user can add, update and remove feeds and categories.
+func (u *User) CanManageSubscriptions() bool {
+	return u.HasPermission(PermissionManageSubscriptions)
+}
+
+// CanManageUser returns true if the user can update or remove another user.
+func (u *User) CanManageUser(other *User) bool {
+	return CanAssignRole(u.Role, other.Role)
+}
+
+// IsGuest returns true if the user has a read-only account.
+func (u *User) IsGuest() bool {
+	return u.Role == RoleGuest
+}
+
+// IsActive returns true if the user is allowed to sign in.
+func (u *User) IsActive() bool {
+	return u.Status == UserStatusActive
+}
+
+// IsPendingApproval returns true if the account was created by self-registration and is not approved yet.
+func (u *User) IsPendingApproval() bool {
+	return u.Status == UserStatusPending
 }
 
 // UseTimezone converts last login date to the given timezone.
@@ -0,0 +1,41 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"time"
+
+	"miniflux.app/crypto"
+)
+
+// UserInvitation represents a link that allows to create an account.
+type UserInvitation struct {
+	ID        int64
+	Token     string
+	Email     string
+	Role      string
+	CreatedBy int64
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// NewUserInvitation initializes an invitation valid for the given duration.
+func NewUserInvitation(createdBy int64, email, role string, validity time.Duration) *UserInvitation {
+	return &UserInvitation{
+		Token:     crypto.GenerateRandomString(32),
+		Email:     email,
+		Role:      role,
+		CreatedBy: createdBy,
+		ExpiresAt: time.Now().Add(validity),
+	}
+}
+
+// IsExpired returns true if the invitation can no longer be used.
+func (u *UserInvitation) IsExpired() bool {
+	return time.Now().After(u.ExpiresAt)
+}
+
+// UserInvitations represents a list of invitations.
+type UserInvitations []*UserInvitation
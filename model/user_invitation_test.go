@@ -0,0 +1,48 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package model // import "miniflux.app/model"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewUserInvitation(t *testing.T) {
+	invitation := NewUserInvitation(1, "bob@example.org", RoleGuest, time.Hour)
+
+	if invitation.Token == "" {
+		t.Fatal(`The invitation should have a token`)
+	}
+
+	if invitation.IsExpired() {
+		t.Error(`A new invitation should not be expired`)
+	}
+
+	if other := NewUserInvitation(1, "", RoleUser, time.Hour); other.Token == invitation.Token {
+		t.Error(`Each invitation should have its own token`)
+	}
+
+	invitation.ExpiresAt = time.Now().Add(-time.Minute)
+	if !invitation.IsExpired() {
+		t.Error(`The invitation should be expired`)
+	}
+}
+
+func TestUserStatus(t *testing.T) {
+	if status := (&UserCreationRequest{}).UserStatus(); status != UserStatusActive {
+		t.Errorf(`Users should be active by default, got %q`, status)
+	}
+
+	user := &User{Status: UserStatusPending}
+	if user.IsActive() || !user.IsPendingApproval() {
+		t.Error(`A pending user should not be active`)
+	}
+
+	status := UserStatusDisabled
+	(&UserModificationRequest{Status: &status}).Patch(user)
+	if user.IsActive() || user.IsPendingApproval() {
+		t.Errorf(`The status should be updated, got %q`, user.Status)
+	}
+}
@@ -19,12 +19,22 @@ type UserSession struct {
 	CreatedAt time.Time
 	UserAgent string
 	IP        string
+	UserRole  string
+
+	// TwoFactorEnabled and TwoFactorRequired are used to enforce the two-factor policy.
+	TwoFactorEnabled  bool
+	TwoFactorRequired bool
 }
 
 func (u *UserSession) String() string {
 	return fmt.Sprintf(`ID="%d", UserID="%d", IP="%s", Token="%s"`, u.ID, u.UserID, u.IP, u.Token)
 }
 
+// IsTwoFactorEnrollmentRequired returns true if the user must set up a second factor before using the application.
+func (u *UserSession) IsTwoFactorEnrollmentRequired(requiredForEveryone bool) bool {
+	return (requiredForEveryone || u.TwoFactorRequired) && !u.TwoFactorEnabled
+}
+
 // UseTimezone converts creation date to the given timezone.
 func (u *UserSession) UseTimezone(tz string) {
 	u.CreatedAt = timezone.Convert(tz, u.CreatedAt)
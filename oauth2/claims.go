@@ -0,0 +1,78 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package oauth2 // import "miniflux.app/oauth2"
+
+import (
+	"strings"
+
+	"miniflux.app/config"
+	"miniflux.app/model"
+)
+
+// claimString returns the claim as a string, nested claims are separated by dots like "realm_access.roles".
+func claimString(claims map[string]interface{}, name string) string {
+	if value, ok := lookupClaim(claims, name).(string); ok {
+		return value
+	}
+	return ""
+}
+
+// claimStrings returns a claim that is either a list of strings or a single string.
+func claimStrings(claims map[string]interface{}, name string) []string {
+	var values []string
+	switch value := lookupClaim(claims, name).(type) {
+	case string:
+		if value != "" {
+			values = append(values, value)
+		}
+	case []interface{}:
+		for _, item := range value {
+			if s, ok := item.(string); ok && s != "" {
+				values = append(values, s)
+			}
+		}
+	case []string:
+		values = append(values, value...)
+	}
+	return values
+}
+
+func lookupClaim(claims map[string]interface{}, name string) interface{} {
+	if value, found := claims[name]; found {
+		return value
+	}
+
+	parts := strings.Split(name, ".")
+	var current interface{} = claims
+	for _, part := range parts {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = object[part]
+	}
+	return current
+}
+
+// mapRole returns the role given by the first mapping matching one of the groups.
+// Without mapping, the role is empty and the role of existing users is left alone.
+func mapRole(mapping []config.OAuth2RoleMapping, groups []string) string {
+	if len(mapping) == 0 {
+		return ""
+	}
+
+	for _, rule := range mapping {
+		if !model.IsValidRole(rule.Role) {
+			continue
+		}
+		for _, group := range groups {
+			if group == rule.Group {
+				return rule.Role
+			}
+		}
+	}
+
+	return model.RoleUser
+}
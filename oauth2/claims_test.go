@@ -0,0 +1,84 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package oauth2 // import "miniflux.app/oauth2"
+
+import (
+	"reflect"
+	"testing"
+
+	"miniflux.app/config"
+	"miniflux.app/model"
+)
+
+func TestClaimString(t *testing.T) {
+	claims := map[string]interface{}{
+		"email":              "alice@example.org",
+		"preferred_username": "alice",
+		"number":             42.0,
+	}
+
+	scenarios := map[string]string{
+		"email":              "alice@example.org",
+		"preferred_username": "alice",
+		"number":             "",
+		"missing":            "",
+	}
+
+	for name, expected := range scenarios {
+		if result := claimString(claims, name); result != expected {
+			t.Errorf(`Unexpected value for %q, got %q instead of %q`, name, result, expected)
+		}
+	}
+}
+
+func TestClaimStrings(t *testing.T) {
+	claims := map[string]interface{}{
+		"groups":       []interface{}{"ops", 12.0, "", "friends"},
+		"group":        "ops",
+		"realm_access": map[string]interface{}{"roles": []interface{}{"admin"}},
+	}
+
+	scenarios := map[string][]string{
+		"groups":             {"ops", "friends"},
+		"group":              {"ops"},
+		"realm_access.roles": {"admin"},
+		"realm_access.none":  nil,
+		"missing":            nil,
+	}
+
+	for name, expected := range scenarios {
+		if result := claimStrings(claims, name); !reflect.DeepEqual(result, expected) {
+			t.Errorf(`Unexpected values for %q, got %v instead of %v`, name, result, expected)
+		}
+	}
+}
+
+func TestMapRole(t *testing.T) {
+	mapping := []config.OAuth2RoleMapping{
+		{Group: "ops", Role: model.RoleAdmin},
+		{Group: "broken", Role: "superuser"},
+		{Group: "friends", Role: model.RoleGuest},
+	}
+
+	scenarios := []struct {
+		groups   []string
+		expected string
+	}{
+		{[]string{"friends", "ops"}, model.RoleAdmin},
+		{[]string{"friends"}, model.RoleGuest},
+		{[]string{"broken"}, model.RoleUser},
+		{nil, model.RoleUser},
+	}
+
+	for _, scenario := range scenarios {
+		if result := mapRole(mapping, scenario.groups); result != scenario.expected {
+			t.Errorf(`Unexpected role for %v, got %q instead of %q`, scenario.groups, result, scenario.expected)
+		}
+	}
+
+	if result := mapRole(nil, []string{"ops"}); result != "" {
+		t.Errorf(`The role should be empty without mapping, got %q`, result)
+	}
+}
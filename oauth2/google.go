@@ -7,6 +7,7 @@ package oauth2 // import "miniflux.app/oauth2"
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"miniflux.app/model"
@@ -53,7 +54,7 @@ func (g *googleProvider) GetProfile(ctx context.Context, code string) (*Profile,
 		return nil, fmt.Errorf("oauth2: unable to unserialize google profile: %v", err)
 	}
 
-	profile := &Profile{Key: g.GetUserExtraKey(), ID: user.Sub, Username: user.Email}
+	profile := &Profile{Key: g.GetUserExtraKey(), ID: user.Sub, Username: user.Email, Email: user.Email}
 	return profile, nil
 }
 
@@ -69,6 +70,14 @@ func (g *googleProvider) UnsetUserProfileID(user *model.User) {
 	user.GoogleID = ""
 }
 
+func (g *googleProvider) IsUserLinked(user *model.User) bool {
+	return IsUserLinked(user, googleProviderName)
+}
+
+func (g *googleProvider) VerifyLogoutToken(ctx context.Context, rawToken string) (*LogoutToken, error) {
+	return nil, errors.New("oauth2: google does not support back-channel logout")
+}
+
 func (g *googleProvider) config() *oauth2.Config {
 	return &oauth2.Config{
 		RedirectURL:  g.redirectURL,
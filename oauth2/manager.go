@@ -7,22 +7,51 @@ package oauth2 // import "miniflux.app/oauth2"
 import (
 	"context"
 	"errors"
+	"fmt"
 
-	"miniflux.app/logger"
+	"miniflux.app/config"
+)
+
+const (
+	googleProviderName     = "google"
+	legacyOidcProviderName = "oidc"
 )
 
 // Manager handles OAuth2 providers.
 type Manager struct {
+	ctx       context.Context
+	options   map[string]*config.OAuth2ProviderOptions
 	providers map[string]Provider
 }
 
 // FindProvider returns the given provider.
+// OpenID Connect providers are initialized on first use because the discovery requires a network request.
 func (m *Manager) FindProvider(name string) (Provider, error) {
 	if provider, found := m.providers[name]; found {
 		return provider, nil
 	}
 
-	return nil, errors.New("oauth2 provider not found")
+	options, found := m.options[name]
+	if !found {
+		return nil, errors.New("oauth2 provider not found")
+	}
+
+	if name == googleProviderName {
+		m.AddProvider(name, newGoogleProvider(options.ClientID, options.ClientSecret, options.RedirectURL))
+		return m.providers[name], nil
+	}
+
+	if options.DiscoveryEndpoint == "" {
+		return nil, fmt.Errorf("oauth2: the provider %q has no discovery endpoint", name)
+	}
+
+	provider, err := newOidcProvider(m.ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("oauth2: failed to initialize the provider %q: %v", name, err)
+	}
+
+	m.AddProvider(name, provider)
+	return provider, nil
 }
 
 // AddProvider add a new OAuth2 provider.
@@ -31,17 +60,10 @@ func (m *Manager) AddProvider(name string, provider Provider) {
 }
 
 // NewManager returns a new Manager.
-func NewManager(ctx context.Context, clientID, clientSecret, redirectURL, oidcDiscoveryEndpoint string) *Manager {
-	m := &Manager{providers: make(map[string]Provider)}
-	m.AddProvider("google", newGoogleProvider(clientID, clientSecret, redirectURL))
-
-	if oidcDiscoveryEndpoint != "" {
-		if genericOidcProvider, err := newOidcProvider(ctx, clientID, clientSecret, redirectURL, oidcDiscoveryEndpoint); err != nil {
-			logger.Error("[OAuth2] failed to initialize OIDC provider: %v", err)
-		} else {
-			m.AddProvider("oidc", genericOidcProvider)
-		}
+func NewManager(ctx context.Context, providers []*config.OAuth2ProviderOptions) *Manager {
+	m := &Manager{ctx: ctx, options: make(map[string]*config.OAuth2ProviderOptions), providers: make(map[string]Provider)}
+	for _, options := range providers {
+		m.options[options.Name] = options
 	}
-
 	return m
 }
@@ -6,18 +6,28 @@ package oauth2 // import "miniflux.app/oauth2"
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"miniflux.app/config"
 	"miniflux.app/model"
 
 	"github.com/coreos/go-oidc"
 	"golang.org/x/oauth2"
 )
 
+const (
+	backChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+	// Logout tokens older than this are refused to limit replays.
+	logoutTokenMaxAge = 5 * time.Minute
+)
+
 type oidcProvider struct {
-	clientID     string
-	clientSecret string
-	redirectURL  string
-	provider     *oidc.Provider
+	options  *config.OAuth2ProviderOptions
+	provider *oidc.Provider
 }
 
 func (o *oidcProvider) GetUserExtraKey() string {
@@ -35,12 +45,47 @@ func (o *oidcProvider) GetProfile(ctx context.Context, code string) (*Profile, e
 		return nil, err
 	}
 
+	claims := make(map[string]interface{})
+	if rawIDToken, ok := token.Extra("id_token").(string); ok {
+		idToken, err := o.provider.Verifier(&oidc.Config{ClientID: o.options.ClientID}).Verify(ctx, rawIDToken)
+		if err != nil {
+			return nil, fmt.Errorf("oauth2: invalid ID token: %v", err)
+		}
+
+		if err := idToken.Claims(&claims); err != nil {
+			return nil, fmt.Errorf("oauth2: unable to read ID token claims: %v", err)
+		}
+	}
+
 	userInfo, err := o.provider.UserInfo(ctx, oauth2.StaticTokenSource(token))
 	if err != nil {
 		return nil, err
 	}
 
-	profile := &Profile{Key: o.GetUserExtraKey(), ID: userInfo.Subject, Username: userInfo.Email}
+	userInfoClaims := make(map[string]interface{})
+	if err := userInfo.Claims(&userInfoClaims); err != nil {
+		return nil, fmt.Errorf("oauth2: unable to read user info claims: %v", err)
+	}
+
+	// The user info endpoint has the last word, except for the session ID that only exists in the ID token.
+	for name, value := range userInfoClaims {
+		claims[name] = value
+	}
+
+	profile := &Profile{
+		Key:       o.GetUserExtraKey(),
+		ID:        o.profileID(userInfo.Subject),
+		Username:  claimString(claims, o.options.UsernameClaim),
+		Email:     userInfo.Email,
+		Groups:    claimStrings(claims, o.options.GroupsClaim),
+		SessionID: claimString(claims, "sid"),
+	}
+
+	if profile.Username == "" {
+		profile.Username = userInfo.Email
+	}
+
+	profile.Role = mapRole(o.options.RoleMapping, profile.Groups)
 	return profile, nil
 }
 
@@ -56,21 +101,90 @@ func (o *oidcProvider) UnsetUserProfileID(user *model.User) {
 	user.OpenIDConnectID = ""
 }
 
+func (o *oidcProvider) IsUserLinked(user *model.User) bool {
+	return isLinkedToOidcProvider(user, o.options.Name)
+}
+
+// VerifyLogoutToken validates a logout token sent by the provider as described in OpenID Connect Back-Channel Logout 1.0.
+func (o *oidcProvider) VerifyLogoutToken(ctx context.Context, rawToken string) (*LogoutToken, error) {
+	verifier := o.provider.Verifier(&oidc.Config{ClientID: o.options.ClientID, SkipExpiryCheck: true})
+	token, err := verifier.Verify(ctx, rawToken)
+	if err != nil {
+		return nil, fmt.Errorf("oauth2: invalid logout token: %v", err)
+	}
+
+	now := time.Now()
+	if !token.Expiry.IsZero() && now.After(token.Expiry) {
+		return nil, errors.New("oauth2: the logout token is expired")
+	}
+
+	if token.IssuedAt.IsZero() || now.Sub(token.IssuedAt) > logoutTokenMaxAge {
+		return nil, errors.New("oauth2: the logout token is too old")
+	}
+
+	if token.Nonce != "" {
+		return nil, errors.New("oauth2: the logout token must not have a nonce")
+	}
+
+	var claims struct {
+		SessionID string                 `json:"sid"`
+		Events    map[string]interface{} `json:"events"`
+	}
+	if err := token.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("oauth2: unable to read logout token claims: %v", err)
+	}
+
+	if _, found := claims.Events[backChannelLogoutEvent]; !found {
+		return nil, errors.New("oauth2: the logout token has no back-channel logout event")
+	}
+
+	if token.Subject == "" && claims.SessionID == "" {
+		return nil, errors.New("oauth2: the logout token has neither subject nor session ID")
+	}
+
+	logoutToken := &LogoutToken{Key: o.GetUserExtraKey(), SessionID: claims.SessionID}
+	if token.Subject != "" {
+		logoutToken.ID = o.profileID(token.Subject)
+	}
+	return logoutToken, nil
+}
+
+// profileID prefixes the subject with the provider name to keep the identities of each provider apart.
+// The historical "oidc" provider stores the subject as is.
+func (o *oidcProvider) profileID(subject string) string {
+	if o.options.Name == legacyOidcProviderName {
+		return subject
+	}
+	return o.options.Name + ":" + subject
+}
+
 func (o *oidcProvider) config() *oauth2.Config {
 	return &oauth2.Config{
-		RedirectURL:  o.redirectURL,
-		ClientID:     o.clientID,
-		ClientSecret: o.clientSecret,
-		Scopes:       []string{"openid", "email"},
+		RedirectURL:  o.options.RedirectURL,
+		ClientID:     o.options.ClientID,
+		ClientSecret: o.options.ClientSecret,
+		Scopes:       []string{"openid", "email", "profile"},
 		Endpoint:     o.provider.Endpoint(),
 	}
 }
 
-func newOidcProvider(ctx context.Context, clientID, clientSecret, redirectURL, discoveryEndpoint string) (*oidcProvider, error) {
-	provider, err := oidc.NewProvider(ctx, discoveryEndpoint)
+func isLinkedToOidcProvider(user *model.User, name string) bool {
+	if user.OpenIDConnectID == "" {
+		return false
+	}
+
+	if name == legacyOidcProviderName {
+		return !strings.Contains(user.OpenIDConnectID, ":")
+	}
+
+	return strings.HasPrefix(user.OpenIDConnectID, name+":")
+}
+
+func newOidcProvider(ctx context.Context, options *config.OAuth2ProviderOptions) (*oidcProvider, error) {
+	provider, err := oidc.NewProvider(ctx, options.DiscoveryEndpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	return &oidcProvider{clientID: clientID, clientSecret: clientSecret, redirectURL: redirectURL, provider: provider}, nil
+	return &oidcProvider{options: options, provider: provider}, nil
 }
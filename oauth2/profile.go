@@ -10,11 +10,22 @@ import (
 
 // Profile is the OAuth2 user profile.
 type Profile struct {
-	Key      string
-	ID       string
-	Username string
+	Key       string
+	ID        string
+	Username  string
+	Email     string
+	Groups    []string
+	Role      string
+	SessionID string
 }
 
 func (p Profile) String() string {
-	return fmt.Sprintf(`Key=%s ; ID=%s ; Username=%s`, p.Key, p.ID, p.Username)
+	return fmt.Sprintf(`Key=%s ; ID=%s ; Username=%s ; Groups=%v ; Role=%s`, p.Key, p.ID, p.Username, p.Groups, p.Role)
+}
+
+// LogoutToken identifies the user or the session ended by a back-channel logout request.
+type LogoutToken struct {
+	Key       string
+	ID        string
+	SessionID string
 }
@@ -18,4 +18,15 @@ type Provider interface {
 	PopulateUserCreationWithProfileID(user *model.UserCreationRequest, profile *Profile)
 	PopulateUserWithProfileID(user *model.User, profile *Profile)
 	UnsetUserProfileID(user *model.User)
+	IsUserLinked(user *model.User) bool
+	VerifyLogoutToken(ctx context.Context, rawToken string) (*LogoutToken, error)
+}
+
+// IsUserLinked returns true if the user account is linked to the given provider.
+// Unlike Provider.IsUserLinked, it does not need to contact the provider.
+func IsUserLinked(user *model.User, name string) bool {
+	if name == googleProviderName {
+		return user.GoogleID != ""
+	}
+	return isLinkedToOidcProvider(user, name)
 }
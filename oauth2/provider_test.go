@@ -0,0 +1,33 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package oauth2 // import "miniflux.app/oauth2"
+
+import (
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestIsUserLinked(t *testing.T) {
+	scenarios := []struct {
+		user     model.User
+		provider string
+		expected bool
+	}{
+		{model.User{GoogleID: "123"}, "google", true},
+		{model.User{}, "google", false},
+		{model.User{OpenIDConnectID: "abc"}, "oidc", true},
+		{model.User{OpenIDConnectID: "keycloak:abc"}, "oidc", false},
+		{model.User{OpenIDConnectID: "keycloak:abc"}, "keycloak", true},
+		{model.User{OpenIDConnectID: "keycloak:abc"}, "authentik", false},
+		{model.User{OpenIDConnectID: "abc"}, "keycloak", false},
+	}
+
+	for _, scenario := range scenarios {
+		if result := IsUserLinked(&scenario.user, scenario.provider); result != scenario.expected {
+			t.Errorf(`Unexpected result for %+v and %q, got %v instead of %v`, scenario.user, scenario.provider, result, scenario.expected)
+		}
+	}
+}
@@ -5,6 +5,7 @@
 package proxy // import "miniflux.app/proxy"
 
 import (
+	"fmt"
 	"strings"
 
 	"miniflux.app/config"
@@ -30,6 +31,35 @@ func AbsoluteImageProxyRewriter(router *mux.Router, host, data string) string {
 	return genericImageProxyRewriter(router, proxifyFunction, data)
 }
 
+// OfflineImageProxyRewriter replaces every image URL with an internal proxy URL and removes the responsive variants,
+// the returned URLs are the only images the content needs, they can be cached for offline reading.
+func OfflineImageProxyRewriter(router *mux.Router, data string) (string, []string) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(data))
+	if err != nil {
+		return data, nil
+	}
+
+	var imageURLs []string
+	doc.Find("picture source").Remove()
+	doc.Find("img").Each(func(i int, img *goquery.Selection) {
+		img.RemoveAttr("srcset")
+		img.RemoveAttr("sizes")
+
+		if srcAttrValue, ok := img.Attr("src"); ok && srcAttrValue != "" && !isDataURL(srcAttrValue) {
+			proxifiedURL := ProxifyURL(router, srcAttrValue)
+			img.SetAttr("src", proxifiedURL)
+			imageURLs = append(imageURLs, proxifiedURL)
+		}
+	})
+
+	output, err := doc.Find("body").First().Html()
+	if err != nil {
+		return data, nil
+	}
+
+	return output, imageURLs
+}
+
 func genericImageProxyRewriter(router *mux.Router, proxifyFunction urlProxyRewriter, data string) string {
 	proxyImages := config.Opts.ProxyImages()
 	if proxyImages == "none" {
@@ -42,14 +72,20 @@ func genericImageProxyRewriter(router *mux.Router, proxifyFunction urlProxyRewri
 	}
 
 	doc.Find("img").Each(func(i int, img *goquery.Selection) {
+		srcsetAttrValue, hasSourceSet := img.Attr("srcset")
+		if hasSourceSet {
+			proxifySourceSet(img, router, proxifyFunction, proxyImages, srcsetAttrValue)
+		}
+
 		if srcAttrValue, ok := img.Attr("src"); ok {
 			if !isDataURL(srcAttrValue) && (proxyImages == "all" || !url.IsHTTPS(srcAttrValue)) {
-				img.SetAttr("src", proxifyFunction(router, srcAttrValue))
-			}
-		}
+				proxifiedURL := proxifyFunction(router, srcAttrValue)
+				img.SetAttr("src", proxifiedURL)
 
-		if srcsetAttrValue, ok := img.Attr("srcset"); ok {
-			proxifySourceSet(img, router, proxifyFunction, proxyImages, srcsetAttrValue)
+				if !hasSourceSet && config.Opts.MediaProxyResizeImages() && config.Opts.ProxyImageUrl() == "" {
+					img.SetAttr("srcset", resizedSourceSet(proxifiedURL))
+				}
+			}
 		}
 	})
 
@@ -79,6 +115,18 @@ func proxifySourceSet(element *goquery.Selection, router *mux.Router, proxifyFun
 	element.SetAttr("srcset", imageCandidates.String())
 }
 
+// resizedSourceSet returns a srcset attribute that lets the browser pick a downscaled variant of the image.
+func resizedSourceSet(proxifiedURL string) string {
+	var imageCandidates sanitizer.ImageCandidates
+	for _, width := range ImageWidths {
+		imageCandidates = append(imageCandidates, &sanitizer.ImageCandidate{
+			ImageURL:   fmt.Sprintf("%s?w=%d", proxifiedURL, width),
+			Descriptor: fmt.Sprintf("%dw", width),
+		})
+	}
+	return imageCandidates.String()
+}
+
 func isDataURL(s string) bool {
 	return strings.HasPrefix(s, "data:")
 }
@@ -0,0 +1,194 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package proxy // import "miniflux.app/proxy"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"mime"
+	"strings"
+)
+
+// maxImagePixels prevents decompression bombs from exhausting the memory.
+const maxImagePixels = 40_000_000
+
+const jpegQuality = 80
+
+// ImageWidths are the widths offered in the srcset attribute of proxified images.
+var ImageWidths = []int{480, 800, 1200, 1600}
+
+// ErrImageNotResizable is returned when the format of the image is not supported or the image is animated.
+var ErrImageNotResizable = errors.New("proxy: the image cannot be resized")
+
+// ResizedImage is an image downscaled or re-encoded by the proxy.
+type ResizedImage struct {
+	Data        []byte
+	ContentType string
+}
+
+// IsValidImageWidth returns true if the width is one of the widths generated by the rewriter.
+func IsValidImageWidth(width int) bool {
+	for _, imageWidth := range ImageWidths {
+		if width == imageWidth {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptsJPEG returns true if the Accept header of the client allows JPEG images.
+func AcceptsJPEG(accept string) bool {
+	if accept == "" {
+		return true
+	}
+
+	for _, value := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+
+		switch mediaType {
+		case "image/jpeg", "image/*", "*/*":
+			return true
+		}
+	}
+
+	return false
+}
+
+// ImageVariantKey returns the cache key of a resized image.
+func ImageVariantKey(key string, width int, allowJPEG bool) string {
+	if allowJPEG {
+		return fmt.Sprintf("%s-w%d-jpeg", key, width)
+	}
+	return fmt.Sprintf("%s-w%d", key, width)
+}
+
+// ResizeImage downscales a JPEG, PNG or GIF image to the given width.
+// Opaque images are converted to JPEG when allowed and when the result is smaller.
+// The original image is returned if it cannot be made smaller.
+func ResizeImage(data []byte, width int, allowJPEG bool) (*ResizedImage, error) {
+	imageConfig, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrImageNotResizable
+	}
+
+	if imageConfig.Width <= 0 || imageConfig.Height <= 0 || imageConfig.Width*imageConfig.Height > maxImagePixels {
+		return nil, ErrImageNotResizable
+	}
+
+	switch format {
+	case "jpeg", "png":
+	case "gif":
+		animation, err := gif.DecodeAll(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if len(animation.Image) > 1 {
+			return nil, ErrImageNotResizable
+		}
+	default:
+		return nil, ErrImageNotResizable
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	rgba := toRGBA(img)
+	if rgba.Bounds().Dx() > width {
+		height := rgba.Bounds().Dy() * width / rgba.Bounds().Dx()
+		if height < 1 {
+			height = 1
+		}
+		rgba = scaleImage(rgba, width, height)
+	}
+
+	var candidates []*ResizedImage
+	if format == "jpeg" || (allowJPEG && rgba.Opaque()) {
+		var buffer bytes.Buffer
+		if err := jpeg.Encode(&buffer, rgba, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, &ResizedImage{Data: buffer.Bytes(), ContentType: "image/jpeg"})
+	}
+
+	if format != "jpeg" {
+		var buffer bytes.Buffer
+		encoder := png.Encoder{CompressionLevel: png.BestCompression}
+		if err := encoder.Encode(&buffer, rgba); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, &ResizedImage{Data: buffer.Bytes(), ContentType: "image/png"})
+	}
+
+	smallest := &ResizedImage{Data: data, ContentType: "image/" + format}
+	for _, candidate := range candidates {
+		if len(candidate.Data) < len(smallest.Data) {
+			smallest = candidate
+		}
+	}
+
+	return smallest, nil
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	return rgba
+}
+
+// scaleImage downscales the image by averaging the source pixels covered by each destination pixel.
+func scaleImage(src *image.RGBA, width, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	srcWidth, srcHeight := src.Bounds().Dx(), src.Bounds().Dy()
+
+	for y := 0; y < height; y++ {
+		y0 := y * srcHeight / height
+		y1 := maxInt((y+1)*srcHeight/height, y0+1)
+
+		for x := 0; x < width; x++ {
+			x0 := x * srcWidth / width
+			x1 := maxInt((x+1)*srcWidth/width, x0+1)
+
+			var r, g, b, a, count uint32
+			for sy := y0; sy < y1; sy++ {
+				offset := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint32(src.Pix[offset])
+					g += uint32(src.Pix[offset+1])
+					b += uint32(src.Pix[offset+2])
+					a += uint32(src.Pix[offset+3])
+					offset += 4
+					count++
+				}
+			}
+
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / count)
+			dst.Pix[offset+1] = uint8(g / count)
+			dst.Pix[offset+2] = uint8(b / count)
+			dst.Pix[offset+3] = uint8(a / count)
+		}
+	}
+
+	return dst
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,184 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package proxy // import "miniflux.app/proxy"
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"math/rand"
+	"testing"
+)
+
+func newTestImage(width, height int, opaque bool) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	random := rand.New(rand.NewSource(1))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			alpha := uint8(255)
+			if !opaque {
+				alpha = uint8(x % 256)
+			}
+			img.Set(x, y, color.NRGBA{uint8(random.Intn(256)), uint8(y), uint8(x), alpha})
+		}
+	}
+	return img
+}
+
+func encodeTestImage(t *testing.T, img image.Image, format string) []byte {
+	var buffer bytes.Buffer
+	var err error
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(&buffer, img, nil)
+	case "png":
+		err = png.Encode(&buffer, img)
+	case "gif":
+		err = gif.Encode(&buffer, img, nil)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func decodedWidth(t *testing.T, data []byte) int {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return config.Width
+}
+
+func TestResizeJPEG(t *testing.T) {
+	data := encodeTestImage(t, newTestImage(1000, 500, true), "jpeg")
+
+	resized, err := ResizeImage(data, 480, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resized.ContentType != "image/jpeg" {
+		t.Errorf(`Unexpected content type: %q`, resized.ContentType)
+	}
+
+	if width := decodedWidth(t, resized.Data); width != 480 {
+		t.Errorf(`Unexpected width: %d`, width)
+	}
+}
+
+func TestResizeOpaquePNGToJPEG(t *testing.T) {
+	data := encodeTestImage(t, newTestImage(1000, 500, true), "png")
+
+	resized, err := ResizeImage(data, 800, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resized.ContentType != "image/jpeg" {
+		t.Errorf(`Opaque images should be converted to JPEG, got %q`, resized.ContentType)
+	}
+
+	if width := decodedWidth(t, resized.Data); width != 800 {
+		t.Errorf(`Unexpected width: %d`, width)
+	}
+}
+
+func TestResizeOpaquePNGWithoutJPEGSupport(t *testing.T) {
+	data := encodeTestImage(t, newTestImage(1000, 500, true), "png")
+
+	resized, err := ResizeImage(data, 480, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resized.ContentType != "image/png" {
+		t.Errorf(`Unexpected content type: %q`, resized.ContentType)
+	}
+}
+
+func TestResizeTransparentPNGKeepsPNG(t *testing.T) {
+	data := encodeTestImage(t, newTestImage(1000, 500, false), "png")
+
+	resized, err := ResizeImage(data, 480, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resized.ContentType != "image/png" {
+		t.Errorf(`Transparent images should not be converted to JPEG, got %q`, resized.ContentType)
+	}
+
+	if width := decodedWidth(t, resized.Data); width != 480 {
+		t.Errorf(`Unexpected width: %d`, width)
+	}
+}
+
+func TestResizeSmallImageIsNotUpscaled(t *testing.T) {
+	data := encodeTestImage(t, newTestImage(100, 50, false), "png")
+
+	resized, err := ResizeImage(data, 480, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resized.Data) > len(data) {
+		t.Error(`The image should never be larger than the original`)
+	}
+
+	if width := decodedWidth(t, resized.Data); width != 100 {
+		t.Errorf(`Unexpected width: %d`, width)
+	}
+}
+
+func TestResizeAnimatedGIF(t *testing.T) {
+	frame := image.NewPaletted(image.Rect(0, 0, 600, 600), []color.Color{color.Black, color.White})
+	animation := &gif.GIF{Image: []*image.Paletted{frame, frame}, Delay: []int{10, 10}}
+
+	var buffer bytes.Buffer
+	if err := gif.EncodeAll(&buffer, animation); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ResizeImage(buffer.Bytes(), 480, true); err != ErrImageNotResizable {
+		t.Errorf(`Animated images should not be resized, got %v`, err)
+	}
+}
+
+func TestResizeUnsupportedFormat(t *testing.T) {
+	if _, err := ResizeImage([]byte("<svg></svg>"), 480, true); err != ErrImageNotResizable {
+		t.Errorf(`Expected ErrImageNotResizable, got %v`, err)
+	}
+}
+
+func TestAcceptsJPEG(t *testing.T) {
+	scenarios := map[string]bool{
+		"":                                true,
+		"image/avif,image/webp,*/*":       true,
+		"image/webp,image/*;q=0.8":        true,
+		"image/jpeg":                      true,
+		"image/webp,image/png":            false,
+		"text/html,application/xhtml+xml": false,
+	}
+
+	for input, expected := range scenarios {
+		if result := AcceptsJPEG(input); result != expected {
+			t.Errorf(`Unexpected result for %q: got %v instead of %v`, input, result, expected)
+		}
+	}
+}
+
+func TestIsValidImageWidth(t *testing.T) {
+	if !IsValidImageWidth(ImageWidths[0]) {
+		t.Error(`The widths used in srcset should be valid`)
+	}
+
+	if IsValidImageWidth(12345) {
+		t.Error(`Arbitrary widths should be rejected`)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package proxy // import "miniflux.app/proxy"
+
+import (
+	"bufio"
+	"container/list"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"miniflux.app/logger"
+)
+
+const tempFilePrefix = "tmp-"
+
+// ErrMediaTooLarge is returned when a media file exceeds the size limit.
+var ErrMediaTooLarge = errors.New("proxy: the media file is too large")
+
+// MediaCache stores proxied media files on disk.
+// The least recently used files are removed when the cache is full.
+type MediaCache struct {
+	dir     string
+	maxSize int64
+	maxAge  time.Duration
+
+	mu    sync.Mutex
+	size  int64
+	lru   *list.List
+	items map[string]*list.Element
+}
+
+type mediaCacheItem struct {
+	key       string
+	size      int64
+	createdAt time.Time
+}
+
+// MediaFile is a media file read from the cache. It must be closed after use.
+type MediaFile struct {
+	io.ReadSeeker
+	ContentType string
+	ModTime     time.Time
+
+	file *os.File
+}
+
+// Close releases the underlying file.
+func (m *MediaFile) Close() error {
+	return m.file.Close()
+}
+
+// NewMediaCache returns a cache stored in the given directory and loads the files already present.
+func NewMediaCache(dir string, maxSize int64, maxAge time.Duration) (*MediaCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("proxy: unable to create cache directory: %v", err)
+	}
+
+	cache := &MediaCache{
+		dir:     dir,
+		maxSize: maxSize,
+		maxAge:  maxAge,
+		lru:     list.New(),
+		items:   make(map[string]*list.Element),
+	}
+
+	if err := cache.load(); err != nil {
+		return nil, err
+	}
+
+	return cache, nil
+}
+
+// Get returns a cached media file or nil if the key is not in the cache or has expired.
+func (c *MediaCache) Get(key string) (*MediaFile, error) {
+	c.mu.Lock()
+	element, found := c.items[key]
+	if !found {
+		c.mu.Unlock()
+		return nil, nil
+	}
+
+	item := element.Value.(*mediaCacheItem)
+	if c.maxAge > 0 && time.Since(item.createdAt) > c.maxAge {
+		c.removeElement(element)
+		c.mu.Unlock()
+		return nil, nil
+	}
+
+	c.lru.MoveToFront(element)
+	c.mu.Unlock()
+
+	file, err := os.Open(c.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			c.Remove(key)
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	contentType, err := bufio.NewReader(file).ReadString('\n')
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("proxy: invalid cache file %q: %v", key, err)
+	}
+
+	offset := int64(len(contentType))
+	return &MediaFile{
+		ReadSeeker:  io.NewSectionReader(file, offset, item.size-offset),
+		ContentType: strings.TrimSuffix(contentType, "\n"),
+		ModTime:     item.createdAt,
+		file:        file,
+	}, nil
+}
+
+// Put stores a media file in the cache.
+// ErrMediaTooLarge is returned if the body is larger than maxBytes.
+func (c *MediaCache) Put(key, contentType string, body io.Reader, maxBytes int64) error {
+	file, err := os.CreateTemp(c.dir, tempFilePrefix)
+	if err != nil {
+		return fmt.Errorf("proxy: unable to create cache file: %v", err)
+	}
+	defer os.Remove(file.Name())
+
+	header := strings.ReplaceAll(contentType, "\n", "") + "\n"
+	if _, err := io.WriteString(file, header); err != nil {
+		file.Close()
+		return err
+	}
+
+	written, err := io.Copy(file, io.LimitReader(body, maxBytes+1))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("proxy: unable to write cache file: %v", err)
+	}
+
+	if written > maxBytes {
+		return ErrMediaTooLarge
+	}
+
+	if err := os.Rename(file.Name(), c.path(key)); err != nil {
+		return fmt.Errorf("proxy: unable to store cache file: %v", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, found := c.items[key]; found {
+		c.size -= element.Value.(*mediaCacheItem).size
+		c.lru.Remove(element)
+	}
+
+	c.add(&mediaCacheItem{key: key, size: int64(len(header)) + written, createdAt: time.Now()})
+	c.evict()
+	return nil
+}
+
+// Remove deletes a media file from the cache.
+func (c *MediaCache) Remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, found := c.items[key]; found {
+		c.removeElement(element)
+	}
+}
+
+// Size returns the total size of the cached files in bytes.
+func (c *MediaCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+func (c *MediaCache) load() error {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("proxy: unable to read cache directory: %v", err)
+	}
+
+	var items []*mediaCacheItem
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() {
+			continue
+		}
+
+		// Incomplete downloads from a previous process.
+		if strings.HasPrefix(dirEntry.Name(), tempFilePrefix) {
+			os.Remove(filepath.Join(c.dir, dirEntry.Name()))
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+
+		items = append(items, &mediaCacheItem{key: dirEntry.Name(), size: info.Size(), createdAt: info.ModTime()})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].createdAt.Before(items[j].createdAt)
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, item := range items {
+		c.add(item)
+	}
+	c.evict()
+
+	logger.Debug("[Proxy] Loaded %d cached media files (%d bytes)", len(items), c.size)
+	return nil
+}
+
+func (c *MediaCache) add(item *mediaCacheItem) {
+	c.items[item.key] = c.lru.PushFront(item)
+	c.size += item.size
+}
+
+func (c *MediaCache) evict() {
+	for c.size > c.maxSize && c.lru.Len() > 0 {
+		c.removeElement(c.lru.Back())
+	}
+}
+
+func (c *MediaCache) removeElement(element *list.Element) {
+	item := element.Value.(*mediaCacheItem)
+	c.lru.Remove(element)
+	delete(c.items, item.key)
+	c.size -= item.size
+	os.Remove(c.path(item.key))
+}
+
+func (c *MediaCache) path(key string) string {
+	return filepath.Join(c.dir, key)
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package proxy // import "miniflux.app/proxy"
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMediaCachePutAndGet(t *testing.T) {
+	cache, err := NewMediaCache(t.TempDir(), 1024, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cache.Put("key", "image/png", strings.NewReader("image data"), 100); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := cache.Get("key")
+	if err != nil || file == nil {
+		t.Fatalf(`The file should be cached: %v`, err)
+	}
+	defer file.Close()
+
+	if file.ContentType != "image/png" {
+		t.Errorf(`Unexpected content type: %q`, file.ContentType)
+	}
+
+	if _, err := file.Seek(6, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := io.ReadAll(file)
+	if string(data) != "data" {
+		t.Errorf(`Unexpected content: %q`, data)
+	}
+}
+
+func TestMediaCacheMissingKey(t *testing.T) {
+	cache, err := NewMediaCache(t.TempDir(), 1024, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if file, _ := cache.Get("missing"); file != nil {
+		t.Error(`The file should not be cached`)
+	}
+}
+
+func TestMediaCacheRejectsLargeFiles(t *testing.T) {
+	cache, err := NewMediaCache(t.TempDir(), 1024, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cache.Put("key", "image/png", strings.NewReader("0123456789"), 5); err != ErrMediaTooLarge {
+		t.Errorf(`Expected ErrMediaTooLarge, got %v`, err)
+	}
+
+	if cache.Size() != 0 {
+		t.Errorf(`The cache should be empty, got %d bytes`, cache.Size())
+	}
+}
+
+func TestMediaCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewMediaCache(dir, 60, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := strings.Repeat("x", 10)
+	for _, key := range []string{"a", "b", "c"} {
+		if err := cache.Put(key, "image/png", strings.NewReader(body), 100); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Use "a" so "b" becomes the least recently used file.
+	file, _ := cache.Get("a")
+	file.Close()
+
+	if err := cache.Put("d", "image/png", strings.NewReader(body), 100); err != nil {
+		t.Fatal(err)
+	}
+
+	if file, _ := cache.Get("b"); file != nil {
+		t.Error(`The least recently used file should be evicted`)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "b")); !os.IsNotExist(err) {
+		t.Error(`The evicted file should be removed from the disk`)
+	}
+
+	for _, key := range []string{"a", "c", "d"} {
+		file, _ := cache.Get(key)
+		if file == nil {
+			t.Errorf(`The file %q should be cached`, key)
+			continue
+		}
+		file.Close()
+	}
+}
+
+func TestMediaCacheExpiration(t *testing.T) {
+	cache, err := NewMediaCache(t.TempDir(), 1024, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cache.Put("key", "image/png", strings.NewReader("data"), 100); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if file, _ := cache.Get("key"); file != nil {
+		t.Error(`The file should be expired`)
+	}
+
+	if cache.Size() != 0 {
+		t.Errorf(`The cache should be empty, got %d bytes`, cache.Size())
+	}
+}
+
+func TestMediaCacheLoadsExistingFiles(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewMediaCache(dir, 1024, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cache.Put("key", "audio/mpeg", strings.NewReader("data"), 100); err != nil {
+		t.Fatal(err)
+	}
+
+	os.WriteFile(filepath.Join(dir, tempFilePrefix+"123"), []byte("partial"), 0600)
+
+	cache, err = NewMediaCache(dir, 1024, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, _ := cache.Get("key")
+	if file == nil {
+		t.Fatal(`The file should be loaded from the disk`)
+	}
+	file.Close()
+
+	if file.ContentType != "audio/mpeg" {
+		t.Errorf(`Unexpected content type: %q`, file.ContentType)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, tempFilePrefix+"123")); !os.IsNotExist(err) {
+		t.Error(`Temporary files should be removed`)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package proxy // import "miniflux.app/proxy"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"miniflux.app/config"
+)
+
+// ErrPrivateNetwork is returned when a media file is hosted on a private network.
+var ErrPrivateNetwork = errors.New("proxy: private network addresses are not allowed")
+
+var allowedMediaTypes = []string{"image/", "audio/", "video/"}
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598).
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// FetchMedia downloads a remote media file. The Range header is forwarded when not empty.
+func FetchMedia(mediaURL, rangeHeader string) (*http.Response, error) {
+	u, err := url.Parse(mediaURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("proxy: unsupported scheme %q", u.Scheme)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, mediaURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Note: User-Agent HTTP header is omitted to avoid being blocked by bot protection mechanisms.
+	req.Header.Add("Connection", "close")
+	if rangeHeader != "" {
+		req.Header.Set("Range", rangeHeader)
+	}
+
+	client, err := newMediaClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if client.Transport.(*http.Transport).Proxy != nil {
+		// The dialer only sees the address of the HTTP proxy.
+		if err := checkHost(req.Context(), u.Hostname()); err != nil {
+			return nil, err
+		}
+	}
+
+	return client.Do(req)
+}
+
+// IsAllowedMediaType returns true if the content type can be served by the proxy.
+func IsAllowedMediaType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, prefix := range allowedMediaTypes {
+		if strings.HasPrefix(mediaType, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FetchImage downloads a remote image, the same restrictions as the media proxy apply.
+func FetchImage(imageURL string) ([]byte, string, error) {
+	resp, err := FetchMedia(imageURL, "")
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("proxy: status code is %d", resp.StatusCode)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err != nil || !strings.HasPrefix(mediaType, "image/") {
+		return nil, "", fmt.Errorf("proxy: content type %q is not an image", contentType)
+	}
+
+	maxBodySize := config.Opts.HTTPClientMaxBodySize()
+	if resp.ContentLength > maxBodySize {
+		return nil, "", ErrMediaTooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize+1))
+	if err != nil {
+		return nil, "", err
+	}
+
+	if int64(len(data)) > maxBodySize {
+		return nil, "", ErrMediaTooLarge
+	}
+
+	return data, contentType, nil
+}
+
+func newMediaClient() (*http.Client, error) {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	transport := &http.Transport{}
+
+	if proxyURL := config.Opts.HTTPClientProxy(); proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("proxy: invalid HTTP proxy URL: %v", err)
+		}
+		transport.Proxy = http.ProxyURL(u)
+	} else if !config.Opts.MediaProxyAllowPrivateNetworks() {
+		// The address is checked after the DNS resolution to prevent DNS rebinding.
+		dialer.Control = func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isPrivateIP(ip) {
+				return ErrPrivateNetwork
+			}
+			return nil
+		}
+	}
+
+	transport.DialContext = dialer.DialContext
+
+	client := &http.Client{
+		Timeout:   time.Duration(config.Opts.HTTPClientTimeout()) * time.Second,
+		Transport: transport,
+	}
+
+	if transport.Proxy != nil {
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("proxy: stopped after 10 redirects")
+			}
+			return checkHost(req.Context(), req.URL.Hostname())
+		}
+	}
+
+	return client, nil
+}
+
+// checkHost resolves the hostname and rejects private addresses.
+func checkHost(ctx context.Context, host string) error {
+	if config.Opts.MediaProxyAllowPrivateNetworks() {
+		return nil
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		if isPrivateIP(ip) {
+			return ErrPrivateNetwork
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		if isPrivateIP(addr.IP) {
+			return ErrPrivateNetwork
+		}
+	}
+
+	return nil
+}
+
+func isPrivateIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		sharedAddressSpace.Contains(ip)
+}
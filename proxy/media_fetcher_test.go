@@ -0,0 +1,127 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package proxy // import "miniflux.app/proxy"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"testing"
+
+	"miniflux.app/config"
+)
+
+func TestIsPrivateIP(t *testing.T) {
+	scenarios := map[string]bool{
+		"127.0.0.1":       true,
+		"10.0.0.1":        true,
+		"172.16.0.1":      true,
+		"192.168.1.1":     true,
+		"169.254.169.254": true,
+		"100.64.0.1":      true,
+		"0.0.0.0":         true,
+		"::1":             true,
+		"fe80::1":         true,
+		"fd00::1":         true,
+		"8.8.8.8":         false,
+		"100.128.0.1":     false,
+		"2001:4860::8888": false,
+	}
+
+	for input, expected := range scenarios {
+		if result := isPrivateIP(net.ParseIP(input)); result != expected {
+			t.Errorf(`Unexpected result for %q: got %v instead of %v`, input, result, expected)
+		}
+	}
+}
+
+func TestIsAllowedMediaType(t *testing.T) {
+	scenarios := map[string]bool{
+		"image/png":                true,
+		"image/svg+xml":            true,
+		"audio/mpeg":               true,
+		"video/mp4; codecs=avc1":   true,
+		"text/html; charset=utf-8": false,
+		"application/javascript":   false,
+		"":                         false,
+	}
+
+	for input, expected := range scenarios {
+		if result := IsAllowedMediaType(input); result != expected {
+			t.Errorf(`Unexpected result for %q: got %v instead of %v`, input, result, expected)
+		}
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if err := checkHost(context.Background(), "127.0.0.1"); err != ErrPrivateNetwork {
+		t.Errorf(`Loopback addresses should be rejected, got %v`, err)
+	}
+
+	if err := checkHost(context.Background(), "93.184.216.34"); err != nil {
+		t.Errorf(`Public addresses should be allowed, got %v`, err)
+	}
+
+	os.Setenv("MEDIA_PROXY_ALLOW_PRIVATE_NETWORKS", "1")
+	config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	if err := checkHost(context.Background(), "127.0.0.1"); err != nil {
+		t.Errorf(`Private addresses should be allowed, got %v`, err)
+	}
+}
+
+func TestFetchMediaRejectsPrivateNetworks(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer listener.Close()
+
+	_, err = FetchMedia("http://"+listener.Addr().String()+"/image.png", "")
+	if err == nil || !errors.Is(err, ErrPrivateNetwork) {
+		t.Errorf(`Expected ErrPrivateNetwork, got %v`, err)
+	}
+}
+
+func TestFetchImageRejectsPrivateNetworks(t *testing.T) {
+	os.Clearenv()
+
+	var err error
+	config.Opts, err = config.NewParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer listener.Close()
+
+	_, _, err = FetchImage("http://" + listener.Addr().String() + "/image.png")
+	if err == nil || !errors.Is(err, ErrPrivateNetwork) {
+		t.Errorf(`Expected ErrPrivateNetwork, got %v`, err)
+	}
+}
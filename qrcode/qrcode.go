@@ -0,0 +1,484 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package qrcode // import "miniflux.app/qrcode"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxVersion is the largest symbol supported, enough for about 200 bytes.
+const maxVersion = 10
+
+// quietZone is the number of light modules around the symbol.
+const quietZone = 4
+
+// Error correction level M: number of error correction codewords per block,
+// size of the data blocks and position of the alignment patterns of each version.
+var (
+	eccCodewordsPerBlock = [maxVersion + 1]int{0, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26}
+	dataBlocks           = [maxVersion + 1][]int{
+		nil,
+		{16},
+		{28},
+		{44},
+		{32, 32},
+		{43, 43},
+		{27, 27, 27, 27},
+		{31, 31, 31, 31},
+		{38, 38, 39, 39},
+		{36, 36, 36, 37, 37},
+		{43, 43, 43, 43, 44},
+	}
+	alignmentPatterns = [maxVersion + 1][]int{
+		nil,
+		nil,
+		{6, 18},
+		{6, 22},
+		{6, 26},
+		{6, 30},
+		{6, 34},
+		{6, 22, 38},
+		{6, 24, 42},
+		{6, 26, 46},
+		{6, 28, 50},
+	}
+)
+
+// ErrDataTooLong is returned when the text does not fit in the largest supported symbol.
+var ErrDataTooLong = errors.New("qrcode: data too long")
+
+// Code is a QR code symbol.
+type Code struct {
+	Version int
+	Size    int
+
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// Encode returns the smallest QR code that contains the given text, in byte mode with the error correction level M.
+func Encode(text string) (*Code, error) {
+	data := []byte(text)
+
+	for version := 1; version <= maxVersion; version++ {
+		if len(data) <= dataCapacity(version) {
+			code := newCode(version)
+			code.drawFunctionPatterns()
+			code.drawCodewords(addErrorCorrection(version, encodeData(version, data)))
+			code.applyBestMask()
+			return code, nil
+		}
+	}
+
+	return nil, ErrDataTooLong
+}
+
+// Module returns true if the module at the given column and row is dark.
+func (c *Code) Module(x, y int) bool {
+	return c.modules[y][x]
+}
+
+// SVG returns the symbol as an SVG image, with a quiet zone.
+func (c *Code) SVG() string {
+	var path strings.Builder
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.modules[y][x] {
+				fmt.Fprintf(&path, "M%d,%dh1v1h-1z", x+quietZone, y+quietZone)
+			}
+		}
+	}
+
+	dimension := c.Size + quietZone*2
+	return fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges"><rect width="100%%" height="100%%" fill="#fff"/><path d="%s" fill="#000"/></svg>`,
+		dimension,
+		dimension,
+		path.String(),
+	)
+}
+
+func newCode(version int) *Code {
+	size := version*4 + 17
+	code := &Code{Version: version, Size: size}
+	code.modules = make([][]bool, size)
+	code.isFunction = make([][]bool, size)
+	for i := 0; i < size; i++ {
+		code.modules[i] = make([]bool, size)
+		code.isFunction[i] = make([]bool, size)
+	}
+	return code
+}
+
+func dataCodewords(version int) int {
+	total := 0
+	for _, size := range dataBlocks[version] {
+		total += size
+	}
+	return total
+}
+
+// dataCapacity returns the number of bytes that fit in the symbol once the mode and length header are added.
+func dataCapacity(version int) int {
+	return (dataCodewords(version)*8 - 4 - characterCountBits(version)) / 8
+}
+
+func characterCountBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+	return 16
+}
+
+func encodeData(version int, data []byte) []byte {
+	var buffer bitBuffer
+	buffer.append(0x4, 4)
+	buffer.append(len(data), characterCountBits(version))
+	for _, b := range data {
+		buffer.append(int(b), 8)
+	}
+
+	capacity := dataCodewords(version) * 8
+	terminator := capacity - len(buffer)
+	if terminator > 4 {
+		terminator = 4
+	}
+	buffer.append(0, terminator)
+	buffer.append(0, (8-len(buffer)%8)%8)
+
+	for padding := 0xEC; len(buffer) < capacity; padding ^= 0xEC ^ 0x11 {
+		buffer.append(padding, 8)
+	}
+
+	return buffer.bytes()
+}
+
+// addErrorCorrection splits the data in blocks, computes their error
+// correction codewords and interleaves the result.
+func addErrorCorrection(version int, data []byte) []byte {
+	eccLength := eccCodewordsPerBlock[version]
+	generator := reedSolomonGenerator(eccLength)
+
+	var blocks, eccBlocks [][]byte
+	offset := 0
+	for _, size := range dataBlocks[version] {
+		block := data[offset : offset+size]
+		blocks = append(blocks, block)
+		eccBlocks = append(eccBlocks, reedSolomonRemainder(block, generator))
+		offset += size
+	}
+
+	var result []byte
+	largestBlock := len(blocks[len(blocks)-1])
+	for i := 0; i < largestBlock; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+
+	for i := 0; i < eccLength; i++ {
+		for _, block := range eccBlocks {
+			result = append(result, block[i])
+		}
+	}
+
+	return result
+}
+
+func (c *Code) setFunctionModule(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.isFunction[y][x] = true
+}
+
+func (c *Code) drawFunctionPatterns() {
+	for i := 0; i < c.Size; i++ {
+		c.setFunctionModule(6, i, i%2 == 0)
+		c.setFunctionModule(i, 6, i%2 == 0)
+	}
+
+	c.drawFinderPattern(3, 3)
+	c.drawFinderPattern(c.Size-4, 3)
+	c.drawFinderPattern(3, c.Size-4)
+
+	positions := alignmentPatterns[c.Version]
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// Skip the corners occupied by the finder patterns.
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			c.drawAlignmentPattern(x, y)
+		}
+	}
+
+	// Reserve the format and version areas, they are drawn once the mask is chosen.
+	c.drawFormatBits(0)
+	c.drawVersion()
+}
+
+func (c *Code) drawFinderPattern(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= c.Size || yy < 0 || yy >= c.Size {
+				continue
+			}
+			distance := max(abs(dx), abs(dy))
+			c.setFunctionModule(xx, yy, distance != 2 && distance != 4)
+		}
+	}
+}
+
+func (c *Code) drawAlignmentPattern(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunctionModule(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// formatBits returns the 15 bits of format information for the level M and the given mask.
+func formatBits(mask int) int {
+	data := mask // The level M is encoded as 00.
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 9) * 0x537)
+	}
+	return (data<<10 | remainder) ^ 0x5412
+}
+
+func (c *Code) drawFormatBits(mask int) {
+	bits := formatBits(mask)
+
+	for i := 0; i <= 5; i++ {
+		c.setFunctionModule(8, i, bit(bits, i))
+	}
+	c.setFunctionModule(8, 7, bit(bits, 6))
+	c.setFunctionModule(8, 8, bit(bits, 7))
+	c.setFunctionModule(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		c.setFunctionModule(14-i, 8, bit(bits, i))
+	}
+
+	for i := 0; i < 8; i++ {
+		c.setFunctionModule(c.Size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunctionModule(8, c.Size-15+i, bit(bits, i))
+	}
+
+	// The dark module is always set.
+	c.setFunctionModule(8, c.Size-8, true)
+}
+
+// versionBits returns the 18 bits of version information, only used from version 7.
+func versionBits(version int) int {
+	remainder := version
+	for i := 0; i < 12; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 11) * 0x1F25)
+	}
+	return version<<12 | remainder
+}
+
+func (c *Code) drawVersion() {
+	if c.Version < 7 {
+		return
+	}
+
+	bits := versionBits(c.Version)
+	for i := 0; i < 18; i++ {
+		a, b := c.Size-11+i%3, i/3
+		c.setFunctionModule(a, b, bit(bits, i))
+		c.setFunctionModule(b, a, bit(bits, i))
+	}
+}
+
+// drawCodewords places the codewords in the zigzag order, starting from the bottom right corner.
+func (c *Code) drawCodewords(codewords []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+
+		upward := (right+1)&2 == 0
+		for vertical := 0; vertical < c.Size; vertical++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vertical
+				if upward {
+					y = c.Size - 1 - vertical
+				}
+
+				if !c.isFunction[y][x] && i < len(codewords)*8 {
+					c.modules[y][x] = bit(int(codewords[i/8]), 7-i%8)
+					i++
+				}
+			}
+		}
+	}
+}
+
+func maskCondition(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if !c.isFunction[y][x] && maskCondition(mask, x, y) {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// applyBestMask keeps the mask with the lowest penalty score.
+func (c *Code) applyBestMask() {
+	bestMask, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		if penalty := c.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			bestMask, bestPenalty = mask, penalty
+		}
+		c.applyMask(mask)
+	}
+
+	c.applyMask(bestMask)
+	c.drawFormatBits(bestMask)
+}
+
+func (c *Code) penalty() int {
+	penalty := 0
+
+	for i := 0; i < c.Size; i++ {
+		penalty += linePenalty(c.Size, func(j int) bool { return c.modules[i][j] })
+		penalty += linePenalty(c.Size, func(j int) bool { return c.modules[j][i] })
+	}
+
+	dark := 0
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+
+			if x < c.Size-1 && y < c.Size-1 {
+				color := c.modules[y][x]
+				if color == c.modules[y][x+1] && color == c.modules[y+1][x] && color == c.modules[y+1][x+1] {
+					penalty += 3
+				}
+			}
+		}
+	}
+
+	total := c.Size * c.Size
+	penalty += abs(dark*20-total*10) / total * 10
+
+	return penalty
+}
+
+// linePenalty scores the runs of modules of the same color and the patterns looking like a finder.
+func linePenalty(size int, module func(int) bool) int {
+	penalty := 0
+
+	run := 1
+	for j := 1; j <= size; j++ {
+		if j < size && module(j) == module(j-1) {
+			run++
+			continue
+		}
+		if run >= 5 {
+			penalty += run - 2
+		}
+		run = 1
+	}
+
+	// The modules outside the symbol are light.
+	isLight := func(from, to int) bool {
+		for j := from; j < to; j++ {
+			if j >= 0 && j < size && module(j) {
+				return false
+			}
+		}
+		return true
+	}
+
+	finderLike := []bool{true, false, true, true, true, false, true}
+	for j := 0; j+len(finderLike) <= size; j++ {
+		matches := true
+		for k, dark := range finderLike {
+			if module(j+k) != dark {
+				matches = false
+				break
+			}
+		}
+
+		if matches && (isLight(j-4, j) || isLight(j+len(finderLike), j+len(finderLike)+4)) {
+			penalty += 40
+		}
+	}
+
+	return penalty
+}
+
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, bit(value, i))
+	}
+}
+
+func (b bitBuffer) bytes() []byte {
+	result := make([]byte, len(b)/8)
+	for i, dark := range b {
+		if dark {
+			result[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return result
+}
+
+func bit(value, i int) bool {
+	return (value>>i)&1 != 0
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package qrcode // import "miniflux.app/qrcode"
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomonRemainder(t *testing.T) {
+	// Data and error correction codewords of "HELLO WORLD" encoded as a 1-M symbol.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	expected := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	if result := reedSolomonRemainder(data, reedSolomonGenerator(10)); !bytes.Equal(result, expected) {
+		t.Errorf(`Unexpected error correction codewords, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestFormatBits(t *testing.T) {
+	expected := []int{0x5412, 0x5125, 0x5E7C, 0x5B4B, 0x45F9, 0x40CE, 0x4F97, 0x4AA0}
+	for mask, bits := range expected {
+		if result := formatBits(mask); result != bits {
+			t.Errorf(`Unexpected format bits for the mask %d, got %#x instead of %#x`, mask, result, bits)
+		}
+	}
+}
+
+func TestVersionBits(t *testing.T) {
+	expected := map[int]int{7: 0x07C94, 8: 0x085BC, 9: 0x09A99, 10: 0x0A4D3}
+	for version, bits := range expected {
+		if result := versionBits(version); result != bits {
+			t.Errorf(`Unexpected version bits for the version %d, got %#x instead of %#x`, version, result, bits)
+		}
+	}
+}
+
+func TestDataCapacity(t *testing.T) {
+	expected := []int{0, 14, 26, 42, 62, 84, 106, 122, 152, 180, 213}
+	for version := 1; version <= maxVersion; version++ {
+		if result := dataCapacity(version); result != expected[version] {
+			t.Errorf(`Unexpected capacity for the version %d, got %d instead of %d`, version, result, expected[version])
+		}
+	}
+}
+
+func TestEncodeChoosesTheSmallestVersion(t *testing.T) {
+	scenarios := map[int]int{1: 1, 14: 1, 15: 2, 122: 7, 123: 8, 213: 10}
+	for length, version := range scenarios {
+		code, err := Encode(strings.Repeat("a", length))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if code.Version != version || code.Size != version*4+17 {
+			t.Errorf(`Unexpected version for %d bytes, got %d instead of %d`, length, code.Version, version)
+		}
+	}
+
+	if _, err := Encode(strings.Repeat("a", 214)); err != ErrDataTooLong {
+		t.Errorf(`Long texts should be rejected, got %v`, err)
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	for _, text := range []string{
+		"otpauth://totp/Miniflux:bob?secret=JBSWY3DPEHPK3PXP&issuer=Miniflux",
+		"otpauth://totp/Miniflux:someone%40example.org?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ&issuer=Miniflux&algorithm=SHA1&digits=6&period=30",
+		"https://miniflux.app",
+	} {
+		code, err := Encode(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result := decode(t, code); result != text {
+			t.Errorf(`Unexpected content for a version %d symbol, got %q instead of %q`, code.Version, result, text)
+		}
+	}
+}
+
+func TestSVG(t *testing.T) {
+	code, err := Encode("Miniflux")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svg := code.SVG()
+	if !strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 29 29"`) {
+		t.Errorf(`Unexpected SVG header: %s`, svg[:80])
+	}
+
+	// The top left module of the finder pattern, shifted by the quiet zone.
+	if !strings.Contains(svg, `d="M4,4h1v1h-1z`) {
+		t.Error(`The finder pattern is missing`)
+	}
+}
+
+// decode reads the symbol back, it checks the format information and the error correction codewords.
+func decode(t *testing.T, code *Code) string {
+	var bits int
+	for i := 0; i < 8; i++ {
+		if code.Module(code.Size-1-i, 8) {
+			bits |= 1 << i
+		}
+	}
+	for i := 8; i < 15; i++ {
+		if code.Module(8, code.Size-15+i) {
+			bits |= 1 << i
+		}
+	}
+
+	mask := -1
+	for m := 0; m < 8; m++ {
+		if formatBits(m) == bits {
+			mask = m
+		}
+	}
+	if mask < 0 {
+		t.Fatalf(`Invalid format information: %#x`, bits)
+	}
+
+	reference := newCode(code.Version)
+	reference.drawFunctionPatterns()
+
+	var stream bitBuffer
+	for right := code.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vertical := 0; vertical < code.Size; vertical++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vertical
+				if (right+1)&2 == 0 {
+					y = code.Size - 1 - vertical
+				}
+				if !reference.isFunction[y][x] {
+					stream = append(stream, code.Module(x, y) != maskCondition(mask, x, y))
+				}
+			}
+		}
+	}
+	codewords := stream[:len(stream)/8*8].bytes()
+
+	sizes := dataBlocks[code.Version]
+	eccLength := eccCodewordsPerBlock[code.Version]
+	blocks := make([][]byte, len(sizes))
+	index := 0
+	for i := 0; i < sizes[len(sizes)-1]; i++ {
+		for b, size := range sizes {
+			if i < size {
+				blocks[b] = append(blocks[b], codewords[index])
+				index++
+			}
+		}
+	}
+
+	generator := reedSolomonGenerator(eccLength)
+	var data []byte
+	for b := range blocks {
+		ecc := make([]byte, eccLength)
+		for i := range ecc {
+			ecc[i] = codewords[index+i*len(blocks)+b]
+		}
+		if expected := reedSolomonRemainder(blocks[b], generator); !bytes.Equal(ecc, expected) {
+			t.Fatalf(`Invalid error correction codewords for the block %d`, b)
+		}
+		data = append(data, blocks[b]...)
+	}
+
+	var dataBits bitBuffer
+	for _, b := range data {
+		dataBits.append(int(b), 8)
+	}
+
+	read := func(offset, length int) int {
+		value := 0
+		for i := 0; i < length; i++ {
+			value <<= 1
+			if dataBits[offset+i] {
+				value |= 1
+			}
+		}
+		return value
+	}
+
+	if read(0, 4) != 0x4 {
+		t.Fatalf(`Unexpected mode`)
+	}
+
+	countBits := characterCountBits(code.Version)
+	length := read(4, countBits)
+	result := make([]byte, length)
+	for i := range result {
+		result[i] = byte(read(4+countBits+i*8, 8))
+	}
+	return string(result)
+}
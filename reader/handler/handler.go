@@ -11,6 +11,7 @@ import (
 	"miniflux.app/config"
 	"miniflux.app/errors"
 	"miniflux.app/http/client"
+	"miniflux.app/integration"
 	"miniflux.app/locale"
 	"miniflux.app/logger"
 	"miniflux.app/model"
@@ -134,27 +135,26 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64) error {
 	originalFeed.CheckedNow()
 	originalFeed.ScheduleNextCheck(weeklyEntryCount)
 
-	request := client.NewClientWithConfig(originalFeed.FeedURL, config.Opts)
-	request.WithCredentials(originalFeed.Username, originalFeed.Password)
-	request.WithUserAgent(originalFeed.UserAgent)
-	request.WithCookie(originalFeed.Cookie)
-	request.AllowSelfSignedCertificates = originalFeed.AllowSelfSignedCertificates
-
-	if !originalFeed.IgnoreHTTPCache {
-		request.WithCacheHeaders(originalFeed.EtagHeader, originalFeed.LastModifiedHeader)
+	// The feeds shared with guests are downloaded with the credentials of their owner, kept out of the guest feed.
+	fetchedFeed := originalFeed
+	username, password, cookie, shared, storeErr := store.SharedFeedCredentials(feedID)
+	if storeErr != nil {
+		return storeErr
 	}
-
-	if originalFeed.FetchViaProxy {
-		request.WithProxy()
+	if shared {
+		sharedFeed := *originalFeed
+		sharedFeed.Username, sharedFeed.Password, sharedFeed.Cookie = username, password, cookie
+		fetchedFeed = &sharedFeed
 	}
 
-	response, requestErr := browser.Exec(request)
-	if requestErr != nil {
-		originalFeed.WithError(requestErr.Localize(printer))
+	fetch := fetchFeed(fetchedFeed)
+	if fetch.requestErr != nil {
+		originalFeed.WithError(fetch.requestErr.Localize(printer))
 		store.UpdateFeedError(originalFeed)
-		return requestErr
+		return fetch.requestErr
 	}
 
+	response := fetch.response
 	if store.AnotherFeedURLExists(userID, originalFeed.ID, response.EffectiveURL) {
 		storeErr := errors.NewLocalizedError(errDuplicate, response.EffectiveURL)
 		originalFeed.WithError(storeErr.Error())
@@ -165,23 +165,29 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64) error {
 	if originalFeed.IgnoreHTTPCache || response.IsModified(originalFeed.EtagHeader, originalFeed.LastModifiedHeader) {
 		logger.Debug("[RefreshFeed] Feed #%d has been modified", feedID)
 
-		updatedFeed, parseErr := parser.ParseFeed(response.EffectiveURL, response.BodyAsString())
+		entries, parseErr := fetch.Entries()
 		if parseErr != nil {
 			originalFeed.WithError(parseErr.Localize(printer))
 			store.UpdateFeedError(originalFeed)
 			return parseErr
 		}
 
-		originalFeed.Entries = updatedFeed.Entries
-		processor.ProcessFeedEntries(store, originalFeed, user)
+		fetchedFeed.Entries = entries
+		processor.ProcessFeedEntries(store, fetchedFeed, user)
+		originalFeed.Entries = fetchedFeed.Entries
 
 		// We don't update existing entries when the crawler is enabled (we crawl only inexisting entries).
-		if storeErr := store.RefreshFeedEntries(originalFeed.UserID, originalFeed.ID, originalFeed.Entries, !originalFeed.Crawler); storeErr != nil {
+		newEntries, storeErr := store.RefreshFeedEntries(originalFeed.UserID, originalFeed.ID, originalFeed.Entries, !originalFeed.Crawler)
+		if storeErr != nil {
 			originalFeed.WithError(storeErr.Error())
 			store.UpdateFeedError(originalFeed)
 			return storeErr
 		}
 
+		if len(newEntries) > 0 {
+			go integration.NotifyEntries(store, originalFeed, newEntries, user)
+		}
+
 		// We update caching headers only if the feed has been modified,
 		// because some websites don't return the same headers when replying with a 304.
 		originalFeed.WithClientResponse(response)
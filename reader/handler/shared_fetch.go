@@ -0,0 +1,248 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package handler // import "miniflux.app/reader/handler"
+
+import (
+	"sync"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/errors"
+	"miniflux.app/http/client"
+	"miniflux.app/logger"
+	"miniflux.app/metric"
+	"miniflux.app/model"
+	"miniflux.app/reader/browser"
+	"miniflux.app/reader/parser"
+)
+
+var (
+	sharedFetchesOnce sync.Once
+	sharedFetches     *sharedFetchCache
+)
+
+// fetchFeed downloads a feed or reuses the response received by another subscriber.
+func fetchFeed(feed *model.Feed) *sharedFetch {
+	sharedFetchesOnce.Do(func() {
+		interval := time.Duration(config.Opts.PollingSharedFetchInterval()) * time.Minute
+		sharedFetches = newSharedFetchCache(interval, downloadFeed)
+	})
+
+	return sharedFetches.Fetch(feed)
+}
+
+// sharedFetchKey identifies the upstream request of a subscription.
+// Subscriptions with the same key receive the same response, whoever the user is.
+type sharedFetchKey struct {
+	feedURL                     string
+	username                    string
+	password                    string
+	userAgent                   string
+	cookie                      string
+	fetchViaProxy               bool
+	allowSelfSignedCertificates bool
+}
+
+func newSharedFetchKey(feed *model.Feed) sharedFetchKey {
+	return sharedFetchKey{
+		feedURL:                     feed.FeedURL,
+		username:                    feed.Username,
+		password:                    feed.Password,
+		userAgent:                   feed.UserAgent,
+		cookie:                      feed.Cookie,
+		fetchViaProxy:               feed.FetchViaProxy,
+		allowSelfSignedCertificates: feed.AllowSelfSignedCertificates,
+	}
+}
+
+// sharedFetch is a download shared by all the subscribers of a feed.
+// The body is parsed once, on the first refresh that needs the entries.
+type sharedFetch struct {
+	done      chan struct{}
+	fetchedAt time.Time
+
+	// Conditional headers sent with the request, a 304 is only valid for subscriptions having the same headers.
+	etagHeader         string
+	lastModifiedHeader string
+
+	response   *client.Response
+	requestErr *errors.LocalizedError
+
+	parseOnce sync.Once
+	body      string
+	entries   model.Entries
+	parseErr  *errors.LocalizedError
+}
+
+func newSharedFetch(etagHeader, lastModifiedHeader string) *sharedFetch {
+	return &sharedFetch{
+		done:               make(chan struct{}),
+		etagHeader:         etagHeader,
+		lastModifiedHeader: lastModifiedHeader,
+	}
+}
+
+func (s *sharedFetch) isCompleted() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// isUsableBy returns true if the outcome of the download is valid for this subscription.
+func (s *sharedFetch) isUsableBy(feed *model.Feed) bool {
+	if s.requestErr != nil || s.response.StatusCode != 304 {
+		return true
+	}
+
+	return !feed.IgnoreHTTPCache && feed.EtagHeader == s.etagHeader && feed.LastModifiedHeader == s.lastModifiedHeader
+}
+
+// Entries returns a copy of the parsed entries, each subscriber applies its own rules to the entries.
+func (s *sharedFetch) Entries() (model.Entries, *errors.LocalizedError) {
+	s.parseOnce.Do(func() {
+		updatedFeed, parseErr := parser.ParseFeed(s.response.EffectiveURL, s.body)
+		if parseErr != nil {
+			s.parseErr = parseErr
+		} else {
+			s.entries = updatedFeed.Entries
+		}
+		s.body = ""
+	})
+
+	if s.parseErr != nil {
+		return nil, s.parseErr
+	}
+
+	return cloneEntries(s.entries), nil
+}
+
+// sharedFetchCache deduplicates the downloads of the same feed made by several users within an interval.
+type sharedFetchCache struct {
+	interval time.Duration
+	download func(feed *model.Feed, fetch *sharedFetch)
+
+	mu        sync.Mutex
+	fetches   map[sharedFetchKey]*sharedFetch
+	lastSweep time.Time
+}
+
+func newSharedFetchCache(interval time.Duration, download func(feed *model.Feed, fetch *sharedFetch)) *sharedFetchCache {
+	return &sharedFetchCache{
+		interval:  interval,
+		download:  download,
+		fetches:   make(map[sharedFetchKey]*sharedFetch),
+		lastSweep: time.Now(),
+	}
+}
+
+// Fetch returns the response of the feed URL, the download is made only if no recent response can be reused.
+// Concurrent refreshes of the same feed wait for the download in progress.
+func (c *sharedFetchCache) Fetch(feed *model.Feed) *sharedFetch {
+	etagHeader, lastModifiedHeader := feed.EtagHeader, feed.LastModifiedHeader
+	if feed.IgnoreHTTPCache {
+		etagHeader, lastModifiedHeader = "", ""
+	}
+
+	if c.interval <= 0 {
+		fetch := newSharedFetch(etagHeader, lastModifiedHeader)
+		c.download(feed, fetch)
+		close(fetch.done)
+		return fetch
+	}
+
+	key := newSharedFetchKey(feed)
+
+	c.mu.Lock()
+	c.sweep()
+	if fetch, found := c.fetches[key]; found {
+		c.mu.Unlock()
+		<-fetch.done
+
+		if time.Since(fetch.fetchedAt) < c.interval && fetch.isUsableBy(feed) {
+			logger.Debug("[SharedFetch] Reusing the response of %q for feed #%d", feed.FeedURL, feed.ID)
+			countSharedFetch("hit")
+			return fetch
+		}
+
+		c.mu.Lock()
+	}
+
+	fetch := newSharedFetch(etagHeader, lastModifiedHeader)
+	c.fetches[key] = fetch
+	c.mu.Unlock()
+
+	countSharedFetch("miss")
+	c.download(feed, fetch)
+	fetch.fetchedAt = time.Now()
+	close(fetch.done)
+
+	return fetch
+}
+
+// sweep forgets the expired downloads to release the memory used by the entries.
+func (c *sharedFetchCache) sweep() {
+	if time.Since(c.lastSweep) < c.interval {
+		return
+	}
+
+	for key, fetch := range c.fetches {
+		if fetch.isCompleted() && time.Since(fetch.fetchedAt) >= c.interval {
+			delete(c.fetches, key)
+		}
+	}
+
+	c.lastSweep = time.Now()
+}
+
+func downloadFeed(feed *model.Feed, fetch *sharedFetch) {
+	request := client.NewClientWithConfig(feed.FeedURL, config.Opts)
+	request.WithCredentials(feed.Username, feed.Password)
+	request.WithUserAgent(feed.UserAgent)
+	request.WithCookie(feed.Cookie)
+	request.AllowSelfSignedCertificates = feed.AllowSelfSignedCertificates
+	request.WithCacheHeaders(fetch.etagHeader, fetch.lastModifiedHeader)
+
+	if feed.FetchViaProxy {
+		request.WithProxy()
+	}
+
+	response, requestErr := browser.Exec(request)
+	if requestErr != nil {
+		fetch.requestErr = requestErr
+		return
+	}
+
+	if response.StatusCode != 304 {
+		fetch.body = response.BodyAsString()
+	}
+
+	response.Body = nil
+	fetch.response = response
+}
+
+func cloneEntries(entries model.Entries) model.Entries {
+	clones := make(model.Entries, 0, len(entries))
+	for _, entry := range entries {
+		clone := *entry
+		if entry.Enclosures != nil {
+			clone.Enclosures = make(model.EnclosureList, 0, len(entry.Enclosures))
+			for _, enclosure := range entry.Enclosures {
+				enclosureClone := *enclosure
+				clone.Enclosures = append(clone.Enclosures, &enclosureClone)
+			}
+		}
+		clones = append(clones, &clone)
+	}
+	return clones
+}
+
+func countSharedFetch(result string) {
+	if config.Opts.HasMetricsCollector() {
+		metric.SharedFeedFetches.WithLabelValues(result).Inc()
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package handler // import "miniflux.app/reader/handler"
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/http/client"
+	"miniflux.app/model"
+)
+
+const sharedFetchTestFeed = `<?xml version="1.0" encoding="utf-8"?>
+<rss version="2.0">
+<channel>
+	<title>Example</title>
+	<link>https://example.org/</link>
+	<item>
+		<title>Item 1</title>
+		<link>https://example.org/item1</link>
+		<enclosure url="https://example.org/item1.mp3" length="42" type="audio/mpeg"/>
+	</item>
+</channel>
+</rss>`
+
+type fakeDownloader struct {
+	calls      int32
+	statusCode int
+	etag       string
+	delay      time.Duration
+}
+
+func (f *fakeDownloader) download(feed *model.Feed, fetch *sharedFetch) {
+	atomic.AddInt32(&f.calls, 1)
+	time.Sleep(f.delay)
+
+	fetch.response = &client.Response{StatusCode: f.statusCode, EffectiveURL: feed.FeedURL, ETag: f.etag}
+	if f.statusCode != 304 {
+		fetch.body = sharedFetchTestFeed
+	}
+}
+
+func (f *fakeDownloader) count() int {
+	return int(atomic.LoadInt32(&f.calls))
+}
+
+func TestSharedFetchWithSameFeedURL(t *testing.T) {
+	config.Opts = config.NewOptions()
+	downloader := &fakeDownloader{statusCode: 200, etag: "v2"}
+	cache := newSharedFetchCache(time.Minute, downloader.download)
+
+	first := cache.Fetch(&model.Feed{ID: 1, UserID: 1, FeedURL: "https://example.org/feed.xml"})
+	second := cache.Fetch(&model.Feed{ID: 2, UserID: 2, FeedURL: "https://example.org/feed.xml", EtagHeader: "v1"})
+
+	if downloader.count() != 1 {
+		t.Fatalf(`The feed should be downloaded once, got %d downloads`, downloader.count())
+	}
+
+	firstEntries, err := first.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secondEntries, err := second.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(firstEntries) != 1 || len(secondEntries) != 1 {
+		t.Fatalf(`Unexpected entries: %d and %d`, len(firstEntries), len(secondEntries))
+	}
+
+	firstEntries[0].Title = "Rewritten"
+	firstEntries[0].Enclosures[0].UserID = 1
+	if secondEntries[0].Title != "Item 1" || secondEntries[0].Enclosures[0].UserID != 0 {
+		t.Error(`The entries of a subscriber should not be modified by another subscriber`)
+	}
+}
+
+func TestSharedFetchWithDifferentRequestOptions(t *testing.T) {
+	config.Opts = config.NewOptions()
+	downloader := &fakeDownloader{statusCode: 200}
+	cache := newSharedFetchCache(time.Minute, downloader.download)
+
+	cache.Fetch(&model.Feed{ID: 1, FeedURL: "https://example.org/feed.xml"})
+	cache.Fetch(&model.Feed{ID: 2, FeedURL: "https://example.org/feed.xml", UserAgent: "Custom"})
+	cache.Fetch(&model.Feed{ID: 3, FeedURL: "https://example.org/feed.xml", Username: "user", Password: "secret"})
+
+	if downloader.count() != 3 {
+		t.Errorf(`Each set of request options should be downloaded separately, got %d downloads`, downloader.count())
+	}
+}
+
+func TestSharedFetchNotModifiedWithDifferentCacheHeaders(t *testing.T) {
+	config.Opts = config.NewOptions()
+	downloader := &fakeDownloader{statusCode: 304}
+	cache := newSharedFetchCache(time.Minute, downloader.download)
+
+	cache.Fetch(&model.Feed{ID: 1, FeedURL: "https://example.org/feed.xml", EtagHeader: "v1"})
+	cache.Fetch(&model.Feed{ID: 2, FeedURL: "https://example.org/feed.xml", EtagHeader: "v1"})
+	if downloader.count() != 1 {
+		t.Fatalf(`A 304 response should be shared with the same cache headers, got %d downloads`, downloader.count())
+	}
+
+	cache.Fetch(&model.Feed{ID: 3, FeedURL: "https://example.org/feed.xml", EtagHeader: "v0"})
+	if downloader.count() != 2 {
+		t.Errorf(`A 304 response should not be shared with other cache headers, got %d downloads`, downloader.count())
+	}
+}
+
+func TestSharedFetchAfterInterval(t *testing.T) {
+	config.Opts = config.NewOptions()
+	downloader := &fakeDownloader{statusCode: 200}
+	cache := newSharedFetchCache(time.Minute, downloader.download)
+
+	fetch := cache.Fetch(&model.Feed{ID: 1, FeedURL: "https://example.org/feed.xml"})
+	fetch.fetchedAt = time.Now().Add(-2 * time.Minute)
+
+	cache.Fetch(&model.Feed{ID: 2, FeedURL: "https://example.org/feed.xml"})
+	if downloader.count() != 2 {
+		t.Errorf(`An expired response should not be reused, got %d downloads`, downloader.count())
+	}
+}
+
+func TestSharedFetchDisabled(t *testing.T) {
+	config.Opts = config.NewOptions()
+	downloader := &fakeDownloader{statusCode: 200}
+	cache := newSharedFetchCache(0, downloader.download)
+
+	cache.Fetch(&model.Feed{ID: 1, FeedURL: "https://example.org/feed.xml"})
+	cache.Fetch(&model.Feed{ID: 2, FeedURL: "https://example.org/feed.xml"})
+
+	if downloader.count() != 2 {
+		t.Errorf(`The downloads should not be shared, got %d downloads`, downloader.count())
+	}
+}
+
+func TestSharedFetchWithConcurrentRefreshes(t *testing.T) {
+	config.Opts = config.NewOptions()
+	downloader := &fakeDownloader{statusCode: 200, delay: 50 * time.Millisecond}
+	cache := newSharedFetchCache(time.Minute, downloader.download)
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(1)
+		go func(feedID int64) {
+			defer wg.Done()
+			fetch := cache.Fetch(&model.Feed{ID: feedID, UserID: feedID, FeedURL: "https://example.org/feed.xml"})
+			if _, err := fetch.Entries(); err != nil {
+				t.Error(err)
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+
+	if downloader.count() != 1 {
+		t.Errorf(`Concurrent refreshes should wait for the download in progress, got %d downloads`, downloader.count())
+	}
+}
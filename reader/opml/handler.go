@@ -12,6 +12,7 @@ import (
 	"miniflux.app/logger"
 	"miniflux.app/model"
 	"miniflux.app/storage"
+	"miniflux.app/validator"
 )
 
 // Handler handles the logic for OPML import/export.
@@ -19,7 +20,7 @@ type Handler struct {
 	store *storage.Storage
 }
 
-// Export exports user feeds to OPML.
+// Export exports user feeds and their settings to OPML.
 func (h *Handler) Export(userID int64) (string, error) {
 	feeds, err := h.store.Feeds(userID)
 	if err != nil {
@@ -33,6 +34,7 @@ func (h *Handler) Export(userID int64) (string, error) {
 			FeedURL:      feed.FeedURL,
 			SiteURL:      feed.SiteURL,
 			CategoryName: feed.Category.Title,
+			Settings:     NewFeedSettings(feed),
 		})
 	}
 
@@ -40,52 +42,106 @@ func (h *Handler) Export(userID int64) (string, error) {
 }
 
 // Import parses and create feeds from an OPML import.
-func (h *Handler) Import(userID int64, data io.Reader) error {
+// The report contains the outcome of each subscription found in the file.
+func (h *Handler) Import(userID int64, data io.Reader) (ImportReport, error) {
 	subscriptions, err := Parse(data)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	categories := make(map[string]*model.Category)
+	var report ImportReport
+
 	for _, subscription := range subscriptions {
-		if !h.store.FeedURLExists(userID, subscription.FeedURL) {
-			var category *model.Category
-			var err error
-
-			if subscription.CategoryName == "" {
-				category, err = h.store.FirstCategory(userID)
-				if err != nil {
-					logger.Error("[OPML:Import] %v", err)
-					return errors.New("unable to find first category")
-				}
-			} else {
-				category, err = h.store.CategoryByTitle(userID, subscription.CategoryName)
-				if err != nil {
-					logger.Error("[OPML:Import] %v", err)
-					return errors.New("unable to search category by title")
-				}
-
-				if category == nil {
-					category, err = h.store.CreateCategory(userID, &model.CategoryRequest{Title: subscription.CategoryName})
-					if err != nil {
-						logger.Error("[OPML:Import] %v", err)
-						return fmt.Errorf(`unable to create this category: %q`, subscription.CategoryName)
-					}
-				}
-			}
+		result := &ImportResult{
+			Title:    subscription.Title,
+			FeedURL:  subscription.FeedURL,
+			Category: subscription.CategoryName,
+			Status:   ImportStatusCreated,
+		}
+		report = append(report, result)
 
-			feed := &model.Feed{
-				UserID:   userID,
-				Title:    subscription.Title,
-				FeedURL:  subscription.FeedURL,
-				SiteURL:  subscription.SiteURL,
-				Category: category,
-			}
+		if h.store.FeedURLExists(userID, subscription.FeedURL) {
+			result.Status = ImportStatusDuplicate
+			continue
+		}
+
+		category, err := h.findOrCreateCategory(userID, subscription.CategoryName, categories)
+		if err == nil {
+			_, err = h.createFeed(userID, subscription, category)
+		}
+
+		if err != nil {
+			logger.Error("[OPML:Import] %q: %v", subscription.FeedURL, err)
+			result.Status = ImportStatusFailed
+			result.Error = err.Error()
+		}
+	}
+
+	return report, nil
+}
+
+func (h *Handler) createFeed(userID int64, subscription *Subcription, category *model.Category) (*model.Feed, error) {
+	if !validator.IsValidURL(subscription.FeedURL) {
+		return nil, errors.New("invalid feed URL")
+	}
+
+	if subscription.Settings.BlocklistRules != "" && !validator.IsValidRegex(subscription.Settings.BlocklistRules) {
+		return nil, errors.New("invalid blocklist rules")
+	}
 
-			h.store.CreateFeed(feed)
+	if subscription.Settings.KeeplistRules != "" && !validator.IsValidRegex(subscription.Settings.KeeplistRules) {
+		return nil, errors.New("invalid keeplist rules")
+	}
+
+	feed := &model.Feed{
+		UserID:   userID,
+		Title:    subscription.Title,
+		FeedURL:  subscription.FeedURL,
+		SiteURL:  subscription.SiteURL,
+		Category: category,
+	}
+	subscription.Settings.Apply(feed)
+
+	if err := h.store.CreateFeed(feed); err != nil {
+		return nil, fmt.Errorf("unable to create the feed: %v", err)
+	}
+
+	return feed, nil
+}
+
+func (h *Handler) findOrCreateCategory(userID int64, title string, categories map[string]*model.Category) (*model.Category, error) {
+	if category, found := categories[title]; found {
+		return category, nil
+	}
+
+	var category *model.Category
+	var err error
+
+	if title == "" {
+		category, err = h.store.FirstCategory(userID)
+		if err != nil {
+			logger.Error("[OPML:Import] %v", err)
+			return nil, errors.New("unable to find first category")
+		}
+	} else {
+		category, err = h.store.CategoryByTitle(userID, title)
+		if err != nil {
+			logger.Error("[OPML:Import] %v", err)
+			return nil, errors.New("unable to search category by title")
+		}
+
+		if category == nil {
+			category, err = h.store.CreateCategory(userID, &model.CategoryRequest{Title: title})
+			if err != nil {
+				logger.Error("[OPML:Import] %v", err)
+				return nil, fmt.Errorf(`unable to create this category: %q`, title)
+			}
 		}
 	}
 
-	return nil
+	categories[title] = category
+	return category, nil
 }
 
 // NewHandler creates a new handler for OPML files.
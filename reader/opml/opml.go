@@ -9,12 +9,18 @@ import (
 	"strings"
 )
 
+const (
+	namespaceURL    = "https://miniflux.app/opml"
+	namespacePrefix = "miniflux"
+)
+
 // Specs: http://opml.org/spec2.opml
 type opmlDocument struct {
-	XMLName  xml.Name              `xml:"opml"`
-	Version  string                `xml:"version,attr"`
-	Header   opmlHeader            `xml:"head"`
-	Outlines opmlOutlineCollection `xml:"body>outline"`
+	XMLName   xml.Name              `xml:"opml"`
+	Version   string                `xml:"version,attr"`
+	Namespace string                `xml:"xmlns:miniflux,attr,omitempty"`
+	Header    opmlHeader            `xml:"head"`
+	Outlines  opmlOutlineCollection `xml:"body>outline"`
 }
 
 func NewOPMLDocument() *opmlDocument {
@@ -33,6 +39,9 @@ type opmlOutline struct {
 	FeedURL  string                `xml:"xmlUrl,attr,omitempty"`
 	SiteURL  string                `xml:"htmlUrl,attr,omitempty"`
 	Outlines opmlOutlineCollection `xml:"outline,omitempty"`
+
+	// Attrs holds the feed settings, they are namespaced to be ignored by other feed readers.
+	Attrs []xml.Attr `xml:",any,attr"`
 }
 
 func (o *opmlOutline) IsSubscription() bool {
@@ -59,6 +68,23 @@ func (o *opmlOutline) GetTitle() string {
 	return ""
 }
 
+// GetAttr returns the value of a Miniflux attribute.
+func (o *opmlOutline) GetAttr(name string) string {
+	for _, attr := range o.Attrs {
+		if attr.Name.Local == name && (attr.Name.Space == namespaceURL || attr.Name.Space == namespacePrefix) {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// SetAttr adds a Miniflux attribute, empty values are omitted.
+func (o *opmlOutline) SetAttr(name, value string) {
+	if value != "" {
+		o.Attrs = append(o.Attrs, xml.Attr{Name: xml.Name{Local: namespacePrefix + ":" + name}, Value: value})
+	}
+}
+
 func (o *opmlOutline) GetSiteURL() string {
 	if o.SiteURL != "" {
 		return o.SiteURL
@@ -7,6 +7,7 @@ package opml // import "miniflux.app/reader/opml"
 import (
 	"encoding/xml"
 	"io"
+	"strings"
 
 	"miniflux.app/errors"
 	"miniflux.app/reader/encoding"
@@ -36,10 +37,23 @@ func getSubscriptionsFromOutlines(outlines opmlOutlineCollection, category strin
 				FeedURL:      outline.FeedURL,
 				SiteURL:      outline.GetSiteURL(),
 				CategoryName: category,
+				Settings:     readFeedSettings(&outline),
 			})
 		} else if outline.Outlines.HasChildren() {
-			subscriptions = append(subscriptions, getSubscriptionsFromOutlines(outline.Outlines, outline.Text)...)
+			subscriptions = append(subscriptions, getSubscriptionsFromOutlines(outline.Outlines, nestedCategory(category, outline.Text))...)
 		}
 	}
 	return subscriptions
 }
+
+func nestedCategory(parent, title string) string {
+	title = strings.TrimSpace(title)
+	switch {
+	case parent == "":
+		return title
+	case title == "":
+		return parent
+	default:
+		return parent + CategorySeparator + title
+	}
+}
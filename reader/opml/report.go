@@ -0,0 +1,46 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package opml // import "miniflux.app/reader/opml"
+
+// Import statuses.
+const (
+	ImportStatusCreated   = "created"
+	ImportStatusDuplicate = "duplicate"
+	ImportStatusFailed    = "failed"
+)
+
+// ImportResult is the outcome of the import of an OPML outline.
+type ImportResult struct {
+	Title    string `json:"title"`
+	FeedURL  string `json:"feed_url"`
+	Category string `json:"category"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ImportReport lists the outcome of each outline of an OPML import.
+type ImportReport []*ImportResult
+
+// Count returns the number of outlines with the given status.
+func (r ImportReport) Count(status string) int {
+	count := 0
+	for _, result := range r {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Failures returns the outlines that could not be imported.
+func (r ImportReport) Failures() ImportReport {
+	var failures ImportReport
+	for _, result := range r {
+		if result.Status == ImportStatusFailed {
+			failures = append(failures, result)
+		}
+	}
+	return failures
+}
@@ -45,22 +45,57 @@ func convertSubscriptionsToOPML(subscriptions SubcriptionList) *opmlDocument {
 	sort.Strings(categories)
 
 	for _, categoryName := range categories {
-		category := opmlOutline{Text: categoryName}
+		category := findOrCreateCategoryOutline(&opmlDocument.Outlines, splitCategory(categoryName))
 		for _, subscription := range groupedSubs[categoryName] {
-			category.Outlines = append(category.Outlines, opmlOutline{
+			outline := opmlOutline{
 				Title:   subscription.Title,
 				Text:    subscription.Title,
 				FeedURL: subscription.FeedURL,
 				SiteURL: subscription.SiteURL,
-			})
-		}
+			}
+			subscription.Settings.writeAttrs(&outline)
+
+			if len(outline.Attrs) > 0 {
+				opmlDocument.Namespace = namespaceURL
+			}
 
-		opmlDocument.Outlines = append(opmlDocument.Outlines, category)
+			if category == nil {
+				opmlDocument.Outlines = append(opmlDocument.Outlines, outline)
+			} else {
+				category.Outlines = append(category.Outlines, outline)
+			}
+		}
 	}
 
 	return opmlDocument
 }
 
+// findOrCreateCategoryOutline returns the outline of a nested category, e.g. "News / Tech" is exported as
+// a "Tech" outline inside of a "News" outline.
+func findOrCreateCategoryOutline(outlines *opmlOutlineCollection, path []string) *opmlOutline {
+	if len(path) == 0 {
+		return nil
+	}
+
+	for i := range *outlines {
+		outline := &(*outlines)[i]
+		if !outline.IsSubscription() && outline.Text == path[0] {
+			if len(path) == 1 {
+				return outline
+			}
+			return findOrCreateCategoryOutline(&outline.Outlines, path[1:])
+		}
+	}
+
+	*outlines = append(*outlines, opmlOutline{Text: path[0]})
+	outline := &(*outlines)[len(*outlines)-1]
+	if len(path) == 1 {
+		return outline
+	}
+
+	return findOrCreateCategoryOutline(&outline.Outlines, path[1:])
+}
+
 func groupSubscriptionsByFeed(subscriptions SubcriptionList) map[string]SubcriptionList {
 	groups := make(map[string]SubcriptionList)
 
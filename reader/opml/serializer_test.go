@@ -6,6 +6,7 @@ package opml // import "miniflux.app/reader/opml"
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 )
 
@@ -62,3 +63,96 @@ func TestNormalizedCategoriesOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestSerializeFeedSettings(t *testing.T) {
+	settings := FeedSettings{
+		Crawler:        true,
+		ScraperRules:   "article",
+		RewriteRules:   "add_dynamic_image",
+		BlocklistRules: "(?i)sponsored",
+		KeeplistRules:  "golang",
+		UserAgent:      "Custom Agent",
+		Disabled:       true,
+		HideGlobally:   true,
+		FetchViaProxy:  true,
+	}
+
+	var subscriptions SubcriptionList
+	subscriptions = append(subscriptions, &Subcription{Title: "Feed 1", FeedURL: "http://example.org/feed/1", SiteURL: "http://example.org/1", CategoryName: "Category 1", Settings: settings})
+	subscriptions = append(subscriptions, &Subcription{Title: "Feed 2", FeedURL: "http://example.org/feed/2", SiteURL: "http://example.org/2", CategoryName: "Category 1"})
+
+	output := Serialize(subscriptions)
+	if !strings.Contains(output, `xmlns:miniflux="https://miniflux.app/opml"`) {
+		t.Errorf(`The Miniflux namespace is not declared: %s`, output)
+	}
+
+	if !strings.Contains(output, `miniflux:crawler="true"`) {
+		t.Errorf(`The feed settings are not serialized: %s`, output)
+	}
+
+	feeds, err := Parse(bytes.NewBufferString(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range subscriptions {
+		if !feeds[i].Equals(subscriptions[i]) {
+			t.Errorf(`Subscription is different: "%v" vs "%v"`, feeds[i], subscriptions[i])
+		}
+	}
+}
+
+func TestSerializeNestedCategories(t *testing.T) {
+	var subscriptions SubcriptionList
+	subscriptions = append(subscriptions, &Subcription{Title: "Feed 1", FeedURL: "http://example.org/feed/1", SiteURL: "http://example.org/1", CategoryName: "News"})
+	subscriptions = append(subscriptions, &Subcription{Title: "Feed 2", FeedURL: "http://example.org/feed/2", SiteURL: "http://example.org/2", CategoryName: "News / Tech"})
+	subscriptions = append(subscriptions, &Subcription{Title: "Feed 3", FeedURL: "http://example.org/feed/3", SiteURL: "http://example.org/3", CategoryName: "News / Tech / Go"})
+
+	document := convertSubscriptionsToOPML(subscriptions)
+	if len(document.Outlines) != 1 || document.Outlines[0].Text != "News" {
+		t.Fatalf(`Unexpected outlines: %+v`, document.Outlines)
+	}
+
+	news := document.Outlines[0]
+	if len(news.Outlines) != 2 || news.Outlines[1].Text != "Tech" {
+		t.Fatalf(`Unexpected nested outlines: %+v`, news.Outlines)
+	}
+
+	feeds, err := Parse(bytes.NewBufferString(Serialize(subscriptions)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range subscriptions {
+		if !feeds[i].Equals(subscriptions[i]) {
+			t.Errorf(`Subscription is different: "%v" vs "%v"`, feeds[i], subscriptions[i])
+		}
+	}
+}
+
+func TestSerializeCategoriesWithSlash(t *testing.T) {
+	var subscriptions SubcriptionList
+	subscriptions = append(subscriptions, &Subcription{Title: "Feed 1", FeedURL: "http://example.org/feed/1", SiteURL: "http://example.org/1", CategoryName: "AC/DC"})
+	subscriptions = append(subscriptions, &Subcription{Title: "Feed 2", FeedURL: "http://example.org/feed/2", SiteURL: "http://example.org/2", CategoryName: "Music / AC/DC"})
+
+	document := convertSubscriptionsToOPML(subscriptions)
+	if len(document.Outlines) != 2 || document.Outlines[0].Text != "AC/DC" || document.Outlines[1].Text != "Music" {
+		t.Fatalf(`Unexpected outlines: %+v`, document.Outlines)
+	}
+
+	music := document.Outlines[1]
+	if len(music.Outlines) != 1 || music.Outlines[0].Text != "AC/DC" {
+		t.Fatalf(`Unexpected nested outlines: %+v`, music.Outlines)
+	}
+
+	feeds, err := Parse(bytes.NewBufferString(Serialize(subscriptions)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range subscriptions {
+		if !feeds[i].Equals(subscriptions[i]) {
+			t.Errorf(`Subscription is different: "%v" vs "%v"`, feeds[i], subscriptions[i])
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package opml // import "miniflux.app/reader/opml"
+
+import (
+	"strconv"
+	"strings"
+
+	"miniflux.app/model"
+)
+
+// CategorySeparator joins the titles of nested OPML outlines into a category title.
+const CategorySeparator = " / "
+
+// NewFeedSettings returns the settings of a feed.
+func NewFeedSettings(feed *model.Feed) FeedSettings {
+	return FeedSettings{
+		Crawler:                     feed.Crawler,
+		ScraperRules:                feed.ScraperRules,
+		RewriteRules:                feed.RewriteRules,
+		UrlRewriteRules:             feed.UrlRewriteRules,
+		BlocklistRules:              feed.BlocklistRules,
+		KeeplistRules:               feed.KeeplistRules,
+		UserAgent:                   feed.UserAgent,
+		Disabled:                    feed.Disabled,
+		HideGlobally:                feed.HideGlobally,
+		FetchViaProxy:               feed.FetchViaProxy,
+		IgnoreHTTPCache:             feed.IgnoreHTTPCache,
+		AllowSelfSignedCertificates: feed.AllowSelfSignedCertificates,
+	}
+}
+
+// Apply copies the settings to a feed.
+func (s FeedSettings) Apply(feed *model.Feed) {
+	feed.Crawler = s.Crawler
+	feed.ScraperRules = s.ScraperRules
+	feed.RewriteRules = s.RewriteRules
+	feed.UrlRewriteRules = s.UrlRewriteRules
+	feed.BlocklistRules = s.BlocklistRules
+	feed.KeeplistRules = s.KeeplistRules
+	feed.UserAgent = s.UserAgent
+	feed.Disabled = s.Disabled
+	feed.HideGlobally = s.HideGlobally
+	feed.FetchViaProxy = s.FetchViaProxy
+	feed.IgnoreHTTPCache = s.IgnoreHTTPCache
+	feed.AllowSelfSignedCertificates = s.AllowSelfSignedCertificates
+}
+
+func (s FeedSettings) writeAttrs(outline *opmlOutline) {
+	outline.SetAttr("crawler", formatBool(s.Crawler))
+	outline.SetAttr("scraperRules", s.ScraperRules)
+	outline.SetAttr("rewriteRules", s.RewriteRules)
+	outline.SetAttr("urlRewriteRules", s.UrlRewriteRules)
+	outline.SetAttr("blocklistRules", s.BlocklistRules)
+	outline.SetAttr("keeplistRules", s.KeeplistRules)
+	outline.SetAttr("userAgent", s.UserAgent)
+	outline.SetAttr("disabled", formatBool(s.Disabled))
+	outline.SetAttr("hideGlobally", formatBool(s.HideGlobally))
+	outline.SetAttr("fetchViaProxy", formatBool(s.FetchViaProxy))
+	outline.SetAttr("ignoreHttpCache", formatBool(s.IgnoreHTTPCache))
+	outline.SetAttr("allowSelfSignedCertificates", formatBool(s.AllowSelfSignedCertificates))
+}
+
+func readFeedSettings(outline *opmlOutline) FeedSettings {
+	return FeedSettings{
+		Crawler:                     parseBool(outline.GetAttr("crawler")),
+		ScraperRules:                outline.GetAttr("scraperRules"),
+		RewriteRules:                outline.GetAttr("rewriteRules"),
+		UrlRewriteRules:             outline.GetAttr("urlRewriteRules"),
+		BlocklistRules:              outline.GetAttr("blocklistRules"),
+		KeeplistRules:               outline.GetAttr("keeplistRules"),
+		UserAgent:                   outline.GetAttr("userAgent"),
+		Disabled:                    parseBool(outline.GetAttr("disabled")),
+		HideGlobally:                parseBool(outline.GetAttr("hideGlobally")),
+		FetchViaProxy:               parseBool(outline.GetAttr("fetchViaProxy")),
+		IgnoreHTTPCache:             parseBool(outline.GetAttr("ignoreHttpCache")),
+		AllowSelfSignedCertificates: parseBool(outline.GetAttr("allowSelfSignedCertificates")),
+	}
+}
+
+// splitCategory returns the titles of the nested outlines of a category.
+func splitCategory(categoryName string) []string {
+	var parts []string
+	for _, part := range strings.Split(categoryName, CategorySeparator) {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+func formatBool(value bool) string {
+	if value {
+		return "true"
+	}
+	return ""
+}
+
+func parseBool(value string) bool {
+	b, _ := strconv.ParseBool(value)
+	return b
+}
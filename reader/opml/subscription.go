@@ -10,12 +10,31 @@ type Subcription struct {
 	SiteURL      string
 	FeedURL      string
 	CategoryName string
+	Settings     FeedSettings
+}
+
+// FeedSettings holds the Miniflux settings of a feed.
+// Credentials and cookies are never exported.
+type FeedSettings struct {
+	Crawler                     bool
+	ScraperRules                string
+	RewriteRules                string
+	UrlRewriteRules             string
+	BlocklistRules              string
+	KeeplistRules               string
+	UserAgent                   string
+	Disabled                    bool
+	HideGlobally                bool
+	FetchViaProxy               bool
+	IgnoreHTTPCache             bool
+	AllowSelfSignedCertificates bool
 }
 
 // Equals compare two subscriptions.
 func (s Subcription) Equals(subscription *Subcription) bool {
 	return s.Title == subscription.Title && s.SiteURL == subscription.SiteURL &&
-		s.FeedURL == subscription.FeedURL && s.CategoryName == subscription.CategoryName
+		s.FeedURL == subscription.FeedURL && s.CategoryName == subscription.CategoryName &&
+		s.Settings == subscription.Settings
 }
 
 // SubcriptionList is a list of subscriptions.
@@ -0,0 +1,181 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package opml // import "miniflux.app/reader/opml"
+
+import (
+	"fmt"
+
+	"miniflux.app/config"
+	"miniflux.app/http/client"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+)
+
+// SyncAll synchronizes the remote OPML files of all users.
+func (h *Handler) SyncAll() {
+	subscriptions, err := h.store.AllOPMLSubscriptions()
+	if err != nil {
+		logger.Error("[OPML:Sync] %v", err)
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		h.Sync(subscription)
+	}
+}
+
+// Sync creates the feeds added to a remote OPML file and applies the removal policy
+// to the feeds that are no longer listed. Nil is returned when the file did not change.
+func (h *Handler) Sync(subscription *model.OPMLSubscription) *model.OPMLSubscriptionSync {
+	logger.Debug("[OPML:Sync] Fetching %q for User #%d", subscription.URL, subscription.UserID)
+
+	sync := &model.OPMLSubscriptionSync{SubscriptionID: subscription.ID}
+	modified, err := h.sync(subscription, sync)
+	if err != nil {
+		logger.Error("[OPML:Sync] %q: %v", subscription.URL, err)
+		sync.ErrorMsg = err.Error()
+	}
+
+	if err := h.store.UpdateOPMLSubscriptionCheckedAt(subscription); err != nil {
+		logger.Error("[OPML:Sync] %v", err)
+	}
+
+	if err == nil && !modified {
+		return nil
+	}
+
+	if err := h.store.CreateOPMLSubscriptionSync(sync); err != nil {
+		logger.Error("[OPML:Sync] %v", err)
+	}
+
+	return sync
+}
+
+func (h *Handler) sync(subscription *model.OPMLSubscription, sync *model.OPMLSubscriptionSync) (bool, error) {
+	clt := client.NewClientWithConfig(subscription.URL, config.Opts)
+	clt.WithCacheHeaders(subscription.EtagHeader, subscription.LastModifiedHeader)
+
+	response, err := clt.Get()
+	if err != nil {
+		return false, err
+	}
+
+	if response.HasServerFailure() {
+		return false, fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+
+	if !response.IsModified(subscription.EtagHeader, subscription.LastModifiedHeader) {
+		return false, nil
+	}
+
+	subscriptions, parseErr := Parse(response.Body)
+	if parseErr != nil {
+		return false, parseErr
+	}
+
+	var mappedCategory *model.Category
+	if subscription.CategoryID != nil {
+		mappedCategory, err = h.store.Category(subscription.UserID, *subscription.CategoryID)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	linkedFeeds, err := h.store.OPMLSubscriptionFeeds(subscription.ID)
+	if err != nil {
+		return false, err
+	}
+
+	linkedFeedsByURL := make(map[string]*model.OPMLSubscriptionFeed)
+	for _, linkedFeed := range linkedFeeds {
+		linkedFeedsByURL[linkedFeed.FeedURL] = linkedFeed
+	}
+
+	categories := make(map[string]*model.Category)
+	listedURLs := make(map[string]bool)
+
+	for _, outline := range subscriptions {
+		listedURLs[outline.FeedURL] = true
+
+		if linkedFeed, found := linkedFeedsByURL[outline.FeedURL]; found {
+			// The feed was added back to the list.
+			if linkedFeed.Disabled {
+				if err := h.store.SetOPMLSubscriptionFeedDisabled(subscription.UserID, subscription.ID, linkedFeed.FeedID, false); err != nil {
+					logger.Error("[OPML:Sync] %v", err)
+				}
+			}
+			continue
+		}
+
+		// Feeds subscribed manually are never modified.
+		if h.store.FeedURLExists(subscription.UserID, outline.FeedURL) {
+			continue
+		}
+
+		category := mappedCategory
+		if category == nil {
+			category, err = h.findOrCreateCategory(subscription.UserID, outline.CategoryName, categories)
+		}
+
+		// The settings of a remote file are chosen by whoever publishes it,
+		// only the title, the URLs and the category of the feeds are kept.
+		outline.Settings = FeedSettings{}
+
+		var feed *model.Feed
+		if err == nil {
+			feed, err = h.createFeed(subscription.UserID, outline, category)
+		}
+
+		if err == nil {
+			err = h.store.AddOPMLSubscriptionFeed(subscription.ID, feed.ID)
+		}
+
+		if err != nil {
+			logger.Error("[OPML:Sync] %q: %v", outline.FeedURL, err)
+			sync.Failed++
+			continue
+		}
+
+		sync.Created++
+	}
+
+	// An empty file is more likely a publishing mistake than a request to drop every feed.
+	if len(subscriptions) > 0 {
+		h.applyRemovalPolicy(subscription, linkedFeeds, listedURLs, sync)
+	}
+
+	subscription.EtagHeader = response.ETag
+	subscription.LastModifiedHeader = response.LastModified
+	return true, nil
+}
+
+func (h *Handler) applyRemovalPolicy(subscription *model.OPMLSubscription, linkedFeeds []*model.OPMLSubscriptionFeed, listedURLs map[string]bool, sync *model.OPMLSubscriptionSync) {
+	for _, linkedFeed := range linkedFeeds {
+		if listedURLs[linkedFeed.FeedURL] {
+			continue
+		}
+
+		switch subscription.RemovalPolicy {
+		case model.OPMLRemovalPolicyDisable:
+			if linkedFeed.Disabled {
+				continue
+			}
+
+			if err := h.store.SetOPMLSubscriptionFeedDisabled(subscription.UserID, subscription.ID, linkedFeed.FeedID, true); err != nil {
+				logger.Error("[OPML:Sync] %v", err)
+				continue
+			}
+
+			sync.Disabled++
+		case model.OPMLRemovalPolicyRemove:
+			if err := h.store.RemoveFeed(subscription.UserID, linkedFeed.FeedID); err != nil {
+				logger.Error("[OPML:Sync] %v", err)
+				continue
+			}
+
+			sync.Removed++
+		}
+	}
+}
@@ -14,8 +14,6 @@ import (
 	"time"
 	"unicode/utf8"
 
-	"miniflux.app/integration"
-
 	"miniflux.app/config"
 	"miniflux.app/http/client"
 	"miniflux.app/logger"
@@ -41,8 +39,12 @@ var (
 func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.User) {
 	var filteredEntries model.Entries
 
-	// array used for bulk push
-	entriesToPush := model.Entries{}
+	authorStatuses := make(map[string]string)
+	if preferences, err := store.AuthorPreferences(user.ID); err != nil {
+		logger.Error("[Processor] %v", err)
+	} else {
+		authorStatuses = preferences.Statuses()
+	}
 
 	for _, entry := range feed.Entries {
 		logger.Debug("[Processor] Processing entry %q from feed %q", entry.URL, feed.FeedURL)
@@ -87,32 +89,16 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, user *model.Us
 		// The sanitizer should always run at the end of the process to make sure unsafe HTML is filtered.
 		entry.Content = sanitizer.Sanitize(url, entry.Content)
 
-		if entryIsNew {
-			intg, err := store.Integration(feed.UserID)
-			if err != nil {
-				logger.Error("[Processor] Get integrations for user %d failed: %v; the refresh process will go on, but no integrations will run this time.", feed.UserID, err)
-			} else if intg != nil {
-				localEntry := entry
-				go func() {
-					integration.PushEntry(localEntry, intg)
-				}()
-				entriesToPush = append(entriesToPush, localEntry)
-			}
+		updateEntryReadingTime(store, feed, entry, entryIsNew, user)
+
+		if entryIsNew && isMutedAuthor(authorStatuses, entry) {
+			logger.Debug("[Processor] Marking entry %q from muted author %q as read", entry.URL, entry.Author)
+			entry.Status = model.EntryStatusRead
 		}
 
-		updateEntryReadingTime(store, feed, entry, entryIsNew, user)
 		filteredEntries = append(filteredEntries, entry)
 	}
 
-	intg, err := store.Integration(feed.UserID)
-	if err != nil {
-		logger.Error("[Processor] Get integrations for user %d failed: %v; the refresh process will go on, but no integrations will run this time.", feed.UserID, err)
-	} else if intg != nil && len(entriesToPush) > 0 {
-		go func() {
-			integration.PushEntries(entriesToPush, intg)
-		}()
-	}
-
 	feed.Entries = filteredEntries
 }
 
@@ -139,6 +125,14 @@ func isAllowedEntry(feed *model.Feed, entry *model.Entry) bool {
 	return true
 }
 
+// isMutedAuthor returns true if the author of the entry is muted, the statuses are indexed by normalized author.
+func isMutedAuthor(authorStatuses map[string]string, entry *model.Entry) bool {
+	if entry.Author == "" {
+		return false
+	}
+	return authorStatuses[model.NormalizeAuthor(entry.Author)] == model.AuthorStatusMuted
+}
+
 // ProcessEntryWebPage downloads the entry web page and apply rewrite rules.
 func ProcessEntryWebPage(feed *model.Feed, entry *model.Entry, user *model.User) error {
 	startTime := time.Now()
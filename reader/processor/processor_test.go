@@ -49,6 +49,31 @@ func TestAllowEntries(t *testing.T) {
 	}
 }
 
+func TestMutedAuthors(t *testing.T) {
+	statuses := model.AuthorPreferences{
+		{Author: "Jane Doe", Status: model.AuthorStatusMuted},
+		{Author: "John Doe", Status: model.AuthorStatusFollowed},
+	}.Statuses()
+
+	var scenarios = []struct {
+		author   string
+		expected bool
+	}{
+		{"Jane Doe", true},
+		{" jane DOE ", true},
+		{"John Doe", false},
+		{"Someone Else", false},
+		{"", false},
+	}
+
+	for _, tc := range scenarios {
+		result := isMutedAuthor(statuses, &model.Entry{Author: tc.author})
+		if tc.expected != result {
+			t.Errorf(`Unexpected result, got %v for author %q`, result, tc.author)
+		}
+	}
+}
+
 func TestParseISO8601(t *testing.T) {
 	var scenarios = []struct {
 		duration string
@@ -0,0 +1,8 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+/*
+Package scoring ranks the unread entries with a model learned from the past choices of each user.
+*/
+package scoring // import "miniflux.app/scoring"
@@ -0,0 +1,122 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package scoring // import "miniflux.app/scoring"
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"miniflux.app/model"
+)
+
+const (
+	// minExamples is the number of entries needed before learning anything.
+	minExamples = 20
+
+	// minWordLength skips the short words of titles, mostly articles and prepositions.
+	minWordLength = 4
+
+	// smoothing avoids infinite weights for the features seen in one class only.
+	smoothing = 1.0
+)
+
+// Features returns the properties of an entry used by the model.
+func Features(entry *model.Entry) []string {
+	features := []string{
+		fmt.Sprintf("feed:%d", entry.FeedID),
+		"reading_time:" + readingTimeBucket(entry.ReadingTime),
+	}
+
+	if author := strings.ToLower(strings.TrimSpace(entry.Author)); author != "" {
+		features = append(features, "author:"+author)
+	}
+
+	seen := make(map[string]bool)
+	words := strings.FieldsFunc(strings.ToLower(entry.Title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	for _, word := range words {
+		if utf8.RuneCountInString(word) >= minWordLength && !seen[word] {
+			seen[word] = true
+			features = append(features, "word:"+word)
+		}
+	}
+
+	return features
+}
+
+func readingTimeBucket(minutes int) string {
+	switch {
+	case minutes <= 2:
+		return "short"
+	case minutes <= 10:
+		return "medium"
+	default:
+		return "long"
+	}
+}
+
+// Model is a naive Bayes classifier telling if the user will find an entry interesting.
+type Model struct {
+	prior   float64
+	weights map[string]float64
+}
+
+// Train learns a model from the entries the user found interesting and the ones marked as read without being opened.
+// No model is returned when there are not enough examples of both kinds.
+func Train(interesting, ignored model.Entries) *Model {
+	if len(interesting)+len(ignored) < minExamples || len(interesting) == 0 || len(ignored) == 0 {
+		return nil
+	}
+
+	positives := countFeatures(interesting)
+	negatives := countFeatures(ignored)
+
+	totalPositives := float64(len(interesting))
+	totalNegatives := float64(len(ignored))
+
+	m := &Model{
+		prior:   math.Log(totalPositives / totalNegatives),
+		weights: make(map[string]float64, len(positives)+len(negatives)),
+	}
+
+	for _, counts := range []map[string]int{positives, negatives} {
+		for feature := range counts {
+			if _, found := m.weights[feature]; found {
+				continue
+			}
+
+			positive := (float64(positives[feature]) + smoothing) / (totalPositives + 2*smoothing)
+			negative := (float64(negatives[feature]) + smoothing) / (totalNegatives + 2*smoothing)
+			m.weights[feature] = math.Log(positive / negative)
+		}
+	}
+
+	return m
+}
+
+func countFeatures(entries model.Entries) map[string]int {
+	counts := make(map[string]int)
+	for _, entry := range entries {
+		for _, feature := range Features(entry) {
+			counts[feature]++
+		}
+	}
+	return counts
+}
+
+// Score returns the probability, between 0 and 100, that the user finds the entry interesting.
+func (m *Model) Score(entry *model.Entry) float64 {
+	logit := m.prior
+	for _, feature := range Features(entry) {
+		logit += m.weights[feature]
+	}
+
+	return 100 / (1 + math.Exp(-logit))
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package scoring // import "miniflux.app/scoring"
+
+import (
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestFeatures(t *testing.T) {
+	features := Features(&model.Entry{FeedID: 3, Author: " Jane Doe ", Title: "The new Go release: what's new in Go?", ReadingTime: 12})
+
+	expected := map[string]bool{
+		"feed:3":            true,
+		"reading_time:long": true,
+		"author:jane doe":   true,
+		"word:release":      true,
+		"word:what":         true,
+	}
+
+	if len(features) != len(expected) {
+		t.Fatalf(`Unexpected features: %v`, features)
+	}
+
+	for _, feature := range features {
+		if !expected[feature] {
+			t.Errorf(`Unexpected feature %q`, feature)
+		}
+	}
+}
+
+func TestTrainWithoutEnoughExamples(t *testing.T) {
+	interesting := model.Entries{{FeedID: 1}}
+	ignored := model.Entries{{FeedID: 2}}
+
+	if Train(interesting, ignored) != nil {
+		t.Error(`A model should not be trained with only two examples`)
+	}
+
+	var many model.Entries
+	for i := 0; i < minExamples; i++ {
+		many = append(many, &model.Entry{FeedID: 1})
+	}
+
+	if Train(many, nil) != nil {
+		t.Error(`A model should not be trained without ignored entries`)
+	}
+}
+
+func TestScore(t *testing.T) {
+	var interesting, ignored model.Entries
+	for i := 0; i < 15; i++ {
+		interesting = append(interesting, &model.Entry{FeedID: 1, Title: "Compiler internals explained"})
+		ignored = append(ignored, &model.Entry{FeedID: 2, Title: "Celebrity gossip of the week"})
+	}
+
+	m := Train(interesting, ignored)
+	if m == nil {
+		t.Fatal(`A model should be trained`)
+	}
+
+	liked := m.Score(&model.Entry{FeedID: 1, Title: "Compiler optimizations"})
+	disliked := m.Score(&model.Entry{FeedID: 2, Title: "Celebrity wedding"})
+	unknown := m.Score(&model.Entry{FeedID: 3, Title: "Gardening"})
+
+	if liked <= unknown || unknown <= disliked {
+		t.Errorf(`Unexpected ranking: liked=%v unknown=%v disliked=%v`, liked, unknown, disliked)
+	}
+
+	if liked > 100 || disliked < 0 {
+		t.Errorf(`Scores should be between 0 and 100, got %v and %v`, liked, disliked)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package scoring // import "miniflux.app/scoring"
+
+import (
+	"time"
+
+	"miniflux.app/logger"
+	"miniflux.app/storage"
+)
+
+// trainingDays is the age of the oldest choices used to train a model.
+const trainingDays = 90
+
+// ScoreEntries updates the score of the unread entries of the users who enabled the ranking.
+func ScoreEntries(store *storage.Storage) {
+	userIDs, err := store.UserIDsWithEntryScoring()
+	if err != nil {
+		logger.Error("[Scoring] %v", err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		if err := ScoreUserEntries(store, userID); err != nil {
+			logger.Error("[Scoring] User #%d: %v", userID, err)
+		}
+	}
+}
+
+// ScoreUserEntries trains the model of a user and updates the score of the unread entries.
+func ScoreUserEntries(store *storage.Storage, userID int64) error {
+	interesting, ignored, err := store.ScoringExamples(userID, time.Now().AddDate(0, 0, -trainingDays))
+	if err != nil {
+		return err
+	}
+
+	m := Train(interesting, ignored)
+	if m == nil {
+		logger.Debug("[Scoring] Not enough examples to rank the entries of User #%d", userID)
+		return nil
+	}
+
+	entries, err := store.EntriesToScore(userID)
+	if err != nil {
+		return err
+	}
+
+	scores := make(map[int64]float64, len(entries))
+	for _, entry := range entries {
+		scores[entry.ID] = m.Score(entry)
+	}
+
+	logger.Debug("[Scoring] Learned from %d entries to rank %d entries of User #%d", len(interesting)+len(ignored), len(entries), userID)
+	return store.UpdateEntryScores(userID, scores)
+}
@@ -0,0 +1,421 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package search // import "miniflux.app/search"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Fields of the operators written as "field:value", words and phrases have no field.
+const (
+	FieldText     = ""
+	FieldTitle    = "title"
+	FieldContent  = "content"
+	FieldAuthor   = "author"
+	FieldFeed     = "feed"
+	FieldCategory = "category"
+	FieldAfter    = "after"
+	FieldBefore   = "before"
+	FieldIs       = "is"
+	FieldHas      = "has"
+)
+
+// Values of the "is:" and "has:" operators.
+const (
+	IsStarred     = "starred"
+	IsUnstarred   = "unstarred"
+	IsRead        = "read"
+	IsUnread      = "unread"
+	HasEnclosure  = "enclosure"
+	HasComments   = "comments"
+	DateLayout    = "2006-01-02"
+	keywordOr     = "OR"
+	keywordAnd    = "AND"
+	maxQueryTerms = 32
+)
+
+var fieldValues = map[string]map[string]bool{
+	FieldIs:  {IsStarred: true, IsUnstarred: true, IsRead: true, IsUnread: true},
+	FieldHas: {HasEnclosure: true, HasComments: true},
+}
+
+// Expression is a parsed search query.
+type Expression interface {
+	String() string
+}
+
+// And matches the entries matching all the expressions.
+type And []Expression
+
+func (a And) String() string {
+	return join(a, " ")
+}
+
+// Or matches the entries matching at least one of the expressions.
+type Or []Expression
+
+func (o Or) String() string {
+	return join(o, " OR ")
+}
+
+// Not matches the entries not matching the expression.
+type Not struct {
+	Expression Expression
+}
+
+func (n Not) String() string {
+	return "-" + group(n.Expression)
+}
+
+// Term matches a word, a phrase or the value of an operator.
+// Feeds and categories are matched by ID when the value is a number, by title otherwise.
+type Term struct {
+	Field  string
+	Value  string
+	Phrase bool
+	ID     int64
+	Date   time.Time
+}
+
+func (t *Term) String() string {
+	value := t.Value
+	if t.Phrase {
+		value = strconv.Quote(value)
+	}
+
+	if t.Field == FieldText {
+		return value
+	}
+
+	return t.Field + ":" + value
+}
+
+// IsText returns true if the term is matched against the indexed title and content of entries.
+func (t *Term) IsText() bool {
+	return t.Field == FieldText || t.Field == FieldTitle || t.Field == FieldContent
+}
+
+// Error reports an operator with an invalid value.
+type Error struct {
+	Operator string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("search: invalid operator %q", e.Operator)
+}
+
+// Parse returns the expression of a search query, nil when the query is empty.
+//
+// Words and "quoted phrases" are matched against the title and the content, all terms are required
+// unless separated by OR, a leading dash excludes a term, and parentheses group terms.
+// Operators restrict a term to a field: title:, content:, author:, feed:, category:,
+// after: and before: with a YYYY-MM-DD date, is:starred, is:unstarred, is:read, is:unread,
+// has:enclosure and has:comments. Unknown operators are searched as words.
+func Parse(query string) (Expression, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	var expressions []Expression
+	for !p.done() {
+		// Parentheses closed without being opened are ignored.
+		if p.peek().kind == tokenClose {
+			p.pos++
+			continue
+		}
+		expressions = append(expressions, p.parseOr())
+	}
+
+	return newAnd(expressions), nil
+}
+
+// TextTerms returns the values of the words and phrases that entries must contain, to rank the results.
+func TextTerms(expression Expression) []string {
+	var values []string
+	switch e := expression.(type) {
+	case And:
+		for _, child := range e {
+			values = append(values, TextTerms(child)...)
+		}
+	case Or:
+		for _, child := range e {
+			values = append(values, TextTerms(child)...)
+		}
+	case *Term:
+		if e.IsText() {
+			values = append(values, e.Value)
+		}
+	}
+	return values
+}
+
+type tokenKind int
+
+const (
+	tokenTerm tokenKind = iota
+	tokenNot
+	tokenOr
+	tokenOpen
+	tokenClose
+)
+
+type token struct {
+	kind tokenKind
+	term *Term
+}
+
+func tokenize(query string) ([]token, error) {
+	var tokens []token
+	runes := []rune(query)
+	terms := 0
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenOpen})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenClose})
+			i++
+		case r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) && runes[i+1] != ')' && runes[i+1] != '-':
+			tokens = append(tokens, token{kind: tokenNot})
+			i++
+		case r == '"':
+			var phrase string
+			phrase, i = readPhrase(runes, i+1)
+			if phrase != "" {
+				terms++
+				tokens = append(tokens, token{kind: tokenTerm, term: &Term{Value: phrase, Phrase: true}})
+			}
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' && runes[i] != '"' {
+				i++
+			}
+			word := string(runes[start:i])
+
+			if word == keywordOr {
+				tokens = append(tokens, token{kind: tokenOr})
+				continue
+			}
+
+			if word == keywordAnd || strings.Trim(word, "-") == "" {
+				continue
+			}
+
+			term := &Term{Value: word}
+			if field, value, found := strings.Cut(word, ":"); found && isField(strings.ToLower(field)) {
+				term.Field = strings.ToLower(field)
+				term.Value = value
+				if value == "" && i < len(runes) && runes[i] == '"' {
+					term.Value, i = readPhrase(runes, i+1)
+					term.Phrase = true
+				}
+
+				if err := term.validate(); err != nil {
+					return nil, err
+				}
+			}
+
+			terms++
+			tokens = append(tokens, token{kind: tokenTerm, term: term})
+		}
+
+		// The following terms are ignored to keep the database query small.
+		if terms > maxQueryTerms {
+			tokens = tokens[:len(tokens)-1]
+			break
+		}
+	}
+
+	return tokens, nil
+}
+
+// readPhrase returns the text up to the closing quote or the end of the query, and the position after it.
+func readPhrase(runes []rune, start int) (string, int) {
+	end := start
+	for end < len(runes) && runes[end] != '"' {
+		end++
+	}
+
+	next := end
+	if next < len(runes) {
+		next++
+	}
+
+	return strings.Join(strings.Fields(string(runes[start:end])), " "), next
+}
+
+func (t *Term) validate() error {
+	invalid := &Error{Operator: t.String()}
+	if strings.TrimSpace(t.Value) == "" {
+		return invalid
+	}
+
+	switch t.Field {
+	case FieldFeed, FieldCategory:
+		if id, err := strconv.ParseInt(t.Value, 10, 64); err == nil {
+			if id <= 0 {
+				return invalid
+			}
+			t.ID = id
+		}
+	case FieldAfter, FieldBefore:
+		date, err := time.Parse(DateLayout, t.Value)
+		if err != nil {
+			return invalid
+		}
+		t.Date = date
+	case FieldIs, FieldHas:
+		t.Value = strings.ToLower(t.Value)
+		if !fieldValues[t.Field][t.Value] {
+			return invalid
+		}
+	}
+
+	return nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) parseOr() Expression {
+	var expressions []Expression
+	for {
+		expressions = append(expressions, p.parseAnd())
+		if p.done() || p.peek().kind != tokenOr {
+			break
+		}
+		p.pos++
+	}
+
+	return newOr(expressions)
+}
+
+func (p *parser) parseAnd() Expression {
+	var expressions []Expression
+	for !p.done() {
+		kind := p.peek().kind
+		if kind == tokenClose || kind == tokenOr {
+			break
+		}
+		expressions = append(expressions, p.parseUnary())
+	}
+
+	return newAnd(expressions)
+}
+
+func (p *parser) parseUnary() Expression {
+	t := p.peek()
+	p.pos++
+
+	switch t.kind {
+	case tokenNot:
+		if p.done() || p.peek().kind == tokenClose || p.peek().kind == tokenOr {
+			return nil
+		}
+		if expression := p.parseUnary(); expression != nil {
+			return Not{Expression: expression}
+		}
+	case tokenOpen:
+		expression := p.parseOr()
+		if !p.done() && p.peek().kind == tokenClose {
+			p.pos++
+		}
+		return expression
+	case tokenTerm:
+		return t.term
+	}
+
+	return nil
+}
+
+// newAnd returns nil without expression, the expression itself when alone, or the group of expressions.
+func newAnd(expressions []Expression) Expression {
+	var group And
+	for _, expression := range expressions {
+		switch e := expression.(type) {
+		case nil:
+		case And:
+			group = append(group, e...)
+		default:
+			group = append(group, e)
+		}
+	}
+
+	switch len(group) {
+	case 0:
+		return nil
+	case 1:
+		return group[0]
+	}
+	return group
+}
+
+// newOr returns nil without expression, the expression itself when alone, or the alternative of expressions.
+func newOr(expressions []Expression) Expression {
+	var group Or
+	for _, expression := range expressions {
+		switch e := expression.(type) {
+		case nil:
+		case Or:
+			group = append(group, e...)
+		default:
+			group = append(group, e)
+		}
+	}
+
+	switch len(group) {
+	case 0:
+		return nil
+	case 1:
+		return group[0]
+	}
+	return group
+}
+
+func join(expressions []Expression, separator string) string {
+	parts := make([]string, len(expressions))
+	for i, expression := range expressions {
+		parts[i] = group(expression)
+	}
+	return strings.Join(parts, separator)
+}
+
+// group returns the expression between parentheses when it is made of several terms.
+func group(expression Expression) string {
+	switch expression.(type) {
+	case And, Or:
+		return "(" + expression.String() + ")"
+	}
+	return expression.String()
+}
+
+func isField(field string) bool {
+	switch field {
+	case FieldTitle, FieldContent, FieldAuthor, FieldFeed, FieldCategory, FieldAfter, FieldBefore, FieldIs, FieldHas:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package search // import "miniflux.app/search"
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	scenarios := []struct {
+		query    string
+		expected string
+	}{
+		{``, ``},
+		{`   `, ``},
+		{`golang`, `golang`},
+		{`go generics`, `(go generics)`},
+		{`"go 1.22" release`, `("go 1.22" release)`},
+		{`"  spaced   phrase "`, `"spaced phrase"`},
+		{`"unterminated phrase`, `"unterminated phrase"`},
+		{`-beta`, `-beta`},
+		{`go -beta -"release candidate"`, `(go -beta -"release candidate")`},
+		{`go OR rust`, `(go OR rust)`},
+		{`go OR rust OR zig`, `(go OR rust OR zig)`},
+		{`go AND rust`, `(go rust)`},
+		{`go or rust`, `(go or rust)`},
+		{`release (go OR rust)`, `(release (go OR rust))`},
+		{`(go rust) OR zig`, `((go rust) OR zig)`},
+		{`-(go OR rust)`, `-(go OR rust)`},
+		{`((go))`, `go`},
+		{`(go rust`, `(go rust)`},
+		{`go) rust`, `(go rust)`},
+		{`OR go OR`, `go`},
+		{`go - rust`, `(go rust)`},
+		{`-`, ``},
+		{`e-mail`, `e-mail`},
+		{`title:"go 1.22" -beta author:alice feed:42 after:2024-01-01 is:starred has:enclosure`, `(title:"go 1.22" -beta author:alice feed:42 after:2024-01-01 is:starred has:enclosure)`},
+		{`TITLE:go Is:Starred`, `(title:go is:starred)`},
+		{`content:generics`, `content:generics`},
+		{`category:"Tech News"`, `category:"Tech News"`},
+		{`https://example.org/`, `https://example.org/`},
+		{`foo:bar`, `foo:bar`},
+		{`-author:bob is:unread`, `(-author:bob is:unread)`},
+	}
+
+	for _, scenario := range scenarios {
+		expression, err := Parse(scenario.query)
+		if err != nil {
+			t.Errorf(`Unexpected error for %q: %v`, scenario.query, err)
+			continue
+		}
+
+		result := ""
+		if expression != nil {
+			result = group(expression)
+		}
+
+		if result != scenario.expected {
+			t.Errorf(`Unexpected expression for %q, got %s instead of %s`, scenario.query, result, scenario.expected)
+		}
+	}
+}
+
+func TestParseOperatorValues(t *testing.T) {
+	expression, err := Parse(`feed:42 feed:"Go Blog" category:7 after:2024-01-31 before:2024-02-01`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	terms := expression.(And)
+	if term := terms[0].(*Term); term.Field != FieldFeed || term.ID != 42 {
+		t.Errorf(`Unexpected feed term: %#v`, term)
+	}
+
+	if term := terms[1].(*Term); term.Field != FieldFeed || term.ID != 0 || term.Value != "Go Blog" || !term.Phrase {
+		t.Errorf(`Unexpected feed term: %#v`, term)
+	}
+
+	if term := terms[2].(*Term); term.Field != FieldCategory || term.ID != 7 {
+		t.Errorf(`Unexpected category term: %#v`, term)
+	}
+
+	if term := terms[3].(*Term); !term.Date.Equal(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf(`Unexpected after date: %v`, term.Date)
+	}
+
+	if term := terms[4].(*Term); term.Field != FieldBefore || !term.Date.Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf(`Unexpected before term: %#v`, term)
+	}
+}
+
+func TestParseInvalidOperators(t *testing.T) {
+	queries := []string{
+		`after:yesterday`,
+		`before:2024-13-01`,
+		`is:pinned`,
+		`has:video`,
+		`feed:0`,
+		`feed:-3`,
+		`author:`,
+		`go title:""`,
+	}
+
+	for _, query := range queries {
+		_, err := Parse(query)
+		var searchErr *Error
+		if !errors.As(err, &searchErr) {
+			t.Errorf(`The query %q should be invalid, got %v`, query, err)
+		}
+	}
+}
+
+func TestParseLimitsTerms(t *testing.T) {
+	expression, err := Parse(strings.Repeat("word ", maxQueryTerms+10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count := len(expression.(And)); count != maxQueryTerms {
+		t.Errorf(`The query should be limited to %d terms, got %d`, maxQueryTerms, count)
+	}
+}
+
+func TestTextTerms(t *testing.T) {
+	expression, err := Parse(`go -beta (title:"release notes" OR content:changelog) author:alice is:unread`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := strings.Join(TextTerms(expression), "|")
+	if result != "go|release notes|changelog" {
+		t.Errorf(`Unexpected text terms: %q`, result)
+	}
+}
@@ -7,12 +7,22 @@ package scheduler // import "miniflux.app/service/scheduler"
 import (
 	"time"
 
+	"miniflux.app/cluster"
 	"miniflux.app/config"
+	"miniflux.app/digest"
+	"miniflux.app/epub"
+	"miniflux.app/integration"
 	"miniflux.app/logger"
+	"miniflux.app/mail"
 	"miniflux.app/metric"
 	"miniflux.app/model"
+	"miniflux.app/reader/opml"
+	"miniflux.app/scoring"
 	"miniflux.app/storage"
+	"miniflux.app/template"
 	"miniflux.app/worker"
+
+	"github.com/gorilla/mux"
 )
 
 // Serve starts the internal scheduler.
@@ -33,11 +43,60 @@ func Serve(store *storage.Storage, pool *worker.Pool) {
 		config.Opts.CleanupArchiveUnreadDays(),
 		config.Opts.CleanupArchiveBatchSize(),
 		config.Opts.CleanupRemoveSessionsDays(),
+		config.Opts.CleanupRemoveDeliveriesDays(),
+		config.Opts.CleanupRemoveAuditLogsDays(),
+	)
+
+	go integrationScheduler(
+		store,
+		config.Opts.IntegrationRetryFrequency(),
+		config.Opts.BatchSize(),
+	)
+
+	go notificationScheduler(
+		store,
+		config.Opts.NotificationFrequency(),
 	)
+
+	go opmlSubscriptionScheduler(
+		store,
+		config.Opts.OPMLSubscriptionFrequency(),
+	)
+
+	go entryScoringScheduler(
+		store,
+		config.Opts.EntryScoringFrequency(),
+	)
+
+	if config.Opts.StoryClustering() {
+		go storyClusteringScheduler(
+			store,
+			config.Opts.StoryClusteringFrequency(),
+			config.Opts.StoryClusteringWindowHours(),
+		)
+	}
+
+	if mail.IsEnabled() {
+		go emailDigestScheduler(
+			store,
+			config.Opts.EmailDigestFrequency(),
+		)
+	}
+
+	if mail.IsEnabled() || config.Opts.EPUBExportDirectory() != "" {
+		go epubExportScheduler(
+			store,
+			config.Opts.EPUBExportFrequency(),
+		)
+	}
 }
 
 func feedScheduler(store *storage.Storage, pool *worker.Pool, frequency, batchSize int) {
 	for range time.Tick(time.Duration(frequency) * time.Minute) {
+		if err := store.SyncGuestFeeds(); err != nil {
+			logger.Error("[Scheduler:Feed] %v", err)
+		}
+
 		jobs, err := store.NewBatch(batchSize)
 		if err != nil {
 			logger.Error("[Scheduler:Feed] %v", err)
@@ -48,12 +107,79 @@ func feedScheduler(store *storage.Storage, pool *worker.Pool, frequency, batchSi
 	}
 }
 
-func cleanupScheduler(store *storage.Storage, frequency, archiveReadDays, archiveUnreadDays, archiveBatchSize, sessionsDays int) {
+func integrationScheduler(store *storage.Storage, frequency, batchSize int) {
+	for range time.Tick(time.Duration(frequency) * time.Minute) {
+		logger.Debug("[Scheduler:Integration] Retrying failed deliveries")
+		integration.RetryDeliveries(store, batchSize)
+	}
+}
+
+func notificationScheduler(store *storage.Storage, frequency int) {
+	for range time.Tick(time.Duration(frequency) * time.Minute) {
+		logger.Debug("[Scheduler:Notification] Sending queued notifications")
+		integration.SendQueuedNotifications(store)
+	}
+}
+
+func opmlSubscriptionScheduler(store *storage.Storage, frequency int) {
+	handler := opml.NewHandler(store)
+	for range time.Tick(time.Duration(frequency) * time.Minute) {
+		logger.Debug("[Scheduler:OPMLSubscription] Synchronizing remote OPML files")
+		handler.SyncAll()
+	}
+}
+
+func storyClusteringScheduler(store *storage.Storage, frequency, windowHours int) {
+	for range time.Tick(time.Duration(frequency) * time.Minute) {
+		logger.Debug("[Scheduler:StoryClustering] Grouping the entries of the last %d hours", windowHours)
+		cluster.ClusterEntries(store, windowHours)
+	}
+}
+
+func entryScoringScheduler(store *storage.Storage, frequency int) {
+	for range time.Tick(time.Duration(frequency) * time.Minute) {
+		logger.Debug("[Scheduler:EntryScoring] Ranking the unread entries")
+		scoring.ScoreEntries(store)
+	}
+}
+
+func emailDigestScheduler(store *storage.Storage, frequency int) {
+	// The digest template only uses absolute URLs, the routes of the user interface are not needed.
+	templateEngine := template.NewEngine(mux.NewRouter())
+	if err := templateEngine.ParseTemplates(); err != nil {
+		logger.Error("[Scheduler:EmailDigest] Unable to parse templates: %v", err)
+		return
+	}
+
+	for range time.Tick(time.Duration(frequency) * time.Minute) {
+		logger.Debug("[Scheduler:EmailDigest] Sending due email digests")
+		digest.SendDueDigests(store, templateEngine)
+	}
+}
+
+func epubExportScheduler(store *storage.Storage, frequency int) {
+	for range time.Tick(time.Duration(frequency) * time.Minute) {
+		logger.Debug("[Scheduler:EPUBExport] Delivering due EPUB exports")
+		epub.DeliverDueExports(store)
+	}
+}
+
+func cleanupScheduler(store *storage.Storage, frequency, archiveReadDays, archiveUnreadDays, archiveBatchSize, sessionsDays, deliveriesDays, auditLogsDays int) {
 	for range time.Tick(time.Duration(frequency) * time.Hour) {
 		nbSessions := store.CleanOldSessions(sessionsDays)
 		nbUserSessions := store.CleanOldUserSessions(sessionsDays)
 		logger.Info("[Scheduler:Cleanup] Cleaned %d sessions and %d user sessions", nbSessions, nbUserSessions)
 
+		nbDeliveries := store.CleanOldIntegrationDeliveries(deliveriesDays)
+		logger.Info("[Scheduler:Cleanup] Cleaned %d integration deliveries", nbDeliveries)
+
+		nbAuditLogs := store.CleanOldAuditLogs(auditLogsDays)
+		logger.Info("[Scheduler:Cleanup] Cleaned %d audit log entries", nbAuditLogs)
+
+		nbInvitations := store.CleanExpiredUserInvitations()
+		nbResetTokens := store.CleanExpiredPasswordResetTokens()
+		logger.Info("[Scheduler:Cleanup] Cleaned %d invitations and %d password reset tokens", nbInvitations, nbResetTokens)
+
 		startTime := time.Now()
 		if rowsAffected, err := store.ArchiveEntries(model.EntryStatusRead, archiveReadDays, archiveBatchSize); err != nil {
 			logger.Error("[Scheduler:ArchiveReadEntries] %v", err)
@@ -62,6 +188,7 @@ func cleanupScheduler(store *storage.Storage, frequency, archiveReadDays, archiv
 
 			if config.Opts.HasMetricsCollector() {
 				metric.ArchiveEntriesDuration.WithLabelValues(model.EntryStatusRead).Observe(time.Since(startTime).Seconds())
+				metric.ArchivedEntries.WithLabelValues(model.EntryStatusRead, "removed").Add(float64(rowsAffected))
 			}
 		}
 
@@ -73,7 +200,28 @@ func cleanupScheduler(store *storage.Storage, frequency, archiveReadDays, archiv
 
 			if config.Opts.HasMetricsCollector() {
 				metric.ArchiveEntriesDuration.WithLabelValues(model.EntryStatusUnread).Observe(time.Since(startTime).Seconds())
+				metric.ArchivedEntries.WithLabelValues(model.EntryStatusUnread, "removed").Add(float64(rowsAffected))
 			}
 		}
+
+		archiveEntriesByRetention("age", archiveBatchSize, store.ArchiveEntriesByRetentionAge)
+		archiveEntriesByRetention("count", archiveBatchSize, store.ArchiveEntriesByRetentionCount)
+	}
+}
+
+func archiveEntriesByRetention(policy string, batchSize int, archive func(limit int) (int64, int64, error)) {
+	startTime := time.Now()
+	removed, deleted, err := archive(batchSize)
+	if err != nil {
+		logger.Error("[Scheduler:ArchiveEntriesByRetention] %v", err)
+		return
+	}
+
+	logger.Info("[Scheduler:ArchiveEntriesByRetention] %d entries removed and %d entries deleted by %s policies", removed, deleted, policy)
+
+	if config.Opts.HasMetricsCollector() {
+		metric.ArchiveEntriesDuration.WithLabelValues("retention_" + policy).Observe(time.Since(startTime).Seconds())
+		metric.ArchivedEntries.WithLabelValues(policy, "removed").Add(float64(removed))
+		metric.ArchivedEntries.WithLabelValues(policy, "deleted").Add(float64(deleted))
 	}
 }
@@ -5,6 +5,7 @@
 package storage // import "miniflux.app/storage"
 
 import (
+	"database/sql"
 	"fmt"
 
 	"miniflux.app/model"
@@ -66,6 +67,37 @@ func (s *Storage) APIKeys(userID int64) (model.APIKeys, error) {
 	return apiKeys, nil
 }
 
+// APIKeyByToken returns the API key of the user with the given token.
+func (s *Storage) APIKeyByToken(userID int64, token string) (*model.APIKey, error) {
+	query := `
+		SELECT
+			id, user_id, token, description, last_used_at, created_at
+		FROM
+			api_keys
+		WHERE
+			user_id=$1 AND token=$2
+	`
+
+	var apiKey model.APIKey
+	err := s.db.QueryRow(query, userID, token).Scan(
+		&apiKey.ID,
+		&apiKey.UserID,
+		&apiKey.Token,
+		&apiKey.Description,
+		&apiKey.LastUsedAt,
+		&apiKey.CreatedAt,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch API Key: %v`, err)
+	default:
+		return &apiKey, nil
+	}
+}
+
 // CreateAPIKey inserts a new API key.
 func (s *Storage) CreateAPIKey(apiKey *model.APIKey) error {
 	query := `
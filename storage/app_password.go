@@ -0,0 +1,116 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"fmt"
+
+	"miniflux.app/model"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// AppPasswordExists checks if an app password with the same description exists.
+func (s *Storage) AppPasswordExists(userID int64, description string) bool {
+	var result bool
+	query := `SELECT true FROM app_passwords WHERE user_id=$1 AND lower(description)=lower($2) LIMIT 1`
+	s.db.QueryRow(query, userID, description).Scan(&result)
+	return result
+}
+
+// AppPasswords returns the app passwords of a user.
+func (s *Storage) AppPasswords(userID int64) (model.AppPasswords, error) {
+	query := `
+		SELECT
+			id, user_id, description, last_used_at, created_at
+		FROM
+			app_passwords
+		WHERE
+			user_id=$1
+		ORDER BY description ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch app passwords: %v`, err)
+	}
+	defer rows.Close()
+
+	appPasswords := make(model.AppPasswords, 0)
+	for rows.Next() {
+		var appPassword model.AppPassword
+		if err := rows.Scan(
+			&appPassword.ID,
+			&appPassword.UserID,
+			&appPassword.Description,
+			&appPassword.LastUsedAt,
+			&appPassword.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch app password row: %v`, err)
+		}
+
+		appPasswords = append(appPasswords, &appPassword)
+	}
+
+	return appPasswords, nil
+}
+
+// CreateAppPassword stores the hash of a new app password.
+func (s *Storage) CreateAppPassword(userID int64, description, password string) error {
+	hashedPassword, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO app_passwords (user_id, description, password_hash) VALUES ($1, $2, $3)`
+	if _, err := s.db.Exec(query, userID, description, hashedPassword); err != nil {
+		return fmt.Errorf(`store: unable to create app password: %v`, err)
+	}
+
+	return nil
+}
+
+// RemoveAppPassword deletes an app password.
+func (s *Storage) RemoveAppPassword(userID, appPasswordID int64) error {
+	query := `DELETE FROM app_passwords WHERE id=$1 AND user_id=$2`
+	if _, err := s.db.Exec(query, appPasswordID, userID); err != nil {
+		return fmt.Errorf(`store: unable to remove app password: %v`, err)
+	}
+
+	return nil
+}
+
+// CheckAppPassword returns true if the password matches one of the app passwords of an active user.
+func (s *Storage) CheckAppPassword(username, password string) bool {
+	query := `
+		SELECT
+			a.id, a.password_hash
+		FROM
+			app_passwords a
+		JOIN
+			users u ON u.id=a.user_id
+		WHERE
+			u.username=LOWER($1) AND u.status='active'
+	`
+	rows, err := s.db.Query(query, username)
+	if err != nil {
+		return false
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var hash string
+		if err := rows.Scan(&id, &hash); err != nil {
+			return false
+		}
+
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			s.db.Exec(`UPDATE app_passwords SET last_used_at=now() WHERE id=$1`, id)
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"fmt"
+	"strings"
+
+	"miniflux.app/model"
+)
+
+// CreateAuditLog records an audit log entry.
+// The username of the user is copied to keep the history readable after the user removal.
+func (s *Storage) CreateAuditLog(auditLog *model.AuditLog) error {
+	query := `
+		INSERT INTO audit_logs
+			(user_id, username, source, action, target, details, ip, user_agent)
+		VALUES
+			($1, COALESCE(NULLIF($2, ''), (SELECT username FROM users WHERE id=$1), ''), $3, $4, $5, $6, $7, $8)
+		RETURNING
+			id, username, created_at
+	`
+	err := s.db.QueryRow(
+		query,
+		auditLog.UserID,
+		auditLog.Username,
+		auditLog.Source,
+		auditLog.Action,
+		auditLog.Target,
+		auditLog.Details,
+		auditLog.IP,
+		auditLog.UserAgent,
+	).Scan(
+		&auditLog.ID,
+		&auditLog.Username,
+		&auditLog.CreatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf(`store: unable to create audit log: %v`, err)
+	}
+
+	return nil
+}
+
+// AuditLogs returns the most recent audit log entries matching the filter.
+func (s *Storage) AuditLogs(filter *model.AuditLogFilter) (model.AuditLogs, error) {
+	condition, args := auditLogCondition(filter)
+	query := `
+		SELECT
+			id, user_id, username, source, action, target, details, ip, user_agent, created_at
+		FROM
+			audit_logs
+		WHERE
+			%s
+		ORDER BY
+			created_at DESC, id DESC
+		OFFSET $%d
+		LIMIT $%d
+	`
+	args = append(args, filter.Offset, filter.Limit)
+	query = fmt.Sprintf(query, condition, len(args)-1, len(args))
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch audit logs: %v`, err)
+	}
+	defer rows.Close()
+
+	auditLogs := make(model.AuditLogs, 0)
+	for rows.Next() {
+		var auditLog model.AuditLog
+		err := rows.Scan(
+			&auditLog.ID,
+			&auditLog.UserID,
+			&auditLog.Username,
+			&auditLog.Source,
+			&auditLog.Action,
+			&auditLog.Target,
+			&auditLog.Details,
+			&auditLog.IP,
+			&auditLog.UserAgent,
+			&auditLog.CreatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch audit log row: %v`, err)
+		}
+
+		auditLogs = append(auditLogs, &auditLog)
+	}
+
+	return auditLogs, nil
+}
+
+// CountAuditLogs returns the number of audit log entries matching the filter.
+func (s *Storage) CountAuditLogs(filter *model.AuditLogFilter) (int, error) {
+	condition, args := auditLogCondition(filter)
+
+	var count int
+	err := s.db.QueryRow(`SELECT count(*) FROM audit_logs WHERE `+condition, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to count audit logs: %v`, err)
+	}
+
+	return count, nil
+}
+
+// CleanOldAuditLogs removes the audit log entries older than the given number of days.
+func (s *Storage) CleanOldAuditLogs(days int) int64 {
+	query := `
+		DELETE FROM
+			audit_logs
+		WHERE
+			created_at < now() - interval '%d days'
+	`
+	result, err := s.db.Exec(fmt.Sprintf(query, days))
+	if err != nil {
+		return 0
+	}
+
+	n, _ := result.RowsAffected()
+	return n
+}
+
+func auditLogCondition(filter *model.AuditLogFilter) (string, []interface{}) {
+	conditions := []string{"1=1"}
+	var args []interface{}
+
+	if filter.UserID > 0 {
+		args = append(args, filter.UserID)
+		conditions = append(conditions, fmt.Sprintf("user_id=$%d", len(args)))
+	}
+
+	if filter.Username != "" {
+		args = append(args, filter.Username)
+		conditions = append(conditions, fmt.Sprintf("lower(username)=lower($%d)", len(args)))
+	}
+
+	if filter.Source != "" {
+		args = append(args, filter.Source)
+		conditions = append(conditions, fmt.Sprintf("source=$%d", len(args)))
+	}
+
+	if filter.Action != "" {
+		args = append(args, filter.Action)
+		conditions = append(conditions, fmt.Sprintf("action=$%d", len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"miniflux.app/model"
+)
+
+// AuthorPreferences returns the authors followed or muted by a user.
+func (s *Storage) AuthorPreferences(userID int64) (model.AuthorPreferences, error) {
+	query := `
+		SELECT
+			id, user_id, author, status, created_at
+		FROM
+			author_preferences
+		WHERE
+			user_id=$1
+		ORDER BY
+			status ASC, lower(author) ASC
+	`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch author preferences: %v`, err)
+	}
+	defer rows.Close()
+
+	preferences := make(model.AuthorPreferences, 0)
+	for rows.Next() {
+		var preference model.AuthorPreference
+		if err := rows.Scan(&preference.ID, &preference.UserID, &preference.Author, &preference.Status, &preference.CreatedAt); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch author preference row: %v`, err)
+		}
+		preferences = append(preferences, &preference)
+	}
+
+	return preferences, nil
+}
+
+// AuthorPreference returns the preference of a user for the given author.
+func (s *Storage) AuthorPreference(userID int64, author string) (*model.AuthorPreference, error) {
+	query := `
+		SELECT
+			id, user_id, author, status, created_at
+		FROM
+			author_preferences
+		WHERE
+			user_id=$1 AND lower(author)=lower($2)
+	`
+	var preference model.AuthorPreference
+	err := s.db.QueryRow(query, userID, strings.TrimSpace(author)).Scan(
+		&preference.ID,
+		&preference.UserID,
+		&preference.Author,
+		&preference.Status,
+		&preference.CreatedAt,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch author preference: %v`, err)
+	}
+
+	return &preference, nil
+}
+
+// SetAuthorStatus follows or mutes an author, the previous status of the author is replaced.
+func (s *Storage) SetAuthorStatus(userID int64, author, status string) error {
+	query := `
+		INSERT INTO author_preferences
+			(user_id, author, status)
+		VALUES
+			($1, $2, $3)
+		ON CONFLICT (user_id, lower(author)) DO UPDATE SET
+			status=EXCLUDED.status
+	`
+	if _, err := s.db.Exec(query, userID, strings.TrimSpace(author), status); err != nil {
+		return fmt.Errorf(`store: unable to set author status: %v`, err)
+	}
+
+	return nil
+}
+
+// RemoveAuthorPreference stops following or muting an author.
+func (s *Storage) RemoveAuthorPreference(userID int64, author string) error {
+	query := `DELETE FROM author_preferences WHERE user_id=$1 AND lower(author)=lower($2)`
+	if _, err := s.db.Exec(query, userID, strings.TrimSpace(author)); err != nil {
+		return fmt.Errorf(`store: unable to remove author preference: %v`, err)
+	}
+
+	return nil
+}
+
+// MarkAuthorEntriesAsRead marks all unread entries of an author as read.
+func (s *Storage) MarkAuthorEntriesAsRead(userID int64, author string) error {
+	query := `
+		UPDATE
+			entries
+		SET
+			status='read',
+			changed_at=now()
+		WHERE
+			user_id=$1 AND status='unread' AND lower(author)=lower($2)
+	`
+	if _, err := s.db.Exec(query, userID, strings.TrimSpace(author)); err != nil {
+		return fmt.Errorf(`store: unable to mark author entries as read: %v`, err)
+	}
+
+	return nil
+}
@@ -108,7 +108,7 @@ func (s *Storage) UserCredentialsByUsername(username string) (*model.UserCredent
 	if err != nil {
 		return nil, err
 	}
-	if user == nil {
+	if user == nil || !user.IsActive() {
 		return nil, errors.NewLocalizedError("error.bad_credentials")
 	}
 	return s.fetchUserCredentials(user)
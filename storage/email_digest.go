@@ -0,0 +1,167 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/model"
+
+	"github.com/lib/pq"
+)
+
+// EmailDigest returns the email digest preferences of a user.
+// Default preferences are returned if the user never configured the digest.
+func (s *Storage) EmailDigest(userID int64) (*model.EmailDigest, error) {
+	query := `
+		SELECT
+			user_id, enabled, email, frequency, hour, weekday, category_ids, token, sent_at
+		FROM
+			email_digests
+		WHERE
+			user_id=$1
+	`
+	digest, err := s.fetchEmailDigest(query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if digest == nil {
+		return model.NewEmailDigest(userID), nil
+	}
+
+	return digest, nil
+}
+
+// EmailDigestByToken returns the email digest preferences associated to an unsubscribe token.
+func (s *Storage) EmailDigestByToken(token string) (*model.EmailDigest, error) {
+	query := `
+		SELECT
+			user_id, enabled, email, frequency, hour, weekday, category_ids, token, sent_at
+		FROM
+			email_digests
+		WHERE
+			token=$1
+	`
+	return s.fetchEmailDigest(query, token)
+}
+
+// EnabledEmailDigests returns the email digest preferences of all subscribed users.
+func (s *Storage) EnabledEmailDigests() (model.EmailDigests, error) {
+	query := `
+		SELECT
+			user_id, enabled, email, frequency, hour, weekday, category_ids, token, sent_at
+		FROM
+			email_digests
+		WHERE
+			enabled='t' AND email<>''
+	`
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch email digests: %v`, err)
+	}
+	defer rows.Close()
+
+	digests := make(model.EmailDigests, 0)
+	for rows.Next() {
+		digest, err := scanEmailDigest(rows)
+		if err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch email digest row: %v`, err)
+		}
+
+		digests = append(digests, digest)
+	}
+
+	return digests, nil
+}
+
+// UpdateEmailDigest saves the email digest preferences of a user.
+func (s *Storage) UpdateEmailDigest(digest *model.EmailDigest) error {
+	query := `
+		INSERT INTO email_digests
+			(user_id, enabled, email, frequency, hour, weekday, category_ids, token)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id) DO UPDATE SET
+			enabled=EXCLUDED.enabled,
+			email=EXCLUDED.email,
+			frequency=EXCLUDED.frequency,
+			hour=EXCLUDED.hour,
+			weekday=EXCLUDED.weekday,
+			category_ids=EXCLUDED.category_ids
+	`
+	_, err := s.db.Exec(
+		query,
+		digest.UserID,
+		digest.Enabled,
+		digest.Email,
+		digest.Frequency,
+		digest.Hour,
+		digest.Weekday,
+		pq.Array(digest.CategoryIDs),
+		digest.Token,
+	)
+
+	if err != nil {
+		return fmt.Errorf(`store: unable to update email digest: %v`, err)
+	}
+
+	return nil
+}
+
+// UnsubscribeEmailDigest disables the email digest associated to an unsubscribe token.
+func (s *Storage) UnsubscribeEmailDigest(token string) error {
+	if _, err := s.db.Exec(`UPDATE email_digests SET enabled='f' WHERE token=$1`, token); err != nil {
+		return fmt.Errorf(`store: unable to unsubscribe from email digest: %v`, err)
+	}
+
+	return nil
+}
+
+// UpdateEmailDigestSentAt records the time of the last email digest.
+func (s *Storage) UpdateEmailDigestSentAt(userID int64) error {
+	if _, err := s.db.Exec(`UPDATE email_digests SET sent_at=now() WHERE user_id=$1`, userID); err != nil {
+		return fmt.Errorf(`store: unable to update email digest date: %v`, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) fetchEmailDigest(query string, args ...interface{}) (*model.EmailDigest, error) {
+	digest, err := scanEmailDigest(s.db.QueryRow(query, args...))
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch email digest: %v`, err)
+	}
+
+	return digest, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEmailDigest(row rowScanner) (*model.EmailDigest, error) {
+	var digest model.EmailDigest
+	var categoryIDs pq.Int64Array
+
+	err := row.Scan(
+		&digest.UserID,
+		&digest.Enabled,
+		&digest.Email,
+		&digest.Frequency,
+		&digest.Hour,
+		&digest.Weekday,
+		&categoryIDs,
+		&digest.Token,
+		&digest.SentAt,
+	)
+
+	digest.CategoryIDs = categoryIDs
+	return &digest, err
+}
@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"miniflux.app/crypto"
@@ -118,6 +119,7 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 				user_id,
 				feed_id,
 				reading_time,
+				status,
 				changed_at,
 				document_vectors
 			)
@@ -133,6 +135,7 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 				$8,
 				$9,
 				$10,
+				coalesce(nullif($11, ''), 'unread')::entry_status,
 				now(),
 				setweight(to_tsvector(left(coalesce($1, ''), 500000)), 'A') || setweight(to_tsvector(left(coalesce($6, ''), 500000)), 'B')
 			)
@@ -151,6 +154,7 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 		entry.UserID,
 		entry.FeedID,
 		entry.ReadingTime,
+		entry.Status,
 	).Scan(&entry.ID, &entry.Status)
 
 	if err != nil {
@@ -257,8 +261,8 @@ func (s *Storage) cleanupEntries(feedID int64, entryHashes []string) error {
 	return nil
 }
 
-// RefreshFeedEntries updates feed entries while refreshing a feed.
-func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (err error) {
+// RefreshFeedEntries updates feed entries while refreshing a feed and returns the new entries.
+func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (newEntries model.Entries, err error) {
 	var entryHashes []string
 
 	for _, entry := range entries {
@@ -267,7 +271,7 @@ func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries
 
 		tx, err := s.db.Begin()
 		if err != nil {
-			return fmt.Errorf(`store: unable to start transaction: %v`, err)
+			return nil, fmt.Errorf(`store: unable to start transaction: %v`, err)
 		}
 
 		if s.entryExists(tx, entry) {
@@ -276,15 +280,16 @@ func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries
 			}
 		} else {
 			err = s.createEntry(tx, entry)
+			newEntries = append(newEntries, entry)
 		}
 
 		if err != nil {
 			tx.Rollback()
-			return err
+			return nil, err
 		}
 
 		if err := tx.Commit(); err != nil {
-			return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+			return nil, fmt.Errorf(`store: unable to commit transaction: %v`, err)
 		}
 
 		entryHashes = append(entryHashes, entry.Hash)
@@ -296,10 +301,11 @@ func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries
 		}
 	}()
 
-	return nil
+	return newEntries, nil
 }
 
 // ArchiveEntries changes the status of entries to "removed" after the given number of days.
+// Entries of feeds with a retention policy, set on the feed or its category, are left to the retention policies.
 func (s *Storage) ArchiveEntries(status string, days, limit int) (int64, error) {
 	if days < 0 || limit <= 0 {
 		return 0, nil
@@ -311,7 +317,20 @@ func (s *Storage) ArchiveEntries(status string, days, limit int) (int64, error)
 		SET
 			status='removed'
 		WHERE
-			id=ANY(SELECT id FROM entries WHERE status=$1 AND starred is false AND share_code='' AND created_at < now () - '%d days'::interval ORDER BY created_at ASC LIMIT %d)
+			id=ANY(
+				SELECT
+					e.id
+				FROM
+					entries e
+				WHERE
+					e.status=$1 AND e.starred is false AND e.share_code='' AND e.created_at < now () - '%d days'::interval AND
+					NOT EXISTS (
+						SELECT 1 FROM feeds f JOIN categories c ON c.id=f.category_id
+						WHERE f.id=e.feed_id AND (f.retention_mode <> '' OR c.retention_mode <> '')
+					)
+				ORDER BY e.created_at ASC
+				LIMIT %d
+			)
 	`
 
 	result, err := s.db.Exec(fmt.Sprintf(query, days, limit), status)
@@ -347,6 +366,44 @@ func (s *Storage) SetEntriesStatus(userID int64, entryIDs []int64, status string
 	return nil
 }
 
+// UpdateEntriesState changes the status and the bookmark flag of the given entries, empty values are left untouched.
+// When changedAt is set, the entries modified after that time are skipped and the change keeps its original time,
+// so the changes replayed later in the same order are not rejected by the previous ones.
+func (s *Storage) UpdateEntriesState(userID int64, entryIDs []int64, status string, starred *bool, changedAt *time.Time) (int64, error) {
+	args := []interface{}{userID, pq.Array(entryIDs)}
+	columns := []string{"changed_at=now()"}
+	conditions := []string{"user_id=$1", "id=ANY($2)"}
+
+	if changedAt != nil {
+		args = append(args, *changedAt)
+		columns[0] = fmt.Sprintf("changed_at=least($%d, now())", len(args))
+		conditions = append(conditions, fmt.Sprintf("changed_at <= $%d", len(args)))
+	}
+
+	if status != "" {
+		args = append(args, status)
+		columns = append(columns, fmt.Sprintf("status=$%d", len(args)))
+	}
+
+	if starred != nil {
+		args = append(args, *starred)
+		columns = append(columns, fmt.Sprintf("starred=$%d", len(args)))
+	}
+
+	query := `UPDATE entries SET ` + strings.Join(columns, ", ") + ` WHERE ` + strings.Join(conditions, " AND ")
+	result, err := s.db.Exec(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to update entries %v: %v`, entryIDs, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to update these entries %v: %v`, entryIDs, err)
+	}
+
+	return count, nil
+}
+
 func (s *Storage) SetEntriesStatusCount(userID int64, entryIDs []int64, status string) (int, error) {
 	if err := s.SetEntriesStatus(userID, entryIDs, status); err != nil {
 		return 0, err
@@ -411,6 +468,16 @@ func (s *Storage) ToggleBookmark(userID int64, entryID int64) error {
 	return nil
 }
 
+// SetEntryReadingProgress saves the reading progress and the scroll position of an entry.
+func (s *Storage) SetEntryReadingProgress(userID, entryID int64, progress, scrollPosition int) error {
+	query := `UPDATE entries SET reading_progress=$1, scroll_position=$2 WHERE user_id=$3 AND id=$4`
+	if _, err := s.db.Exec(query, progress, scrollPosition, userID, entryID); err != nil {
+		return fmt.Errorf(`store: unable to update reading progress of entry #%d: %v`, entryID, err)
+	}
+
+	return nil
+}
+
 // FlushHistory set all entries with the status "read" to "removed".
 func (s *Storage) FlushHistory(userID int64) error {
 	query := `
@@ -526,6 +593,42 @@ func (s *Storage) EntryShareCode(userID int64, entryID int64) (shareCode string,
 	return
 }
 
+// ShareEntries generates the missing share codes of the given entries in a single transaction.
+func (s *Storage) ShareEntries(userID int64, entryIDs []int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	rows, err := tx.Query(`SELECT id FROM entries WHERE user_id=$1 AND id=ANY($2) AND share_code=''`, userID, pq.Array(entryIDs))
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to fetch entries %v: %v`, entryIDs, err)
+	}
+
+	var unsharedIDs []int64
+	for rows.Next() {
+		var entryID int64
+		if err := rows.Scan(&entryID); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return fmt.Errorf(`store: unable to fetch entry ID: %v`, err)
+		}
+		unsharedIDs = append(unsharedIDs, entryID)
+	}
+	rows.Close()
+
+	for _, entryID := range unsharedIDs {
+		query := `UPDATE entries SET share_code=$1 WHERE user_id=$2 AND id=$3`
+		if _, err := tx.Exec(query, crypto.GenerateRandomStringHex(20), userID, entryID); err != nil {
+			tx.Rollback()
+			return fmt.Errorf(`store: unable to set share code for entry #%d: %v`, entryID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
 // UnshareEntry removes the share code for the given entry.
 func (s *Storage) UnshareEntry(userID int64, entryID int64) (err error) {
 	query := `UPDATE entries SET share_code='' WHERE user_id=$1 AND id=$2`
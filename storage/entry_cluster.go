@@ -0,0 +1,131 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"fmt"
+	"time"
+
+	"miniflux.app/logger"
+	"miniflux.app/model"
+
+	"github.com/lib/pq"
+)
+
+// UserIDsWithEntriesSince returns the users having entries published after the given date.
+func (s *Storage) UserIDsWithEntriesSince(since time.Time) ([]int64, error) {
+	rows, err := s.db.Query(`SELECT DISTINCT user_id FROM entries WHERE published_at >= $1 AND status <> $2`, since, model.EntryStatusRemoved)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch users with recent entries: %v`, err)
+	}
+	defer rows.Close()
+
+	var userIDs []int64
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch user ID: %v`, err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, nil
+}
+
+// EntriesForClustering returns the text and the cluster of the user entries published after the given date.
+func (s *Storage) EntriesForClustering(userID int64, since time.Time) (model.Entries, error) {
+	query := `
+		SELECT
+			id,
+			title,
+			content,
+			coalesce(cluster_id, 0)
+		FROM
+			entries
+		WHERE
+			user_id=$1 AND published_at >= $2 AND status <> $3
+		ORDER BY
+			id ASC
+	`
+	rows, err := s.db.Query(query, userID, since, model.EntryStatusRemoved)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch entries to cluster: %v`, err)
+	}
+	defer rows.Close()
+
+	entries := make(model.Entries, 0)
+	for rows.Next() {
+		entry := &model.Entry{UserID: userID}
+		if err := rows.Scan(&entry.ID, &entry.Title, &entry.Content, &entry.ClusterID); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch entry to cluster: %v`, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// UpdateEntryClusters saves the cluster of the given entries, a cluster ID of 0 removes the entry from its cluster.
+func (s *Storage) UpdateEntryClusters(userID int64, clusters map[int64]int64) error {
+	if len(clusters) == 0 {
+		return nil
+	}
+
+	entryIDs := make([]int64, 0, len(clusters))
+	clusterIDs := make([]int64, 0, len(clusters))
+	for entryID, clusterID := range clusters {
+		entryIDs = append(entryIDs, entryID)
+		clusterIDs = append(clusterIDs, clusterID)
+	}
+
+	query := `
+		UPDATE
+			entries e
+		SET
+			cluster_id=nullif(c.cluster_id, 0)
+		FROM
+			(SELECT unnest($2::bigint[]) AS id, unnest($3::bigint[]) AS cluster_id) c
+		WHERE
+			e.user_id=$1 AND e.id=c.id AND e.cluster_id IS DISTINCT FROM nullif(c.cluster_id, 0)
+	`
+	result, err := s.db.Exec(query, userID, pq.Array(entryIDs), pq.Array(clusterIDs))
+	if err != nil {
+		return fmt.Errorf(`store: unable to update entry clusters: %v`, err)
+	}
+
+	count, _ := result.RowsAffected()
+	logger.Debug("[Storage:UpdateEntryClusters] %d entries changed for user #%d", count, userID)
+
+	return nil
+}
+
+// ClusterExists returns true if the user has entries in the given cluster.
+func (s *Storage) ClusterExists(userID, clusterID int64) bool {
+	var result bool
+	s.db.QueryRow(`SELECT true FROM entries WHERE user_id=$1 AND cluster_id=$2 LIMIT 1`, userID, clusterID).Scan(&result)
+	return result
+}
+
+// MarkClusterAsRead updates all unread entries of a cluster to the read status.
+func (s *Storage) MarkClusterAsRead(userID, clusterID int64) (int64, error) {
+	query := `
+		UPDATE
+			entries
+		SET
+			status=$1,
+			changed_at=now()
+		WHERE
+			user_id=$2 AND cluster_id=$3 AND status=$4
+	`
+	result, err := s.db.Exec(query, model.EntryStatusRead, userID, clusterID, model.EntryStatusUnread)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to mark cluster entries as read: %v`, err)
+	}
+
+	count, _ := result.RowsAffected()
+	logger.Debug("[Storage:MarkClusterAsRead] %d items marked as read", count)
+
+	return count, nil
+}
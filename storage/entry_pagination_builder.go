@@ -24,11 +24,19 @@ type EntryPaginationBuilder struct {
 	direction  string
 }
 
-// WithSearchQuery adds full-text search query to the condition.
+// WithSearchQuery adds a search query to the condition, see the search package for the syntax.
 func (e *EntryPaginationBuilder) WithSearchQuery(query string) {
-	if query != "" {
-		e.conditions = append(e.conditions, fmt.Sprintf("e.document_vectors @@ plainto_tsquery($%d)", len(e.args)+1))
-		e.args = append(e.args, query)
+	if condition, args, _ := searchQueryCondition(query, e.args); condition != "" {
+		e.conditions = append(e.conditions, condition)
+		e.args = args
+	}
+}
+
+// WithAuthor adds author to the condition.
+func (e *EntryPaginationBuilder) WithAuthor(author string) {
+	if author != "" {
+		e.conditions = append(e.conditions, fmt.Sprintf("lower(e.author) = lower($%d)", len(e.args)+1))
+		e.args = append(e.args, author)
 	}
 }
 
@@ -37,6 +45,11 @@ func (e *EntryPaginationBuilder) WithStarred() {
 	e.conditions = append(e.conditions, "e.starred is true")
 }
 
+// WithReadingInProgress adds partially read entries to the condition.
+func (e *EntryPaginationBuilder) WithReadingInProgress() {
+	e.conditions = append(e.conditions, "e.reading_progress > 0 AND e.reading_progress < 100")
+}
+
 // WithFeedID adds feed_id to the condition.
 func (e *EntryPaginationBuilder) WithFeedID(feedID int64) {
 	if feedID != 0 {
@@ -25,22 +25,41 @@ type EntryQueryBuilder struct {
 	direction  string
 	limit      int
 	offset     int
+
+	// rankingText is added to the arguments only when the results are sorted by search ranking.
+	rankingText string
 }
 
-// WithSearchQuery adds full-text search query to the condition.
+// searchRankingOrder sorts the results by relevance of the search query, and by date.
+// 0.0000001 = 0.1 / (seconds_in_a_day)
+const searchRankingOrder = "ts_rank(document_vectors, plainto_tsquery($%d)) - extract (epoch from now() - published_at)::float * 0.0000001"
+
+// WithSearchQuery adds a search query to the condition, see the search package for the syntax.
+// Results are ranked by relevance of the words and phrases of the query, and by date.
 func (e *EntryQueryBuilder) WithSearchQuery(query string) *EntryQueryBuilder {
-	if query != "" {
-		nArgs := len(e.args) + 1
-		e.conditions = append(e.conditions, fmt.Sprintf("e.document_vectors @@ plainto_tsquery($%d)", nArgs))
-		e.args = append(e.args, query)
+	condition, args, rankingText := searchQueryCondition(query, e.args)
+	if condition != "" {
+		e.conditions = append(e.conditions, condition)
+		e.args = args
+	}
 
-		// 0.0000001 = 0.1 / (seconds_in_a_day)
-		e.WithOrder(fmt.Sprintf("ts_rank(document_vectors, plainto_tsquery($%d)) - extract (epoch from now() - published_at)::float * 0.0000001", nArgs))
+	if rankingText != "" {
+		e.rankingText = rankingText
+		e.WithOrder(searchRankingOrder)
 		e.WithDirection("DESC")
 	}
 	return e
 }
 
+// WithAuthor adds author filter, the comparison is case-insensitive.
+func (e *EntryQueryBuilder) WithAuthor(author string) *EntryQueryBuilder {
+	if author != "" {
+		e.conditions = append(e.conditions, fmt.Sprintf("lower(e.author) = lower($%d)", len(e.args)+1))
+		e.args = append(e.args, author)
+	}
+	return e
+}
+
 // WithStarred adds starred filter.
 func (e *EntryQueryBuilder) WithStarred(starred bool) *EntryQueryBuilder {
 	if starred {
@@ -90,6 +109,13 @@ func (e *EntryQueryBuilder) WithEntryIDs(entryIDs []int64) *EntryQueryBuilder {
 	return e
 }
 
+// WithStoryIDs filter by stories, a story is a cluster or an entry without similar ones.
+func (e *EntryQueryBuilder) WithStoryIDs(storyIDs []int64) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, fmt.Sprintf("coalesce(e.cluster_id, -e.id) = ANY($%d)", len(e.args)+1))
+	e.args = append(e.args, pq.Int64Array(storyIDs))
+	return e
+}
+
 // WithEntryID filter by entry ID.
 func (e *EntryQueryBuilder) WithEntryID(entryID int64) *EntryQueryBuilder {
 	if entryID != 0 {
@@ -117,6 +143,22 @@ func (e *EntryQueryBuilder) WithCategoryID(categoryID int64) *EntryQueryBuilder
 	return e
 }
 
+// WithCategoryIDs filter by a list of category IDs.
+func (e *EntryQueryBuilder) WithCategoryIDs(categoryIDs []int64) *EntryQueryBuilder {
+	if len(categoryIDs) > 0 {
+		e.conditions = append(e.conditions, fmt.Sprintf("f.category_id = ANY($%d)", len(e.args)+1))
+		e.args = append(e.args, pq.Int64Array(categoryIDs))
+	}
+	return e
+}
+
+// CreatedAfter adds a condition > created_at
+func (e *EntryQueryBuilder) CreatedAfter(date time.Time) *EntryQueryBuilder {
+	e.conditions = append(e.conditions, fmt.Sprintf("e.created_at > $%d", len(e.args)+1))
+	e.args = append(e.args, date)
+	return e
+}
+
 // WithStatus filter by entry status.
 func (e *EntryQueryBuilder) WithStatus(status string) *EntryQueryBuilder {
 	if status != "" {
@@ -157,6 +199,12 @@ func (e *EntryQueryBuilder) WithShareCodeNotEmpty() *EntryQueryBuilder {
 	return e
 }
 
+// WithReadingInProgress adds a filter for entries partially read.
+func (e *EntryQueryBuilder) WithReadingInProgress() *EntryQueryBuilder {
+	e.conditions = append(e.conditions, "e.reading_progress > 0 AND e.reading_progress < 100")
+	return e
+}
+
 // WithOrder set the sorting order.
 func (e *EntryQueryBuilder) WithOrder(order string) *EntryQueryBuilder {
 	e.order = order
@@ -210,6 +258,117 @@ func (e *EntryQueryBuilder) CountEntries() (count int, err error) {
 	return count, nil
 }
 
+// CountStories count the number of stories that match the condition, the entries of a cluster are counted once.
+func (e *EntryQueryBuilder) CountStories() (count int, err error) {
+	query := `
+		SELECT count(DISTINCT coalesce(e.cluster_id, -e.id))
+		FROM entries e
+			JOIN feeds f ON f.id = e.feed_id
+			JOIN categories c ON c.id = f.category_id
+		WHERE %s
+	`
+	condition := e.buildCondition()
+
+	err = e.store.db.QueryRow(fmt.Sprintf(query, condition), e.args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("unable to count stories: %v", err)
+	}
+
+	return count, nil
+}
+
+// GetStories returns the entries of the stories that match the condition, a story is a cluster or an entry without similar ones.
+// The limit and the offset apply to the stories, so the entries of a cluster are never split across two pages.
+func (e *EntryQueryBuilder) GetStories() (model.Entries, error) {
+	storyIDs, err := e.getStoryIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(storyIDs) == 0 {
+		return make(model.Entries, 0), nil
+	}
+
+	members := *e
+	members.conditions = append([]string(nil), e.conditions...)
+	members.args = append([]interface{}(nil), e.args...)
+	members.limit, members.offset = 0, 0
+	members.WithStoryIDs(storyIDs)
+
+	return members.GetEntries()
+}
+
+// getStoryIDs returns a page of stories sorted by their first entry.
+func (e *EntryQueryBuilder) getStoryIDs() ([]int64, error) {
+	query := `
+		SELECT story
+		FROM (
+			SELECT story, row_number() OVER (%s) AS position
+			FROM (
+				SELECT
+					coalesce(e.cluster_id, -e.id) AS story,
+					e.id,
+					e.status,
+					e.title,
+					e.author,
+					e.score,
+					e.document_vectors,
+					e.published_at,
+					e.created_at,
+					e.changed_at,
+					f.category_id,
+					c.title AS category_title
+				FROM
+					entries e
+				LEFT JOIN
+					feeds f ON f.id=e.feed_id
+				LEFT JOIN
+					categories c ON c.id=f.category_id
+				WHERE %s
+			) e
+		) s
+		GROUP BY story
+		ORDER BY min(position)
+		%s
+	`
+
+	sortingBuilder := *e
+	sortingBuilder.limit, sortingBuilder.offset = 0, 0
+	sorting, args := sortingBuilder.buildSorting()
+	if sorting == "" {
+		sorting = "ORDER BY e.id"
+	} else {
+		sorting += ", e.id " + e.direction
+	}
+
+	var pagination []string
+	if e.limit > 0 {
+		pagination = append(pagination, fmt.Sprintf(`LIMIT %d`, e.limit))
+	}
+	if e.offset > 0 {
+		pagination = append(pagination, fmt.Sprintf(`OFFSET %d`, e.offset))
+	}
+
+	query = fmt.Sprintf(query, sorting, e.buildCondition(), strings.Join(pagination, " "))
+
+	rows, err := e.store.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get stories: %v", err)
+	}
+	defer rows.Close()
+
+	var storyIDs []int64
+	for rows.Next() {
+		var storyID int64
+		if err := rows.Scan(&storyID); err != nil {
+			return nil, fmt.Errorf("unable to fetch story row: %v", err)
+		}
+		storyIDs = append(storyIDs, storyID)
+	}
+
+	return storyIDs, nil
+}
+
 // GetEntry returns a single entry that match the condition.
 func (e *EntryQueryBuilder) GetEntry() (*model.Entry, error) {
 	e.limit = 1
@@ -243,11 +402,16 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			e.url,
 			e.comments_url,
 			e.author,
+			coalesce((SELECT ap.status FROM author_preferences ap WHERE ap.user_id=e.user_id AND lower(ap.author)=lower(e.author)), ''),
 			e.share_code,
 			e.content,
 			e.status,
 			e.starred,
 			e.reading_time,
+			e.reading_progress,
+			e.scroll_position,
+			coalesce(e.cluster_id, 0),
+			e.score,
 			e.created_at,
 			e.changed_at,
 			f.title as feed_title,
@@ -276,10 +440,10 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 	`
 
 	condition := e.buildCondition()
-	sorting := e.buildSorting()
+	sorting, args := e.buildSorting()
 	query = fmt.Sprintf(query, condition, sorting)
 
-	rows, err := e.store.db.Query(query, e.args...)
+	rows, err := e.store.db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get entries: %v", err)
 	}
@@ -305,11 +469,16 @@ func (e *EntryQueryBuilder) GetEntries() (model.Entries, error) {
 			&entry.URL,
 			&entry.CommentsURL,
 			&entry.Author,
+			&entry.AuthorStatus,
 			&entry.ShareCode,
 			&entry.Content,
 			&entry.Status,
 			&entry.Starred,
 			&entry.ReadingTime,
+			&entry.ReadingProgress,
+			&entry.ScrollPosition,
+			&entry.ClusterID,
+			&entry.Score,
 			&entry.CreatedAt,
 			&entry.ChangedAt,
 			&entry.Feed.Title,
@@ -358,9 +527,10 @@ func (e *EntryQueryBuilder) GetEntryIDs() ([]int64, error) {
 	query := `SELECT e.id FROM entries e LEFT JOIN feeds f ON f.id=e.feed_id WHERE %s %s`
 
 	condition := e.buildCondition()
-	query = fmt.Sprintf(query, condition, e.buildSorting())
+	sorting, args := e.buildSorting()
+	query = fmt.Sprintf(query, condition, sorting)
 
-	rows, err := e.store.db.Query(query, e.args...)
+	rows, err := e.store.db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get entries: %v", err)
 	}
@@ -385,10 +555,17 @@ func (e *EntryQueryBuilder) buildCondition() string {
 	return strings.Join(e.conditions, " AND ")
 }
 
-func (e *EntryQueryBuilder) buildSorting() string {
+// buildSorting returns the sorting clause and the arguments of the query, the ranking text of
+// a search query is only needed when another order did not replace the search ranking.
+func (e *EntryQueryBuilder) buildSorting() (string, []interface{}) {
 	var parts []string
+	args := e.args
 
-	if e.order != "" {
+	switch {
+	case e.order == searchRankingOrder && e.rankingText != "":
+		args = append(args[:len(args):len(args)], e.rankingText)
+		parts = append(parts, fmt.Sprintf(`ORDER BY `+searchRankingOrder, len(args)))
+	case e.order != "":
 		parts = append(parts, fmt.Sprintf(`ORDER BY %s`, e.order))
 	}
 
@@ -396,6 +573,11 @@ func (e *EntryQueryBuilder) buildSorting() string {
 		parts = append(parts, e.direction)
 	}
 
+	// The scores are all equal until a model has been trained, the tiebreaker keeps the pages stable.
+	if e.order == "score" {
+		parts = append(parts, `, e.published_at DESC, e.id DESC`)
+	}
+
 	if e.limit > 0 {
 		parts = append(parts, fmt.Sprintf(`LIMIT %d`, e.limit))
 	}
@@ -404,7 +586,7 @@ func (e *EntryQueryBuilder) buildSorting() string {
 		parts = append(parts, fmt.Sprintf(`OFFSET %d`, e.offset))
 	}
 
-	return strings.Join(parts, " ")
+	return strings.Join(parts, " "), args
 }
 
 // NewEntryQueryBuilder returns a new EntryQueryBuilder.
@@ -0,0 +1,152 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"fmt"
+	"time"
+
+	"miniflux.app/logger"
+	"miniflux.app/model"
+
+	"github.com/lib/pq"
+)
+
+// maxScoringExamples limits the number of recent entries used to train a model.
+const maxScoringExamples = 5000
+
+// UserIDsWithEntryScoring returns the users who enabled the ranking of entries.
+func (s *Storage) UserIDsWithEntryScoring() ([]int64, error) {
+	rows, err := s.db.Query(`SELECT id FROM users WHERE entry_scoring='t' AND status=$1`, model.UserStatusActive)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch users with entry scoring: %v`, err)
+	}
+	defer rows.Close()
+
+	var userIDs []int64
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch user ID: %v`, err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, nil
+}
+
+// ScoringExamples returns the entries changed after the given date that the user found interesting,
+// starred, opened or saved to a third-party service, and the ones marked as read without being opened.
+func (s *Storage) ScoringExamples(userID int64, since time.Time) (interesting, ignored model.Entries, err error) {
+	query := `
+		SELECT
+			id,
+			feed_id,
+			title,
+			author,
+			reading_time,
+			interesting
+		FROM (
+			SELECT
+				e.id,
+				e.feed_id,
+				e.title,
+				e.author,
+				e.reading_time,
+				e.status,
+				e.changed_at,
+				(
+					e.starred OR
+					e.reading_progress > 0 OR
+					EXISTS (SELECT 1 FROM integration_deliveries d WHERE d.entry_id=e.id AND d.action=$3)
+				) AS interesting
+			FROM
+				entries e
+			WHERE
+				e.user_id=$1 AND e.changed_at >= $2
+		) examples
+		WHERE
+			interesting OR status <> $4
+		ORDER BY
+			changed_at DESC
+		LIMIT $5
+	`
+	rows, err := s.db.Query(query, userID, since, model.DeliveryActionSave, model.EntryStatusUnread, maxScoringExamples)
+	if err != nil {
+		return nil, nil, fmt.Errorf(`store: unable to fetch scoring examples: %v`, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var isInteresting bool
+		entry := &model.Entry{UserID: userID}
+		if err := rows.Scan(&entry.ID, &entry.FeedID, &entry.Title, &entry.Author, &entry.ReadingTime, &isInteresting); err != nil {
+			return nil, nil, fmt.Errorf(`store: unable to fetch scoring example: %v`, err)
+		}
+
+		if isInteresting {
+			interesting = append(interesting, entry)
+		} else {
+			ignored = append(ignored, entry)
+		}
+	}
+
+	return interesting, ignored, nil
+}
+
+// EntriesToScore returns the unread entries of a user with the fields used by the ranking.
+func (s *Storage) EntriesToScore(userID int64) (model.Entries, error) {
+	query := `SELECT id, feed_id, title, author, reading_time FROM entries WHERE user_id=$1 AND status=$2`
+	rows, err := s.db.Query(query, userID, model.EntryStatusUnread)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch entries to score: %v`, err)
+	}
+	defer rows.Close()
+
+	entries := make(model.Entries, 0)
+	for rows.Next() {
+		entry := &model.Entry{UserID: userID}
+		if err := rows.Scan(&entry.ID, &entry.FeedID, &entry.Title, &entry.Author, &entry.ReadingTime); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch entry to score: %v`, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// UpdateEntryScores saves the score of the given entries.
+func (s *Storage) UpdateEntryScores(userID int64, scores map[int64]float64) error {
+	if len(scores) == 0 {
+		return nil
+	}
+
+	entryIDs := make([]int64, 0, len(scores))
+	values := make([]float64, 0, len(scores))
+	for entryID, score := range scores {
+		entryIDs = append(entryIDs, entryID)
+		values = append(values, score)
+	}
+
+	query := `
+		UPDATE
+			entries e
+		SET
+			score=v.score
+		FROM
+			(SELECT unnest($2::bigint[]) AS id, unnest($3::real[]) AS score) v
+		WHERE
+			e.user_id=$1 AND e.id=v.id
+	`
+	result, err := s.db.Exec(query, userID, pq.Array(entryIDs), pq.Array(values))
+	if err != nil {
+		return fmt.Errorf(`store: unable to update entry scores: %v`, err)
+	}
+
+	count, _ := result.RowsAffected()
+	logger.Debug("[Storage:UpdateEntryScores] %d entries changed for user #%d", count, userID)
+
+	return nil
+}
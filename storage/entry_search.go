@@ -0,0 +1,154 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"fmt"
+	"strings"
+
+	"miniflux.app/search"
+)
+
+// searchConditionBuilder converts a parsed search query to a SQL condition, the values are appended to the arguments.
+type searchConditionBuilder struct {
+	args []interface{}
+}
+
+func (b *searchConditionBuilder) arg(value interface{}) int {
+	b.args = append(b.args, value)
+	return len(b.args)
+}
+
+func (b *searchConditionBuilder) build(expression search.Expression) string {
+	switch e := expression.(type) {
+	case search.And:
+		// Words are searched together like plainto_tsquery does, stop words are ignored among other words.
+		var fields, others []string
+		words := make(map[string][]string)
+		for _, child := range e {
+			if term, ok := child.(*search.Term); ok && term.IsText() && !term.Phrase {
+				if _, found := words[term.Field]; !found {
+					fields = append(fields, term.Field)
+				}
+				words[term.Field] = append(words[term.Field], term.Value)
+			} else {
+				others = append(others, b.build(child))
+			}
+		}
+
+		var conditions []string
+		for _, field := range fields {
+			conditions = append(conditions, b.textCondition(&search.Term{Field: field, Value: strings.Join(words[field], " ")}, false))
+		}
+
+		return "(" + strings.Join(append(conditions, others...), " AND ") + ")"
+	case search.Or:
+		conditions := make([]string, len(e))
+		for i, child := range e {
+			conditions[i] = b.build(child)
+		}
+		return "(" + strings.Join(conditions, " OR ") + ")"
+	case search.Not:
+		if term, ok := e.Expression.(*search.Term); ok && term.IsText() {
+			return b.textCondition(term, true)
+		}
+		return "NOT " + b.build(e.Expression)
+	case *search.Term:
+		if e.IsText() {
+			return b.textCondition(e, false)
+		}
+		return b.fieldCondition(e)
+	}
+
+	return "true"
+}
+
+// textCondition matches the words or the phrase of the term against the title and the content,
+// or only one of them with the weight given to the title and the content when indexing entries.
+func (b *searchConditionBuilder) textCondition(term *search.Term, negated bool) string {
+	vector := "e.document_vectors"
+	switch term.Field {
+	case search.FieldTitle:
+		vector = "ts_filter(e.document_vectors, '{a}')"
+	case search.FieldContent:
+		vector = "ts_filter(e.document_vectors, '{b}')"
+	}
+
+	function := "plainto_tsquery"
+	if term.Phrase {
+		function = "phraseto_tsquery"
+	}
+
+	condition := fmt.Sprintf("%s @@ %s($%d)", vector, function, b.arg(term.Value))
+	if negated {
+		return "NOT (" + condition + ")"
+	}
+	return condition
+}
+
+func (b *searchConditionBuilder) fieldCondition(term *search.Term) string {
+	switch term.Field {
+	case search.FieldAuthor:
+		return fmt.Sprintf("strpos(lower(e.author), lower($%d)) > 0", b.arg(term.Value))
+	case search.FieldFeed:
+		if term.ID > 0 {
+			return fmt.Sprintf("e.feed_id = $%d", b.arg(term.ID))
+		}
+		return fmt.Sprintf(
+			"e.feed_id IN (SELECT sf.id FROM feeds sf WHERE sf.user_id=e.user_id AND strpos(lower(sf.title), lower($%d)) > 0)",
+			b.arg(term.Value),
+		)
+	case search.FieldCategory:
+		if term.ID > 0 {
+			return fmt.Sprintf("e.feed_id IN (SELECT sf.id FROM feeds sf WHERE sf.category_id = $%d)", b.arg(term.ID))
+		}
+		return fmt.Sprintf(
+			"e.feed_id IN (SELECT sf.id FROM feeds sf JOIN categories sc ON sc.id=sf.category_id WHERE sf.user_id=e.user_id AND strpos(lower(sc.title), lower($%d)) > 0)",
+			b.arg(term.Value),
+		)
+	case search.FieldAfter:
+		return fmt.Sprintf("e.published_at >= $%d", b.arg(term.Date))
+	case search.FieldBefore:
+		return fmt.Sprintf("e.published_at < $%d", b.arg(term.Date))
+	case search.FieldIs:
+		switch term.Value {
+		case search.IsStarred:
+			return "e.starred is true"
+		case search.IsUnstarred:
+			return "e.starred is false"
+		case search.IsRead:
+			return "e.status = 'read'"
+		case search.IsUnread:
+			return "e.status = 'unread'"
+		}
+	case search.FieldHas:
+		switch term.Value {
+		case search.HasEnclosure:
+			return "EXISTS (SELECT 1 FROM enclosures en WHERE en.entry_id=e.id)"
+		case search.HasComments:
+			return "e.comments_url <> ''"
+		}
+	}
+
+	return "true"
+}
+
+// searchQueryCondition returns the SQL condition of a search query and the text used to rank the results.
+// Queries with invalid operators, rejected when saved, are searched as plain text.
+func searchQueryCondition(query string, args []interface{}) (string, []interface{}, string) {
+	expression, err := search.Parse(query)
+	if err != nil {
+		args = append(args, query)
+		return fmt.Sprintf("e.document_vectors @@ plainto_tsquery($%d)", len(args)), args, query
+	}
+
+	if expression == nil {
+		return "", args, ""
+	}
+
+	builder := &searchConditionBuilder{args: args}
+	condition := builder.build(expression)
+	return condition, builder.args, strings.Join(search.TextTerms(expression), " ")
+}
@@ -0,0 +1,147 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/model"
+)
+
+const epubExportColumns = `
+	x.id,
+	x.user_id,
+	x.title,
+	x.kind,
+	x.category_id,
+	coalesce((SELECT c.title FROM categories c WHERE c.id=x.category_id), ''),
+	x.search_query,
+	x.delivery,
+	x.email,
+	x.frequency,
+	x.delivered_at,
+	x.created_at
+`
+
+// EPUBExports returns the EPUB exports of a user.
+func (s *Storage) EPUBExports(userID int64) (model.EPUBExports, error) {
+	query := `SELECT ` + epubExportColumns + ` FROM epub_exports x WHERE x.user_id=$1 ORDER BY lower(x.title) ASC`
+	return s.fetchEPUBExports(query, userID)
+}
+
+// ScheduledEPUBExports returns the EPUB exports delivered periodically.
+func (s *Storage) ScheduledEPUBExports() (model.EPUBExports, error) {
+	query := `SELECT ` + epubExportColumns + ` FROM epub_exports x WHERE x.delivery <> $1 ORDER BY x.id ASC`
+	return s.fetchEPUBExports(query, model.EPUBExportDeliveryNone)
+}
+
+func (s *Storage) fetchEPUBExports(query string, args ...interface{}) (model.EPUBExports, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch EPUB exports: %v`, err)
+	}
+	defer rows.Close()
+
+	exports := make(model.EPUBExports, 0)
+	for rows.Next() {
+		export, err := scanEPUBExport(rows)
+		if err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch EPUB export row: %v`, err)
+		}
+		exports = append(exports, export)
+	}
+
+	return exports, nil
+}
+
+// EPUBExport returns an EPUB export of a user.
+func (s *Storage) EPUBExport(userID, exportID int64) (*model.EPUBExport, error) {
+	query := `SELECT ` + epubExportColumns + ` FROM epub_exports x WHERE x.user_id=$1 AND x.id=$2`
+
+	export, err := scanEPUBExport(s.db.QueryRow(query, userID, exportID))
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch EPUB export: %v`, err)
+	}
+
+	return export, nil
+}
+
+// EPUBExportTitleExists checks if the user already has an EPUB export with the given title.
+func (s *Storage) EPUBExportTitleExists(userID int64, title string) bool {
+	var result bool
+	query := `SELECT true FROM epub_exports WHERE user_id=$1 AND title=$2`
+	s.db.QueryRow(query, userID, title).Scan(&result)
+	return result
+}
+
+// CreateEPUBExport creates a new EPUB export.
+func (s *Storage) CreateEPUBExport(export *model.EPUBExport) error {
+	query := `
+		INSERT INTO epub_exports
+			(user_id, title, kind, category_id, search_query, delivery, email, frequency)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING
+			id, created_at
+	`
+	err := s.db.QueryRow(
+		query,
+		export.UserID,
+		export.Title,
+		export.Kind,
+		export.CategoryID,
+		export.SearchQuery,
+		export.Delivery,
+		export.Email,
+		export.Frequency,
+	).Scan(&export.ID, &export.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf(`store: unable to create EPUB export: %v`, err)
+	}
+
+	return nil
+}
+
+// UpdateEPUBExportDeliveredAt records the date of the last scheduled delivery.
+func (s *Storage) UpdateEPUBExportDeliveredAt(userID, exportID int64) error {
+	if _, err := s.db.Exec(`UPDATE epub_exports SET delivered_at=now() WHERE id=$1 AND user_id=$2`, exportID, userID); err != nil {
+		return fmt.Errorf(`store: unable to update EPUB export delivery date: %v`, err)
+	}
+
+	return nil
+}
+
+// RemoveEPUBExport deletes an EPUB export.
+func (s *Storage) RemoveEPUBExport(userID, exportID int64) error {
+	if _, err := s.db.Exec(`DELETE FROM epub_exports WHERE id=$1 AND user_id=$2`, exportID, userID); err != nil {
+		return fmt.Errorf(`store: unable to remove EPUB export: %v`, err)
+	}
+
+	return nil
+}
+
+func scanEPUBExport(row rowScanner) (*model.EPUBExport, error) {
+	var export model.EPUBExport
+	err := row.Scan(
+		&export.ID,
+		&export.UserID,
+		&export.Title,
+		&export.Kind,
+		&export.CategoryID,
+		&export.CategoryTitle,
+		&export.SearchQuery,
+		&export.Delivery,
+		&export.Email,
+		&export.Frequency,
+		&export.DeliveredAt,
+		&export.CreatedAt,
+	)
+	return &export, err
+}
@@ -333,9 +333,13 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 			allow_self_signed_certificates=$22,
 			fetch_via_proxy=$23,
 			hide_globally=$24,
-			url_rewrite_rules=$25
+			url_rewrite_rules=$25,
+			notify=$26,
+			retention_mode=$27,
+			retention_value=$28,
+			retention_delete=$29
 		WHERE
-			id=$26 AND user_id=$27
+			id=$30 AND user_id=$31
 	`
 	_, err = s.db.Exec(query,
 		feed.FeedURL,
@@ -363,6 +367,10 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 		feed.FetchViaProxy,
 		feed.HideGlobally,
 		feed.UrlRewriteRules,
+		feed.Notify,
+		feed.RetentionMode,
+		feed.RetentionValue,
+		feed.RetentionDelete,
 		feed.ID,
 		feed.UserID,
 	)
@@ -0,0 +1,123 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// FeedIDsExist returns true if all the feeds belong to the user.
+func (s *Storage) FeedIDsExist(userID int64, feedIDs []int64) bool {
+	unique := make(map[int64]bool, len(feedIDs))
+	for _, feedID := range feedIDs {
+		unique[feedID] = true
+	}
+
+	var count int
+	query := `SELECT count(*) FROM feeds WHERE user_id=$1 AND id=ANY($2)`
+	if err := s.db.QueryRow(query, userID, pq.Array(feedIDs)).Scan(&count); err != nil {
+		return false
+	}
+
+	return count == len(unique)
+}
+
+// MoveFeeds moves the given feeds to another category.
+func (s *Storage) MoveFeeds(userID int64, feedIDs []int64, categoryID int64) (int64, error) {
+	query := `
+		UPDATE feeds
+		SET category_id=$3
+		WHERE user_id=$1 AND id=ANY($2) AND EXISTS (SELECT 1 FROM categories WHERE id=$3 AND user_id=$1)
+	`
+	return s.updateFeeds(userID, feedIDs, query, categoryID)
+}
+
+// SetFeedsDisabled enables or disables the given feeds, the errors are cleared when feeds are enabled again.
+func (s *Storage) SetFeedsDisabled(userID int64, feedIDs []int64, disabled bool) (int64, error) {
+	query := `
+		UPDATE feeds
+		SET
+			disabled=$3,
+			parsing_error_count=CASE WHEN $3 THEN parsing_error_count ELSE 0 END,
+			parsing_error_msg=CASE WHEN $3 THEN parsing_error_msg ELSE '' END
+		WHERE user_id=$1 AND id=ANY($2)
+	`
+	return s.updateFeeds(userID, feedIDs, query, disabled)
+}
+
+// SetFeedsUserAgent sets the user agent of the given feeds, an empty value restores the default one.
+func (s *Storage) SetFeedsUserAgent(userID int64, feedIDs []int64, userAgent string) (int64, error) {
+	query := `UPDATE feeds SET user_agent=$3 WHERE user_id=$1 AND id=ANY($2)`
+	return s.updateFeeds(userID, feedIDs, query, userAgent)
+}
+
+// SetFeedsRules replaces the rules of the given feeds, nil rules are left untouched.
+func (s *Storage) SetFeedsRules(userID int64, feedIDs []int64, scraperRules, rewriteRules, blocklistRules, keeplistRules *string) (int64, error) {
+	query := `
+		UPDATE feeds
+		SET
+			scraper_rules=coalesce($3, scraper_rules),
+			rewrite_rules=coalesce($4, rewrite_rules),
+			blocklist_rules=coalesce($5, blocklist_rules),
+			keeplist_rules=coalesce($6, keeplist_rules)
+		WHERE user_id=$1 AND id=ANY($2)
+	`
+	return s.updateFeeds(userID, feedIDs, query, scraperRules, rewriteRules, blocklistRules, keeplistRules)
+}
+
+// RemoveFeeds deletes the given feeds and their entries, either all of them or none.
+func (s *Storage) RemoveFeeds(userID int64, feedIDs []int64) (int64, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM entries WHERE user_id=$1 AND feed_id=ANY($2)`, userID, pq.Array(feedIDs)); err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf(`store: unable to delete the entries of feeds %v: %v`, feedIDs, err)
+	}
+
+	result, err := tx.Exec(`DELETE FROM feeds WHERE user_id=$1 AND id=ANY($2)`, userID, pq.Array(feedIDs))
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf(`store: unable to delete feeds %v: %v`, feedIDs, err)
+	}
+
+	return commitFeedBatch(tx, result, feedIDs)
+}
+
+// updateFeeds runs an update of the feeds in a transaction, the values given start at $3.
+func (s *Storage) updateFeeds(userID int64, feedIDs []int64, query string, values ...interface{}) (int64, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	args := append([]interface{}{userID, pq.Array(feedIDs)}, values...)
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf(`store: unable to update feeds %v: %v`, feedIDs, err)
+	}
+
+	return commitFeedBatch(tx, result, feedIDs)
+}
+
+func commitFeedBatch(tx *sql.Tx, result sql.Result, feedIDs []int64) (int64, error) {
+	count, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf(`store: unable to update feeds %v: %v`, feedIDs, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf(`store: unable to commit the changes of feeds %v: %v`, feedIDs, err)
+	}
+
+	return count, nil
+}
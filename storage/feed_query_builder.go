@@ -168,9 +168,17 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			f.fetch_via_proxy,
 			f.disabled,
 			f.hide_globally,
+			f.notify,
+			f.retention_mode,
+			f.retention_value,
+			f.retention_delete,
 			f.category_id,
 			c.title as category_title,
 			c.hide_globally as category_hidden,
+			c.notify as category_notify,
+			c.retention_mode as category_retention_mode,
+			c.retention_value as category_retention_value,
+			c.retention_delete as category_retention_delete,
 			fi.icon_id,
 			u.timezone
 		FROM
@@ -231,9 +239,17 @@ func (f *FeedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			&feed.FetchViaProxy,
 			&feed.Disabled,
 			&feed.HideGlobally,
+			&feed.Notify,
+			&feed.RetentionMode,
+			&feed.RetentionValue,
+			&feed.RetentionDelete,
 			&feed.Category.ID,
 			&feed.Category.Title,
 			&feed.Category.HideGlobally,
+			&feed.Category.Notify,
+			&feed.Category.RetentionMode,
+			&feed.Category.RetentionValue,
+			&feed.Category.RetentionDelete,
 			&iconID,
 			&tz,
 		)
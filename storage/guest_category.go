@@ -0,0 +1,178 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// GuestCategoryIDs returns the categories shared with a guest.
+func (s *Storage) GuestCategoryIDs(userID int64) ([]int64, error) {
+	rows, err := s.db.Query(`SELECT category_id FROM guest_categories WHERE user_id=$1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch guest categories: %v`, err)
+	}
+	defer rows.Close()
+
+	categoryIDs := make([]int64, 0)
+	for rows.Next() {
+		var categoryID int64
+		if err := rows.Scan(&categoryID); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch guest category row: %v`, err)
+		}
+		categoryIDs = append(categoryIDs, categoryID)
+	}
+
+	return categoryIDs, nil
+}
+
+// SetGuestCategories replaces the categories of ownerID shared with a guest.
+// The categories shared by other users are left untouched.
+func (s *Storage) SetGuestCategories(guestID, ownerID int64, categoryIDs []int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	_, err = tx.Exec(
+		`DELETE FROM guest_categories g USING categories c WHERE g.category_id=c.id AND g.user_id=$1 AND c.user_id=$2`,
+		guestID,
+		ownerID,
+	)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to remove guest categories: %v`, err)
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO guest_categories (user_id, category_id) SELECT $1, id FROM categories WHERE user_id=$2 AND id=ANY($3)`,
+		guestID,
+		ownerID,
+		pq.Array(categoryIDs),
+	)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to share categories: %v`, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// SyncGuestFeeds subscribes guests to the feeds of their shared categories and
+// removes the feeds of the categories that are no longer shared.
+// The credentials and cookies of the owner are not copied, see SharedFeedCredentials.
+func (s *Storage) SyncGuestFeeds() error {
+	queries := []string{
+		// Only guests receive shared categories.
+		`DELETE FROM guest_categories g USING users u WHERE g.user_id=u.id AND u.role <> 'guest'`,
+
+		`INSERT INTO categories (user_id, title)
+			SELECT DISTINCT g.user_id, c.title
+			FROM guest_categories g
+			JOIN categories c ON c.id=g.category_id
+		ON CONFLICT (user_id, title) DO NOTHING`,
+
+		`INSERT INTO feeds (
+			feed_url,
+			site_url,
+			title,
+			category_id,
+			user_id,
+			crawler,
+			user_agent,
+			disabled,
+			scraper_rules,
+			rewrite_rules,
+			blocklist_rules,
+			keeplist_rules,
+			ignore_http_cache,
+			allow_self_signed_certificates,
+			fetch_via_proxy,
+			url_rewrite_rules,
+			shared_feed_id
+		)
+			SELECT
+				f.feed_url,
+				f.site_url,
+				f.title,
+				gc.id,
+				g.user_id,
+				f.crawler,
+				f.user_agent,
+				f.disabled,
+				f.scraper_rules,
+				f.rewrite_rules,
+				f.blocklist_rules,
+				f.keeplist_rules,
+				f.ignore_http_cache,
+				f.allow_self_signed_certificates,
+				f.fetch_via_proxy,
+				f.url_rewrite_rules,
+				f.id
+			FROM guest_categories g
+			JOIN categories c ON c.id=g.category_id
+			JOIN categories gc ON gc.user_id=g.user_id AND gc.title=c.title
+			JOIN feeds f ON f.category_id=c.id
+			WHERE f.shared_feed_id IS NULL
+		ON CONFLICT (user_id, feed_url) DO NOTHING`,
+
+		`DELETE FROM feeds f
+			WHERE f.shared_feed_id IS NOT NULL AND NOT EXISTS (
+				SELECT 1
+				FROM guest_categories g
+				JOIN feeds sf ON sf.category_id=g.category_id
+				WHERE g.user_id=f.user_id AND sf.id=f.shared_feed_id
+			)`,
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	for _, query := range queries {
+		if _, err := tx.Exec(query); err != nil {
+			tx.Rollback()
+			return fmt.Errorf(`store: unable to synchronize guest feeds: %v`, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// SharedFeedCredentials returns the credentials and the cookie of the owner of a feed shared with a guest,
+// found is false when the feed is not shared.
+func (s *Storage) SharedFeedCredentials(feedID int64) (username, password, cookie string, found bool, err error) {
+	query := `
+		SELECT
+			sf.username, sf.password, sf.cookie
+		FROM
+			feeds f
+		JOIN
+			feeds sf ON sf.id=f.shared_feed_id
+		WHERE
+			f.id=$1
+	`
+	err = s.db.QueryRow(query, feedID).Scan(&username, &password, &cookie)
+	switch {
+	case err == sql.ErrNoRows:
+		return "", "", "", false, nil
+	case err != nil:
+		return "", "", "", false, fmt.Errorf(`store: unable to fetch the credentials of the shared feed #%d: %v`, feedID, err)
+	}
+
+	return username, password, cookie, true, nil
+}
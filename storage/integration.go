@@ -6,8 +6,10 @@ package storage // import "miniflux.app/storage"
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 
+	"github.com/lib/pq"
 	"golang.org/x/crypto/bcrypt"
 	"miniflux.app/model"
 )
@@ -32,7 +34,7 @@ func (s *Storage) HasDuplicateGoogleReaderUsername(userID int64, googleReaderUse
 func (s *Storage) UserByFeverToken(token string) (*model.User, error) {
 	query := `
 		SELECT
-			users.id, users.is_admin, users.timezone
+			users.id, users.is_admin, users.role, users.status, users.timezone
 		FROM
 			users
 		LEFT JOIN
@@ -42,7 +44,7 @@ func (s *Storage) UserByFeverToken(token string) (*model.User, error) {
 	`
 
 	var user model.User
-	err := s.db.QueryRow(query, token).Scan(&user.ID, &user.IsAdmin, &user.Timezone)
+	err := s.db.QueryRow(query, token).Scan(&user.ID, &user.IsAdmin, &user.Role, &user.Status, &user.Timezone)
 	switch {
 	case err == sql.ErrNoRows:
 		return nil, nil
@@ -59,11 +61,13 @@ func (s *Storage) GoogleReaderUserCheckPassword(username, password string) error
 
 	query := `
 		SELECT
-			googlereader_password
+			integrations.googlereader_password
 		FROM
 			integrations
+		JOIN
+			users ON users.id=integrations.user_id
 		WHERE
-			integrations.googlereader_enabled='t' AND integrations.googlereader_username=$1
+			integrations.googlereader_enabled='t' AND integrations.googlereader_username=$1 AND users.status='active'
 	`
 
 	err := s.db.QueryRow(query, username).Scan(&hash)
@@ -111,47 +115,17 @@ func (s *Storage) Integration(userID int64) (*model.Integration, error) {
 	query := `
 		SELECT
 			user_id,
-			pinboard_enabled,
-			pinboard_token,
-			pinboard_tags,
-			pinboard_mark_as_unread,
-			instapaper_enabled,
-			instapaper_username,
-			instapaper_password,
 			fever_enabled,
 			fever_username,
 			fever_token,
 			googlereader_enabled,
 			googlereader_username,
 			googlereader_password,
-			wallabag_enabled,
-			wallabag_only_url,
-			wallabag_url,
-			wallabag_client_id,
-			wallabag_client_secret,
-			wallabag_username,
-			wallabag_password,
-			nunux_keeper_enabled,
-			nunux_keeper_url,
-			nunux_keeper_api_key,
-			espial_enabled,
-			espial_url,
-			espial_api_key,
-			espial_tags,
-			pocket_enabled,
-			pocket_access_token,
-			pocket_consumer_key,
-			telegram_bot_enabled,
-			telegram_bot_token,
-			telegram_bot_chat_id,
-			linkding_enabled,
-			linkding_url,
-			linkding_api_key,
-			matrix_bot_enabled,
-			matrix_bot_user,
-			matrix_bot_password,
-			matrix_bot_url,
-			matrix_bot_chat_id
+			notification_rules,
+			notification_quiet_hours_start,
+			notification_quiet_hours_end,
+			notification_digest_interval,
+			notification_digest_sent_at
 		FROM
 			integrations
 		WHERE
@@ -160,56 +134,57 @@ func (s *Storage) Integration(userID int64) (*model.Integration, error) {
 	var integration model.Integration
 	err := s.db.QueryRow(query, userID).Scan(
 		&integration.UserID,
-		&integration.PinboardEnabled,
-		&integration.PinboardToken,
-		&integration.PinboardTags,
-		&integration.PinboardMarkAsUnread,
-		&integration.InstapaperEnabled,
-		&integration.InstapaperUsername,
-		&integration.InstapaperPassword,
 		&integration.FeverEnabled,
 		&integration.FeverUsername,
 		&integration.FeverToken,
 		&integration.GoogleReaderEnabled,
 		&integration.GoogleReaderUsername,
 		&integration.GoogleReaderPassword,
-		&integration.WallabagEnabled,
-		&integration.WallabagOnlyURL,
-		&integration.WallabagURL,
-		&integration.WallabagClientID,
-		&integration.WallabagClientSecret,
-		&integration.WallabagUsername,
-		&integration.WallabagPassword,
-		&integration.NunuxKeeperEnabled,
-		&integration.NunuxKeeperURL,
-		&integration.NunuxKeeperAPIKey,
-		&integration.EspialEnabled,
-		&integration.EspialURL,
-		&integration.EspialAPIKey,
-		&integration.EspialTags,
-		&integration.PocketEnabled,
-		&integration.PocketAccessToken,
-		&integration.PocketConsumerKey,
-		&integration.TelegramBotEnabled,
-		&integration.TelegramBotToken,
-		&integration.TelegramBotChatID,
-		&integration.LinkdingEnabled,
-		&integration.LinkdingURL,
-		&integration.LinkdingAPIKey,
-		&integration.MatrixBotEnabled,
-		&integration.MatrixBotUser,
-		&integration.MatrixBotPassword,
-		&integration.MatrixBotURL,
-		&integration.MatrixBotChatID,
+		&integration.NotificationRules,
+		&integration.NotificationQuietHoursStart,
+		&integration.NotificationQuietHoursEnd,
+		&integration.NotificationDigestInterval,
+		&integration.NotificationDigestSentAt,
 	)
 	switch {
 	case err == sql.ErrNoRows:
 		return &integration, nil
 	case err != nil:
 		return &integration, fmt.Errorf(`store: unable to fetch integration row: %v`, err)
-	default:
-		return &integration, nil
 	}
+
+	integration.Providers, err = s.integrationProviders(userID)
+	if err != nil {
+		return &integration, err
+	}
+
+	return &integration, nil
+}
+
+func (s *Storage) integrationProviders(userID int64) (map[string]*model.ProviderSettings, error) {
+	rows, err := s.db.Query(`SELECT provider, enabled, settings FROM integration_providers WHERE user_id=$1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch integration providers: %v`, err)
+	}
+	defer rows.Close()
+
+	providers := make(map[string]*model.ProviderSettings)
+	for rows.Next() {
+		var settings model.ProviderSettings
+		var values []byte
+
+		if err := rows.Scan(&settings.Name, &settings.Enabled, &values); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch integration provider row: %v`, err)
+		}
+
+		if err := json.Unmarshal(values, &settings.Values); err != nil {
+			return nil, fmt.Errorf(`store: unable to decode settings of integration provider %q: %v`, settings.Name, err)
+		}
+
+		providers[settings.Name] = &settings
+	}
+
+	return providers, nil
 }
 
 // UpdateIntegration saves user integration settings.
@@ -220,215 +195,91 @@ func (s *Storage) UpdateIntegration(integration *model.Integration) error {
 		if err != nil {
 			return err
 		}
-		query := `
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	query := `
 		UPDATE
 			integrations
 		SET
-			pinboard_enabled=$1,
-			pinboard_token=$2,
-			pinboard_tags=$3,
-			pinboard_mark_as_unread=$4,
-			instapaper_enabled=$5,
-			instapaper_username=$6,
-			instapaper_password=$7,
-			fever_enabled=$8,
-			fever_username=$9,
-			fever_token=$10,
-			wallabag_enabled=$11,
-			wallabag_only_url=$12,
-			wallabag_url=$13,
-			wallabag_client_id=$14,
-			wallabag_client_secret=$15,
-			wallabag_username=$16,
-			wallabag_password=$17,
-			nunux_keeper_enabled=$18,
-			nunux_keeper_url=$19,
-			nunux_keeper_api_key=$20,
-			pocket_enabled=$21,
-			pocket_access_token=$22,
-			pocket_consumer_key=$23,
-			googlereader_enabled=$24,
-			googlereader_username=$25,
-			googlereader_password=$26,
-			telegram_bot_enabled=$27,
-			telegram_bot_token=$28,
-			telegram_bot_chat_id=$29,
-			espial_enabled=$30,
-			espial_url=$31,
-			espial_api_key=$32,
-			espial_tags=$33,
-			linkding_enabled=$34,
-			linkding_url=$35,
-			linkding_api_key=$36,
-			matrix_bot_enabled=$37,
-			matrix_bot_user=$38,
-			matrix_bot_password=$39,
-			matrix_bot_url=$40,
-			matrix_bot_chat_id=$41
+			fever_enabled=$1,
+			fever_username=$2,
+			fever_token=$3,
+			googlereader_enabled=$4,
+			googlereader_username=$5,
+			googlereader_password=$6,
+			notification_rules=$7,
+			notification_quiet_hours_start=$8,
+			notification_quiet_hours_end=$9,
+			notification_digest_interval=$10
 		WHERE
-			user_id=$42
+			user_id=$11
 	`
-		_, err = s.db.Exec(
-			query,
-			integration.PinboardEnabled,
-			integration.PinboardToken,
-			integration.PinboardTags,
-			integration.PinboardMarkAsUnread,
-			integration.InstapaperEnabled,
-			integration.InstapaperUsername,
-			integration.InstapaperPassword,
-			integration.FeverEnabled,
-			integration.FeverUsername,
-			integration.FeverToken,
-			integration.WallabagEnabled,
-			integration.WallabagOnlyURL,
-			integration.WallabagURL,
-			integration.WallabagClientID,
-			integration.WallabagClientSecret,
-			integration.WallabagUsername,
-			integration.WallabagPassword,
-			integration.NunuxKeeperEnabled,
-			integration.NunuxKeeperURL,
-			integration.NunuxKeeperAPIKey,
-			integration.PocketEnabled,
-			integration.PocketAccessToken,
-			integration.PocketConsumerKey,
-			integration.GoogleReaderEnabled,
-			integration.GoogleReaderUsername,
-			integration.GoogleReaderPassword,
-			integration.TelegramBotEnabled,
-			integration.TelegramBotToken,
-			integration.TelegramBotChatID,
-			integration.EspialEnabled,
-			integration.EspialURL,
-			integration.EspialAPIKey,
-			integration.EspialTags,
-			integration.LinkdingEnabled,
-			integration.LinkdingURL,
-			integration.LinkdingAPIKey,
-			integration.MatrixBotEnabled,
-			integration.MatrixBotUser,
-			integration.MatrixBotPassword,
-			integration.MatrixBotURL,
-			integration.MatrixBotChatID,
-			integration.UserID,
-		)
-	} else {
-		query := `
-		UPDATE
-			integrations
-		SET
-		pinboard_enabled=$1,
-		pinboard_token=$2,
-		pinboard_tags=$3,
-		pinboard_mark_as_unread=$4,
-		instapaper_enabled=$5,
-		instapaper_username=$6,
-		instapaper_password=$7,
-		fever_enabled=$8,
-		fever_username=$9,
-		fever_token=$10,
-		wallabag_enabled=$11,
-		wallabag_only_url=$12,
-		wallabag_url=$13,
-		wallabag_client_id=$14,
-		wallabag_client_secret=$15,
-		wallabag_username=$16,
-		wallabag_password=$17,
-		nunux_keeper_enabled=$18,
-		nunux_keeper_url=$19,
-		nunux_keeper_api_key=$20,
-		pocket_enabled=$21,
-		pocket_access_token=$22,
-		pocket_consumer_key=$23,
-		googlereader_enabled=$24,
-		googlereader_username=$25,
-		googlereader_password=$26,
-		telegram_bot_enabled=$27,
-		telegram_bot_token=$28,
-		telegram_bot_chat_id=$29,
-		espial_enabled=$30,
-		espial_url=$31,
-		espial_api_key=$32,
-		espial_tags=$33,
-		linkding_enabled=$34,
-		linkding_url=$35,
-		linkding_api_key=$36,
-		matrix_bot_enabled=$37,
-		matrix_bot_user=$38,
-		matrix_bot_password=$39,
-		matrix_bot_url=$40,
-		matrix_bot_chat_id=$41
-	WHERE
-		user_id=$42
+	_, err = tx.Exec(
+		query,
+		integration.FeverEnabled,
+		integration.FeverUsername,
+		integration.FeverToken,
+		integration.GoogleReaderEnabled,
+		integration.GoogleReaderUsername,
+		integration.GoogleReaderPassword,
+		integration.NotificationRules,
+		integration.NotificationQuietHoursStart,
+		integration.NotificationQuietHoursEnd,
+		integration.NotificationDigestInterval,
+		integration.UserID,
+	)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to update integration row: %v`, err)
+	}
+
+	query = `
+		INSERT INTO integration_providers
+			(user_id, provider, enabled, settings)
+		VALUES
+			($1, $2, $3, $4)
+		ON CONFLICT (user_id, provider) DO UPDATE SET
+			enabled=EXCLUDED.enabled,
+			settings=EXCLUDED.settings
 	`
-		_, err = s.db.Exec(
-			query,
-			integration.PinboardEnabled,
-			integration.PinboardToken,
-			integration.PinboardTags,
-			integration.PinboardMarkAsUnread,
-			integration.InstapaperEnabled,
-			integration.InstapaperUsername,
-			integration.InstapaperPassword,
-			integration.FeverEnabled,
-			integration.FeverUsername,
-			integration.FeverToken,
-			integration.WallabagEnabled,
-			integration.WallabagOnlyURL,
-			integration.WallabagURL,
-			integration.WallabagClientID,
-			integration.WallabagClientSecret,
-			integration.WallabagUsername,
-			integration.WallabagPassword,
-			integration.NunuxKeeperEnabled,
-			integration.NunuxKeeperURL,
-			integration.NunuxKeeperAPIKey,
-			integration.PocketEnabled,
-			integration.PocketAccessToken,
-			integration.PocketConsumerKey,
-			integration.GoogleReaderEnabled,
-			integration.GoogleReaderUsername,
-			integration.GoogleReaderPassword,
-			integration.TelegramBotEnabled,
-			integration.TelegramBotToken,
-			integration.TelegramBotChatID,
-			integration.EspialEnabled,
-			integration.EspialURL,
-			integration.EspialAPIKey,
-			integration.EspialTags,
-			integration.LinkdingEnabled,
-			integration.LinkdingURL,
-			integration.LinkdingAPIKey,
-			integration.MatrixBotEnabled,
-			integration.MatrixBotUser,
-			integration.MatrixBotPassword,
-			integration.MatrixBotURL,
-			integration.MatrixBotChatID,
-			integration.UserID,
-		)
+	for _, settings := range integration.Providers {
+		values, err := json.Marshal(settings.Values)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf(`store: unable to encode settings of integration provider %q: %v`, settings.Name, err)
+		}
+
+		if _, err := tx.Exec(query, integration.UserID, settings.Name, settings.Enabled, values); err != nil {
+			tx.Rollback()
+			return fmt.Errorf(`store: unable to update integration provider %q: %v`, settings.Name, err)
+		}
 	}
 
-	if err != nil {
-		return fmt.Errorf(`store: unable to update integration row: %v`, err)
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
 	}
 
 	return nil
 }
 
 // HasSaveEntry returns true if the given user can save articles to third-parties.
-func (s *Storage) HasSaveEntry(userID int64) (result bool) {
+// The providers argument lists the integration providers able to save entries.
+func (s *Storage) HasSaveEntry(userID int64, providers []string) (result bool) {
 	query := `
 		SELECT
 			true
 		FROM
-			integrations
+			integration_providers
 		WHERE
-			user_id=$1
-		AND
-			(pinboard_enabled='t' OR instapaper_enabled='t' OR wallabag_enabled='t' OR nunux_keeper_enabled='t' OR espial_enabled='t' OR pocket_enabled='t' OR linkding_enabled='t')
+			user_id=$1 AND enabled='t' AND provider=ANY($2)
+		LIMIT 1
 	`
-	if err := s.db.QueryRow(query, userID).Scan(&result); err != nil {
+	if err := s.db.QueryRow(query, userID, pq.Array(providers)).Scan(&result); err != nil {
 		result = false
 	}
 
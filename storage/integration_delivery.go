@@ -0,0 +1,178 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"fmt"
+
+	"miniflux.app/model"
+)
+
+// SaveIntegrationDelivery records the result of a delivery attempt.
+// A previous delivery of the same entry to the same provider is replaced.
+func (s *Storage) SaveIntegrationDelivery(delivery *model.IntegrationDelivery) error {
+	query := `
+		INSERT INTO integration_deliveries
+			(user_id, entry_id, provider, action, status, attempts, last_error, next_attempt_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (entry_id, provider, action) DO UPDATE SET
+			status=EXCLUDED.status,
+			attempts=EXCLUDED.attempts,
+			last_error=EXCLUDED.last_error,
+			next_attempt_at=EXCLUDED.next_attempt_at,
+			updated_at=now()
+		RETURNING
+			id, created_at, updated_at
+	`
+	err := s.db.QueryRow(
+		query,
+		delivery.UserID,
+		delivery.EntryID,
+		delivery.Provider,
+		delivery.Action,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.LastError,
+		delivery.NextAttemptAt,
+	).Scan(
+		&delivery.ID,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf(`store: unable to save integration delivery: %v`, err)
+	}
+
+	return nil
+}
+
+// IntegrationDeliveriesToRetry returns the failed deliveries that are due for a new attempt.
+func (s *Storage) IntegrationDeliveriesToRetry(limit int) (model.IntegrationDeliveries, error) {
+	query := `
+		SELECT
+			id, user_id, entry_id, provider, action, status, attempts, last_error, next_attempt_at, created_at, updated_at
+		FROM
+			integration_deliveries
+		WHERE
+			status=$1 AND next_attempt_at <= now()
+		ORDER BY
+			next_attempt_at ASC
+		LIMIT $2
+	`
+	return s.fetchIntegrationDeliveries(query, model.DeliveryStatusRetrying, limit)
+}
+
+// EntryIntegrationDeliveries returns the deliveries of an entry.
+func (s *Storage) EntryIntegrationDeliveries(userID, entryID int64) (model.IntegrationDeliveries, error) {
+	query := `
+		SELECT
+			id, user_id, entry_id, provider, action, status, attempts, last_error, next_attempt_at, created_at, updated_at
+		FROM
+			integration_deliveries
+		WHERE
+			user_id=$1 AND entry_id=$2 AND action=$3
+		ORDER BY
+			provider ASC
+	`
+	return s.fetchIntegrationDeliveries(query, userID, entryID, model.DeliveryActionSave)
+}
+
+// IntegrationDeliveries returns the most recent deliveries of a user.
+// All statuses are returned when the status is empty.
+func (s *Storage) IntegrationDeliveries(userID int64, status string, offset, limit int) (model.IntegrationDeliveries, error) {
+	query := `
+		SELECT
+			id, user_id, entry_id, provider, action, status, attempts, last_error, next_attempt_at, created_at, updated_at
+		FROM
+			integration_deliveries
+		WHERE
+			user_id=$1 AND ($2='' OR status=$2)
+		ORDER BY
+			updated_at DESC, id DESC
+		OFFSET $3
+		LIMIT $4
+	`
+	return s.fetchIntegrationDeliveries(query, userID, status, offset, limit)
+}
+
+// CountAllIntegrationDeliveries returns the number of deliveries by status.
+func (s *Storage) CountAllIntegrationDeliveries() map[string]int64 {
+	rows, err := s.db.Query(`SELECT status, count(*) FROM integration_deliveries GROUP BY status`)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	results := make(map[string]int64)
+	results[model.DeliveryStatusSuccess] = 0
+	results[model.DeliveryStatusRetrying] = 0
+	results[model.DeliveryStatusFailed] = 0
+
+	for rows.Next() {
+		var status string
+		var count int64
+
+		if err := rows.Scan(&status, &count); err != nil {
+			continue
+		}
+
+		results[status] = count
+	}
+
+	return results
+}
+
+// CleanOldIntegrationDeliveries removes the completed deliveries older than the given number of days.
+func (s *Storage) CleanOldIntegrationDeliveries(days int) int64 {
+	query := `
+		DELETE FROM
+			integration_deliveries
+		WHERE
+			status<>$1 AND updated_at < now() - interval '%d days'
+	`
+	result, err := s.db.Exec(fmt.Sprintf(query, days), model.DeliveryStatusRetrying)
+	if err != nil {
+		return 0
+	}
+
+	n, _ := result.RowsAffected()
+	return n
+}
+
+func (s *Storage) fetchIntegrationDeliveries(query string, args ...interface{}) (model.IntegrationDeliveries, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch integration deliveries: %v`, err)
+	}
+	defer rows.Close()
+
+	deliveries := make(model.IntegrationDeliveries, 0)
+	for rows.Next() {
+		var delivery model.IntegrationDelivery
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.UserID,
+			&delivery.EntryID,
+			&delivery.Provider,
+			&delivery.Action,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.LastError,
+			&delivery.NextAttemptAt,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch integration delivery row: %v`, err)
+		}
+
+		deliveries = append(deliveries, &delivery)
+	}
+
+	return deliveries, nil
+}
@@ -9,6 +9,8 @@ import (
 
 	"miniflux.app/config"
 	"miniflux.app/model"
+
+	"github.com/lib/pq"
 )
 
 // NewBatch returns a series of jobs.
@@ -62,6 +64,21 @@ func (s *Storage) NewCategoryBatch(userID int64, categoryID int64, batchSize int
 	return s.fetchBatchRows(fmt.Sprintf(query, batchSize), userID, categoryID)
 }
 
+// NewFeedsBatch returns a series of jobs for the given feeds of the user, disabled feeds are skipped.
+func (s *Storage) NewFeedsBatch(userID int64, feedIDs []int64) (jobs model.JobList, err error) {
+	query := `
+		SELECT
+			id,
+			user_id
+		FROM
+			feeds
+		WHERE
+			user_id=$1 AND id=ANY($2) AND disabled is false
+		ORDER BY next_check_at ASC
+	`
+	return s.fetchBatchRows(query, userID, pq.Array(feedIDs))
+}
+
 func (s *Storage) fetchBatchRows(query string, args ...interface{}) (jobs model.JobList, err error) {
 	rows, err := s.db.Query(query, args...)
 	if err != nil {
@@ -0,0 +1,89 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// QueueNotifications postpones the notification of new entries.
+func (s *Storage) QueueNotifications(userID int64, entryIDs []int64) error {
+	query := `
+		INSERT INTO notification_queue
+			(user_id, entry_id)
+		SELECT
+			$1, unnest($2::bigint[])
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := s.db.Exec(query, userID, pq.Array(entryIDs)); err != nil {
+		return fmt.Errorf(`store: unable to queue notifications: %v`, err)
+	}
+
+	return nil
+}
+
+// UsersWithQueuedNotifications returns the users that have pending notifications.
+func (s *Storage) UsersWithQueuedNotifications() ([]int64, error) {
+	rows, err := s.db.Query(`SELECT DISTINCT user_id FROM notification_queue`)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch queued notifications: %v`, err)
+	}
+	defer rows.Close()
+
+	var userIDs []int64
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch queued notification row: %v`, err)
+		}
+
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, nil
+}
+
+// QueuedNotifications returns the entries waiting to be notified.
+func (s *Storage) QueuedNotifications(userID int64) ([]int64, error) {
+	rows, err := s.db.Query(`SELECT entry_id FROM notification_queue WHERE user_id=$1 ORDER BY created_at ASC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch queued notifications: %v`, err)
+	}
+	defer rows.Close()
+
+	var entryIDs []int64
+	for rows.Next() {
+		var entryID int64
+		if err := rows.Scan(&entryID); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch queued notification row: %v`, err)
+		}
+
+		entryIDs = append(entryIDs, entryID)
+	}
+
+	return entryIDs, nil
+}
+
+// RemoveQueuedNotifications removes the notified entries from the queue.
+func (s *Storage) RemoveQueuedNotifications(userID int64, entryIDs []int64) error {
+	query := `DELETE FROM notification_queue WHERE user_id=$1 AND entry_id=ANY($2)`
+	if _, err := s.db.Exec(query, userID, pq.Array(entryIDs)); err != nil {
+		return fmt.Errorf(`store: unable to remove queued notifications: %v`, err)
+	}
+
+	return nil
+}
+
+// UpdateNotificationDigestSentAt records the time of the last notification digest.
+func (s *Storage) UpdateNotificationDigestSentAt(userID int64) error {
+	query := `UPDATE integrations SET notification_digest_sent_at=now() WHERE user_id=$1`
+	if _, err := s.db.Exec(query, userID); err != nil {
+		return fmt.Errorf(`store: unable to update notification digest date: %v`, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,300 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/model"
+)
+
+// maxOPMLSubscriptionSyncs is the number of synchronizations kept in the history of a remote OPML file.
+const maxOPMLSubscriptionSyncs = 50
+
+const opmlSubscriptionColumns = `
+	s.id,
+	s.user_id,
+	s.url,
+	s.category_id,
+	coalesce((SELECT c.title FROM categories c WHERE c.id=s.category_id), ''),
+	s.removal_policy,
+	s.etag_header,
+	s.last_modified_header,
+	s.checked_at,
+	s.created_at,
+	(SELECT count(*) FROM opml_subscription_feeds f WHERE f.subscription_id=s.id)
+`
+
+// OPMLSubscriptions returns the remote OPML files of a user.
+func (s *Storage) OPMLSubscriptions(userID int64) (model.OPMLSubscriptions, error) {
+	query := `SELECT ` + opmlSubscriptionColumns + ` FROM opml_subscriptions s WHERE s.user_id=$1 ORDER BY s.created_at ASC`
+	return s.fetchOPMLSubscriptions(query, userID)
+}
+
+// AllOPMLSubscriptions returns the remote OPML files of all users.
+func (s *Storage) AllOPMLSubscriptions() (model.OPMLSubscriptions, error) {
+	query := `SELECT ` + opmlSubscriptionColumns + ` FROM opml_subscriptions s ORDER BY s.checked_at ASC NULLS FIRST`
+	return s.fetchOPMLSubscriptions(query)
+}
+
+// OPMLSubscription returns a remote OPML file.
+func (s *Storage) OPMLSubscription(userID, subscriptionID int64) (*model.OPMLSubscription, error) {
+	query := `SELECT ` + opmlSubscriptionColumns + ` FROM opml_subscriptions s WHERE s.user_id=$1 AND s.id=$2`
+
+	subscription, err := scanOPMLSubscription(s.db.QueryRow(query, userID, subscriptionID))
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch OPML subscription: %v`, err)
+	}
+
+	return subscription, nil
+}
+
+// OPMLSubscriptionURLExists checks if the user is already subscribed to a remote OPML file.
+func (s *Storage) OPMLSubscriptionURLExists(userID int64, url string) bool {
+	var result bool
+	query := `SELECT true FROM opml_subscriptions WHERE user_id=$1 AND url=$2`
+	s.db.QueryRow(query, userID, url).Scan(&result)
+	return result
+}
+
+// CreateOPMLSubscription subscribes a user to a remote OPML file.
+func (s *Storage) CreateOPMLSubscription(subscription *model.OPMLSubscription) error {
+	query := `
+		INSERT INTO opml_subscriptions
+			(user_id, url, category_id, removal_policy)
+		VALUES
+			($1, $2, $3, $4)
+		RETURNING
+			id, created_at
+	`
+	err := s.db.QueryRow(
+		query,
+		subscription.UserID,
+		subscription.URL,
+		subscription.CategoryID,
+		subscription.RemovalPolicy,
+	).Scan(&subscription.ID, &subscription.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf(`store: unable to create OPML subscription: %v`, err)
+	}
+
+	return nil
+}
+
+// UpdateOPMLSubscriptionCheckedAt records the caching headers and the time of the last check.
+func (s *Storage) UpdateOPMLSubscriptionCheckedAt(subscription *model.OPMLSubscription) error {
+	query := `
+		UPDATE opml_subscriptions SET
+			etag_header=$1,
+			last_modified_header=$2,
+			checked_at=now()
+		WHERE
+			id=$3
+	`
+	if _, err := s.db.Exec(query, subscription.EtagHeader, subscription.LastModifiedHeader, subscription.ID); err != nil {
+		return fmt.Errorf(`store: unable to update OPML subscription: %v`, err)
+	}
+
+	return nil
+}
+
+// RemoveOPMLSubscription unsubscribes a user from a remote OPML file, the feeds are kept.
+func (s *Storage) RemoveOPMLSubscription(userID, subscriptionID int64) error {
+	if _, err := s.db.Exec(`DELETE FROM opml_subscriptions WHERE id=$1 AND user_id=$2`, subscriptionID, userID); err != nil {
+		return fmt.Errorf(`store: unable to remove OPML subscription: %v`, err)
+	}
+
+	return nil
+}
+
+// OPMLSubscriptionFeeds returns the feeds created from a remote OPML file.
+func (s *Storage) OPMLSubscriptionFeeds(subscriptionID int64) ([]*model.OPMLSubscriptionFeed, error) {
+	query := `
+		SELECT
+			f.id, f.feed_url, sf.disabled
+		FROM
+			opml_subscription_feeds sf
+		JOIN
+			feeds f ON f.id=sf.feed_id
+		WHERE
+			sf.subscription_id=$1
+	`
+	rows, err := s.db.Query(query, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch OPML subscription feeds: %v`, err)
+	}
+	defer rows.Close()
+
+	var feeds []*model.OPMLSubscriptionFeed
+	for rows.Next() {
+		var feed model.OPMLSubscriptionFeed
+		if err := rows.Scan(&feed.FeedID, &feed.FeedURL, &feed.Disabled); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch OPML subscription feed row: %v`, err)
+		}
+		feeds = append(feeds, &feed)
+	}
+
+	return feeds, nil
+}
+
+// AddOPMLSubscriptionFeed links a feed to the remote OPML file it was created from.
+func (s *Storage) AddOPMLSubscriptionFeed(subscriptionID, feedID int64) error {
+	query := `
+		INSERT INTO opml_subscription_feeds
+			(subscription_id, feed_id)
+		VALUES
+			($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := s.db.Exec(query, subscriptionID, feedID); err != nil {
+		return fmt.Errorf(`store: unable to link feed to OPML subscription: %v`, err)
+	}
+
+	return nil
+}
+
+// SetOPMLSubscriptionFeedDisabled disables or enables a feed that was removed from or added back to a remote OPML file.
+func (s *Storage) SetOPMLSubscriptionFeedDisabled(userID, subscriptionID, feedID int64, disabled bool) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	if _, err := tx.Exec(`UPDATE feeds SET disabled=$1 WHERE id=$2 AND user_id=$3`, disabled, feedID, userID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to update feed #%d: %v`, feedID, err)
+	}
+
+	query := `UPDATE opml_subscription_feeds SET disabled=$1 WHERE subscription_id=$2 AND feed_id=$3`
+	if _, err := tx.Exec(query, disabled, subscriptionID, feedID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to update OPML subscription feed: %v`, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// CreateOPMLSubscriptionSync records the outcome of a synchronization and trims the history.
+func (s *Storage) CreateOPMLSubscriptionSync(sync *model.OPMLSubscriptionSync) error {
+	query := `
+		INSERT INTO opml_subscription_syncs
+			(subscription_id, created, disabled, removed, failed, error_msg)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		RETURNING
+			id, synced_at
+	`
+	err := s.db.QueryRow(
+		query,
+		sync.SubscriptionID,
+		sync.Created,
+		sync.Disabled,
+		sync.Removed,
+		sync.Failed,
+		sync.ErrorMsg,
+	).Scan(&sync.ID, &sync.SyncedAt)
+
+	if err != nil {
+		return fmt.Errorf(`store: unable to create OPML subscription sync: %v`, err)
+	}
+
+	query = `
+		DELETE FROM opml_subscription_syncs
+		WHERE subscription_id=$1 AND id NOT IN (
+			SELECT id FROM opml_subscription_syncs WHERE subscription_id=$1 ORDER BY synced_at DESC LIMIT $2
+		)
+	`
+	if _, err := s.db.Exec(query, sync.SubscriptionID, maxOPMLSubscriptionSyncs); err != nil {
+		return fmt.Errorf(`store: unable to clean OPML subscription syncs: %v`, err)
+	}
+
+	return nil
+}
+
+// OPMLSubscriptionSyncs returns the synchronization history of a remote OPML file, most recent first.
+func (s *Storage) OPMLSubscriptionSyncs(subscriptionID int64) (model.OPMLSubscriptionSyncs, error) {
+	query := `
+		SELECT
+			id, subscription_id, created, disabled, removed, failed, error_msg, synced_at
+		FROM
+			opml_subscription_syncs
+		WHERE
+			subscription_id=$1
+		ORDER BY
+			synced_at DESC
+	`
+	rows, err := s.db.Query(query, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch OPML subscription syncs: %v`, err)
+	}
+	defer rows.Close()
+
+	syncs := make(model.OPMLSubscriptionSyncs, 0)
+	for rows.Next() {
+		var sync model.OPMLSubscriptionSync
+		err := rows.Scan(
+			&sync.ID,
+			&sync.SubscriptionID,
+			&sync.Created,
+			&sync.Disabled,
+			&sync.Removed,
+			&sync.Failed,
+			&sync.ErrorMsg,
+			&sync.SyncedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch OPML subscription sync row: %v`, err)
+		}
+		syncs = append(syncs, &sync)
+	}
+
+	return syncs, nil
+}
+
+func (s *Storage) fetchOPMLSubscriptions(query string, args ...interface{}) (model.OPMLSubscriptions, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch OPML subscriptions: %v`, err)
+	}
+	defer rows.Close()
+
+	subscriptions := make(model.OPMLSubscriptions, 0)
+	for rows.Next() {
+		subscription, err := scanOPMLSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch OPML subscription row: %v`, err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
+
+func scanOPMLSubscription(row rowScanner) (*model.OPMLSubscription, error) {
+	var subscription model.OPMLSubscription
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.UserID,
+		&subscription.URL,
+		&subscription.CategoryID,
+		&subscription.CategoryTitle,
+		&subscription.RemovalPolicy,
+		&subscription.EtagHeader,
+		&subscription.LastModifiedHeader,
+		&subscription.CheckedAt,
+		&subscription.CreatedAt,
+		&subscription.FeedCount,
+	)
+	return &subscription, err
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"miniflux.app/crypto"
+)
+
+// CreatePasswordResetToken returns a token that allows a user to choose a new password.
+func (s *Storage) CreatePasswordResetToken(userID int64, validity time.Duration) (string, error) {
+	token := crypto.GenerateRandomString(32)
+
+	query := `INSERT INTO password_reset_tokens (token, user_id, expires_at) VALUES ($1, $2, $3)`
+	if _, err := s.db.Exec(query, token, userID, time.Now().Add(validity)); err != nil {
+		return "", fmt.Errorf(`store: unable to create password reset token: %v`, err)
+	}
+
+	return token, nil
+}
+
+// UserIDByPasswordResetToken returns the user of a valid password reset token, or zero.
+func (s *Storage) UserIDByPasswordResetToken(token string) (int64, error) {
+	var userID int64
+
+	query := `
+		SELECT
+			t.user_id
+		FROM
+			password_reset_tokens t
+		JOIN
+			users u ON u.id=t.user_id
+		WHERE
+			t.token=$1 AND t.expires_at > now() AND u.status='active'
+	`
+	err := s.db.QueryRow(query, token).Scan(&userID)
+	switch {
+	case err == sql.ErrNoRows:
+		return 0, nil
+	case err != nil:
+		return 0, fmt.Errorf(`store: unable to fetch password reset token: %v`, err)
+	}
+
+	return userID, nil
+}
+
+// ResetPassword changes the password of a user, then removes their reset tokens and sessions.
+func (s *Storage) ResetPassword(userID int64, password string) error {
+	hashedPassword, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	if _, err := tx.Exec(`UPDATE users SET password=$1 WHERE id=$2`, hashedPassword, userID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to update password: %v`, err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM password_reset_tokens WHERE user_id=$1`, userID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to remove password reset tokens: %v`, err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM user_sessions WHERE user_id=$1`, userID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to remove user sessions: %v`, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// CleanExpiredPasswordResetTokens removes the expired password reset tokens.
+func (s *Storage) CleanExpiredPasswordResetTokens() int64 {
+	result, err := s.db.Exec(`DELETE FROM password_reset_tokens WHERE expires_at < now()`)
+	if err != nil {
+		return 0
+	}
+
+	n, _ := result.RowsAffected()
+	return n
+}
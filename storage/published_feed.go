@@ -0,0 +1,128 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/model"
+)
+
+const publishedFeedColumns = `
+	p.id,
+	p.user_id,
+	p.title,
+	p.kind,
+	p.token,
+	p.category_id,
+	coalesce((SELECT c.title FROM categories c WHERE c.id=p.category_id), ''),
+	p.feed_id,
+	coalesce((SELECT f.title FROM feeds f WHERE f.id=p.feed_id), ''),
+	p.search_query,
+	p.unread_only,
+	p.created_at
+`
+
+// PublishedFeeds returns the published feeds of a user.
+func (s *Storage) PublishedFeeds(userID int64) (model.PublishedFeeds, error) {
+	query := `SELECT ` + publishedFeedColumns + ` FROM published_feeds p WHERE p.user_id=$1 ORDER BY lower(p.title) ASC`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch published feeds: %v`, err)
+	}
+	defer rows.Close()
+
+	publishedFeeds := make(model.PublishedFeeds, 0)
+	for rows.Next() {
+		publishedFeed, err := scanPublishedFeed(rows)
+		if err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch published feed row: %v`, err)
+		}
+		publishedFeeds = append(publishedFeeds, publishedFeed)
+	}
+
+	return publishedFeeds, nil
+}
+
+// PublishedFeedByToken returns the published feed matching the given token.
+func (s *Storage) PublishedFeedByToken(token string) (*model.PublishedFeed, error) {
+	query := `SELECT ` + publishedFeedColumns + ` FROM published_feeds p WHERE p.token=$1`
+
+	publishedFeed, err := scanPublishedFeed(s.db.QueryRow(query, token))
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch published feed: %v`, err)
+	}
+
+	return publishedFeed, nil
+}
+
+// PublishedFeedTitleExists checks if the user already has a published feed with the given title.
+func (s *Storage) PublishedFeedTitleExists(userID int64, title string) bool {
+	var result bool
+	query := `SELECT true FROM published_feeds WHERE user_id=$1 AND title=$2`
+	s.db.QueryRow(query, userID, title).Scan(&result)
+	return result
+}
+
+// CreatePublishedFeed creates a new published feed.
+func (s *Storage) CreatePublishedFeed(publishedFeed *model.PublishedFeed) error {
+	query := `
+		INSERT INTO published_feeds
+			(user_id, title, kind, token, category_id, feed_id, search_query, unread_only)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING
+			id, created_at
+	`
+	err := s.db.QueryRow(
+		query,
+		publishedFeed.UserID,
+		publishedFeed.Title,
+		publishedFeed.Kind,
+		publishedFeed.Token,
+		publishedFeed.CategoryID,
+		publishedFeed.FeedID,
+		publishedFeed.SearchQuery,
+		publishedFeed.UnreadOnly,
+	).Scan(&publishedFeed.ID, &publishedFeed.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf(`store: unable to create published feed: %v`, err)
+	}
+
+	return nil
+}
+
+// RemovePublishedFeed deletes a published feed, its URLs stop working immediately.
+func (s *Storage) RemovePublishedFeed(userID, publishedFeedID int64) error {
+	if _, err := s.db.Exec(`DELETE FROM published_feeds WHERE id=$1 AND user_id=$2`, publishedFeedID, userID); err != nil {
+		return fmt.Errorf(`store: unable to remove published feed: %v`, err)
+	}
+
+	return nil
+}
+
+func scanPublishedFeed(row rowScanner) (*model.PublishedFeed, error) {
+	var publishedFeed model.PublishedFeed
+	err := row.Scan(
+		&publishedFeed.ID,
+		&publishedFeed.UserID,
+		&publishedFeed.Title,
+		&publishedFeed.Kind,
+		&publishedFeed.Token,
+		&publishedFeed.CategoryID,
+		&publishedFeed.CategoryTitle,
+		&publishedFeed.FeedID,
+		&publishedFeed.FeedTitle,
+		&publishedFeed.SearchQuery,
+		&publishedFeed.UnreadOnly,
+		&publishedFeed.CreatedAt,
+	)
+	return &publishedFeed, err
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"fmt"
+)
+
+// retentionPolicies selects the retention policy of each feed: the policy of the feed, otherwise the one of its category,
+// limited by the caps of the user. A NULL limit means the entries of the feed are not archived by this rule.
+const retentionPolicies = `
+	policies AS (
+		SELECT
+			f.id AS feed_id,
+			CASE WHEN f.retention_mode <> '' THEN f.retention_delete ELSE c.retention_delete END AS delete_entries,
+			LEAST(
+				CASE
+					WHEN f.retention_mode = 'days' THEN NULLIF(f.retention_value, 0)
+					WHEN f.retention_mode = '' AND c.retention_mode = 'days' THEN NULLIF(c.retention_value, 0)
+				END,
+				NULLIF(u.retention_max_days, 0)
+			) AS max_days,
+			LEAST(
+				CASE
+					WHEN f.retention_mode = 'count' THEN NULLIF(f.retention_value, 0)
+					WHEN f.retention_mode = '' AND c.retention_mode = 'count' THEN NULLIF(c.retention_value, 0)
+				END,
+				NULLIF(u.retention_max_entries, 0)
+			) AS max_entries
+		FROM
+			feeds f
+		JOIN
+			categories c ON c.id=f.category_id
+		JOIN
+			users u ON u.id=f.user_id
+	)
+`
+
+// retentionActions marks the candidates as removed, or removes their content and enclosures when the policy deletes entries.
+// The rows of deleted entries are kept to avoid fetching them again and are cleaned up once they are no longer in the feed.
+const retentionActions = `
+	removed AS (
+		UPDATE entries SET status='removed'
+		WHERE id IN (SELECT id FROM candidates WHERE NOT delete_entries)
+		RETURNING 1
+	),
+	deleted AS (
+		UPDATE entries SET status='removed', content='', document_vectors=NULL
+		WHERE id IN (SELECT id FROM candidates WHERE delete_entries)
+		RETURNING 1
+	),
+	deleted_enclosures AS (
+		DELETE FROM enclosures WHERE entry_id IN (SELECT id FROM candidates WHERE delete_entries)
+	)
+	SELECT (SELECT count(*) FROM removed), (SELECT count(*) FROM deleted)
+`
+
+// ArchiveEntriesByRetentionAge archives the entries older than the number of days kept by the retention policies
+// and the caps of users. Starred and shared entries are always kept.
+func (s *Storage) ArchiveEntriesByRetentionAge(limit int) (removed, deleted int64, err error) {
+	query := `
+		WITH ` + retentionPolicies + `,
+		candidates AS (
+			SELECT
+				e.id,
+				p.delete_entries
+			FROM
+				entries e
+			JOIN
+				policies p ON p.feed_id=e.feed_id
+			WHERE
+				p.max_days IS NOT NULL AND
+				e.starred is false AND
+				e.share_code='' AND
+				e.created_at < now() - make_interval(days => p.max_days) AND
+				(e.status <> 'removed' OR (p.delete_entries AND e.content <> ''))
+			ORDER BY e.created_at ASC
+			LIMIT $1
+		),` + retentionActions
+
+	return s.archiveEntriesByRetention(query, limit, "age")
+}
+
+// ArchiveEntriesByRetentionCount archives the entries beyond the number of most recent entries kept per feed
+// by the retention policies and the caps of users. Starred and shared entries are always kept.
+func (s *Storage) ArchiveEntriesByRetentionCount(limit int) (removed, deleted int64, err error) {
+	query := `
+		WITH ` + retentionPolicies + `,
+		ranked_entries AS (
+			SELECT
+				e.id,
+				e.status,
+				e.content,
+				e.starred,
+				e.share_code,
+				e.created_at,
+				p.delete_entries,
+				p.max_entries,
+				row_number() OVER (PARTITION BY e.feed_id ORDER BY e.published_at DESC, e.id DESC) AS position
+			FROM
+				entries e
+			JOIN
+				policies p ON p.feed_id=e.feed_id
+			WHERE
+				p.max_entries IS NOT NULL
+		),
+		candidates AS (
+			SELECT
+				id,
+				delete_entries
+			FROM
+				ranked_entries
+			WHERE
+				position > max_entries AND
+				starred is false AND
+				share_code='' AND
+				(status <> 'removed' OR (delete_entries AND content <> ''))
+			ORDER BY created_at ASC
+			LIMIT $1
+		),` + retentionActions
+
+	return s.archiveEntriesByRetention(query, limit, "count")
+}
+
+func (s *Storage) archiveEntriesByRetention(query string, limit int, rule string) (removed, deleted int64, err error) {
+	if limit <= 0 {
+		return 0, 0, nil
+	}
+
+	if err := s.db.QueryRow(query, limit).Scan(&removed, &deleted); err != nil {
+		return 0, 0, fmt.Errorf(`store: unable to archive entries by retention %s: %v`, rule, err)
+	}
+
+	return removed, deleted, nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/model"
+)
+
+// TwoFactor returns the two-factor authentication settings of a user.
+func (s *Storage) TwoFactor(userID int64) (*model.TwoFactor, error) {
+	query := `
+		SELECT
+			id, totp_secret, totp_enabled, totp_last_counter, two_factor_required
+		FROM
+			users
+		WHERE
+			id=$1
+	`
+
+	var twoFactor model.TwoFactor
+	err := s.db.QueryRow(query, userID).Scan(
+		&twoFactor.UserID,
+		&twoFactor.Secret,
+		&twoFactor.Enabled,
+		&twoFactor.LastCounter,
+		&twoFactor.Required,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch two-factor settings: %v`, err)
+	}
+
+	return &twoFactor, nil
+}
+
+// HasTwoFactorEnabled returns true if the user must enter a second factor to log in.
+func (s *Storage) HasTwoFactorEnabled(username string) bool {
+	var result bool
+	s.db.QueryRow(`SELECT totp_enabled FROM users WHERE username=LOWER($1)`, username).Scan(&result)
+	return result
+}
+
+// SetTOTPSecret stores the secret of an enrollment in progress, it replaces any previous secret.
+func (s *Storage) SetTOTPSecret(userID int64, secret string) error {
+	query := `UPDATE users SET totp_secret=$1, totp_enabled='f', totp_last_counter=0 WHERE id=$2 AND totp_enabled='f'`
+	if _, err := s.db.Exec(query, secret, userID); err != nil {
+		return fmt.Errorf(`store: unable to update TOTP secret: %v`, err)
+	}
+
+	return nil
+}
+
+// EnableTOTP completes the enrollment and replaces the recovery codes.
+func (s *Storage) EnableTOTP(userID, counter int64, recoveryCodes []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	if _, err := tx.Exec(`UPDATE users SET totp_enabled='t', totp_last_counter=$1 WHERE id=$2 AND totp_secret <> ''`, counter, userID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to enable TOTP: %v`, err)
+	}
+
+	if err := replaceRecoveryCodes(tx, userID, recoveryCodes); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// DisableTOTP removes the secret and the recovery codes of a user.
+func (s *Storage) DisableTOTP(userID int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	if _, err := tx.Exec(`UPDATE users SET totp_secret='', totp_enabled='f', totp_last_counter=0 WHERE id=$1`, userID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to disable TOTP: %v`, err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM recovery_codes WHERE user_id=$1`, userID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to remove recovery codes: %v`, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// UseTOTPCounter records the counter of a verified code.
+// It returns false if a code of the same or a later period was used concurrently.
+func (s *Storage) UseTOTPCounter(userID, counter int64) (bool, error) {
+	result, err := s.db.Exec(`UPDATE users SET totp_last_counter=$1 WHERE id=$2 AND totp_last_counter < $1`, counter, userID)
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to update TOTP counter: %v`, err)
+	}
+
+	count, _ := result.RowsAffected()
+	return count == 1, nil
+}
+
+// SetTwoFactorRequired forces a user to set up a second factor.
+func (s *Storage) SetTwoFactorRequired(userID int64, required bool) error {
+	if _, err := s.db.Exec(`UPDATE users SET two_factor_required=$1 WHERE id=$2`, required, userID); err != nil {
+		return fmt.Errorf(`store: unable to update two-factor policy: %v`, err)
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes invalidates the recovery codes of a user and stores new ones.
+func (s *Storage) ReplaceRecoveryCodes(userID int64, recoveryCodes []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	if err := replaceRecoveryCodes(tx, userID, recoveryCodes); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+func replaceRecoveryCodes(tx *sql.Tx, userID int64, recoveryCodes []string) error {
+	if _, err := tx.Exec(`DELETE FROM recovery_codes WHERE user_id=$1`, userID); err != nil {
+		return fmt.Errorf(`store: unable to remove recovery codes: %v`, err)
+	}
+
+	for _, code := range recoveryCodes {
+		if _, err := tx.Exec(`INSERT INTO recovery_codes (user_id, code_hash) VALUES ($1, $2)`, userID, model.HashRecoveryCode(code)); err != nil {
+			return fmt.Errorf(`store: unable to create recovery code: %v`, err)
+		}
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks a recovery code as used, it returns false if the code is unknown or already used.
+func (s *Storage) UseRecoveryCode(userID int64, code string) (bool, error) {
+	query := `UPDATE recovery_codes SET used_at=now() WHERE user_id=$1 AND code_hash=$2 AND used_at IS NULL`
+	result, err := s.db.Exec(query, userID, model.HashRecoveryCode(code))
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to use recovery code: %v`, err)
+	}
+
+	count, _ := result.RowsAffected()
+	return count == 1, nil
+}
+
+// CountUnusedRecoveryCodes returns the number of recovery codes left.
+func (s *Storage) CountUnusedRecoveryCodes(userID int64) int {
+	var count int
+	s.db.QueryRow(`SELECT count(*) FROM recovery_codes WHERE user_id=$1 AND used_at IS NULL`, userID).Scan(&count)
+	return count
+}
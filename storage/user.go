@@ -46,6 +46,13 @@ func (s *Storage) UserExists(username string) bool {
 	return result
 }
 
+// AnotherUserWithEmailExists checks if another user has the given email address.
+func (s *Storage) AnotherUserWithEmailExists(userID int64, email string) bool {
+	var result bool
+	s.db.QueryRow(`SELECT true FROM users WHERE id != $1 AND lower(email)=lower($2)`, userID, email).Scan(&result)
+	return result
+}
+
 // AnotherUserExists checks if another user exists with the given username.
 func (s *Storage) AnotherUserExists(userID int64, username string) bool {
 	var result bool
@@ -66,13 +73,16 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 
 	query := `
 		INSERT INTO users
-			(username, password, is_admin, google_id, openid_connect_id)
+			(username, password, is_admin, role, status, email, google_id, openid_connect_id)
 		VALUES
-			(LOWER($1), $2, $3, $4, $5)
+			(LOWER($1), $2, $3 = 'admin', $3, $4, $5, $6, $7)
 		RETURNING
 			id,
 			username,
 			is_admin,
+			role,
+			status,
+			email,
 			language,
 			theme,
 			timezone,
@@ -89,7 +99,11 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 		    default_reading_speed,
 		    cjk_reading_speed,
 		    default_home_page,
-		    categories_sorting_order
+		    categories_sorting_order,
+		    mark_read_at_end,
+		    entry_scoring,
+		    retention_max_days,
+		    retention_max_entries
 	`
 
 	tx, err := s.db.Begin()
@@ -102,13 +116,18 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 		query,
 		userCreationRequest.Username,
 		hashedPassword,
-		userCreationRequest.IsAdmin,
+		userCreationRequest.UserRole(),
+		userCreationRequest.UserStatus(),
+		userCreationRequest.Email,
 		userCreationRequest.GoogleID,
 		userCreationRequest.OpenIDConnectID,
 	).Scan(
 		&user.ID,
 		&user.Username,
 		&user.IsAdmin,
+		&user.Role,
+		&user.Status,
+		&user.Email,
 		&user.Language,
 		&user.Theme,
 		&user.Timezone,
@@ -126,6 +145,10 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 		&user.CJKReadingSpeed,
 		&user.DefaultHomePage,
 		&user.CategoriesSortingOrder,
+		&user.MarkReadAtEnd,
+		&user.EntryScoring,
+		&user.RetentionMaxDays,
+		&user.RetentionMaxEntries,
 	)
 	if err != nil {
 		tx.Rollback()
@@ -163,7 +186,8 @@ func (s *Storage) UpdateUser(user *model.User) error {
 			UPDATE users SET
 				username=LOWER($1),
 				password=$2,
-				is_admin=$3,
+				is_admin=($3 = 'admin'),
+				role=$3,
 				theme=$4,
 				language=$5,
 				timezone=$6,
@@ -180,16 +204,22 @@ func (s *Storage) UpdateUser(user *model.User) error {
 				default_reading_speed=$17,
 				cjk_reading_speed=$18,
 				default_home_page=$19,
-				categories_sorting_order=$20
+				categories_sorting_order=$20,
+				status=$21,
+				email=$22,
+				mark_read_at_end=$23,
+				entry_scoring=$24,
+				retention_max_days=$25,
+				retention_max_entries=$26
 			WHERE
-				id=$21
+				id=$27
 		`
 
 		_, err = s.db.Exec(
 			query,
 			user.Username,
 			hashedPassword,
-			user.IsAdmin,
+			user.Role,
 			user.Theme,
 			user.Language,
 			user.Timezone,
@@ -207,6 +237,12 @@ func (s *Storage) UpdateUser(user *model.User) error {
 			user.CJKReadingSpeed,
 			user.DefaultHomePage,
 			user.CategoriesSortingOrder,
+			user.Status,
+			user.Email,
+			user.MarkReadAtEnd,
+			user.EntryScoring,
+			user.RetentionMaxDays,
+			user.RetentionMaxEntries,
 			user.ID,
 		)
 		if err != nil {
@@ -216,7 +252,8 @@ func (s *Storage) UpdateUser(user *model.User) error {
 		query := `
 			UPDATE users SET
 				username=LOWER($1),
-				is_admin=$2,
+				is_admin=($2 = 'admin'),
+				role=$2,
 				theme=$3,
 				language=$4,
 				timezone=$5,
@@ -233,15 +270,21 @@ func (s *Storage) UpdateUser(user *model.User) error {
 				default_reading_speed=$16,
 				cjk_reading_speed=$17,
 				default_home_page=$18,
-				categories_sorting_order=$19
+				categories_sorting_order=$19,
+				status=$20,
+				email=$21,
+				mark_read_at_end=$22,
+				entry_scoring=$23,
+				retention_max_days=$24,
+				retention_max_entries=$25
 			WHERE
-				id=$20
+				id=$26
 		`
 
 		_, err := s.db.Exec(
 			query,
 			user.Username,
-			user.IsAdmin,
+			user.Role,
 			user.Theme,
 			user.Language,
 			user.Timezone,
@@ -259,6 +302,12 @@ func (s *Storage) UpdateUser(user *model.User) error {
 			user.CJKReadingSpeed,
 			user.DefaultHomePage,
 			user.CategoriesSortingOrder,
+			user.Status,
+			user.Email,
+			user.MarkReadAtEnd,
+			user.EntryScoring,
+			user.RetentionMaxDays,
+			user.RetentionMaxEntries,
 			user.ID,
 		)
 
@@ -270,6 +319,32 @@ func (s *Storage) UpdateUser(user *model.User) error {
 	return nil
 }
 
+// SetUserStatus enables or disables a user, the sessions of a disabled user are removed.
+func (s *Storage) SetUserStatus(userID int64, status string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+
+	if _, err := tx.Exec(`UPDATE users SET status=$1 WHERE id=$2`, status, userID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf(`store: unable to update user status: %v`, err)
+	}
+
+	if status != model.UserStatusActive {
+		if _, err := tx.Exec(`DELETE FROM user_sessions WHERE user_id=$1`, userID); err != nil {
+			tx.Rollback()
+			return fmt.Errorf(`store: unable to remove user sessions: %v`, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
 // UserLanguage returns the language of the given user.
 func (s *Storage) UserLanguage(userID int64) (language string) {
 	err := s.db.QueryRow(`SELECT language FROM users WHERE id = $1`, userID).Scan(&language)
@@ -287,6 +362,9 @@ func (s *Storage) UserByID(userID int64) (*model.User, error) {
 			id,
 			username,
 			is_admin,
+			role,
+			status,
+			email,
 			theme,
 			language,
 			timezone,
@@ -304,7 +382,11 @@ func (s *Storage) UserByID(userID int64) (*model.User, error) {
 			default_reading_speed,
 			cjk_reading_speed,
 			default_home_page,
-			categories_sorting_order
+			categories_sorting_order,
+			mark_read_at_end,
+			entry_scoring,
+			retention_max_days,
+			retention_max_entries
 		FROM
 			users
 		WHERE
@@ -320,6 +402,9 @@ func (s *Storage) UserByUsername(username string) (*model.User, error) {
 			id,
 			username,
 			is_admin,
+			role,
+			status,
+			email,
 			theme,
 			language,
 			timezone,
@@ -337,7 +422,11 @@ func (s *Storage) UserByUsername(username string) (*model.User, error) {
 			default_reading_speed,
 			cjk_reading_speed,
 			default_home_page,
-			categories_sorting_order
+			categories_sorting_order,
+			mark_read_at_end,
+			entry_scoring,
+			retention_max_days,
+			retention_max_entries
 		FROM
 			users
 		WHERE
@@ -346,6 +435,46 @@ func (s *Storage) UserByUsername(username string) (*model.User, error) {
 	return s.fetchUser(query, username)
 }
 
+// UserByEmail finds a user by the email address.
+func (s *Storage) UserByEmail(email string) (*model.User, error) {
+	query := `
+		SELECT
+			id,
+			username,
+			is_admin,
+			role,
+			status,
+			email,
+			theme,
+			language,
+			timezone,
+			entry_direction,
+			entries_per_page,
+			keyboard_shortcuts,
+			show_reading_time,
+			entry_swipe,
+			last_login_at,
+			stylesheet,
+			google_id,
+			openid_connect_id,
+			display_mode,
+			entry_order,
+			default_reading_speed,
+			cjk_reading_speed,
+			default_home_page,
+			categories_sorting_order,
+			mark_read_at_end,
+			entry_scoring,
+			retention_max_days,
+			retention_max_entries
+		FROM
+			users
+		WHERE
+			email <> '' AND lower(email)=lower($1)
+	`
+	return s.fetchUser(query, email)
+}
+
 // UserByField finds a user by a field value.
 func (s *Storage) UserByField(field, value string) (*model.User, error) {
 	query := `
@@ -353,6 +482,9 @@ func (s *Storage) UserByField(field, value string) (*model.User, error) {
 			id,
 			username,
 			is_admin,
+			role,
+			status,
+			email,
 			theme,
 			language,
 			timezone,
@@ -370,7 +502,11 @@ func (s *Storage) UserByField(field, value string) (*model.User, error) {
 			default_reading_speed,
 			cjk_reading_speed,
 			default_home_page,
-			categories_sorting_order
+			categories_sorting_order,
+			mark_read_at_end,
+			entry_scoring,
+			retention_max_days,
+			retention_max_entries
 		FROM
 			users
 		WHERE
@@ -393,6 +529,9 @@ func (s *Storage) UserByAPIKey(token string) (*model.User, error) {
 			u.id,
 			u.username,
 			u.is_admin,
+			u.role,
+			u.status,
+			u.email,
 			u.theme,
 			u.language,
 			u.timezone,
@@ -410,7 +549,11 @@ func (s *Storage) UserByAPIKey(token string) (*model.User, error) {
 			u.default_reading_speed,
 			u.cjk_reading_speed,
 			u.default_home_page,
-			u.categories_sorting_order
+			u.categories_sorting_order,
+			u.mark_read_at_end,
+			u.entry_scoring,
+			u.retention_max_days,
+			u.retention_max_entries
 		FROM
 			users u
 		LEFT JOIN
@@ -427,6 +570,9 @@ func (s *Storage) fetchUser(query string, args ...interface{}) (*model.User, err
 		&user.ID,
 		&user.Username,
 		&user.IsAdmin,
+		&user.Role,
+		&user.Status,
+		&user.Email,
 		&user.Theme,
 		&user.Language,
 		&user.Timezone,
@@ -445,6 +591,10 @@ func (s *Storage) fetchUser(query string, args ...interface{}) (*model.User, err
 		&user.CJKReadingSpeed,
 		&user.DefaultHomePage,
 		&user.CategoriesSortingOrder,
+		&user.MarkReadAtEnd,
+		&user.EntryScoring,
+		&user.RetentionMaxDays,
+		&user.RetentionMaxEntries,
 	)
 
 	if err == sql.ErrNoRows {
@@ -523,6 +673,9 @@ func (s *Storage) Users() (model.Users, error) {
 			id,
 			username,
 			is_admin,
+			role,
+			status,
+			email,
 			theme,
 			language,
 			timezone,
@@ -540,7 +693,11 @@ func (s *Storage) Users() (model.Users, error) {
 			default_reading_speed,
 			cjk_reading_speed,
 			default_home_page,
-			categories_sorting_order
+			categories_sorting_order,
+			mark_read_at_end,
+			entry_scoring,
+			retention_max_days,
+			retention_max_entries
 		FROM
 			users
 		ORDER BY username ASC
@@ -558,6 +715,9 @@ func (s *Storage) Users() (model.Users, error) {
 			&user.ID,
 			&user.Username,
 			&user.IsAdmin,
+			&user.Role,
+			&user.Status,
+			&user.Email,
 			&user.Theme,
 			&user.Language,
 			&user.Timezone,
@@ -576,6 +736,10 @@ func (s *Storage) Users() (model.Users, error) {
 			&user.CJKReadingSpeed,
 			&user.DefaultHomePage,
 			&user.CategoriesSortingOrder,
+			&user.MarkReadAtEnd,
+			&user.EntryScoring,
+			&user.RetentionMaxDays,
+			&user.RetentionMaxEntries,
 		)
 
 		if err != nil {
@@ -590,10 +754,10 @@ func (s *Storage) Users() (model.Users, error) {
 
 // CheckPassword validate the hashed password.
 func (s *Storage) CheckPassword(username, password string) error {
-	var hash string
+	var hash, status string
 	username = strings.ToLower(username)
 
-	err := s.db.QueryRow("SELECT password FROM users WHERE username=$1", username).Scan(&hash)
+	err := s.db.QueryRow("SELECT password, status FROM users WHERE username=$1", username).Scan(&hash, &status)
 	if err == sql.ErrNoRows {
 		return fmt.Errorf(`store: unable to find this user: %s`, username)
 	} else if err != nil {
@@ -604,6 +768,10 @@ func (s *Storage) CheckPassword(username, password string) error {
 		return fmt.Errorf(`store: invalid password for "%s" (%v)`, username, err)
 	}
 
+	if status != model.UserStatusActive {
+		return fmt.Errorf(`store: the account "%s" is %s`, username, status)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,141 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package storage // import "miniflux.app/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/model"
+)
+
+// UserInvitations returns the invitations that are not expired.
+func (s *Storage) UserInvitations() (model.UserInvitations, error) {
+	query := `
+		SELECT
+			id, token, email, role, created_by, expires_at, created_at
+		FROM
+			user_invitations
+		WHERE
+			expires_at > now()
+		ORDER BY created_at DESC
+	`
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch invitations: %v`, err)
+	}
+	defer rows.Close()
+
+	invitations := make(model.UserInvitations, 0)
+	for rows.Next() {
+		var invitation model.UserInvitation
+		if err := rows.Scan(
+			&invitation.ID,
+			&invitation.Token,
+			&invitation.Email,
+			&invitation.Role,
+			&invitation.CreatedBy,
+			&invitation.ExpiresAt,
+			&invitation.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch invitation row: %v`, err)
+		}
+
+		invitations = append(invitations, &invitation)
+	}
+
+	return invitations, nil
+}
+
+// UserInvitationByToken returns an invitation that is not expired.
+func (s *Storage) UserInvitationByToken(token string) (*model.UserInvitation, error) {
+	query := `
+		SELECT
+			id, token, email, role, created_by, expires_at, created_at
+		FROM
+			user_invitations
+		WHERE
+			token=$1 AND expires_at > now()
+	`
+
+	var invitation model.UserInvitation
+	err := s.db.QueryRow(query, token).Scan(
+		&invitation.ID,
+		&invitation.Token,
+		&invitation.Email,
+		&invitation.Role,
+		&invitation.CreatedBy,
+		&invitation.ExpiresAt,
+		&invitation.CreatedAt,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch invitation: %v`, err)
+	}
+
+	return &invitation, nil
+}
+
+// CreateUserInvitation stores a new invitation.
+func (s *Storage) CreateUserInvitation(invitation *model.UserInvitation) error {
+	query := `
+		INSERT INTO user_invitations
+			(token, email, role, created_by, expires_at)
+		VALUES
+			($1, $2, $3, $4, $5)
+		RETURNING
+			id, created_at
+	`
+	err := s.db.QueryRow(
+		query,
+		invitation.Token,
+		invitation.Email,
+		invitation.Role,
+		invitation.CreatedBy,
+		invitation.ExpiresAt,
+	).Scan(&invitation.ID, &invitation.CreatedAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to create invitation: %v`, err)
+	}
+
+	return nil
+}
+
+// AcceptUserInvitation creates the account of an invited user, an invitation can be used only once.
+func (s *Storage) AcceptUserInvitation(invitation *model.UserInvitation, userCreationRequest *model.UserCreationRequest) (*model.User, error) {
+	result, err := s.db.Exec(`DELETE FROM user_invitations WHERE id=$1 AND expires_at > now()`, invitation.ID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to remove invitation: %v`, err)
+	}
+
+	if count, _ := result.RowsAffected(); count == 0 {
+		return nil, fmt.Errorf(`store: the invitation #%d is no longer valid`, invitation.ID)
+	}
+
+	return s.CreateUser(userCreationRequest)
+}
+
+// RemoveUserInvitation deletes an invitation.
+func (s *Storage) RemoveUserInvitation(invitationID int64) error {
+	if _, err := s.db.Exec(`DELETE FROM user_invitations WHERE id=$1`, invitationID); err != nil {
+		return fmt.Errorf(`store: unable to remove invitation: %v`, err)
+	}
+
+	return nil
+}
+
+// CleanExpiredUserInvitations removes the expired invitations.
+func (s *Storage) CleanExpiredUserInvitations() int64 {
+	result, err := s.db.Exec(`DELETE FROM user_invitations WHERE expires_at < now()`)
+	if err != nil {
+		return 0
+	}
+
+	n, _ := result.RowsAffected()
+	return n
+}
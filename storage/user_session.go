@@ -64,7 +64,7 @@ func (s *Storage) CreateUserSessionFromUsername(username, userAgent, ip string)
 		return "", 0, fmt.Errorf(`store: unable to start transaction: %v`, err)
 	}
 
-	err = tx.QueryRow(`SELECT id FROM users WHERE username = LOWER($1)`, username).Scan(&userID)
+	err = tx.QueryRow(`SELECT id FROM users WHERE username = LOWER($1) AND status='active'`, username).Scan(&userID)
 	if err != nil {
 		tx.Rollback()
 		return "", 0, fmt.Errorf(`store: unable to fetch user ID: %v`, err)
@@ -95,16 +95,21 @@ func (s *Storage) UserSessionByToken(token string) (*model.UserSession, error) {
 
 	query := `
 		SELECT
-			id,
-			user_id,
-			token,
-			created_at,
-			user_agent,
-			ip 
+			s.id,
+			s.user_id,
+			s.token,
+			s.created_at,
+			s.user_agent,
+			s.ip,
+			u.role,
+			u.totp_enabled,
+			u.two_factor_required
 		FROM
-			user_sessions
+			user_sessions s
+		JOIN
+			users u ON u.id=s.user_id
 		WHERE
-			token = $1
+			s.token = $1 AND u.status='active'
 	`
 	err := s.db.QueryRow(query, token).Scan(
 		&session.ID,
@@ -113,6 +118,9 @@ func (s *Storage) UserSessionByToken(token string) (*model.UserSession, error) {
 		&session.CreatedAt,
 		&session.UserAgent,
 		&session.IP,
+		&session.UserRole,
+		&session.TwoFactorEnabled,
+		&session.TwoFactorRequired,
 	)
 
 	switch {
@@ -165,6 +173,38 @@ func (s *Storage) RemoveUserSessionByID(userID, sessionID int64) error {
 	return nil
 }
 
+// SetUserSessionIdPSessionID remembers the session of the identity provider that opened this user session.
+func (s *Storage) SetUserSessionIdPSessionID(token, idpSessionID string) error {
+	query := `UPDATE user_sessions SET idp_session_id=$1 WHERE token=$2`
+	if _, err := s.db.Exec(query, idpSessionID, token); err != nil {
+		return fmt.Errorf(`store: unable to update user session: %v`, err)
+	}
+
+	return nil
+}
+
+// RemoveUserSessionsByIdPSessionID removes the user sessions opened by the given identity provider session.
+func (s *Storage) RemoveUserSessionsByIdPSessionID(idpSessionID string) (int64, error) {
+	result, err := s.db.Exec(`DELETE FROM user_sessions WHERE idp_session_id=$1`, idpSessionID)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to remove user sessions: %v`, err)
+	}
+
+	count, _ := result.RowsAffected()
+	return count, nil
+}
+
+// RemoveUserSessions removes all the sessions of a user.
+func (s *Storage) RemoveUserSessions(userID int64) (int64, error) {
+	result, err := s.db.Exec(`DELETE FROM user_sessions WHERE user_id=$1`, userID)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to remove user sessions: %v`, err)
+	}
+
+	count, _ := result.RowsAffected()
+	return count, nil
+}
+
 // CleanOldUserSessions removes user sessions older than specified days.
 func (s *Storage) CleanOldUserSessions(days int) int64 {
 	query := `
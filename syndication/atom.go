@@ -0,0 +1,128 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package syndication // import "miniflux.app/syndication"
+
+import (
+	"bytes"
+	"encoding/xml"
+	"time"
+)
+
+// AtomContentType is the media type of Atom documents.
+const AtomContentType = "application/atom+xml; charset=utf-8"
+
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+type atomFeed struct {
+	XMLName xml.Name     `xml:"feed"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	ID      string       `xml:"id"`
+	Title   string       `xml:"title"`
+	Updated string       `xml:"updated"`
+	Links   []atomLink   `xml:"link"`
+	Entries []*atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Author    *atomAuthor `xml:"author,omitempty"`
+	Links     []atomLink  `xml:"link"`
+	Content   atomContent `xml:"content"`
+	Source    *atomSource `xml:"source,omitempty"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length int64  `xml:"length,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Data string `xml:",chardata"`
+}
+
+type atomSource struct {
+	ID    string     `xml:"id"`
+	Title string     `xml:"title"`
+	Links []atomLink `xml:"link"`
+}
+
+// Atom returns the channel as an Atom 1.0 document.
+func Atom(channel *Channel) ([]byte, error) {
+	feed := &atomFeed{
+		Xmlns:   atomNamespace,
+		ID:      channel.ID,
+		Title:   channel.Title,
+		Updated: atomDate(channel.Updated),
+		Links: []atomLink{
+			{Href: channel.FeedURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: channel.SiteURL, Rel: "alternate", Type: "text/html"},
+		},
+	}
+
+	for _, entry := range channel.Entries {
+		item := &atomEntry{
+			ID:        entryID(entry),
+			Title:     entry.Title,
+			Published: atomDate(entry.Date),
+			Updated:   atomDate(entryUpdated(entry)),
+			Links:     []atomLink{{Href: entry.URL, Rel: "alternate", Type: "text/html"}},
+			Content:   atomContent{Type: "html", Data: entry.Content},
+		}
+
+		if author := entryAuthor(entry); author != "" {
+			item.Author = &atomAuthor{Name: author}
+		}
+
+		if entry.CommentsURL != "" {
+			item.Links = append(item.Links, atomLink{Href: entry.CommentsURL, Rel: "replies", Type: "text/html"})
+		}
+
+		for _, enclosure := range entry.Enclosures {
+			item.Links = append(item.Links, atomLink{
+				Href:   enclosure.URL,
+				Rel:    "enclosure",
+				Type:   enclosure.MimeType,
+				Length: enclosure.Size,
+			})
+		}
+
+		if entry.Feed != nil {
+			item.Source = &atomSource{
+				ID:    entry.Feed.FeedURL,
+				Title: entry.Feed.Title,
+				Links: []atomLink{
+					{Href: entry.Feed.FeedURL, Rel: "self"},
+					{Href: entry.Feed.SiteURL, Rel: "alternate"},
+				},
+			}
+		}
+
+		feed.Entries = append(feed.Entries, item)
+	}
+
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&b)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(feed); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+func atomDate(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package syndication // import "miniflux.app/syndication"
+
+import (
+	"fmt"
+	"time"
+
+	"miniflux.app/model"
+)
+
+// Channel holds the metadata and the entries of a generated feed.
+type Channel struct {
+	ID      string
+	Title   string
+	SiteURL string
+	FeedURL string
+	Updated time.Time
+	Entries model.Entries
+}
+
+// NewChannel returns a channel whose update time is the most recent change among the entries.
+func NewChannel(id, title, siteURL, feedURL string, createdAt time.Time, entries model.Entries) *Channel {
+	channel := &Channel{
+		ID:      id,
+		Title:   title,
+		SiteURL: siteURL,
+		FeedURL: feedURL,
+		Updated: createdAt,
+		Entries: entries,
+	}
+
+	for _, entry := range entries {
+		if updated := entryUpdated(entry); updated.After(channel.Updated) {
+			channel.Updated = updated
+		}
+	}
+
+	return channel
+}
+
+func entryID(entry *model.Entry) string {
+	return fmt.Sprintf("urn:miniflux:entry:%d", entry.ID)
+}
+
+func entryUpdated(entry *model.Entry) time.Time {
+	if entry.ChangedAt.After(entry.Date) {
+		return entry.ChangedAt
+	}
+	return entry.Date
+}
+
+func entryAuthor(entry *model.Entry) string {
+	if entry.Author != "" {
+		return entry.Author
+	}
+	if entry.Feed != nil {
+		return entry.Feed.Title
+	}
+	return ""
+}
@@ -0,0 +1,8 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+/*
+Package syndication generates Atom and JSON Feed documents from a list of entries.
+*/
+package syndication // import "miniflux.app/syndication"
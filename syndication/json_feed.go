@@ -0,0 +1,93 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package syndication // import "miniflux.app/syndication"
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// JSONFeedContentType is the media type of JSON Feed documents.
+const JSONFeedContentType = "application/feed+json; charset=utf-8"
+
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+type jsonFeed struct {
+	Version     string          `json:"version"`
+	Title       string          `json:"title"`
+	HomePageURL string          `json:"home_page_url,omitempty"`
+	FeedURL     string          `json:"feed_url,omitempty"`
+	Items       []*jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string               `json:"id"`
+	URL           string               `json:"url,omitempty"`
+	Title         string               `json:"title,omitempty"`
+	ContentHTML   string               `json:"content_html"`
+	DatePublished string               `json:"date_published,omitempty"`
+	DateModified  string               `json:"date_modified,omitempty"`
+	Authors       []jsonFeedAuthor     `json:"authors,omitempty"`
+	Attachments   []jsonFeedAttachment `json:"attachments,omitempty"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonFeedAttachment struct {
+	URL         string `json:"url"`
+	MimeType    string `json:"mime_type"`
+	SizeInBytes int64  `json:"size_in_bytes,omitempty"`
+}
+
+// JSONFeed returns the channel as a JSON Feed 1.1 document.
+func JSONFeed(channel *Channel) ([]byte, error) {
+	feed := &jsonFeed{
+		Version:     jsonFeedVersion,
+		Title:       channel.Title,
+		HomePageURL: channel.SiteURL,
+		FeedURL:     channel.FeedURL,
+		Items:       make([]*jsonFeedItem, 0, len(channel.Entries)),
+	}
+
+	for _, entry := range channel.Entries {
+		item := &jsonFeedItem{
+			ID:            entryID(entry),
+			URL:           entry.URL,
+			Title:         entry.Title,
+			ContentHTML:   entry.Content,
+			DatePublished: jsonFeedDate(entry.Date),
+			DateModified:  jsonFeedDate(entryUpdated(entry)),
+		}
+
+		if author := entryAuthor(entry); author != "" {
+			item.Authors = []jsonFeedAuthor{{Name: author}}
+		}
+
+		for _, enclosure := range entry.Enclosures {
+			mimeType := enclosure.MimeType
+			if mimeType == "" {
+				mimeType = "application/octet-stream"
+			}
+			item.Attachments = append(item.Attachments, jsonFeedAttachment{
+				URL:         enclosure.URL,
+				MimeType:    mimeType,
+				SizeInBytes: enclosure.Size,
+			})
+		}
+
+		feed.Items = append(feed.Items, item)
+	}
+
+	return json.MarshalIndent(feed, "", "  ")
+}
+
+func jsonFeedDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package syndication // import "miniflux.app/syndication"
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"miniflux.app/model"
+	"miniflux.app/reader/atom"
+)
+
+func testChannel() *Channel {
+	published := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	entries := model.Entries{
+		{
+			ID:        42,
+			Title:     "Episode 1",
+			URL:       "https://example.org/episode-1",
+			Date:      published,
+			ChangedAt: published.Add(2 * time.Hour),
+			Content:   "<p>Hello <b>World</b></p>",
+			Enclosures: model.EnclosureList{
+				{URL: "https://example.org/episode-1.mp3", MimeType: "audio/mpeg", Size: 1234},
+			},
+			Feed: &model.Feed{Title: "Podcast", FeedURL: "https://example.org/feed.xml", SiteURL: "https://example.org/"},
+		},
+	}
+
+	return NewChannel(
+		"urn:miniflux:published:token",
+		"Starred",
+		"https://miniflux.example.org/",
+		"https://miniflux.example.org/published/token/atom",
+		published.Add(-24*time.Hour),
+		entries,
+	)
+}
+
+func TestNewChannelUpdated(t *testing.T) {
+	channel := testChannel()
+	expected := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	if !channel.Updated.Equal(expected) {
+		t.Errorf(`Unexpected update time: got %v instead of %v`, channel.Updated, expected)
+	}
+
+	empty := NewChannel("id", "title", "", "", expected, nil)
+	if !empty.Updated.Equal(expected) {
+		t.Errorf(`An empty channel should use the creation time, got %v`, empty.Updated)
+	}
+}
+
+func TestAtom(t *testing.T) {
+	data, err := Atom(testChannel())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feed, parseErr := atom.Parse("https://miniflux.example.org/", bytes.NewReader(data))
+	if parseErr != nil {
+		t.Fatalf(`The generated document should be valid Atom: %v`, parseErr)
+	}
+
+	if feed.Title != "Starred" {
+		t.Errorf(`Unexpected title: %q`, feed.Title)
+	}
+
+	if len(feed.Entries) != 1 {
+		t.Fatalf(`Unexpected number of entries: %d`, len(feed.Entries))
+	}
+
+	entry := feed.Entries[0]
+	if entry.URL != "https://example.org/episode-1" {
+		t.Errorf(`Unexpected entry URL: %q`, entry.URL)
+	}
+
+	if entry.Content != "<p>Hello <b>World</b></p>" {
+		t.Errorf(`Unexpected entry content: %q`, entry.Content)
+	}
+
+	if entry.Author != "Podcast" {
+		t.Errorf(`The feed title should be used when the author is missing, got %q`, entry.Author)
+	}
+
+	if len(entry.Enclosures) != 1 || entry.Enclosures[0].MimeType != "audio/mpeg" || entry.Enclosures[0].Size != 1234 {
+		t.Errorf(`Unexpected enclosures: %+v`, entry.Enclosures)
+	}
+}
+
+func TestJSONFeed(t *testing.T) {
+	data, err := JSONFeed(testChannel())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var feed jsonFeed
+	if err := json.Unmarshal(data, &feed); err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Version != "https://jsonfeed.org/version/1.1" {
+		t.Errorf(`Unexpected version: %q`, feed.Version)
+	}
+
+	if len(feed.Items) != 1 {
+		t.Fatalf(`Unexpected number of items: %d`, len(feed.Items))
+	}
+
+	item := feed.Items[0]
+	if item.ID != "urn:miniflux:entry:42" {
+		t.Errorf(`Unexpected item ID: %q`, item.ID)
+	}
+
+	if item.DatePublished != "2023-03-01T10:00:00Z" || item.DateModified != "2023-03-01T12:00:00Z" {
+		t.Errorf(`Unexpected dates: %q, %q`, item.DatePublished, item.DateModified)
+	}
+
+	if len(item.Attachments) != 1 || item.Attachments[0].SizeInBytes != 1234 {
+		t.Errorf(`Unexpected attachments: %+v`, item.Attachments)
+	}
+}
+
+func TestJSONFeedWithoutEntries(t *testing.T) {
+	data, err := JSONFeed(NewChannel("id", "Empty", "", "", time.Now(), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Contains(data, []byte(`"items": []`)) {
+		t.Errorf(`An empty feed should have an empty list of items: %s`, data)
+	}
+}
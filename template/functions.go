@@ -17,6 +17,7 @@ import (
 	"miniflux.app/http/route"
 	"miniflux.app/locale"
 	"miniflux.app/model"
+	"miniflux.app/oauth2"
 	"miniflux.app/proxy"
 	"miniflux.app/timezone"
 	"miniflux.app/url"
@@ -42,8 +43,26 @@ func (f *funcMap) Map() template.FuncMap {
 		"rootURL": func() string {
 			return config.Opts.RootURL()
 		},
-		"hasOAuth2Provider": func(provider string) bool {
-			return config.Opts.OAuth2Provider() == provider
+		"oauth2Providers": func() []*config.OAuth2ProviderOptions {
+			return config.Opts.OAuth2Providers()
+		},
+		"isOAuth2Linked": func(user *model.User, provider string) bool {
+			return oauth2.IsUserLinked(user, provider)
+		},
+		"isRegistrationEnabled": func() bool {
+			return config.Opts.IsRegistrationEnabled()
+		},
+		"isMailEnabled": func() bool {
+			return config.Opts.SMTPHost() != ""
+		},
+		"isOfflineEnabled": func() bool {
+			return config.Opts.OfflineEntries() > 0
+		},
+		"isStoryClusteringEnabled": func() bool {
+			return config.Opts.StoryClustering()
+		},
+		"isEPUBExportDirectoryEnabled": func() bool {
+			return config.Opts.EPUBExportDirectory() != ""
 		},
 		"hasAuthProxy": func() bool {
 			return config.Opts.AuthProxyHeader() != ""
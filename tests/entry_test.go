@@ -93,6 +93,31 @@ func TestGetAllCategoryEntries(t *testing.T) {
 	}
 }
 
+func TestGetEntriesSortedByScoreWithPagination(t *testing.T) {
+	client := createClient(t)
+	createFeed(t, client)
+
+	allResults, err := client.Entries(&miniflux.Filter{Order: "score", Direction: "desc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if allResults.Total < 2 {
+		t.Fatalf(`Invalid number of entries, got %d`, allResults.Total)
+	}
+
+	for offset, entry := range allResults.Entries {
+		result, err := client.Entries(&miniflux.Filter{Order: "score", Direction: "desc", Limit: 1, Offset: offset})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(result.Entries) != 1 || result.Entries[0].ID != entry.ID {
+			t.Fatalf(`The entry at offset %d should be #%d`, offset, entry.ID)
+		}
+	}
+}
+
 func TestGetAllEntries(t *testing.T) {
 	client := createClient(t)
 	createFeed(t, client)
@@ -287,6 +312,22 @@ func TestSearchEntries(t *testing.T) {
 	}
 }
 
+func TestSearchEntriesCountWithSyntax(t *testing.T) {
+	client := createClient(t)
+	createFeed(t, client)
+
+	for _, search := range []string{`"2.0.8"`, `2.0.8 -nonexistentword`, `2.0.8 OR nonexistentword`} {
+		results, err := client.Entries(&miniflux.Filter{Search: search, Order: "published_at", Direction: "asc"})
+		if err != nil {
+			t.Fatalf(`Searching %q failed: %v`, search, err)
+		}
+
+		if results.Total != 1 || len(results.Entries) != 1 {
+			t.Errorf(`Searching %q should count and return one entry, got %d and %d`, search, results.Total, len(results.Entries))
+		}
+	}
+}
+
 func TestInvalidFilters(t *testing.T) {
 	client := createClient(t)
 	createFeed(t, client)
@@ -451,3 +492,39 @@ func TestHistoryOrder(t *testing.T) {
 		t.Fatal("The entry that we just read should be at the top of the history")
 	}
 }
+
+func TestGetEntryClustersWithPagination(t *testing.T) {
+	client := createClient(t)
+	feed, _ := createFeed(t, client)
+
+	entries, err := client.FeedEntries(feed.ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firstPage, err := client.EntryClusters(&miniflux.Filter{FeedID: feed.ID, Order: "published_at", Direction: "desc", Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(firstPage.Clusters) != 2 {
+		t.Fatalf(`Unexpected number of clusters, got %d instead of 2`, len(firstPage.Clusters))
+	}
+
+	if firstPage.Total == 0 || firstPage.Total > entries.Total {
+		t.Fatalf(`Unexpected number of stories, got %d for %d entries`, firstPage.Total, entries.Total)
+	}
+
+	secondPage, err := client.EntryClusters(&miniflux.Filter{FeedID: feed.ID, Order: "published_at", Direction: "desc", Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, cluster := range secondPage.Clusters {
+		for _, previous := range firstPage.Clusters {
+			if cluster.Entries[0].ID == previous.Entries[0].ID {
+				t.Fatalf(`The entry #%d should not be on two pages`, cluster.Entries[0].ID)
+			}
+		}
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"io"
 	"strings"
 	"testing"
+
+	miniflux "miniflux.app/client"
 )
 
 func TestExport(t *testing.T) {
@@ -45,3 +47,45 @@ func TestImport(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestImportWithReport(t *testing.T) {
+	client := createClient(t)
+
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+    <opml version="2.0" xmlns:miniflux="https://miniflux.app/opml">
+        <body>
+            <outline text="Parent">
+				<outline text="Child">
+					<outline title="Test" text="Test" xmlUrl="` + testFeedURL + `" htmlUrl="` + testWebsiteURL + `" miniflux:crawler="true"></outline>
+				</outline>
+			</outline>
+			<outline title="Invalid" text="Invalid" xmlUrl="invalid-url"></outline>
+		</body>
+	</opml>`
+
+	report, err := client.ImportWithReport(io.NopCloser(bytes.NewReader([]byte(data))))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Created != 1 || report.Failed != 1 || len(report.Results) != 2 {
+		t.Fatalf(`Unexpected report: %+v`, report)
+	}
+
+	if report.Results[0].Category != "Parent / Child" {
+		t.Errorf(`Nested categories should be joined, got %q`, report.Results[0].Category)
+	}
+
+	if report.Results[1].Status != miniflux.ImportStatusFailed || report.Results[1].Error == "" {
+		t.Errorf(`The invalid outline should be reported as failed, got %+v`, report.Results[1])
+	}
+
+	output, err := client.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(output), `miniflux:crawler="true"`) {
+		t.Errorf(`The feed settings should be exported, got %s`, output)
+	}
+}
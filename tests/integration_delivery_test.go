@@ -0,0 +1,58 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"testing"
+
+	miniflux "miniflux.app/client"
+)
+
+func TestGetIntegrationDeliveriesWithoutProviders(t *testing.T) {
+	client := createClient(t)
+
+	deliveries, err := client.IntegrationDeliveries("", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(deliveries) != 0 {
+		t.Fatalf(`No delivery should be returned, got %d`, len(deliveries))
+	}
+}
+
+func TestGetIntegrationDeliveriesWithInvalidStatus(t *testing.T) {
+	client := createClient(t)
+
+	if _, err := client.IntegrationDeliveries("invalid", 0, 0); err == nil {
+		t.Fatal(`Using an invalid status should raise an error`)
+	}
+}
+
+func TestGetEntryIntegrationDeliveries(t *testing.T) {
+	client := createClient(t)
+	createFeed(t, client)
+
+	result, err := client.Entries(&miniflux.Filter{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deliveries, err := client.EntryIntegrationDeliveries(result.Entries[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(deliveries) != 0 {
+		t.Fatalf(`No delivery should be returned, got %d`, len(deliveries))
+	}
+
+	if _, err := client.EntryIntegrationDeliveries(123456789); err != miniflux.ErrNotFound {
+		t.Fatalf(`Fetching the deliveries of an unknown entry should return a not found error, got %v`, err)
+	}
+}
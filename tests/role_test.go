@@ -0,0 +1,176 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	miniflux "miniflux.app/client"
+)
+
+func createClientWithRole(t *testing.T, role string) (*miniflux.Client, *miniflux.User) {
+	username := getRandomUsername()
+	client := miniflux.New(testBaseURL, testAdminUsername, testAdminPassword)
+	user, err := client.CreateUserWithRole(username, testStandardPassword, role)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Role != role {
+		t.Fatalf(`Invalid role, got %q instead of %q`, user.Role, role)
+	}
+
+	return miniflux.New(testBaseURL, username, testStandardPassword), user
+}
+
+func TestCreateUserWithInvalidRole(t *testing.T) {
+	client := miniflux.New(testBaseURL, testAdminUsername, testAdminPassword)
+	_, err := client.CreateUserWithRole(getRandomUsername(), testStandardPassword, "superuser")
+	if err == nil {
+		t.Fatal(`An invalid role should be rejected`)
+	}
+}
+
+func TestUserManagerCanManageRegularUsers(t *testing.T) {
+	managerClient, _ := createClientWithRole(t, "user_manager")
+
+	user, err := managerClient.CreateUserWithRole(getRandomUsername(), testStandardPassword, "guest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	role := "user"
+	user, err = managerClient.UpdateUser(user.ID, &miniflux.UserModificationRequest{Role: &role})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Role != role {
+		t.Fatalf(`Invalid role, got %q instead of %q`, user.Role, role)
+	}
+
+	if _, err := managerClient.Users(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := managerClient.DeleteUser(user.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUserManagerCannotManageAdministrators(t *testing.T) {
+	managerClient, manager := createClientWithRole(t, "user_manager")
+
+	_, err := managerClient.CreateUserWithRole(getRandomUsername(), testStandardPassword, "admin")
+	if err != miniflux.ErrForbidden {
+		t.Fatal(`A user manager should not be able to create administrators`)
+	}
+
+	_, err = managerClient.CreateUser(getRandomUsername(), testStandardPassword, true)
+	if err != miniflux.ErrForbidden {
+		t.Fatal(`A user manager should not be able to create administrators`)
+	}
+
+	role := "admin"
+	_, err = managerClient.UpdateUser(manager.ID, &miniflux.UserModificationRequest{Role: &role})
+	if err == nil {
+		t.Fatal(`A user manager should not be able to promote themselves`)
+	}
+
+	adminClient := miniflux.New(testBaseURL, testAdminUsername, testAdminPassword)
+	admin, err := adminClient.Me()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := managerClient.DeleteUser(admin.ID); err != miniflux.ErrForbidden {
+		t.Fatal(`A user manager should not be able to remove administrators`)
+	}
+}
+
+func TestRegularUserCannotManageUsers(t *testing.T) {
+	client, _ := createClientWithRole(t, "user")
+
+	if _, err := client.Users(); err != miniflux.ErrForbidden {
+		t.Fatal(`A regular user should not be able to list users`)
+	}
+
+	if _, err := client.CreateUserWithRole(getRandomUsername(), testStandardPassword, "guest"); err != miniflux.ErrForbidden {
+		t.Fatal(`A regular user should not be able to create users`)
+	}
+}
+
+func TestGuestCannotManageSubscriptions(t *testing.T) {
+	client, _ := createClientWithRole(t, "guest")
+
+	if _, err := client.CreateCategory("My category"); err != miniflux.ErrForbidden {
+		t.Fatal(`A guest should not be able to create categories`)
+	}
+
+	categories, err := client.Categories()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.CreateFeed(&miniflux.FeedCreationRequest{FeedURL: testFeedURL, CategoryID: categories[0].ID})
+	if err != miniflux.ErrForbidden {
+		t.Fatal(`A guest should not be able to subscribe to feeds`)
+	}
+
+	if err := client.RefreshAllFeeds(); err != miniflux.ErrForbidden {
+		t.Fatal(`A guest should not be able to refresh feeds`)
+	}
+
+	if err := client.RefreshCategory(categories[0].ID); err != miniflux.ErrForbidden {
+		t.Fatal(`A guest should not be able to refresh categories`)
+	}
+
+	if _, err := client.Entries(nil); err != nil {
+		t.Fatal(`A guest should be able to read entries`)
+	}
+}
+
+func TestGuestCannotRefreshFeedsFromUserInterface(t *testing.T) {
+	client, user := createClientWithRole(t, "guest")
+
+	categories, err := client.Categories()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ui := createUIClient(t, user.Username, testStandardPassword)
+	categoryID := strconv.FormatInt(categories[0].ID, 10)
+
+	for _, path := range []string{
+		"feeds/refresh",
+		"category/" + categoryID + "/feeds/refresh",
+		"category/" + categoryID + "/entries/refresh",
+	} {
+		if response := ui.get(t, path); response.StatusCode != http.StatusForbidden {
+			t.Errorf(`A guest should not be able to refresh feeds with %q, got status code %d`, path, response.StatusCode)
+		}
+	}
+}
+
+func TestGuestCannotRefreshFeedsInBatch(t *testing.T) {
+	client, user := createClientWithRole(t, "guest")
+
+	err := client.UpdateFeeds(&miniflux.FeedBatchRequest{FeedIDs: []int64{1}, Action: "refresh"})
+	if err != miniflux.ErrForbidden {
+		t.Fatal(`A guest should not be able to refresh feeds in batch`)
+	}
+
+	ui := createUIClient(t, user.Username, testStandardPassword)
+	response := ui.post(t, "feeds/batch", url.Values{"feed_ids": {"1"}, "action": {"refresh"}})
+	if response.StatusCode != http.StatusForbidden {
+		t.Fatalf(`A guest should not be able to refresh feeds in batch from the user interface, got status code %d`, response.StatusCode)
+	}
+}
@@ -8,7 +8,12 @@
 package tests
 
 import (
+	"io"
 	"math/rand"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
@@ -73,3 +78,66 @@ func createFeed(t *testing.T, client *miniflux.Client) (*miniflux.Feed, *miniflu
 
 	return feed, categories[0]
 }
+
+var csrfPattern = regexp.MustCompile(`name="csrf" value="([^"]+)"`)
+
+// uiClient browses the user interface with the session of a user.
+type uiClient struct {
+	*http.Client
+	csrf string
+}
+
+func createUIClient(t *testing.T, username, password string) *uiClient {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &uiClient{Client: &http.Client{
+		Jar: jar,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+
+	client.csrf = client.fetchCSRF(t, "")
+	response := client.post(t, "login", url.Values{"username": {username}, "password": {password}})
+	if response.StatusCode != http.StatusFound {
+		t.Fatalf(`Unable to login to the user interface, got status code %d`, response.StatusCode)
+	}
+
+	return client
+}
+
+func (c *uiClient) fetchCSRF(t *testing.T, path string) string {
+	response := c.get(t, path)
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matches := csrfPattern.FindSubmatch(body)
+	if matches == nil {
+		t.Fatalf(`Unable to find the CSRF token of %q`, testBaseURL+path)
+	}
+	return string(matches[1])
+}
+
+func (c *uiClient) get(t *testing.T, path string) *http.Response {
+	response, err := c.Get(testBaseURL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { response.Body.Close() })
+	return response
+}
+
+func (c *uiClient) post(t *testing.T, path string, values url.Values) *http.Response {
+	values.Set("csrf", c.csrf)
+	response, err := c.PostForm(testBaseURL+path, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { response.Body.Close() })
+	return response
+}
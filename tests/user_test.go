@@ -710,3 +710,67 @@ func TestCannotMarkUserAsReadAsOtherUser(t *testing.T) {
 		t.Errorf(`A "Forbidden" error should be raised, got %q`, err)
 	}
 }
+
+func TestDisabledUserCannotAuthenticate(t *testing.T) {
+	username := getRandomUsername()
+	adminClient := miniflux.New(testBaseURL, testAdminUsername, testAdminPassword)
+	user, err := adminClient.CreateUser(username, testStandardPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Status != "active" {
+		t.Fatalf(`Users should be active by default, got %q`, user.Status)
+	}
+
+	client := miniflux.New(testBaseURL, username, testStandardPassword)
+	if _, err := client.Me(); err != nil {
+		t.Fatal(err)
+	}
+
+	status := "disabled"
+	if _, err := adminClient.UpdateUser(user.ID, &miniflux.UserModificationRequest{Status: &status}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Me(); err != miniflux.ErrNotAuthorized {
+		t.Fatalf(`A disabled user should not be authorized, got %v`, err)
+	}
+
+	status = "active"
+	if _, err := adminClient.UpdateUser(user.ID, &miniflux.UserModificationRequest{Status: &status}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Me(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUserCannotChangeOwnStatus(t *testing.T) {
+	username := getRandomUsername()
+	adminClient := miniflux.New(testBaseURL, testAdminUsername, testAdminPassword)
+	user, err := adminClient.CreateUser(username, testStandardPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := miniflux.New(testBaseURL, username, testStandardPassword)
+	status := "disabled"
+	if _, err := client.UpdateUser(user.ID, &miniflux.UserModificationRequest{Status: &status}); err == nil {
+		t.Fatal(`Users should not be able to change their own status`)
+	}
+}
+
+func TestCreateUserWithInvalidEmail(t *testing.T) {
+	client := miniflux.New(testBaseURL, testAdminUsername, testAdminPassword)
+	email := "invalid"
+	user, err := client.CreateUser(getRandomUsername(), testStandardPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.UpdateUser(user.ID, &miniflux.UserModificationRequest{Email: &email}); err == nil {
+		t.Fatal(`An invalid email address should be rejected`)
+	}
+}
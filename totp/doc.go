@@ -0,0 +1,8 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+/*
+Package totp implements time-based one-time passwords (RFC 6238), as used by authenticator applications.
+*/
+package totp // import "miniflux.app/totp"
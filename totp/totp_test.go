@@ -0,0 +1,91 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package totp // import "miniflux.app/totp"
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The SHA-1 secret of the test vectors of RFC 6238, truncated to 6 digits.
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	scenarios := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for timestamp, expected := range scenarios {
+		code, err := Code(rfcSecret, Counter(time.Unix(timestamp, 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if code != expected {
+			t.Errorf(`Unexpected code at %d, got %q instead of %q`, timestamp, code, expected)
+		}
+	}
+}
+
+func TestCodeWithInvalidSecret(t *testing.T) {
+	if _, err := Code("not base32!", 1); err == nil {
+		t.Error(`An invalid secret should return an error`)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	counter, valid := Validate(rfcSecret, "081804", now, 0)
+	if !valid || counter != Counter(now) {
+		t.Fatalf(`The current code should be valid`)
+	}
+
+	if _, valid := Validate(rfcSecret, "081 804", now, 0); !valid {
+		t.Error(`Spaces should be ignored`)
+	}
+
+	if _, valid := Validate(rfcSecret, "081804", now.Add(Period*time.Second), 0); !valid {
+		t.Error(`The previous code should be accepted to tolerate clock drifts`)
+	}
+
+	if _, valid := Validate(rfcSecret, "081804", now.Add(3*Period*time.Second), 0); valid {
+		t.Error(`An old code should be rejected`)
+	}
+
+	if _, valid := Validate(rfcSecret, "081804", now, counter); valid {
+		t.Error(`A code should not be used twice`)
+	}
+
+	if _, valid := Validate(rfcSecret, "123456", now, 0); valid {
+		t.Error(`An invalid code should be rejected`)
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret := GenerateSecret()
+	if len(secret) != 32 || strings.Contains(secret, "=") {
+		t.Errorf(`Unexpected secret: %q`, secret)
+	}
+
+	if _, err := Code(secret, 1); err != nil {
+		t.Errorf(`The secret should be valid: %v`, err)
+	}
+}
+
+func TestKeyURI(t *testing.T) {
+	uri := KeyURI("Miniflux", "bob@example.org", "JBSWY3DPEHPK3PXP")
+	expected := "otpauth://totp/Miniflux:bob@example.org?algorithm=SHA1&digits=6&issuer=Miniflux&period=30&secret=JBSWY3DPEHPK3PXP"
+	if uri != expected {
+		t.Errorf(`Unexpected URI, got %q instead of %q`, uri, expected)
+	}
+}
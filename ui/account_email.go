@@ -0,0 +1,49 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/http/route"
+	"miniflux.app/locale"
+	"miniflux.app/mail"
+	"miniflux.app/model"
+)
+
+// passwordResetValidity is the duration during which a password reset link can be used.
+const passwordResetValidity = time.Hour
+
+func (h *handler) absoluteURL(name string, args ...interface{}) string {
+	return config.Opts.RootURL() + route.Path(h.router, name, args...)
+}
+
+func (h *handler) sendInvitationEmail(inviter *model.User, invitation *model.UserInvitation) error {
+	return mail.Send(&mail.Message{
+		To:      invitation.Email,
+		Subject: locale.NewPrinter(inviter.Language).Printf("email.invitation.subject"),
+		Body: h.tpl.Render("invitation_email.html", map[string]interface{}{
+			"language":      inviter.Language,
+			"inviter":       inviter.Username,
+			"invitationURL": h.absoluteURL("acceptInvitation", "token", invitation.Token),
+			"expiresAt":     invitation.ExpiresAt,
+			"baseURL":       config.Opts.BaseURL(),
+		}),
+	})
+}
+
+func (h *handler) sendPasswordResetEmail(user *model.User, token string) error {
+	return mail.Send(&mail.Message{
+		To:      user.Email,
+		Subject: locale.NewPrinter(user.Language).Printf("email.password_reset.subject"),
+		Body: h.tpl.Render("password_reset_email.html", map[string]interface{}{
+			"language": user.Language,
+			"username": user.Username,
+			"resetURL": h.absoluteURL("resetPassword", "token", token),
+			"baseURL":  config.Opts.BaseURL(),
+		}),
+	})
+}
@@ -5,12 +5,14 @@
 package ui // import "miniflux.app/ui"
 
 import (
+	"fmt"
 	"net/http"
 
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/html"
 	"miniflux.app/http/route"
 	"miniflux.app/logger"
+	"miniflux.app/model"
 )
 
 func (h *handler) removeAPIKey(w http.ResponseWriter, r *http.Request) {
@@ -18,6 +20,8 @@ func (h *handler) removeAPIKey(w http.ResponseWriter, r *http.Request) {
 	err := h.store.RemoveAPIKey(request.UserID(r), keyID)
 	if err != nil {
 		logger.Error("[UI:RemoveAPIKey] %v", err)
+	} else {
+		recordAuditLog(h.store, r, model.AuditActionAPIKeyRemove, request.UserID(r), fmt.Sprintf("api_key:%d", keyID), "")
 	}
 
 	html.Redirect(w, r, route.Path(h.router, "apiKeys"))
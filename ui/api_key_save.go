@@ -54,5 +54,6 @@ func (h *handler) saveAPIKey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	recordAuditLog(h.store, r, model.AuditActionAPIKeyCreate, user.ID, "api_key:"+apiKey.Description, "")
 	html.Redirect(w, r, route.Path(h.router, "apiKeys"))
 }
@@ -0,0 +1,49 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+func (h *handler) showAppPasswordsPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	view, err := h.newAppPasswordsView(r, user, &form.AppPasswordForm{})
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.OK(w, r, view.Render("app_passwords"))
+}
+
+func (h *handler) newAppPasswordsView(r *http.Request, user *model.User, appPasswordForm *form.AppPasswordForm) (*view.View, error) {
+	appPasswords, err := h.store.AppPasswords(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("appPasswords", appPasswords)
+	view.Set("form", appPasswordForm)
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	return view, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+)
+
+func (h *handler) saveAppPassword(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	appPasswordForm := form.NewAppPasswordForm(r)
+	view, err := h.newAppPasswordsView(r, user, appPasswordForm)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if err := appPasswordForm.Validate(); err != nil {
+		view.Set("errorMessage", err.Error())
+		html.OK(w, r, view.Render("app_passwords"))
+		return
+	}
+
+	if h.store.AppPasswordExists(user.ID, appPasswordForm.Description) {
+		view.Set("errorMessage", "error.app_password_already_exists")
+		html.OK(w, r, view.Render("app_passwords"))
+		return
+	}
+
+	password := model.GenerateAppPassword()
+	if err := h.store.CreateAppPassword(user.ID, appPasswordForm.Description, password); err != nil {
+		logger.Error("[UI:SaveAppPassword] %v", err)
+		view.Set("errorMessage", "error.unable_to_create_app_password")
+		html.OK(w, r, view.Render("app_passwords"))
+		return
+	}
+
+	// The password is displayed only once, only its hash is stored.
+	view, err = h.newAppPasswordsView(r, user, &form.AppPasswordForm{})
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	view.Set("newAppPassword", password)
+	view.Set("newAppPasswordDescription", appPasswordForm.Description)
+	html.OK(w, r, view.Render("app_passwords"))
+}
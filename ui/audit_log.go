@@ -0,0 +1,39 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+)
+
+// recordAuditLog adds an entry to the audit log, a failure is logged but does not interrupt the request.
+func recordAuditLog(store *storage.Storage, r *http.Request, action string, userID int64, target, details string) {
+	auditLog := model.NewAuditLog(model.AuditSourceUI, action, userID, target)
+	auditLog.Details = details
+	auditLog.IP = request.ClientIP(r)
+	auditLog.UserAgent = r.UserAgent()
+
+	if err := store.CreateAuditLog(auditLog); err != nil {
+		logger.Error("[UI:AuditLog] %v", err)
+	}
+}
+
+// recordFailedLogin adds a failed login to the audit log, the username is kept even if no such user exists.
+func recordFailedLogin(store *storage.Storage, r *http.Request, userID int64, username, method string) {
+	auditLog := model.NewAuditLog(model.AuditSourceUI, model.AuditActionLoginFailed, userID, "")
+	auditLog.Username = username
+	auditLog.Details = method
+	auditLog.IP = request.ClientIP(r)
+	auditLog.UserAgent = r.UserAgent()
+
+	if err := store.CreateAuditLog(auditLog); err != nil {
+		logger.Error("[UI:AuditLog] %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/model"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+	"miniflux.app/validator"
+)
+
+const auditLogsPerPage = 100
+
+func (h *handler) showAuditLogsPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	filter := &model.AuditLogFilter{
+		Username: request.QueryStringParam(r, "username", ""),
+		Source:   request.QueryStringParam(r, "source", ""),
+		Action:   request.QueryStringParam(r, "action", ""),
+		Offset:   request.QueryIntParam(r, "offset", 0),
+		Limit:    auditLogsPerPage,
+	}
+
+	if err := validator.ValidateAuditLogFilter(filter); err != nil {
+		html.BadRequest(w, r, err)
+		return
+	}
+
+	count, err := h.store.CountAuditLogs(filter)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	auditLogs, err := h.store.AuditLogs(filter)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	auditLogs.UseTimezone(user.Timezone)
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("auditLogs", auditLogs)
+	view.Set("filter", filter)
+	view.Set("actions", model.AuditActions())
+	view.Set("sources", model.AuditSources())
+	view.Set("total", count)
+	if filter.Offset > 0 {
+		view.Set("prevURL", h.auditLogsURL(filter, filter.Offset-auditLogsPerPage))
+	}
+	if count-filter.Offset > auditLogsPerPage {
+		view.Set("nextURL", h.auditLogsURL(filter, filter.Offset+auditLogsPerPage))
+	}
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	html.OK(w, r, view.Render("audit_logs"))
+}
+
+// auditLogsURL keeps the filters in the pagination links.
+func (h *handler) auditLogsURL(filter *model.AuditLogFilter, offset int) string {
+	values := url.Values{}
+	if filter.Username != "" {
+		values.Set("username", filter.Username)
+	}
+	if filter.Source != "" {
+		values.Set("source", filter.Source)
+	}
+	if filter.Action != "" {
+		values.Set("action", filter.Action)
+	}
+	if offset > 0 {
+		values.Set("offset", strconv.Itoa(offset))
+	}
+
+	path := route.Path(h.router, "auditLogs")
+	if len(values) == 0 {
+		return path
+	}
+	return path + "?" + values.Encode()
+}
@@ -0,0 +1,24 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+	"net/url"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/logger"
+)
+
+func (h *handler) removeAuthorPreference(w http.ResponseWriter, r *http.Request) {
+	author := request.QueryStringParam(r, "q", "")
+	if err := h.store.RemoveAuthorPreference(request.UserID(r), author); err != nil {
+		logger.Error("[UI:RemoveAuthorPreference] %v", err)
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "authorEntries")+"?q="+url.QueryEscape(author))
+}
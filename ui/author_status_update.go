@@ -0,0 +1,42 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/model"
+)
+
+// updateAuthorStatus follows or mutes an author. Muting also marks the unread entries of the author as read.
+func (h *handler) updateAuthorStatus(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	status := request.RouteStringParam(r, "status")
+	author := strings.TrimSpace(request.QueryStringParam(r, "q", ""))
+	if author == "" || !model.IsValidAuthorStatus(status) {
+		html.BadRequest(w, r, errors.New("invalid author or status"))
+		return
+	}
+
+	if err := h.store.SetAuthorStatus(userID, author, status); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if status == model.AuthorStatusMuted {
+		if err := h.store.MarkAuthorEntriesAsRead(userID, author); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "authorEntries")+"?q="+url.QueryEscape(author))
+}
@@ -10,6 +10,7 @@ import (
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/html"
 	"miniflux.app/http/route"
+	"miniflux.app/integration"
 	"miniflux.app/model"
 	"miniflux.app/ui/session"
 	"miniflux.app/ui/view"
@@ -53,7 +54,7 @@ func (h *handler) showStarredPage(w http.ResponseWriter, r *http.Request) {
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
-	view.Set("hasSaveEntry", h.store.HasSaveEntry(user.ID))
+	view.Set("hasSaveEntry", h.store.HasSaveEntry(user.ID, integration.EntrySenderNames()))
 
 	html.OK(w, r, view.Render("bookmark_entries"))
 }
@@ -37,12 +37,18 @@ func (h *handler) showEditCategoryPage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	categoryForm := form.CategoryForm{
-		Title:        category.Title,
-		HideGlobally: "",
+		Title:           category.Title,
+		HideGlobally:    "",
+		RetentionMode:   category.RetentionMode,
+		RetentionValue:  category.RetentionValue,
+		RetentionDelete: category.RetentionDelete,
 	}
 	if category.HideGlobally {
 		categoryForm.HideGlobally = "checked"
 	}
+	if category.Notify {
+		categoryForm.Notify = "checked"
+	}
 
 	view.Set("form", categoryForm)
 	view.Set("category", category)
@@ -49,8 +49,12 @@ func (h *handler) updateCategory(w http.ResponseWriter, r *http.Request) {
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(loggedUser.ID))
 
 	categoryRequest := &model.CategoryRequest{
-		Title:        categoryForm.Title,
-		HideGlobally: categoryForm.HideGlobally,
+		Title:           categoryForm.Title,
+		HideGlobally:    categoryForm.HideGlobally,
+		Notify:          categoryForm.Notify,
+		RetentionMode:   &categoryForm.RetentionMode,
+		RetentionValue:  &categoryForm.RetentionValue,
+		RetentionDelete: &categoryForm.RetentionDelete,
 	}
 
 	if validationErr := validator.ValidateCategoryModification(h.store, loggedUser.ID, category.ID, categoryRequest); validationErr != nil {
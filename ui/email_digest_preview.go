@@ -0,0 +1,36 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+	"time"
+
+	"miniflux.app/digest"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+)
+
+func (h *handler) previewEmailDigest(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	emailDigest, err := h.store.EmailDigest(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	entries, err := digest.Entries(h.store, emailDigest, time.Now())
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	html.OK(w, r, digest.Render(h.tpl, user, emailDigest, entries))
+}
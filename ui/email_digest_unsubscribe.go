@@ -0,0 +1,53 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+// showEmailDigestUnsubscribePage asks for a confirmation, to avoid unsubscribing
+// users when email clients prefetch the links.
+func (h *handler) showEmailDigestUnsubscribePage(w http.ResponseWriter, r *http.Request) {
+	h.renderEmailDigestUnsubscribePage(w, r, false)
+}
+
+func (h *handler) unsubscribeEmailDigest(w http.ResponseWriter, r *http.Request) {
+	h.renderEmailDigestUnsubscribePage(w, r, true)
+}
+
+func (h *handler) renderEmailDigestUnsubscribePage(w http.ResponseWriter, r *http.Request, confirmed bool) {
+	token := request.RouteStringParam(r, "token")
+	digest, err := h.store.EmailDigestByToken(token)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if digest == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	if confirmed {
+		if err := h.store.UnsubscribeEmailDigest(token); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("token", token)
+	view.Set("email", digest.Email)
+	view.Set("unsubscribed", confirmed || !digest.Enabled)
+
+	html.OK(w, r, view.Render("email_digest_unsubscribe"))
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+
+	"miniflux.app/crypto"
+	"miniflux.app/epub"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response"
+	"miniflux.app/http/response/html"
+	"miniflux.app/locale"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+func (h *handler) downloadEPUBExport(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	epubExport, err := h.store.EPUBExport(user.ID, request.RouteInt64Param(r, "epubExportID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if epubExport == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	h.writeEPUB(w, r, user, epubExport)
+}
+
+// exportEPUB downloads the entries of the current list without saving an export.
+func (h *handler) exportEPUB(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	printer := locale.NewPrinter(user.Language)
+	epubExport := model.NewEPUBExport(user.ID, "", request.QueryStringParam(r, "kind", ""))
+
+	switch epubExport.Kind {
+	case model.EPUBExportKindStarred:
+		epubExport.Title = printer.Printf("page.starred.title")
+	case model.EPUBExportKindCategory:
+		categoryID := request.QueryInt64Param(r, "category_id", 0)
+		epubExport.CategoryID = &categoryID
+		if category, err := h.store.Category(user.ID, categoryID); err == nil && category != nil {
+			epubExport.Title = category.Title
+		}
+	case model.EPUBExportKindSearch:
+		epubExport.SearchQuery = request.QueryStringParam(r, "q", "")
+		epubExport.Title = epubExport.SearchQuery
+	}
+
+	if validationErr := validator.ValidateEPUBExportSelection(h.store, user.ID, epubExport); validationErr != nil {
+		html.BadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	h.writeEPUB(w, r, user, epubExport)
+}
+
+func (h *handler) writeEPUB(w http.ResponseWriter, r *http.Request, user *model.User, epubExport *model.EPUBExport) {
+	entries, err := epub.Entries(h.store, epubExport)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	var buffer bytes.Buffer
+	if err := epub.Write(&buffer, user, epubExport, entries, h.fetchEPUBImage); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	response.New(w, r).
+		WithHeader("Content-Type", epub.ContentType).
+		WithAttachment(epub.Filename(epubExport, time.Now())).
+		WithBody(buffer.Bytes()).
+		WithoutCompression().
+		Write()
+}
+
+// fetchEPUBImage shares the media cache of the image proxy when it is enabled.
+func (h *handler) fetchEPUBImage(imageURL string) ([]byte, string, error) {
+	if h.mediaCache == nil {
+		return epub.FetchImage(imageURL)
+	}
+
+	data, contentType, err := h.originalMedia(crypto.Hash(imageURL), imageURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data, contentType = epub.ShrinkImage(data, contentType)
+	return data, contentType, nil
+}
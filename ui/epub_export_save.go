@@ -0,0 +1,87 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/config"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/logger"
+	"miniflux.app/mail"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+func (h *handler) saveEPUBExport(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	epubExports, err := h.store.EPUBExports(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	categories, err := h.store.Categories(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	epubExportForm := form.NewEPUBExportForm(r)
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("epubExports", epubExports)
+	view.Set("categories", categories)
+	view.Set("form", epubExportForm)
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	if err := epubExportForm.Validate(); err != nil {
+		view.Set("errorMessage", err.Error())
+		html.OK(w, r, view.Render("epub_exports"))
+		return
+	}
+
+	if epubExportForm.Kind == model.EPUBExportKindCategory && !h.store.CategoryIDExists(user.ID, epubExportForm.CategoryID) {
+		view.Set("errorMessage", "error.feed_category_not_found")
+		html.OK(w, r, view.Render("epub_exports"))
+		return
+	}
+
+	if (epubExportForm.Delivery == model.EPUBExportDeliveryDirectory && config.Opts.EPUBExportDirectory() == "") ||
+		(epubExportForm.Delivery == model.EPUBExportDeliveryEmail && !mail.IsEnabled()) {
+		view.Set("errorMessage", "error.epub_export_delivery_unavailable")
+		html.OK(w, r, view.Render("epub_exports"))
+		return
+	}
+
+	if h.store.EPUBExportTitleExists(user.ID, epubExportForm.Title) {
+		view.Set("errorMessage", "error.epub_export_already_exists")
+		html.OK(w, r, view.Render("epub_exports"))
+		return
+	}
+
+	epubExport := epubExportForm.Merge(model.NewEPUBExport(user.ID, epubExportForm.Title, epubExportForm.Kind))
+	if err := h.store.CreateEPUBExport(epubExport); err != nil {
+		logger.Error("[UI:SaveEPUBExport] %v", err)
+		view.Set("errorMessage", "error.unable_to_create_epub_export")
+		html.OK(w, r, view.Render("epub_exports"))
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "epubExports"))
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/batch"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/locale"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/validator"
+)
+
+func (h *handler) updateFeedsBatch(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	feedBatchRequest := form.NewFeedBatchForm(r).Request()
+	if !user.CanManageSubscriptions() {
+		html.Forbidden(w, r)
+		return
+	}
+
+	printer := locale.NewPrinter(user.Language)
+	sess := session.New(h.store, request.SessionID(r))
+
+	if validationErr := validator.ValidateFeedBatchRequest(h.store, user.ID, feedBatchRequest); validationErr != nil {
+		sess.NewFlashErrorMessage(printer.Printf(validationErr.TranslationKey))
+		html.Redirect(w, r, route.Path(h.router, "feeds"))
+		return
+	}
+
+	if err := batch.ApplyFeedAction(h.store, h.pool, user.ID, feedBatchRequest); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	count := len(feedBatchRequest.FeedIDs)
+	sess.NewFlashMessage(printer.Plural("alert.feed_batch_applied", count, count))
+	html.Redirect(w, r, route.Path(h.router, "feeds"))
+}
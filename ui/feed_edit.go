@@ -61,6 +61,11 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		Disabled:                    feed.Disabled,
 		HideGlobally:                feed.HideGlobally,
 		CategoryHidden:              feed.Category.HideGlobally,
+		Notify:                      feed.Notify,
+		CategoryNotify:              feed.Category.Notify,
+		RetentionMode:               feed.RetentionMode,
+		RetentionValue:              feed.RetentionValue,
+		RetentionDelete:             feed.RetentionDelete,
 	}
 
 	sess := session.New(h.store, request.SessionID(r))
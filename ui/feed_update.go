@@ -65,6 +65,8 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		BlocklistRules:  model.OptionalString(feedForm.BlocklistRules),
 		KeeplistRules:   model.OptionalString(feedForm.KeeplistRules),
 		UrlRewriteRules: model.OptionalString(feedForm.UrlRewriteRules),
+		RetentionMode:   &feedForm.RetentionMode,
+		RetentionValue:  model.OptionalInt(feedForm.RetentionValue),
 	}
 
 	if validationErr := validator.ValidateFeedModification(h.store, loggedUser.ID, feedModificationRequest); validationErr != nil {
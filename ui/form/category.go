@@ -6,18 +6,32 @@ package form // import "miniflux.app/ui/form"
 
 import (
 	"net/http"
+	"strconv"
 )
 
 // CategoryForm represents a feed form in the UI
 type CategoryForm struct {
-	Title        string
-	HideGlobally string
+	Title           string
+	HideGlobally    string
+	Notify          string
+	RetentionMode   string
+	RetentionValue  int
+	RetentionDelete bool
 }
 
 // NewCategoryForm returns a new CategoryForm.
 func NewCategoryForm(r *http.Request) *CategoryForm {
+	retentionValue, err := strconv.Atoi(r.FormValue("retention_value"))
+	if err != nil {
+		retentionValue = 0
+	}
+
 	return &CategoryForm{
-		Title:        r.FormValue("title"),
-		HideGlobally: r.FormValue("hide_globally"),
+		Title:           r.FormValue("title"),
+		HideGlobally:    r.FormValue("hide_globally"),
+		Notify:          r.FormValue("notify"),
+		RetentionMode:   r.FormValue("retention_mode"),
+		RetentionValue:  retentionValue,
+		RetentionDelete: r.FormValue("retention_delete") == "1",
 	}
 }
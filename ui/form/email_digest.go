@@ -0,0 +1,98 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"net/mail"
+	"strconv"
+
+	"miniflux.app/errors"
+	"miniflux.app/model"
+)
+
+// EmailDigestForm represents the email digest preferences form.
+type EmailDigestForm struct {
+	Enabled     bool
+	Email       string
+	Frequency   string
+	Hour        int
+	Weekday     int
+	CategoryIDs []int64
+}
+
+// HasCategory returns true if the category is selected.
+func (f EmailDigestForm) HasCategory(categoryID int64) bool {
+	for _, id := range f.CategoryIDs {
+		if id == categoryID {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge copy form values to the model.
+func (f EmailDigestForm) Merge(digest *model.EmailDigest) *model.EmailDigest {
+	digest.Enabled = f.Enabled
+	digest.Email = f.Email
+	digest.Frequency = f.Frequency
+	digest.Hour = f.Hour
+	digest.Weekday = f.Weekday
+	digest.CategoryIDs = f.CategoryIDs
+	return digest
+}
+
+// Validate makes sure the form values are valid.
+func (f EmailDigestForm) Validate() error {
+	if f.Enabled && f.Email == "" {
+		return errors.NewLocalizedError("error.email_digest_mandatory_fields")
+	}
+
+	if f.Email != "" {
+		if _, err := mail.ParseAddress(f.Email); err != nil {
+			return errors.NewLocalizedError("error.email_digest_invalid_email")
+		}
+	}
+
+	if f.Frequency != model.DigestFrequencyDaily && f.Frequency != model.DigestFrequencyWeekly {
+		return errors.NewLocalizedError("error.email_digest_invalid_schedule")
+	}
+
+	if f.Hour < 0 || f.Hour > 23 || f.Weekday < 0 || f.Weekday > 6 {
+		return errors.NewLocalizedError("error.email_digest_invalid_schedule")
+	}
+
+	return nil
+}
+
+// NewEmailDigestForm returns a new EmailDigestForm.
+func NewEmailDigestForm(r *http.Request) *EmailDigestForm {
+	hour, err := strconv.Atoi(r.FormValue("hour"))
+	if err != nil {
+		hour = -1
+	}
+
+	weekday, err := strconv.Atoi(r.FormValue("weekday"))
+	if err != nil {
+		weekday = -1
+	}
+
+	r.ParseForm()
+	var categoryIDs []int64
+	for _, value := range r.Form["category_id"] {
+		if categoryID, err := strconv.ParseInt(value, 10, 64); err == nil {
+			categoryIDs = append(categoryIDs, categoryID)
+		}
+	}
+
+	return &EmailDigestForm{
+		Enabled:     r.FormValue("enabled") == "1",
+		Email:       r.FormValue("email"),
+		Frequency:   r.FormValue("frequency"),
+		Hour:        hour,
+		Weekday:     weekday,
+		CategoryIDs: categoryIDs,
+	}
+}
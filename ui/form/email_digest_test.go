@@ -0,0 +1,53 @@
+package form // import "miniflux.app/ui/form"
+
+import (
+	"testing"
+)
+
+func TestValidEmailDigest(t *testing.T) {
+	digestForm := &EmailDigestForm{
+		Enabled:   true,
+		Email:     "user@example.org",
+		Frequency: "weekly",
+		Hour:      8,
+		Weekday:   1,
+	}
+
+	if err := digestForm.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEmailDigestWithoutEmail(t *testing.T) {
+	digestForm := &EmailDigestForm{Enabled: true, Frequency: "daily", Hour: 8}
+
+	if err := digestForm.Validate(); err == nil {
+		t.Error("An enabled digest without email address should be invalid")
+	}
+
+	digestForm.Enabled = false
+	if err := digestForm.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEmailDigestWithInvalidEmail(t *testing.T) {
+	digestForm := &EmailDigestForm{Enabled: true, Email: "not an email", Frequency: "daily", Hour: 8}
+
+	if err := digestForm.Validate(); err == nil {
+		t.Error("An invalid email address should be rejected")
+	}
+}
+
+func TestEmailDigestWithInvalidSchedule(t *testing.T) {
+	for _, digestForm := range []*EmailDigestForm{
+		{Frequency: "monthly", Hour: 8},
+		{Frequency: "daily", Hour: 24},
+		{Frequency: "daily", Hour: -1},
+		{Frequency: "weekly", Hour: 8, Weekday: 7},
+	} {
+		if err := digestForm.Validate(); err == nil {
+			t.Errorf("The schedule %+v should be invalid", digestForm)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"miniflux.app/errors"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+// EPUBExportForm represents the EPUB export form.
+type EPUBExportForm struct {
+	Title       string
+	Kind        string
+	CategoryID  int64
+	SearchQuery string
+	Delivery    string
+	Email       string
+	Frequency   string
+}
+
+// Merge copy form values to the model, only the filter matching the kind is kept.
+func (f EPUBExportForm) Merge(export *model.EPUBExport) *model.EPUBExport {
+	export.Title = f.Title
+	export.Kind = f.Kind
+	export.Delivery = f.Delivery
+	export.Frequency = f.Frequency
+	export.CategoryID = nil
+	export.SearchQuery = ""
+	export.Email = ""
+
+	switch f.Kind {
+	case model.EPUBExportKindCategory:
+		categoryID := f.CategoryID
+		export.CategoryID = &categoryID
+	case model.EPUBExportKindSearch:
+		export.SearchQuery = f.SearchQuery
+	}
+
+	if f.Delivery == model.EPUBExportDeliveryEmail {
+		export.Email = f.Email
+	}
+
+	return export
+}
+
+// Validate makes sure the form values are valid.
+func (f EPUBExportForm) Validate() error {
+	if f.Title == "" {
+		return errors.NewLocalizedError("error.title_required")
+	}
+
+	if !model.IsValidEPUBExportKind(f.Kind) {
+		return errors.NewLocalizedError("error.epub_export_invalid_kind")
+	}
+
+	if f.Kind == model.EPUBExportKindCategory && f.CategoryID <= 0 {
+		return errors.NewLocalizedError("error.feed_category_not_found")
+	}
+
+	if f.Kind == model.EPUBExportKindSearch && f.SearchQuery == "" {
+		return errors.NewLocalizedError("error.epub_export_search_required")
+	}
+
+	if validator.ValidateSearchQuery(f.SearchQuery) != nil {
+		return errors.NewLocalizedError("error.invalid_search_query")
+	}
+
+	switch f.Delivery {
+	case model.EPUBExportDeliveryNone, model.EPUBExportDeliveryDirectory:
+	case model.EPUBExportDeliveryEmail:
+		if !validator.IsValidEmail(f.Email) {
+			return errors.NewLocalizedError("error.invalid_email")
+		}
+	default:
+		return errors.NewLocalizedError("error.epub_export_delivery_unavailable")
+	}
+
+	if f.Frequency != model.EPUBExportFrequencyDaily && f.Frequency != model.EPUBExportFrequencyWeekly {
+		return errors.NewLocalizedError("error.epub_export_invalid_frequency")
+	}
+
+	return nil
+}
+
+// NewEPUBExportForm returns a new EPUBExportForm.
+func NewEPUBExportForm(r *http.Request) *EPUBExportForm {
+	categoryID, err := strconv.ParseInt(r.FormValue("category_id"), 10, 64)
+	if err != nil {
+		categoryID = 0
+	}
+
+	return &EPUBExportForm{
+		Title:       strings.TrimSpace(r.FormValue("title")),
+		Kind:        r.FormValue("kind"),
+		CategoryID:  categoryID,
+		SearchQuery: strings.TrimSpace(r.FormValue("search_query")),
+		Delivery:    r.FormValue("delivery"),
+		Email:       strings.TrimSpace(r.FormValue("email")),
+		Frequency:   r.FormValue("frequency"),
+	}
+}
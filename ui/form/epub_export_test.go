@@ -0,0 +1,68 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestValidEPUBExport(t *testing.T) {
+	exportForm := &EPUBExportForm{Title: "Starred", Kind: model.EPUBExportKindStarred, Frequency: model.EPUBExportFrequencyDaily}
+
+	if err := exportForm.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEPUBExportWithMissingFilter(t *testing.T) {
+	for _, kind := range []string{model.EPUBExportKindCategory, model.EPUBExportKindSearch} {
+		exportForm := &EPUBExportForm{Title: "Filtered", Kind: kind, Frequency: model.EPUBExportFrequencyDaily}
+
+		if err := exportForm.Validate(); err == nil {
+			t.Errorf("The %q kind should require a filter", kind)
+		}
+	}
+}
+
+func TestEPUBExportWithInvalidDelivery(t *testing.T) {
+	for _, exportForm := range []*EPUBExportForm{
+		{Title: "Starred", Kind: model.EPUBExportKindStarred, Delivery: "ftp", Frequency: model.EPUBExportFrequencyDaily},
+		{Title: "Starred", Kind: model.EPUBExportKindStarred, Delivery: model.EPUBExportDeliveryEmail, Frequency: model.EPUBExportFrequencyDaily},
+		{Title: "Starred", Kind: model.EPUBExportKindStarred, Delivery: model.EPUBExportDeliveryEmail, Email: "not an email", Frequency: model.EPUBExportFrequencyDaily},
+		{Title: "Starred", Kind: model.EPUBExportKindStarred, Delivery: model.EPUBExportDeliveryDirectory, Frequency: "monthly"},
+	} {
+		if err := exportForm.Validate(); err == nil {
+			t.Errorf("The delivery %+v should be invalid", exportForm)
+		}
+	}
+}
+
+func TestEPUBExportMergeKeepsOnlyMatchingFilter(t *testing.T) {
+	exportForm := &EPUBExportForm{
+		Title:       "News",
+		Kind:        model.EPUBExportKindCategory,
+		CategoryID:  42,
+		SearchQuery: "ignored",
+		Delivery:    model.EPUBExportDeliveryDirectory,
+		Email:       "user@example.org",
+		Frequency:   model.EPUBExportFrequencyWeekly,
+	}
+
+	export := exportForm.Merge(model.NewEPUBExport(1, "", ""))
+
+	if export.CategoryID == nil || *export.CategoryID != 42 {
+		t.Error(`The category should be kept`)
+	}
+
+	if export.SearchQuery != "" || export.Email != "" {
+		t.Errorf(`Only the values used by the kind and the delivery should be kept, got %+v`, export)
+	}
+
+	if export.Frequency != model.EPUBExportFrequencyWeekly || export.Delivery != model.EPUBExportDeliveryDirectory {
+		t.Errorf(`Unexpected schedule, got %+v`, export)
+	}
+}
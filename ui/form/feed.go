@@ -33,6 +33,11 @@ type FeedForm struct {
 	Disabled                    bool
 	HideGlobally                bool
 	CategoryHidden              bool // Category has "hide_globally"
+	Notify                      bool
+	CategoryNotify              bool // Category has "notify"
+	RetentionMode               string
+	RetentionValue              int
+	RetentionDelete             bool
 }
 
 // Merge updates the fields of the given feed.
@@ -58,6 +63,11 @@ func (f FeedForm) Merge(feed *model.Feed) *model.Feed {
 	feed.FetchViaProxy = f.FetchViaProxy
 	feed.Disabled = f.Disabled
 	feed.HideGlobally = f.HideGlobally
+	feed.Notify = f.Notify
+	feed.RetentionMode = f.RetentionMode
+	feed.RetentionValue = f.RetentionValue
+	feed.RetentionDelete = f.RetentionDelete
+	feed.Normalize()
 	return feed
 }
 
@@ -67,6 +77,10 @@ func NewFeedForm(r *http.Request) *FeedForm {
 	if err != nil {
 		categoryID = 0
 	}
+	retentionValue, err := strconv.Atoi(r.FormValue("retention_value"))
+	if err != nil {
+		retentionValue = 0
+	}
 	return &FeedForm{
 		FeedURL:                     r.FormValue("feed_url"),
 		SiteURL:                     r.FormValue("site_url"),
@@ -87,5 +101,9 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		FetchViaProxy:               r.FormValue("fetch_via_proxy") == "1",
 		Disabled:                    r.FormValue("disabled") == "1",
 		HideGlobally:                r.FormValue("hide_globally") == "1",
+		Notify:                      r.FormValue("notify") == "1",
+		RetentionMode:               r.FormValue("retention_mode"),
+		RetentionValue:              retentionValue,
+		RetentionDelete:             r.FormValue("retention_delete") == "1",
 	}
 }
@@ -0,0 +1,79 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"miniflux.app/model"
+)
+
+// FeedBatchForm represents the form applying an action to the selected feeds.
+type FeedBatchForm struct {
+	FeedIDs        []int64
+	Action         string
+	CategoryID     int64
+	UserAgent      string
+	ScraperRules   string
+	RewriteRules   string
+	BlocklistRules string
+	KeeplistRules  string
+}
+
+// Request returns the batch request of the form, the rules left empty are not replaced.
+func (f FeedBatchForm) Request() *model.FeedBatchRequest {
+	request := &model.FeedBatchRequest{
+		FeedIDs:    f.FeedIDs,
+		Action:     f.Action,
+		CategoryID: f.CategoryID,
+		UserAgent:  f.UserAgent,
+	}
+
+	if f.Action == model.FeedBatchActionApplyRules {
+		request.ScraperRules = optionalRule(f.ScraperRules)
+		request.RewriteRules = optionalRule(f.RewriteRules)
+		request.BlocklistRules = optionalRule(f.BlocklistRules)
+		request.KeeplistRules = optionalRule(f.KeeplistRules)
+	}
+
+	return request
+}
+
+// NewFeedBatchForm parses the HTTP request and returns a FeedBatchForm, invalid feed IDs are ignored.
+func NewFeedBatchForm(r *http.Request) *FeedBatchForm {
+	r.ParseForm()
+
+	var feedIDs []int64
+	for _, value := range r.Form["feed_ids"] {
+		if feedID, err := strconv.ParseInt(value, 10, 64); err == nil && feedID > 0 {
+			feedIDs = append(feedIDs, feedID)
+		}
+	}
+
+	categoryID, err := strconv.ParseInt(r.FormValue("category_id"), 10, 64)
+	if err != nil {
+		categoryID = 0
+	}
+
+	return &FeedBatchForm{
+		FeedIDs:        feedIDs,
+		Action:         r.FormValue("action"),
+		CategoryID:     categoryID,
+		UserAgent:      strings.TrimSpace(r.FormValue("user_agent")),
+		ScraperRules:   strings.TrimSpace(r.FormValue("scraper_rules")),
+		RewriteRules:   strings.TrimSpace(r.FormValue("rewrite_rules")),
+		BlocklistRules: strings.TrimSpace(r.FormValue("blocklist_rules")),
+		KeeplistRules:  strings.TrimSpace(r.FormValue("keeplist_rules")),
+	}
+}
+
+func optionalRule(rule string) *string {
+	if rule == "" {
+		return nil
+	}
+	return &rule
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestNewFeedBatchForm(t *testing.T) {
+	values := url.Values{
+		"feed_ids":    {"3", "invalid", "7", "-1"},
+		"action":      {model.FeedBatchActionMove},
+		"category_id": {"12"},
+	}
+
+	r, _ := http.NewRequest(http.MethodPost, "/feeds/batch", strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	request := NewFeedBatchForm(r).Request()
+	if len(request.FeedIDs) != 2 || request.FeedIDs[0] != 3 || request.FeedIDs[1] != 7 {
+		t.Errorf(`Unexpected feed IDs: %v`, request.FeedIDs)
+	}
+
+	if request.Action != model.FeedBatchActionMove || request.CategoryID != 12 {
+		t.Errorf(`Unexpected request: %+v`, request)
+	}
+}
+
+func TestFeedBatchFormKeepsEmptyRules(t *testing.T) {
+	feedBatchForm := &FeedBatchForm{FeedIDs: []int64{1}, Action: model.FeedBatchActionApplyRules, BlocklistRules: "(?i)sponsored"}
+	request := feedBatchForm.Request()
+
+	if request.BlocklistRules == nil || *request.BlocklistRules != "(?i)sponsored" {
+		t.Error(`The blocklist rules should be replaced`)
+	}
+
+	if request.ScraperRules != nil || request.RewriteRules != nil || request.KeeplistRules != nil {
+		t.Error(`The empty rules should be left untouched`)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestFeedFormMergeRetentionPolicy(t *testing.T) {
+	values := url.Values{
+		"title":            {"Example"},
+		"retention_mode":   {model.RetentionModeCount},
+		"retention_value":  {"50"},
+		"retention_delete": {"1"},
+	}
+
+	r, _ := http.NewRequest(http.MethodPost, "/feed/1/update", strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	feed := NewFeedForm(r).Merge(&model.Feed{Category: &model.Category{}})
+	if feed.RetentionMode != model.RetentionModeCount || feed.RetentionValue != 50 || !feed.RetentionDelete {
+		t.Errorf(`Unexpected retention policy %+v`, feed.RetentionPolicy)
+	}
+
+	feedForm := &FeedForm{RetentionMode: model.RetentionModeNever, RetentionValue: 50, RetentionDelete: true}
+	feed = feedForm.Merge(feed)
+	if feed.RetentionMode != model.RetentionModeNever || feed.RetentionValue != 0 || feed.RetentionDelete {
+		t.Errorf(`The value should be cleared when the mode does not use it, got %+v`, feed.RetentionPolicy)
+	}
+}
@@ -6,141 +6,174 @@ package form // import "miniflux.app/ui/form"
 
 import (
 	"net/http"
+	"strconv"
 
+	"miniflux.app/integration"
 	"miniflux.app/model"
 )
 
 // IntegrationForm represents user integration settings form.
 type IntegrationForm struct {
-	PinboardEnabled      bool
-	PinboardToken        string
-	PinboardTags         string
-	PinboardMarkAsUnread bool
-	InstapaperEnabled    bool
-	InstapaperUsername   string
-	InstapaperPassword   string
 	FeverEnabled         bool
 	FeverUsername        string
 	FeverPassword        string
 	GoogleReaderEnabled  bool
 	GoogleReaderUsername string
 	GoogleReaderPassword string
-	WallabagEnabled      bool
-	WallabagOnlyURL      bool
-	WallabagURL          string
-	WallabagClientID     string
-	WallabagClientSecret string
-	WallabagUsername     string
-	WallabagPassword     string
-	NunuxKeeperEnabled   bool
-	NunuxKeeperURL       string
-	NunuxKeeperAPIKey    string
-	EspialEnabled        bool
-	EspialURL            string
-	EspialAPIKey         string
-	EspialTags           string
-	PocketEnabled        bool
-	PocketAccessToken    string
-	PocketConsumerKey    string
-	TelegramBotEnabled   bool
-	TelegramBotToken     string
-	TelegramBotChatID    string
-	LinkdingEnabled      bool
-	LinkdingURL          string
-	LinkdingAPIKey       string
-	MatrixBotEnabled     bool
-	MatrixBotUser        string
-	MatrixBotPassword    string
-	MatrixBotURL         string
-	MatrixBotChatID      string
+	Providers            []*IntegrationProviderForm
+
+	NotificationRules           string
+	NotificationQuietHoursStart int
+	NotificationQuietHoursEnd   int
+	NotificationDigestInterval  int
+}
+
+// IntegrationProviderForm represents the settings of an integration provider.
+type IntegrationProviderForm struct {
+	Name           string
+	Title          string
+	Enabled        bool
+	Fields         []IntegrationFieldForm
+	AuthorizeRoute string
+	Authorized     bool
+}
+
+// IntegrationFieldForm represents a setting of an integration provider.
+type IntegrationFieldForm struct {
+	integration.Field
+	InputName string
+	Value     string
+}
+
+// Checked returns true when a boolean setting is enabled.
+func (f IntegrationFieldForm) Checked() bool {
+	checked, _ := strconv.ParseBool(f.Value)
+	return checked
 }
 
 // Merge copy form values to the model.
 func (i IntegrationForm) Merge(integration *model.Integration) {
-	integration.PinboardEnabled = i.PinboardEnabled
-	integration.PinboardToken = i.PinboardToken
-	integration.PinboardTags = i.PinboardTags
-	integration.PinboardMarkAsUnread = i.PinboardMarkAsUnread
-	integration.InstapaperEnabled = i.InstapaperEnabled
-	integration.InstapaperUsername = i.InstapaperUsername
-	integration.InstapaperPassword = i.InstapaperPassword
 	integration.FeverEnabled = i.FeverEnabled
 	integration.FeverUsername = i.FeverUsername
 	integration.GoogleReaderEnabled = i.GoogleReaderEnabled
 	integration.GoogleReaderUsername = i.GoogleReaderUsername
-	integration.WallabagEnabled = i.WallabagEnabled
-	integration.WallabagOnlyURL = i.WallabagOnlyURL
-	integration.WallabagURL = i.WallabagURL
-	integration.WallabagClientID = i.WallabagClientID
-	integration.WallabagClientSecret = i.WallabagClientSecret
-	integration.WallabagUsername = i.WallabagUsername
-	integration.WallabagPassword = i.WallabagPassword
-	integration.NunuxKeeperEnabled = i.NunuxKeeperEnabled
-	integration.NunuxKeeperURL = i.NunuxKeeperURL
-	integration.NunuxKeeperAPIKey = i.NunuxKeeperAPIKey
-	integration.EspialEnabled = i.EspialEnabled
-	integration.EspialURL = i.EspialURL
-	integration.EspialAPIKey = i.EspialAPIKey
-	integration.EspialTags = i.EspialTags
-	integration.PocketEnabled = i.PocketEnabled
-	integration.PocketAccessToken = i.PocketAccessToken
-	integration.PocketConsumerKey = i.PocketConsumerKey
-	integration.TelegramBotEnabled = i.TelegramBotEnabled
-	integration.TelegramBotToken = i.TelegramBotToken
-	integration.TelegramBotChatID = i.TelegramBotChatID
-	integration.LinkdingEnabled = i.LinkdingEnabled
-	integration.LinkdingURL = i.LinkdingURL
-	integration.LinkdingAPIKey = i.LinkdingAPIKey
-	integration.MatrixBotEnabled = i.MatrixBotEnabled
-	integration.MatrixBotUser = i.MatrixBotUser
-	integration.MatrixBotPassword = i.MatrixBotPassword
-	integration.MatrixBotURL = i.MatrixBotURL
-	integration.MatrixBotChatID = i.MatrixBotChatID
+	integration.NotificationRules = i.NotificationRules
+	integration.NotificationQuietHoursStart = i.NotificationQuietHoursStart
+	integration.NotificationQuietHoursEnd = i.NotificationQuietHoursEnd
+	integration.NotificationDigestInterval = i.NotificationDigestInterval
+
+	for _, providerForm := range i.Providers {
+		current := integration.Provider(providerForm.Name)
+		settings := &model.ProviderSettings{
+			Name:    providerForm.Name,
+			Enabled: providerForm.Enabled,
+			Values:  make(map[string]string, len(current.Values)),
+		}
+
+		// Keep the settings that are not part of the form, like the access token of an authorization flow.
+		for key, value := range current.Values {
+			settings.Values[key] = value
+		}
+
+		for _, field := range providerForm.Fields {
+			settings.Values[field.Key] = field.Value
+		}
+
+		integration.SetProvider(settings)
+	}
+}
+
+// NewIntegrationProviderForms returns the forms of all registered providers populated with the user settings.
+func NewIntegrationProviderForms(userIntegration *model.Integration) []*IntegrationProviderForm {
+	var forms []*IntegrationProviderForm
+	for _, provider := range integration.Providers() {
+		settings := userIntegration.Provider(provider.Name())
+		forms = append(forms, newIntegrationProviderForm(provider, settings.Enabled, settings.Get))
+	}
+	return forms
+}
+
+func newIntegrationProviderForm(provider integration.Provider, enabled bool, valueOf func(key string) string) *IntegrationProviderForm {
+	providerForm := &IntegrationProviderForm{
+		Name:    provider.Name(),
+		Title:   provider.Title(),
+		Enabled: enabled,
+	}
+
+	for _, field := range provider.Fields() {
+		providerForm.Fields = append(providerForm.Fields, IntegrationFieldForm{
+			Field:     field,
+			InputName: provider.Name() + "_" + field.Key,
+			Value:     valueOf(field.Key),
+		})
+	}
+
+	if authorizer, ok := provider.(integration.Authorizer); ok {
+		providerForm.AuthorizeRoute = authorizer.AuthorizeRoute()
+		providerForm.Authorized = authorizer.IsAuthorized(&model.ProviderSettings{
+			Name:   provider.Name(),
+			Values: providerForm.values(),
+		})
+	}
+
+	return providerForm
+}
+
+func (p *IntegrationProviderForm) values() map[string]string {
+	values := make(map[string]string, len(p.Fields))
+	for _, field := range p.Fields {
+		values[field.Key] = field.Value
+	}
+	return values
 }
 
 // NewIntegrationForm returns a new IntegrationForm.
 func NewIntegrationForm(r *http.Request) *IntegrationForm {
 	return &IntegrationForm{
-		PinboardEnabled:      r.FormValue("pinboard_enabled") == "1",
-		PinboardToken:        r.FormValue("pinboard_token"),
-		PinboardTags:         r.FormValue("pinboard_tags"),
-		PinboardMarkAsUnread: r.FormValue("pinboard_mark_as_unread") == "1",
-		InstapaperEnabled:    r.FormValue("instapaper_enabled") == "1",
-		InstapaperUsername:   r.FormValue("instapaper_username"),
-		InstapaperPassword:   r.FormValue("instapaper_password"),
 		FeverEnabled:         r.FormValue("fever_enabled") == "1",
 		FeverUsername:        r.FormValue("fever_username"),
 		FeverPassword:        r.FormValue("fever_password"),
 		GoogleReaderEnabled:  r.FormValue("googlereader_enabled") == "1",
 		GoogleReaderUsername: r.FormValue("googlereader_username"),
 		GoogleReaderPassword: r.FormValue("googlereader_password"),
-		WallabagEnabled:      r.FormValue("wallabag_enabled") == "1",
-		WallabagOnlyURL:      r.FormValue("wallabag_only_url") == "1",
-		WallabagURL:          r.FormValue("wallabag_url"),
-		WallabagClientID:     r.FormValue("wallabag_client_id"),
-		WallabagClientSecret: r.FormValue("wallabag_client_secret"),
-		WallabagUsername:     r.FormValue("wallabag_username"),
-		WallabagPassword:     r.FormValue("wallabag_password"),
-		NunuxKeeperEnabled:   r.FormValue("nunux_keeper_enabled") == "1",
-		NunuxKeeperURL:       r.FormValue("nunux_keeper_url"),
-		NunuxKeeperAPIKey:    r.FormValue("nunux_keeper_api_key"),
-		EspialEnabled:        r.FormValue("espial_enabled") == "1",
-		EspialURL:            r.FormValue("espial_url"),
-		EspialAPIKey:         r.FormValue("espial_api_key"),
-		EspialTags:           r.FormValue("espial_tags"),
-		PocketEnabled:        r.FormValue("pocket_enabled") == "1",
-		PocketAccessToken:    r.FormValue("pocket_access_token"),
-		PocketConsumerKey:    r.FormValue("pocket_consumer_key"),
-		TelegramBotEnabled:   r.FormValue("telegram_bot_enabled") == "1",
-		TelegramBotToken:     r.FormValue("telegram_bot_token"),
-		TelegramBotChatID:    r.FormValue("telegram_bot_chat_id"),
-		LinkdingEnabled:      r.FormValue("linkding_enabled") == "1",
-		LinkdingURL:          r.FormValue("linkding_url"),
-		LinkdingAPIKey:       r.FormValue("linkding_api_key"),
-		MatrixBotEnabled:     r.FormValue("matrix_bot_enabled") == "1",
-		MatrixBotUser:        r.FormValue("matrix_bot_user"),
-		MatrixBotPassword:    r.FormValue("matrix_bot_password"),
-		MatrixBotURL:         r.FormValue("matrix_bot_url"),
-		MatrixBotChatID:      r.FormValue("matrix_bot_chat_id"),
+		Providers:            newIntegrationProviderFormsFromRequest(r),
+
+		NotificationRules:           r.FormValue("notification_rules"),
+		NotificationQuietHoursStart: integerFormValue(r, "notification_quiet_hours_start"),
+		NotificationQuietHoursEnd:   integerFormValue(r, "notification_quiet_hours_end"),
+		NotificationDigestInterval:  integerFormValue(r, "notification_digest_interval"),
+	}
+}
+
+// integerFormValue returns -1 when the value is not a number to make the validation fail.
+func integerFormValue(r *http.Request, name string) int {
+	value := r.FormValue(name)
+	if value == "" {
+		return 0
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return -1
+	}
+	return number
+}
+
+func newIntegrationProviderFormsFromRequest(r *http.Request) []*IntegrationProviderForm {
+	var forms []*IntegrationProviderForm
+	for _, provider := range integration.Providers() {
+		prefix := provider.Name() + "_"
+		fieldTypes := make(map[string]string)
+		for _, field := range provider.Fields() {
+			fieldTypes[field.Key] = field.Type
+		}
+
+		forms = append(forms, newIntegrationProviderForm(provider, r.FormValue(prefix+"enabled") == "1", func(key string) string {
+			if fieldTypes[key] == integration.FieldTypeBool {
+				return strconv.FormatBool(r.FormValue(prefix+key) == "1")
+			}
+			return r.FormValue(prefix + key)
+		}))
 	}
+	return forms
 }
@@ -0,0 +1,47 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"strings"
+
+	"miniflux.app/errors"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+// InvitationForm represents the form used to invite someone.
+type InvitationForm struct {
+	Email string
+	Role  string
+}
+
+// Validate makes sure the form values are valid.
+// The email address is optional, the link can be shared by other means.
+func (f InvitationForm) Validate() error {
+	if f.Email != "" && !validator.IsValidEmail(f.Email) {
+		return errors.NewLocalizedError("error.invalid_email")
+	}
+
+	if !model.IsValidRole(f.Role) {
+		return errors.NewLocalizedError("error.invalid_role")
+	}
+
+	return nil
+}
+
+// NewInvitationForm returns a new InvitationForm.
+func NewInvitationForm(r *http.Request) *InvitationForm {
+	role := r.FormValue("role")
+	if role == "" {
+		role = model.RoleUser
+	}
+
+	return &InvitationForm{
+		Email: strings.TrimSpace(r.FormValue("email")),
+		Role:  role,
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"strconv"
+
+	"miniflux.app/errors"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+// OPMLSubscriptionForm represents the remote OPML subscription form.
+type OPMLSubscriptionForm struct {
+	URL           string
+	CategoryID    int64
+	RemovalPolicy string
+}
+
+// Merge copy form values to the model.
+func (f OPMLSubscriptionForm) Merge(subscription *model.OPMLSubscription) *model.OPMLSubscription {
+	subscription.URL = f.URL
+	subscription.RemovalPolicy = f.RemovalPolicy
+	subscription.CategoryID = nil
+	if f.CategoryID > 0 {
+		categoryID := f.CategoryID
+		subscription.CategoryID = &categoryID
+	}
+	return subscription
+}
+
+// Validate makes sure the form values are valid.
+func (f OPMLSubscriptionForm) Validate() error {
+	if f.URL == "" {
+		return errors.NewLocalizedError("error.feed_url_not_empty")
+	}
+
+	if !validator.IsValidURL(f.URL) {
+		return errors.NewLocalizedError("error.invalid_feed_url")
+	}
+
+	if !model.IsValidOPMLRemovalPolicy(f.RemovalPolicy) {
+		return errors.NewLocalizedError("error.opml_subscription_invalid_removal_policy")
+	}
+
+	return nil
+}
+
+// NewOPMLSubscriptionForm returns a new OPMLSubscriptionForm.
+func NewOPMLSubscriptionForm(r *http.Request) *OPMLSubscriptionForm {
+	categoryID, err := strconv.ParseInt(r.FormValue("category_id"), 10, 64)
+	if err != nil {
+		categoryID = 0
+	}
+
+	return &OPMLSubscriptionForm{
+		URL:           r.FormValue("url"),
+		CategoryID:    categoryID,
+		RemovalPolicy: r.FormValue("removal_policy"),
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestValidOPMLSubscription(t *testing.T) {
+	subscriptionForm := &OPMLSubscriptionForm{URL: "https://example.org/feeds.opml", RemovalPolicy: "disable"}
+
+	if err := subscriptionForm.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestOPMLSubscriptionWithInvalidURL(t *testing.T) {
+	subscriptionForm := &OPMLSubscriptionForm{URL: "example.org/feeds.opml", RemovalPolicy: "keep"}
+
+	if err := subscriptionForm.Validate(); err == nil {
+		t.Error("A relative URL should be rejected")
+	}
+}
+
+func TestOPMLSubscriptionWithInvalidRemovalPolicy(t *testing.T) {
+	subscriptionForm := &OPMLSubscriptionForm{URL: "https://example.org/feeds.opml", RemovalPolicy: "delete"}
+
+	if err := subscriptionForm.Validate(); err == nil {
+		t.Error("An unknown removal policy should be rejected")
+	}
+}
+
+func TestOPMLSubscriptionMergeWithoutCategory(t *testing.T) {
+	subscriptionForm := &OPMLSubscriptionForm{URL: "https://example.org/feeds.opml", RemovalPolicy: "keep"}
+	subscription := subscriptionForm.Merge(&model.OPMLSubscription{})
+
+	if subscription.CategoryID != nil {
+		t.Errorf(`The category should follow the OPML file, got %d`, *subscription.CategoryID)
+	}
+
+	subscriptionForm.CategoryID = 42
+	subscription = subscriptionForm.Merge(subscription)
+	if subscription.CategoryID == nil || *subscription.CategoryID != 42 {
+		t.Error(`The category should be mapped to #42`)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"strings"
+
+	"miniflux.app/errors"
+)
+
+// ForgotPasswordForm represents the form used to request a password reset link.
+type ForgotPasswordForm struct {
+	Email string
+}
+
+// Validate makes sure the form values are valid.
+func (f ForgotPasswordForm) Validate() error {
+	if f.Email == "" {
+		return errors.NewLocalizedError("error.fields_mandatory")
+	}
+
+	return nil
+}
+
+// NewForgotPasswordForm returns a new ForgotPasswordForm.
+func NewForgotPasswordForm(r *http.Request) *ForgotPasswordForm {
+	return &ForgotPasswordForm{
+		Email: strings.TrimSpace(r.FormValue("email")),
+	}
+}
+
+// PasswordResetForm represents the form used to choose a new password.
+type PasswordResetForm struct {
+	Password     string
+	Confirmation string
+}
+
+// Validate makes sure the form values are valid.
+func (f PasswordResetForm) Validate() error {
+	if f.Password == "" || f.Confirmation == "" {
+		return errors.NewLocalizedError("error.fields_mandatory")
+	}
+
+	if f.Password != f.Confirmation {
+		return errors.NewLocalizedError("error.different_passwords")
+	}
+
+	if len(f.Password) < 6 {
+		return errors.NewLocalizedError("error.password_min_length")
+	}
+
+	return nil
+}
+
+// NewPasswordResetForm returns a new PasswordResetForm.
+func NewPasswordResetForm(r *http.Request) *PasswordResetForm {
+	return &PasswordResetForm{
+		Password:     r.FormValue("password"),
+		Confirmation: r.FormValue("confirmation"),
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"miniflux.app/errors"
+	"miniflux.app/model"
+	"miniflux.app/validator"
+)
+
+// PublishedFeedForm represents the published feed form.
+type PublishedFeedForm struct {
+	Title       string
+	Kind        string
+	CategoryID  int64
+	FeedID      int64
+	SearchQuery string
+	UnreadOnly  bool
+}
+
+// Merge copy form values to the model, only the filter matching the kind is kept.
+func (f PublishedFeedForm) Merge(publishedFeed *model.PublishedFeed) *model.PublishedFeed {
+	publishedFeed.Title = f.Title
+	publishedFeed.Kind = f.Kind
+	publishedFeed.SearchQuery = f.SearchQuery
+	publishedFeed.UnreadOnly = f.UnreadOnly
+	publishedFeed.CategoryID = nil
+	publishedFeed.FeedID = nil
+
+	switch f.Kind {
+	case model.PublishedFeedKindCategory:
+		categoryID := f.CategoryID
+		publishedFeed.CategoryID = &categoryID
+	case model.PublishedFeedKindFeed:
+		feedID := f.FeedID
+		publishedFeed.FeedID = &feedID
+	}
+
+	return publishedFeed
+}
+
+// Validate makes sure the form values are valid.
+func (f PublishedFeedForm) Validate() error {
+	if f.Title == "" {
+		return errors.NewLocalizedError("error.title_required")
+	}
+
+	if !model.IsValidPublishedFeedKind(f.Kind) {
+		return errors.NewLocalizedError("error.published_feed_invalid_kind")
+	}
+
+	if f.Kind == model.PublishedFeedKindCategory && f.CategoryID <= 0 {
+		return errors.NewLocalizedError("error.published_feed_category_required")
+	}
+
+	if f.Kind == model.PublishedFeedKindFeed && f.FeedID <= 0 {
+		return errors.NewLocalizedError("error.published_feed_feed_required")
+	}
+
+	if f.Kind == model.PublishedFeedKindSearch && f.SearchQuery == "" {
+		return errors.NewLocalizedError("error.published_feed_search_required")
+	}
+
+	if validator.ValidateSearchQuery(f.SearchQuery) != nil {
+		return errors.NewLocalizedError("error.invalid_search_query")
+	}
+
+	return nil
+}
+
+// NewPublishedFeedForm returns a new PublishedFeedForm.
+func NewPublishedFeedForm(r *http.Request) *PublishedFeedForm {
+	categoryID, err := strconv.ParseInt(r.FormValue("category_id"), 10, 64)
+	if err != nil {
+		categoryID = 0
+	}
+
+	feedID, err := strconv.ParseInt(r.FormValue("feed_id"), 10, 64)
+	if err != nil {
+		feedID = 0
+	}
+
+	return &PublishedFeedForm{
+		Title:       strings.TrimSpace(r.FormValue("title")),
+		Kind:        r.FormValue("kind"),
+		CategoryID:  categoryID,
+		FeedID:      feedID,
+		SearchQuery: strings.TrimSpace(r.FormValue("search_query")),
+		UnreadOnly:  r.FormValue("unread_only") == "1",
+	}
+}
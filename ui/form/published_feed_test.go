@@ -0,0 +1,58 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestValidPublishedFeed(t *testing.T) {
+	publishedFeedForm := &PublishedFeedForm{Title: "Starred", Kind: model.PublishedFeedKindStarred}
+
+	if err := publishedFeedForm.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPublishedFeedWithInvalidKind(t *testing.T) {
+	publishedFeedForm := &PublishedFeedForm{Title: "Everything", Kind: "all"}
+
+	if err := publishedFeedForm.Validate(); err == nil {
+		t.Error("An unknown kind should be rejected")
+	}
+}
+
+func TestPublishedFeedWithMissingFilter(t *testing.T) {
+	for _, kind := range []string{model.PublishedFeedKindCategory, model.PublishedFeedKindFeed, model.PublishedFeedKindSearch} {
+		publishedFeedForm := &PublishedFeedForm{Title: "Filtered", Kind: kind}
+
+		if err := publishedFeedForm.Validate(); err == nil {
+			t.Errorf("The %q kind should require a filter", kind)
+		}
+	}
+}
+
+func TestPublishedFeedWithInvalidSearchQuery(t *testing.T) {
+	publishedFeedForm := &PublishedFeedForm{Title: "Recent", Kind: model.PublishedFeedKindSearch, SearchQuery: "golang after:yesterday"}
+
+	if err := publishedFeedForm.Validate(); err == nil {
+		t.Error("A search query with an invalid operator should be rejected")
+	}
+}
+
+func TestPublishedFeedMergeKeepsOnlyMatchingFilter(t *testing.T) {
+	publishedFeedForm := &PublishedFeedForm{Title: "News", Kind: model.PublishedFeedKindCategory, CategoryID: 42, FeedID: 7}
+	publishedFeed := publishedFeedForm.Merge(&model.PublishedFeed{})
+
+	if publishedFeed.CategoryID == nil || *publishedFeed.CategoryID != 42 {
+		t.Error(`The category #42 should be selected`)
+	}
+
+	if publishedFeed.FeedID != nil {
+		t.Errorf(`The feed should be ignored for a category, got %d`, *publishedFeed.FeedID)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"strings"
+
+	"miniflux.app/errors"
+	"miniflux.app/validator"
+)
+
+// RegistrationForm represents the form used by visitors and invited people to create an account.
+type RegistrationForm struct {
+	Username     string
+	Email        string
+	Password     string
+	Confirmation string
+}
+
+// Validate makes sure the form values are valid.
+func (f RegistrationForm) Validate() error {
+	if f.Username == "" || f.Email == "" || f.Password == "" || f.Confirmation == "" {
+		return errors.NewLocalizedError("error.fields_mandatory")
+	}
+
+	if !validator.IsValidEmail(f.Email) {
+		return errors.NewLocalizedError("error.invalid_email")
+	}
+
+	if f.Password != f.Confirmation {
+		return errors.NewLocalizedError("error.different_passwords")
+	}
+
+	return nil
+}
+
+// NewRegistrationForm returns a new RegistrationForm.
+func NewRegistrationForm(r *http.Request) *RegistrationForm {
+	return &RegistrationForm{
+		Username:     strings.TrimSpace(r.FormValue("username")),
+		Email:        strings.TrimSpace(r.FormValue("email")),
+		Password:     r.FormValue("password"),
+		Confirmation: r.FormValue("confirmation"),
+	}
+}
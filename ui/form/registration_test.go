@@ -0,0 +1,53 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import "testing"
+
+func TestRegistrationFormValidation(t *testing.T) {
+	scenarios := []struct {
+		form  RegistrationForm
+		valid bool
+	}{
+		{RegistrationForm{Username: "bob", Email: "bob@example.org", Password: "secret", Confirmation: "secret"}, true},
+		{RegistrationForm{Username: "bob", Password: "secret", Confirmation: "secret"}, false},
+		{RegistrationForm{Username: "bob", Email: "bob", Password: "secret", Confirmation: "secret"}, false},
+		{RegistrationForm{Username: "bob", Email: "bob@example.org", Password: "secret", Confirmation: "other"}, false},
+	}
+
+	for _, scenario := range scenarios {
+		if err := scenario.form.Validate(); (err == nil) != scenario.valid {
+			t.Errorf(`Unexpected validation result for %+v: %v`, scenario.form, err)
+		}
+	}
+}
+
+func TestPasswordResetFormValidation(t *testing.T) {
+	if err := (PasswordResetForm{Password: "secret", Confirmation: "other"}).Validate(); err == nil {
+		t.Error(`Different passwords should be rejected`)
+	}
+
+	if err := (PasswordResetForm{Password: "short", Confirmation: "short"}).Validate(); err == nil {
+		t.Error(`Short passwords should be rejected`)
+	}
+
+	if err := (PasswordResetForm{Password: "secret", Confirmation: "secret"}).Validate(); err != nil {
+		t.Errorf(`The form should be valid: %v`, err)
+	}
+}
+
+func TestInvitationFormValidation(t *testing.T) {
+	if err := (InvitationForm{Role: "user"}).Validate(); err != nil {
+		t.Errorf(`The email address should be optional: %v`, err)
+	}
+
+	if err := (InvitationForm{Email: "bob", Role: "user"}).Validate(); err == nil {
+		t.Error(`An invalid email address should be rejected`)
+	}
+
+	if err := (InvitationForm{Role: "superuser"}).Validate(); err == nil {
+		t.Error(`An unknown role should be rejected`)
+	}
+}
@@ -7,6 +7,7 @@ package form // import "miniflux.app/ui/form"
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"miniflux.app/errors"
 	"miniflux.app/model"
@@ -15,6 +16,7 @@ import (
 // SettingsForm represents the settings form.
 type SettingsForm struct {
 	Username               string
+	Email                  string
 	Password               string
 	Confirmation           string
 	Theme                  string
@@ -32,11 +34,14 @@ type SettingsForm struct {
 	CJKReadingSpeed        int
 	DefaultHomePage        string
 	CategoriesSortingOrder string
+	MarkReadAtEnd          bool
+	EntryScoring           bool
 }
 
 // Merge updates the fields of the given user.
 func (s *SettingsForm) Merge(user *model.User) *model.User {
 	user.Username = s.Username
+	user.Email = s.Email
 	user.Theme = s.Theme
 	user.Language = s.Language
 	user.Timezone = s.Timezone
@@ -52,6 +57,8 @@ func (s *SettingsForm) Merge(user *model.User) *model.User {
 	user.DefaultReadingSpeed = s.DefaultReadingSpeed
 	user.DefaultHomePage = s.DefaultHomePage
 	user.CategoriesSortingOrder = s.CategoriesSortingOrder
+	user.MarkReadAtEnd = s.MarkReadAtEnd
+	user.EntryScoring = s.EntryScoring
 
 	if s.Password != "" {
 		user.Password = s.Password
@@ -100,6 +107,7 @@ func NewSettingsForm(r *http.Request) *SettingsForm {
 	}
 	return &SettingsForm{
 		Username:               r.FormValue("username"),
+		Email:                  strings.TrimSpace(r.FormValue("email")),
 		Password:               r.FormValue("password"),
 		Confirmation:           r.FormValue("confirmation"),
 		Theme:                  r.FormValue("theme"),
@@ -117,5 +125,7 @@ func NewSettingsForm(r *http.Request) *SettingsForm {
 		CJKReadingSpeed:        int(cjkReadingSpeed),
 		DefaultHomePage:        r.FormValue("default_home_page"),
 		CategoriesSortingOrder: r.FormValue("categories_sorting_order"),
+		MarkReadAtEnd:          r.FormValue("mark_read_at_end") == "1",
+		EntryScoring:           r.FormValue("entry_scoring") == "1",
 	}
 }
@@ -0,0 +1,54 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"strings"
+
+	"miniflux.app/errors"
+)
+
+// TwoFactorForm represents the form used to enter a one-time code or a recovery code.
+type TwoFactorForm struct {
+	Code string
+}
+
+// Validate makes sure the form values are valid.
+func (t TwoFactorForm) Validate() error {
+	if t.Code == "" {
+		return errors.NewLocalizedError("error.fields_mandatory")
+	}
+
+	return nil
+}
+
+// NewTwoFactorForm returns a new TwoFactorForm.
+func NewTwoFactorForm(r *http.Request) *TwoFactorForm {
+	return &TwoFactorForm{
+		Code: strings.TrimSpace(r.FormValue("code")),
+	}
+}
+
+// AppPasswordForm represents the app password form.
+type AppPasswordForm struct {
+	Description string
+}
+
+// Validate makes sure the form values are valid.
+func (a AppPasswordForm) Validate() error {
+	if a.Description == "" {
+		return errors.NewLocalizedError("error.fields_mandatory")
+	}
+
+	return nil
+}
+
+// NewAppPasswordForm returns a new AppPasswordForm.
+func NewAppPasswordForm(r *http.Request) *AppPasswordForm {
+	return &AppPasswordForm{
+		Description: strings.TrimSpace(r.FormValue("description")),
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestNewTwoFactorForm(t *testing.T) {
+	values := url.Values{"code": {" 123456 "}}
+	r, _ := http.NewRequest(http.MethodPost, "http://example.org/login/two-factor", strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	twoFactorForm := NewTwoFactorForm(r)
+	if twoFactorForm.Code != "123456" {
+		t.Errorf(`The code should be trimmed, got %q`, twoFactorForm.Code)
+	}
+
+	if err := twoFactorForm.Validate(); err != nil {
+		t.Errorf(`The form should be valid: %v`, err)
+	}
+
+	if err := (&TwoFactorForm{}).Validate(); err == nil {
+		t.Error(`An empty code should be rejected`)
+	}
+}
+
+func TestUserFormTwoFactorFields(t *testing.T) {
+	values := url.Values{"username": {"bob"}, "two_factor_required": {"1"}, "reset_two_factor": {"1"}}
+	r, _ := http.NewRequest(http.MethodPost, "http://example.org/users/1/update", strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	userForm := NewUserForm(r)
+	if !userForm.TwoFactorRequired || !userForm.ResetTwoFactor {
+		t.Errorf(`The two-factor fields should be parsed: %+v`, userForm)
+	}
+}
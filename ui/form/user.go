@@ -6,9 +6,12 @@ package form // import "miniflux.app/ui/form"
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"miniflux.app/errors"
 	"miniflux.app/model"
+	"miniflux.app/validator"
 )
 
 // UserForm represents the user form.
@@ -16,7 +19,21 @@ type UserForm struct {
 	Username     string
 	Password     string
 	Confirmation string
-	IsAdmin      bool
+	Role         string
+	Status       string
+	Email        string
+
+	// GuestCategoryIDs are the categories shared with a guest.
+	GuestCategoryIDs []int64
+
+	// TwoFactorRequired forces the user to set up two-factor authentication,
+	// ResetTwoFactor removes the second factor of a user who lost it.
+	TwoFactorRequired bool
+	ResetTwoFactor    bool
+
+	// RetentionMaxDays and RetentionMaxEntries cap the retention of all the feeds of the user, zero means unlimited.
+	RetentionMaxDays    int
+	RetentionMaxEntries int
 }
 
 // ValidateCreation validates user creation.
@@ -29,7 +46,11 @@ func (u UserForm) ValidateCreation() error {
 		return errors.NewLocalizedError("error.different_passwords")
 	}
 
-	return nil
+	if !model.IsValidRole(u.Role) {
+		return errors.NewLocalizedError("error.invalid_role")
+	}
+
+	return u.validateAccount()
 }
 
 // ValidateModification validates user modification.
@@ -48,13 +69,38 @@ func (u UserForm) ValidateModification() error {
 		}
 	}
 
+	if !model.IsValidRole(u.Role) {
+		return errors.NewLocalizedError("error.invalid_role")
+	}
+
+	return u.validateAccount()
+}
+
+func (u UserForm) validateAccount() error {
+	if !validator.IsValidUserStatus(u.Status) {
+		return errors.NewLocalizedError("error.invalid_user_status")
+	}
+
+	if u.Email != "" && !validator.IsValidEmail(u.Email) {
+		return errors.NewLocalizedError("error.invalid_email")
+	}
+
+	if u.RetentionMaxDays < 0 || u.RetentionMaxEntries < 0 {
+		return errors.NewLocalizedError("error.retention_invalid_cap")
+	}
+
 	return nil
 }
 
 // Merge updates the fields of the given user.
 func (u UserForm) Merge(user *model.User) *model.User {
 	user.Username = u.Username
-	user.IsAdmin = u.IsAdmin
+	user.Role = u.Role
+	user.IsAdmin = u.Role == model.RoleAdmin
+	user.Status = u.Status
+	user.Email = u.Email
+	user.RetentionMaxDays = u.RetentionMaxDays
+	user.RetentionMaxEntries = u.RetentionMaxEntries
 
 	if u.Password != "" {
 		user.Password = u.Password
@@ -63,12 +109,52 @@ func (u UserForm) Merge(user *model.User) *model.User {
 	return user
 }
 
+// HasGuestCategory returns true if the category is shared with the guest.
+func (u UserForm) HasGuestCategory(categoryID int64) bool {
+	for _, guestCategoryID := range u.GuestCategoryIDs {
+		if guestCategoryID == categoryID {
+			return true
+		}
+	}
+	return false
+}
+
 // NewUserForm returns a new UserForm.
 func NewUserForm(r *http.Request) *UserForm {
+	role := r.FormValue("role")
+	if role == "" {
+		role = model.RoleUser
+	}
+
+	status := r.FormValue("status")
+	if status == "" {
+		status = model.UserStatusActive
+	}
+
+	var guestCategoryIDs []int64
+	if role == model.RoleGuest {
+		for _, value := range r.Form["guest_category_ids"] {
+			if categoryID, err := strconv.ParseInt(value, 10, 64); err == nil {
+				guestCategoryIDs = append(guestCategoryIDs, categoryID)
+			}
+		}
+	}
+
+	retentionMaxDays, _ := strconv.Atoi(r.FormValue("retention_max_days"))
+	retentionMaxEntries, _ := strconv.Atoi(r.FormValue("retention_max_entries"))
+
 	return &UserForm{
-		Username:     r.FormValue("username"),
-		Password:     r.FormValue("password"),
-		Confirmation: r.FormValue("confirmation"),
-		IsAdmin:      r.FormValue("is_admin") == "1",
+		Username:          r.FormValue("username"),
+		Password:          r.FormValue("password"),
+		Confirmation:      r.FormValue("confirmation"),
+		Role:              role,
+		Status:            status,
+		Email:             strings.TrimSpace(r.FormValue("email")),
+		GuestCategoryIDs:  guestCategoryIDs,
+		TwoFactorRequired: r.FormValue("two_factor_required") == "1",
+		ResetTwoFactor:    r.FormValue("reset_two_factor") == "1",
+
+		RetentionMaxDays:    retentionMaxDays,
+		RetentionMaxEntries: retentionMaxEntries,
 	}
 }
@@ -0,0 +1,116 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package form // import "miniflux.app/ui/form"
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestUserFormWithInvalidRole(t *testing.T) {
+	userForm := &UserForm{Username: "bob", Password: "secret", Confirmation: "secret", Role: "superuser"}
+
+	if err := userForm.ValidateCreation(); err == nil {
+		t.Error("An unknown role should be rejected")
+	}
+
+	if err := userForm.ValidateModification(); err == nil {
+		t.Error("An unknown role should be rejected")
+	}
+}
+
+func TestUserFormMergeRole(t *testing.T) {
+	userForm := &UserForm{Username: "bob", Role: model.RoleAdmin}
+	user := userForm.Merge(&model.User{Role: model.RoleUser})
+
+	if user.Role != model.RoleAdmin || !user.IsAdmin {
+		t.Errorf(`The role should be updated, got %q`, user.Role)
+	}
+
+	userForm.Role = model.RoleGuest
+	userForm.Merge(user)
+
+	if user.Role != model.RoleGuest || user.IsAdmin {
+		t.Errorf(`The role should be updated, got %q`, user.Role)
+	}
+}
+
+func TestNewUserFormWithGuestCategories(t *testing.T) {
+	values := url.Values{
+		"username":           {"bob"},
+		"role":               {model.RoleGuest},
+		"guest_category_ids": {"1", "3", "invalid"},
+	}
+
+	r, _ := http.NewRequest(http.MethodPost, "http://example.org/user/save", strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	userForm := NewUserForm(r)
+	if !userForm.HasGuestCategory(1) || !userForm.HasGuestCategory(3) || userForm.HasGuestCategory(2) {
+		t.Errorf(`Unexpected guest categories: %v`, userForm.GuestCategoryIDs)
+	}
+
+	values.Set("role", model.RoleUser)
+	r, _ = http.NewRequest(http.MethodPost, "http://example.org/user/save", strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	userForm = NewUserForm(r)
+	if len(userForm.GuestCategoryIDs) != 0 {
+		t.Errorf(`Only guests should have shared categories: %v`, userForm.GuestCategoryIDs)
+	}
+}
+
+func TestUserFormStatusAndEmail(t *testing.T) {
+	userForm := &UserForm{Username: "bob", Role: model.RoleUser, Status: "unknown"}
+	if err := userForm.ValidateModification(); err == nil {
+		t.Error("An unknown status should be rejected")
+	}
+
+	userForm.Status = model.UserStatusDisabled
+	userForm.Email = "invalid"
+	if err := userForm.ValidateModification(); err == nil {
+		t.Error("An invalid email address should be rejected")
+	}
+
+	userForm.Email = "bob@example.org"
+	if err := userForm.ValidateModification(); err != nil {
+		t.Errorf("The form should be valid: %v", err)
+	}
+
+	user := userForm.Merge(&model.User{Status: model.UserStatusActive})
+	if user.Status != model.UserStatusDisabled || user.Email != "bob@example.org" {
+		t.Errorf(`The status and email should be updated, got %q and %q`, user.Status, user.Email)
+	}
+}
+
+func TestUserFormRetentionCaps(t *testing.T) {
+	values := url.Values{
+		"username":              {"bob"},
+		"retention_max_days":    {"90"},
+		"retention_max_entries": {"invalid"},
+	}
+
+	r, _ := http.NewRequest(http.MethodPost, "http://example.org/users/1/update", strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	userForm := NewUserForm(r)
+	if err := userForm.ValidateModification(); err != nil {
+		t.Errorf("The form should be valid: %v", err)
+	}
+
+	user := userForm.Merge(&model.User{RetentionMaxEntries: 500})
+	if user.RetentionMaxDays != 90 || user.RetentionMaxEntries != 0 {
+		t.Errorf(`Unexpected retention caps %d and %d`, user.RetentionMaxDays, user.RetentionMaxEntries)
+	}
+
+	userForm.RetentionMaxEntries = -1
+	if err := userForm.ValidateModification(); err == nil {
+		t.Error("A negative cap should be rejected")
+	}
+}
@@ -5,6 +5,7 @@
 package ui // import "miniflux.app/ui"
 
 import (
+	"miniflux.app/proxy"
 	"miniflux.app/storage"
 	"miniflux.app/template"
 	"miniflux.app/worker"
@@ -17,4 +18,6 @@ type handler struct {
 	store  *storage.Storage
 	tpl    *template.Engine
 	pool   *worker.Pool
+
+	mediaCache *proxy.MediaCache
 }
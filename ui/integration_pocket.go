@@ -11,6 +11,7 @@ import (
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/html"
 	"miniflux.app/http/route"
+	"miniflux.app/integration"
 	"miniflux.app/integration/pocket"
 	"miniflux.app/locale"
 	"miniflux.app/logger"
@@ -25,14 +26,14 @@ func (h *handler) pocketAuthorize(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	integration, err := h.store.Integration(user.ID)
+	userIntegration, err := h.store.Integration(user.ID)
 	if err != nil {
 		html.ServerError(w, r, err)
 		return
 	}
 
 	sess := session.New(h.store, request.SessionID(r))
-	connector := pocket.NewConnector(config.Opts.PocketConsumerKey(integration.PocketConsumerKey))
+	connector := pocket.NewConnector(integration.PocketConsumerKey(userIntegration.Provider("pocket")))
 	redirectURL := config.Opts.BaseURL() + route.Path(h.router, "pocketCallback")
 	requestToken, err := connector.RequestToken(redirectURL)
 	if err != nil {
@@ -56,13 +57,14 @@ func (h *handler) pocketCallback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	integration, err := h.store.Integration(user.ID)
+	userIntegration, err := h.store.Integration(user.ID)
 	if err != nil {
 		html.ServerError(w, r, err)
 		return
 	}
 
-	connector := pocket.NewConnector(config.Opts.PocketConsumerKey(integration.PocketConsumerKey))
+	settings := userIntegration.Provider("pocket")
+	connector := pocket.NewConnector(integration.PocketConsumerKey(settings))
 	accessToken, err := connector.AccessToken(request.PocketRequestToken(r))
 	if err != nil {
 		logger.Error("[Pocket:Callback] %v", err)
@@ -72,9 +74,10 @@ func (h *handler) pocketCallback(w http.ResponseWriter, r *http.Request) {
 	}
 
 	sess.SetPocketRequestToken("")
-	integration.PocketAccessToken = accessToken
+	settings.Set("access_token", accessToken)
+	userIntegration.SetProvider(settings)
 
-	err = h.store.UpdateIntegration(integration)
+	err = h.store.UpdateIntegration(userIntegration)
 	if err != nil {
 		html.ServerError(w, r, err)
 		return
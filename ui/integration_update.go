@@ -8,11 +8,14 @@ import (
 	"crypto/md5"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/html"
 	"miniflux.app/http/route"
+	"miniflux.app/integration"
 	"miniflux.app/locale"
+	"miniflux.app/model"
 	"miniflux.app/ui/form"
 	"miniflux.app/ui/session"
 )
@@ -26,48 +29,63 @@ func (h *handler) updateIntegration(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	integration, err := h.store.Integration(user.ID)
+	userIntegration, err := h.store.Integration(user.ID)
 	if err != nil {
 		html.ServerError(w, r, err)
 		return
 	}
 
 	integrationForm := form.NewIntegrationForm(r)
-	integrationForm.Merge(integration)
+	integrationForm.Merge(userIntegration)
 
-	if integration.FeverUsername != "" && h.store.HasDuplicateFeverUsername(user.ID, integration.FeverUsername) {
+	if userIntegration.FeverUsername != "" && h.store.HasDuplicateFeverUsername(user.ID, userIntegration.FeverUsername) {
 		sess.NewFlashErrorMessage(printer.Printf("error.duplicate_fever_username"))
 		html.Redirect(w, r, route.Path(h.router, "integrations"))
 		return
 	}
 
-	if integration.FeverEnabled {
+	if userIntegration.FeverEnabled {
 		if integrationForm.FeverPassword != "" {
-			integration.FeverToken = fmt.Sprintf("%x", md5.Sum([]byte(integration.FeverUsername+":"+integrationForm.FeverPassword)))
+			userIntegration.FeverToken = fmt.Sprintf("%x", md5.Sum([]byte(userIntegration.FeverUsername+":"+integrationForm.FeverPassword)))
 		}
 	} else {
-		integration.FeverToken = ""
+		userIntegration.FeverToken = ""
 	}
 
-	if integration.GoogleReaderUsername != "" && h.store.HasDuplicateGoogleReaderUsername(user.ID, integration.GoogleReaderUsername) {
+	if userIntegration.GoogleReaderUsername != "" && h.store.HasDuplicateGoogleReaderUsername(user.ID, userIntegration.GoogleReaderUsername) {
 		sess.NewFlashErrorMessage(printer.Printf("error.duplicate_googlereader_username"))
 		html.Redirect(w, r, route.Path(h.router, "integrations"))
 		return
 	}
 
-	if integration.GoogleReaderEnabled {
+	if userIntegration.GoogleReaderEnabled {
 		if integrationForm.GoogleReaderPassword != "" {
-			integration.GoogleReaderPassword = integrationForm.GoogleReaderPassword
+			userIntegration.GoogleReaderPassword = integrationForm.GoogleReaderPassword
 		}
 	} else {
-		integration.GoogleReaderPassword = ""
+		userIntegration.GoogleReaderPassword = ""
 	}
-	err = h.store.UpdateIntegration(integration)
+
+	if validationErr := integration.ValidateProviderSettings(userIntegration); validationErr != nil {
+		sess.NewFlashErrorMessage(printer.Printf(validationErr.TranslationKey))
+		html.Redirect(w, r, route.Path(h.router, "integrations"))
+		return
+	}
+
+	if validationErr := integration.ValidateNotificationSettings(userIntegration); validationErr != nil {
+		sess.NewFlashErrorMessage(printer.Printf(validationErr.TranslationKey))
+		html.Redirect(w, r, route.Path(h.router, "integrations"))
+		return
+	}
+
+	err = h.store.UpdateIntegration(userIntegration)
 	if err != nil {
 		html.ServerError(w, r, err)
 		return
 	}
 
+	recordAuditLog(h.store, r, model.AuditActionIntegrationUpdate, user.ID, "", "enabled="+strings.Join(userIntegration.EnabledProviders(), ","))
+
 	sess.NewFlashMessage(printer.Printf("alert.prefs_saved"))
 	html.Redirect(w, r, route.Path(h.router, "integrations"))
 }
@@ -0,0 +1,93 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/locale"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+	"miniflux.app/validator"
+)
+
+func (h *handler) showInvitationPage(w http.ResponseWriter, r *http.Request) {
+	h.renderInvitationPage(w, r, false)
+}
+
+func (h *handler) acceptInvitation(w http.ResponseWriter, r *http.Request) {
+	h.renderInvitationPage(w, r, true)
+}
+
+func (h *handler) renderInvitationPage(w http.ResponseWriter, r *http.Request, submitted bool) {
+	token := request.RouteStringParam(r, "token")
+	invitation, err := h.store.UserInvitationByToken(token)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if invitation == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	registrationForm := &form.RegistrationForm{}
+	if submitted {
+		registrationForm = form.NewRegistrationForm(r)
+	}
+
+	// The address that received the invitation cannot be changed.
+	if invitation.Email != "" {
+		registrationForm.Email = invitation.Email
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("invitation", invitation)
+	view.Set("form", registrationForm)
+
+	if !submitted {
+		html.OK(w, r, view.Render("invitation"))
+		return
+	}
+
+	if err := registrationForm.Validate(); err != nil {
+		view.Set("errorMessage", err.Error())
+		html.OK(w, r, view.Render("invitation"))
+		return
+	}
+
+	userCreationRequest := &model.UserCreationRequest{
+		Username: registrationForm.Username,
+		Password: registrationForm.Password,
+		Email:    registrationForm.Email,
+		Role:     invitation.Role,
+	}
+
+	if validationErr := validator.ValidateUserCreationWithPassword(h.store, userCreationRequest); validationErr != nil {
+		view.Set("errorMessage", validationErr.TranslationKey)
+		html.OK(w, r, view.Render("invitation"))
+		return
+	}
+
+	if _, err := h.store.AcceptUserInvitation(invitation, userCreationRequest); err != nil {
+		logger.Error("[UI:AcceptInvitation] %v", err)
+		view.Set("errorMessage", "error.unable_to_create_user")
+		html.OK(w, r, view.Render("invitation"))
+		return
+	}
+
+	logger.Info("[UI:AcceptInvitation] [ClientIP=%s] username=%s accepted the invitation #%d", request.ClientIP(r), userCreationRequest.Username, invitation.ID)
+
+	sess.NewFlashMessage(locale.NewPrinter(request.UserLanguage(r)).Printf("alert.registration_done"))
+	html.Redirect(w, r, route.Path(h.router, "login"))
+}
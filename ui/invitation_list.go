@@ -0,0 +1,52 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/mail"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+func (h *handler) showInvitationsPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	view, err := h.newInvitationsView(r, user)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	view.Set("form", &form.InvitationForm{Role: model.RoleUser})
+	html.OK(w, r, view.Render("invitations"))
+}
+
+func (h *handler) newInvitationsView(r *http.Request, user *model.User) (*view.View, error) {
+	invitations, err := h.store.UserInvitations()
+	if err != nil {
+		return nil, err
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("invitations", invitations)
+	view.Set("roles", model.AssignableRoles(user.Role))
+	view.Set("mailEnabled", mail.IsEnabled())
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	return view, nil
+}
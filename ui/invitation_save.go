@@ -0,0 +1,77 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/locale"
+	"miniflux.app/logger"
+	"miniflux.app/mail"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+)
+
+func (h *handler) saveInvitation(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	invitationForm := form.NewInvitationForm(r)
+	if !model.CanAssignRole(user.Role, invitationForm.Role) {
+		html.Forbidden(w, r)
+		return
+	}
+
+	view, err := h.newInvitationsView(r, user)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+	view.Set("form", invitationForm)
+
+	if err := invitationForm.Validate(); err != nil {
+		view.Set("errorMessage", err.Error())
+		html.OK(w, r, view.Render("invitations"))
+		return
+	}
+
+	if invitationForm.Email != "" && h.store.AnotherUserWithEmailExists(0, invitationForm.Email) {
+		view.Set("errorMessage", "error.email_already_used")
+		html.OK(w, r, view.Render("invitations"))
+		return
+	}
+
+	validity := time.Duration(config.Opts.InvitationExpirationHours()) * time.Hour
+	invitation := model.NewUserInvitation(user.ID, invitationForm.Email, invitationForm.Role, validity)
+	if err := h.store.CreateUserInvitation(invitation); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	printer := locale.NewPrinter(request.UserLanguage(r))
+
+	if invitation.Email != "" && mail.IsEnabled() {
+		if err := h.sendInvitationEmail(user, invitation); err != nil {
+			logger.Error("[UI:SaveInvitation] %v", err)
+			sess.NewFlashErrorMessage(printer.Printf("error.unable_to_send_email"))
+		} else {
+			sess.NewFlashMessage(printer.Printf("alert.invitation_sent", invitation.Email))
+		}
+	} else {
+		sess.NewFlashMessage(printer.Printf("alert.invitation_created"))
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "invitations"))
+}
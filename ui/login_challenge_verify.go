@@ -69,6 +69,7 @@ func (h *handler) verifyChallenge(w http.ResponseWriter, r *http.Request) {
 		// TODO: Extract to common function
 		sess.SetWebAuthnSessionData(nil)
 		logger.Error("[UI:VerifyChallenge] [ClientIP=%s] %v", clientIP, err)
+		recordFailedLogin(h.store, r, 0, challengeForm.Username, "passkey")
 		options, sessionData, err := web.BeginLogin(userCreds)
 		if err != nil {
 			logger.Error("[UI:VerifyChallenge] [ClientIP=%s] %v", clientIP, err)
@@ -98,6 +99,7 @@ func (h *handler) verifyChallenge(w http.ResponseWriter, r *http.Request) {
 	logger.Info("[UI:VerifyChallenge] username=%s just logged in", challengeForm.Username)
 	h.store.SetLastLogin(userID)
 	h.store.SetCredentialUsedTimestamp(userID, cred.ID)
+	recordAuditLog(h.store, r, model.AuditActionLogin, userID, "", "passkey")
 
 	user, err := h.store.UserByID(userID)
 	if err != nil {
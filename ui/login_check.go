@@ -9,6 +9,7 @@ import (
 	"miniflux.app/http/response/html"
 	"miniflux.app/http/route"
 	"miniflux.app/logger"
+	"miniflux.app/model"
 	"miniflux.app/ui/form"
 	"miniflux.app/ui/session"
 	"miniflux.app/ui/view"
@@ -31,10 +32,23 @@ func (h *handler) checkLogin(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.store.CheckPassword(authForm.Username, authForm.Password); err != nil {
 		logger.Error("[UI:CheckLogin] [ClientIP=%s] %v", clientIP, err)
+		recordFailedLogin(h.store, r, 0, authForm.Username, "password")
 		html.OK(w, r, view.Render("login"))
 		return
 	}
 
+	if h.store.HasTwoFactorEnabled(authForm.Username) {
+		user, err := h.store.UserByUsername(authForm.Username)
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		logger.Info("[UI:CheckLogin] [ClientIP=%s] username=%s must enter a second factor", clientIP, authForm.Username)
+		h.startTwoFactorLogin(w, r, sess, user.ID, "")
+		return
+	}
+
 	sessionToken, userID, err := h.store.CreateUserSessionFromUsername(authForm.Username, r.UserAgent(), clientIP)
 	if err != nil {
 		html.ServerError(w, r, err)
@@ -43,6 +57,7 @@ func (h *handler) checkLogin(w http.ResponseWriter, r *http.Request) {
 
 	logger.Info("[UI:CheckLogin] username=%s just logged in", authForm.Username)
 	h.store.SetLastLogin(userID)
+	recordAuditLog(h.store, r, model.AuditActionLogin, userID, "", "password")
 
 	user, err := h.store.UserByID(userID)
 	if err != nil {
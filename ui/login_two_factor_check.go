@@ -0,0 +1,123 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/config"
+	"miniflux.app/http/cookie"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+func (h *handler) checkLoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	clientIP := request.ClientIP(r)
+	sess := session.New(h.store, request.SessionID(r))
+
+	state := request.TwoFactorLoginState(r)
+	if state == nil || !state.IsValid() {
+		sess.SetTwoFactorLoginState(nil)
+		html.Redirect(w, r, route.Path(h.router, "login"))
+		return
+	}
+
+	twoFactor, err := h.store.TwoFactor(state.UserID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if twoFactor == nil || !twoFactor.Enabled {
+		sess.SetTwoFactorLoginState(nil)
+		html.Redirect(w, r, route.Path(h.router, "login"))
+		return
+	}
+
+	twoFactorForm := form.NewTwoFactorForm(r)
+	valid, err := h.verifyTOTPCode(twoFactor, twoFactorForm.Code)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !valid && twoFactorForm.Code != "" {
+		if valid, err = h.store.UseRecoveryCode(state.UserID, twoFactorForm.Code); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		if valid {
+			logger.Info("[UI:CheckLoginTwoFactor] [ClientIP=%s] User #%d used a recovery code", clientIP, state.UserID)
+		}
+	}
+
+	if !valid {
+		logger.Error("[UI:CheckLoginTwoFactor] [ClientIP=%s] Invalid code for user #%d", clientIP, state.UserID)
+		recordFailedLogin(h.store, r, state.UserID, "", "two_factor")
+
+		state.Attempts++
+		if !state.IsValid() {
+			sess.SetTwoFactorLoginState(nil)
+			html.Redirect(w, r, route.Path(h.router, "login"))
+			return
+		}
+		sess.SetTwoFactorLoginState(state)
+
+		view := view.New(h.tpl, r, sess)
+		view.Set("form", twoFactorForm)
+		view.Set("errorMessage", "error.invalid_two_factor_code")
+		html.OK(w, r, view.Render("login_two_factor"))
+		return
+	}
+
+	sess.SetTwoFactorLoginState(nil)
+
+	user, err := h.store.UserByID(state.UserID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if user == nil || !user.IsActive() {
+		html.Redirect(w, r, route.Path(h.router, "login"))
+		return
+	}
+
+	sessionToken, _, err := h.store.CreateUserSessionFromUsername(user.Username, r.UserAgent(), clientIP)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if state.IdPSessionID != "" {
+		if err := h.store.SetUserSessionIdPSessionID(sessionToken, state.IdPSessionID); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	logger.Info("[UI:CheckLoginTwoFactor] username=%s just logged in", user.Username)
+	h.store.SetLastLogin(user.ID)
+	recordAuditLog(h.store, r, model.AuditActionLogin, user.ID, "", "two_factor")
+
+	sess.SetLanguage(user.Language)
+	sess.SetTheme(user.Theme)
+
+	http.SetCookie(w, cookie.New(
+		cookie.CookieUserSessionID,
+		sessionToken,
+		config.Opts.HTTPS,
+		config.Opts.BasePath(),
+	))
+
+	html.Redirect(w, r, route.Path(h.router, user.DefaultHomePage))
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+// startTwoFactorLogin keeps the user waiting for the second factor instead of opening a session.
+func (h *handler) startTwoFactorLogin(w http.ResponseWriter, r *http.Request, sess *session.Session, userID int64, idpSessionID string) {
+	state := model.NewTwoFactorLoginState(userID)
+	state.IdPSessionID = idpSessionID
+	sess.SetTwoFactorLoginState(state)
+	html.Redirect(w, r, route.Path(h.router, "loginTwoFactor"))
+}
+
+func (h *handler) showLoginTwoFactorPage(w http.ResponseWriter, r *http.Request) {
+	if request.IsAuthenticated(r) {
+		html.Redirect(w, r, route.Path(h.router, "unread"))
+		return
+	}
+
+	state := request.TwoFactorLoginState(r)
+	if state == nil || !state.IsValid() {
+		html.Redirect(w, r, route.Path(h.router, "login"))
+		return
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", &form.TwoFactorForm{})
+	html.OK(w, r, view.Render("login_two_factor"))
+}
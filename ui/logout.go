@@ -13,6 +13,7 @@ import (
 	"miniflux.app/http/response/html"
 	"miniflux.app/http/route"
 	"miniflux.app/logger"
+	"miniflux.app/model"
 	"miniflux.app/ui/session"
 )
 
@@ -31,6 +32,8 @@ func (h *handler) logout(w http.ResponseWriter, r *http.Request) {
 		logger.Error("[UI:Logout] %v", err)
 	}
 
+	recordAuditLog(h.store, r, model.AuditActionLogout, user.ID, "", "")
+
 	http.SetCookie(w, cookie.Expired(
 		cookie.CookieUserSessionID,
 		config.Opts.HTTPS,
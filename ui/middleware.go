@@ -49,6 +49,13 @@ func (m *middleware) handleUserSession(next http.Handler) http.Handler {
 			ctx = context.WithValue(ctx, request.UserIDContextKey, session.UserID)
 			ctx = context.WithValue(ctx, request.IsAuthenticatedContextKey, true)
 			ctx = context.WithValue(ctx, request.UserSessionTokenContextKey, session.Token)
+			ctx = context.WithValue(ctx, request.UserRoleContextKey, session.UserRole)
+
+			if session.IsTwoFactorEnrollmentRequired(config.Opts.IsTwoFactorRequired()) && !m.isTwoFactorEnrollmentRoute(r) {
+				logger.Debug("[UI:UserSession] User #%d must set up two-factor authentication", session.UserID)
+				html.Redirect(w, r, route.Path(m.router, "twoFactor"))
+				return
+			}
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		}
@@ -110,6 +117,7 @@ func (m *middleware) handleAppSession(next http.Handler) http.Handler {
 		ctx = context.WithValue(ctx, request.UserThemeContextKey, session.Data.Theme)
 		ctx = context.WithValue(ctx, request.PocketRequestTokenContextKey, session.Data.PocketRequestToken)
 		ctx = context.WithValue(ctx, request.WebAuthnStateContextKey, session.Data.WebAuthnState)
+		ctx = context.WithValue(ctx, request.TwoFactorStateContextKey, session.Data.TwoFactorState)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -137,6 +145,16 @@ func (m *middleware) isPublicRoute(r *http.Request) bool {
 		"initChallenge",
 		"createChallenge",
 		"verifyChallenge",
+		"loginTwoFactor",
+		"checkLoginTwoFactor",
+		"registration",
+		"saveRegistration",
+		"forgotPassword",
+		"sendPasswordResetLink",
+		"showResetPassword",
+		"resetPassword",
+		"showInvitation",
+		"acceptInvitation",
 		"stylesheet",
 		"javascript",
 		"oauth2Redirect",
@@ -146,6 +164,8 @@ func (m *middleware) isPublicRoute(r *http.Request) bool {
 		"webManifest",
 		"robots",
 		"sharedEntry",
+		"emailDigestUnsubscribe",
+		"unsubscribeEmailDigest",
 		"healthcheck",
 		"offline",
 		"proxy":
@@ -155,6 +175,74 @@ func (m *middleware) isPublicRoute(r *http.Request) bool {
 	}
 }
 
+func (m *middleware) isTwoFactorEnrollmentRoute(r *http.Request) bool {
+	switch mux.CurrentRoute(r).GetName() {
+	case "twoFactor",
+		"enableTwoFactor",
+		"logout":
+		return true
+	default:
+		return m.isPublicRoute(r)
+	}
+}
+
+func (m *middleware) handlePermissions(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		permission := m.requiredPermission(r)
+		if permission != "" && request.IsAuthenticated(r) && !request.HasPermission(r, permission) {
+			logger.Error("[UI:Permissions] User #%d is not allowed to access %q", request.UserID(r), r.URL.Path)
+			html.Forbidden(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *middleware) requiredPermission(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	switch route.GetName() {
+	case "addSubscription",
+		"submitSubscription",
+		"chooseSubscription",
+		"bookmarklet",
+		"editFeed",
+		"updateFeed",
+		"removeFeed",
+		"refreshFeed",
+		"refreshAllFeeds",
+		"createCategory",
+		"saveCategory",
+		"editCategory",
+		"updateCategory",
+		"removeCategory",
+		"refreshCategoryFeedsPage",
+		"refreshCategoryEntriesPage",
+		"import",
+		"uploadOPML",
+		"fetchOPML",
+		"opmlSubscriptions",
+		"saveOPMLSubscription",
+		"opmlSubscription",
+		"syncOPMLSubscription",
+		"removeOPMLSubscription":
+		return model.PermissionManageSubscriptions
+	case "users",
+		"createUser",
+		"saveUser",
+		"removeUser",
+		"approveUser",
+		"invitations",
+		"saveInvitation",
+		"removeInvitation":
+		return model.PermissionManageUsers
+	case "auditLogs":
+		return model.PermissionManageServer
+	default:
+		return ""
+	}
+}
+
 func (m *middleware) getUserSessionFromCookie(r *http.Request) *model.UserSession {
 	cookieValue := request.CookieValue(r, cookie.CookieUserSessionID)
 	if cookieValue == "" {
@@ -204,6 +292,14 @@ func (m *middleware) handleAuthProxy(next http.Handler) http.Handler {
 				html.ServerError(w, r, err)
 				return
 			}
+
+			recordAuditLog(m.store, r, model.AuditActionUserCreate, user.ID, "user:"+user.Username, "auth_proxy")
+		}
+
+		if !user.IsActive() {
+			logger.Error("[AuthProxy] [ClientIP=%s] The account %q is %s", clientIP, user.Username, user.Status)
+			html.Forbidden(w, r)
+			return
 		}
 
 		sessionToken, _, err := m.store.CreateUserSessionFromUsername(user.Username, r.UserAgent(), clientIP)
@@ -215,6 +311,7 @@ func (m *middleware) handleAuthProxy(next http.Handler) http.Handler {
 		logger.Info("[AuthProxy] [ClientIP=%s] username=%s just logged in", clientIP, user.Username)
 
 		m.store.SetLastLogin(user.ID)
+		recordAuditLog(m.store, r, model.AuditActionLogin, user.ID, "", "auth_proxy")
 
 		sess := session.New(m.store, request.SessionID(r))
 		sess.SetLanguage(user.Language)
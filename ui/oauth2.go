@@ -12,11 +12,5 @@ import (
 )
 
 func getOAuth2Manager(ctx context.Context) *oauth2.Manager {
-	return oauth2.NewManager(
-		ctx,
-		config.Opts.OAuth2ClientID(),
-		config.Opts.OAuth2ClientSecret(),
-		config.Opts.OAuth2RedirectURL(),
-		config.Opts.OAuth2OidcDiscoveryEndpoint(),
-	)
+	return oauth2.NewManager(ctx, config.Opts.OAuth2Providers())
 }
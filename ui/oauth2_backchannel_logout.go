@@ -0,0 +1,67 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+)
+
+// oauth2BackChannelLogout ends the user sessions when the identity provider notifies that its session is over.
+// See https://openid.net/specs/openid-connect-backchannel-1_0.html
+func (h *handler) oauth2BackChannelLogout(w http.ResponseWriter, r *http.Request) {
+	provider := request.RouteStringParam(r, "provider")
+	rawToken := r.FormValue("logout_token")
+	if rawToken == "" {
+		html.BadRequest(w, r, errors.New("missing logout_token"))
+		return
+	}
+
+	authProvider, err := getOAuth2Manager(r.Context()).FindProvider(provider)
+	if err != nil {
+		logger.Error("[OAuth2] %v", err)
+		html.BadRequest(w, r, err)
+		return
+	}
+
+	logoutToken, err := authProvider.VerifyLogoutToken(r.Context(), rawToken)
+	if err != nil {
+		logger.Error("[OAuth2] [ClientIP=%s] %v", request.ClientIP(r), err)
+		html.BadRequest(w, r, err)
+		return
+	}
+
+	var count int64
+	var userID int64
+	if logoutToken.SessionID != "" {
+		count, err = h.store.RemoveUserSessionsByIdPSessionID(provider + ":" + logoutToken.SessionID)
+	} else {
+		user, userErr := h.store.UserByField(logoutToken.Key, logoutToken.ID)
+		switch {
+		case userErr != nil:
+			err = userErr
+		case user != nil:
+			userID = user.ID
+			count, err = h.store.RemoveUserSessions(user.ID)
+		}
+	}
+
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	logger.Info("[OAuth2] Back-channel logout from %s removed %d session(s)", provider, count)
+	if count > 0 {
+		recordAuditLog(h.store, r, model.AuditActionLogout, userID, "", fmt.Sprintf("backchannel:%s sessions=%d", provider, count))
+	}
+	html.OK(w, r, nil)
+}
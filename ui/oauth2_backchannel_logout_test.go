@@ -0,0 +1,172 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+//go:build integration
+// +build integration
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/database"
+	"miniflux.app/model"
+	"miniflux.app/storage"
+
+	"github.com/gorilla/mux"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+// fakeIdentityProvider serves the discovery document and the signing key of an OpenID Connect provider.
+type fakeIdentityProvider struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+}
+
+func newFakeIdentityProvider(t *testing.T) *fakeIdentityProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idp := &fakeIdentityProvider{key: key}
+	idp.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"issuer":                                idp.URL,
+				"authorization_endpoint":                idp.URL + "/auth",
+				"token_endpoint":                        idp.URL + "/token",
+				"jwks_uri":                              idp.URL + "/keys",
+				"id_token_signing_alg_values_supported": []string{"RS256"},
+			})
+		case "/keys":
+			json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &key.PublicKey, KeyID: "test", Algorithm: "RS256", Use: "sig"}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(idp.Close)
+	return idp
+}
+
+func (idp *fakeIdentityProvider) logoutToken(t *testing.T, claims map[string]interface{}) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: idp.key, KeyID: "test"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims["iss"] = idp.URL
+	claims["aud"] = "miniflux"
+	claims["iat"] = time.Now().Unix()
+	claims["events"] = map[string]interface{}{"http://schemas.openid.net/event/backchannel-logout": map[string]interface{}{}}
+
+	payload, _ := json.Marshal(claims)
+	signature, err := signer.Sign(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := signature.CompactSerialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestOAuth2BackChannelLogoutWithoutSession(t *testing.T) {
+	idp := newFakeIdentityProvider(t)
+
+	t.Setenv("OAUTH2_PROVIDERS", "test")
+	t.Setenv("OAUTH2_TEST_CLIENT_ID", "miniflux")
+	t.Setenv("OAUTH2_TEST_OIDC_DISCOVERY_ENDPOINT", idp.URL)
+
+	var err error
+	if config.Opts, err = config.NewParser().ParseEnvironmentVariables(); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := database.NewConnectionPool(config.Opts.DatabaseURL(), 1, 2, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	store := storage.NewStorage(db)
+
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 10)
+	createSession := func(username string) string {
+		token, _, err := store.CreateUserSessionFromUsername(username, "test", "127.0.0.1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	for _, username := range []string{"alice" + suffix, "bob" + suffix} {
+		if _, err := store.CreateUser(&model.UserCreationRequest{
+			Username:        username,
+			Password:        "secret",
+			Role:            model.RoleUser,
+			Status:          model.UserStatusActive,
+			OpenIDConnectID: "test:" + username,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	aliceIdPSession := createSession("alice" + suffix)
+	aliceOtherSession := createSession("alice" + suffix)
+	bobSession := createSession("bob" + suffix)
+	if err := store.SetUserSessionIdPSessionID(aliceIdPSession, "test:sid-"+suffix); err != nil {
+		t.Fatal(err)
+	}
+
+	router := mux.NewRouter()
+	Serve(router, store, nil)
+
+	scenarios := []struct {
+		claims  map[string]interface{}
+		removed []string
+		kept    []string
+	}{
+		{map[string]interface{}{"sid": "sid-" + suffix}, []string{aliceIdPSession}, []string{aliceOtherSession, bobSession}},
+		{map[string]interface{}{"sub": "bob" + suffix}, []string{bobSession}, []string{aliceOtherSession}},
+	}
+
+	for _, scenario := range scenarios {
+		values := url.Values{"logout_token": {idp.logoutToken(t, scenario.claims)}}
+		r := httptest.NewRequest(http.MethodPost, "/oauth2/test/backchannel-logout", strings.NewReader(values.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf(`Unexpected status code %d for %v: %s`, w.Code, scenario.claims, w.Body.String())
+		}
+
+		for _, token := range scenario.removed {
+			if session, _ := store.UserSessionByToken(token); session != nil {
+				t.Errorf(`The session %d should be removed by %v`, session.ID, scenario.claims)
+			}
+		}
+
+		for _, token := range scenario.kept {
+			if session, err := store.UserSessionByToken(token); err != nil || session == nil {
+				t.Errorf(`A session should be kept by %v`, scenario.claims)
+			}
+		}
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"miniflux.app/locale"
 	"miniflux.app/logger"
 	"miniflux.app/model"
+	"miniflux.app/oauth2"
 	"miniflux.app/ui/session"
 )
 
@@ -81,6 +82,8 @@ func (h *handler) oauth2Callback(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		recordAuditLog(h.store, r, model.AuditActionUserUpdate, loggedUser.ID, "user:"+loggedUser.Username, "oauth2_link:"+provider)
+
 		sess.NewFlashMessage(printer.Printf("alert.account_linked"))
 		html.Redirect(w, r, route.Path(h.router, "settings"))
 		return
@@ -103,7 +106,10 @@ func (h *handler) oauth2Callback(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		userCreationRequest := &model.UserCreationRequest{Username: profile.Username}
+		userCreationRequest := &model.UserCreationRequest{Username: profile.Username, Role: profile.Role}
+		if profile.Email != "" && !h.store.AnotherUserWithEmailExists(0, profile.Email) {
+			userCreationRequest.Email = profile.Email
+		}
 		authProvider.PopulateUserCreationWithProfileID(userCreationRequest, profile)
 
 		user, err = h.store.CreateUser(userCreationRequest)
@@ -111,6 +117,36 @@ func (h *handler) oauth2Callback(w http.ResponseWriter, r *http.Request) {
 			html.ServerError(w, r, err)
 			return
 		}
+
+		if err := h.applyOAuth2UserDefaults(user); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		recordAuditLog(h.store, r, model.AuditActionUserCreate, user.ID, "user:"+user.Username, "oauth2:"+provider)
+	} else if profile.Role != "" && profile.Role != user.Role {
+		logger.Info("[OAuth2] [ClientIP=%s] The role of %q changes from %s to %s", clientIP, user.Username, user.Role, profile.Role)
+		user.Role = profile.Role
+		user.IsAdmin = user.Role == model.RoleAdmin
+		if err := h.store.UpdateUser(user); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		recordAuditLog(h.store, r, model.AuditActionUserUpdate, user.ID, "user:"+user.Username, "role="+user.Role)
+	}
+
+	if !user.IsActive() {
+		logger.Error("[OAuth2] [ClientIP=%s] The account %q is %s", clientIP, user.Username, user.Status)
+		recordFailedLogin(h.store, r, user.ID, user.Username, "oauth2:"+provider)
+		html.Forbidden(w, r)
+		return
+	}
+
+	if h.store.HasTwoFactorEnabled(user.Username) {
+		logger.Info("[OAuth2] [ClientIP=%s] username=%s must enter a second factor", clientIP, user.Username)
+		h.startTwoFactorLogin(w, r, sess, user.ID, idpSessionID(provider, profile))
+		return
 	}
 
 	sessionToken, _, err := h.store.CreateUserSessionFromUsername(user.Username, r.UserAgent(), clientIP)
@@ -119,9 +155,17 @@ func (h *handler) oauth2Callback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if idpSessionID := idpSessionID(provider, profile); idpSessionID != "" {
+		if err := h.store.SetUserSessionIdPSessionID(sessionToken, idpSessionID); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
 	logger.Info("[OAuth2] [ClientIP=%s] username=%s (%s) just logged in", clientIP, user.Username, profile)
 
 	h.store.SetLastLogin(user.ID)
+	recordAuditLog(h.store, r, model.AuditActionLogin, user.ID, "", "oauth2:"+provider)
 	sess.SetLanguage(user.Language)
 	sess.SetTheme(user.Theme)
 
@@ -134,3 +178,37 @@ func (h *handler) oauth2Callback(w http.ResponseWriter, r *http.Request) {
 
 	html.Redirect(w, r, route.Path(h.router, "unread"))
 }
+
+// applyOAuth2UserDefaults gives the configured theme and first category to the users created on their first OAuth2 login.
+func (h *handler) applyOAuth2UserDefaults(user *model.User) error {
+	theme := config.Opts.OAuth2UserDefaultTheme()
+	if _, found := model.Themes()[theme]; theme != "" && !found {
+		logger.Error("[OAuth2] Unknown theme %q in OAUTH2_USER_DEFAULT_THEME", theme)
+	} else if theme != "" && theme != user.Theme {
+		user.Theme = theme
+		if err := h.store.UpdateUser(user); err != nil {
+			return err
+		}
+	}
+
+	title := config.Opts.OAuth2UserDefaultCategory()
+	category, err := h.store.FirstCategory(user.ID)
+	if err != nil {
+		return err
+	}
+
+	if category == nil || title == "" || category.Title == title {
+		return nil
+	}
+
+	category.Title = title
+	return h.store.UpdateCategory(category)
+}
+
+// idpSessionID returns the key used to find the user sessions ended by a back-channel logout.
+func idpSessionID(provider string, profile *oauth2.Profile) string {
+	if profile.SessionID == "" {
+		return ""
+	}
+	return provider + ":" + profile.SessionID
+}
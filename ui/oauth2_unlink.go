@@ -50,6 +50,11 @@ func (h *handler) oauth2Unlink(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !authProvider.IsUserLinked(user) {
+		html.Redirect(w, r, route.Path(h.router, "settings"))
+		return
+	}
+
 	authProvider.UnsetUserProfileID(user)
 	if err := h.store.UpdateUser(user); err != nil {
 		html.ServerError(w, r, err)
@@ -0,0 +1,90 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/json"
+	"miniflux.app/model"
+	"miniflux.app/proxy"
+)
+
+type offlineEntry struct {
+	ID          int64     `json:"id"`
+	FeedID      int64     `json:"feed_id"`
+	FeedTitle   string    `json:"feed_title"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	Author      string    `json:"author"`
+	Content     string    `json:"content"`
+	Status      string    `json:"status"`
+	Starred     bool      `json:"starred"`
+	ReadingTime int       `json:"reading_time"`
+	Date        time.Time `json:"published_at"`
+	ChangedAt   time.Time `json:"changed_at"`
+}
+
+type offlineEntriesResponse struct {
+	Entries []*offlineEntry `json:"entries"`
+	Images  []string        `json:"images"`
+}
+
+// showOfflineEntries returns the latest unread entries stored by the web app for offline reading.
+func (h *handler) showOfflineEntries(w http.ResponseWriter, r *http.Request) {
+	if config.Opts.OfflineEntries() <= 0 {
+		json.NotFound(w, r)
+		return
+	}
+
+	builder := h.store.NewEntryQueryBuilder(request.UserID(r))
+	builder.WithStatus(model.EntryStatusUnread)
+	builder.WithGloballyVisible()
+	builder.WithOrder("published_at")
+	builder.WithDirection("desc")
+	builder.WithLimit(config.Opts.OfflineEntries())
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	response := &offlineEntriesResponse{
+		Entries: make([]*offlineEntry, 0, len(entries)),
+		Images:  make([]string, 0),
+	}
+
+	for _, entry := range entries {
+		content := entry.Content
+		if config.Opts.OfflineImages() {
+			var imageURLs []string
+			content, imageURLs = proxy.OfflineImageProxyRewriter(h.router, content)
+			response.Images = append(response.Images, imageURLs...)
+		} else {
+			content = proxy.ImageProxyRewriter(h.router, content)
+		}
+
+		response.Entries = append(response.Entries, &offlineEntry{
+			ID:          entry.ID,
+			FeedID:      entry.FeedID,
+			FeedTitle:   entry.Feed.Title,
+			Title:       entry.Title,
+			URL:         entry.URL,
+			Author:      entry.Author,
+			Content:     content,
+			Status:      entry.Status,
+			Starred:     entry.Starred,
+			ReadingTime: entry.ReadingTime,
+			Date:        entry.Date,
+			ChangedAt:   entry.ChangedAt,
+		})
+	}
+
+	json.OK(w, r, response)
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/reader/opml"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+func (h *handler) saveOPMLSubscription(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	subscriptions, err := h.store.OPMLSubscriptions(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	categories, err := h.store.Categories(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	subscriptionForm := form.NewOPMLSubscriptionForm(r)
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("subscriptions", subscriptions)
+	view.Set("categories", categories)
+	view.Set("form", subscriptionForm)
+	view.Set("menu", "feeds")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	if err := subscriptionForm.Validate(); err != nil {
+		view.Set("errorMessage", err.Error())
+		html.OK(w, r, view.Render("opml_subscriptions"))
+		return
+	}
+
+	if subscriptionForm.CategoryID > 0 && !h.store.CategoryIDExists(user.ID, subscriptionForm.CategoryID) {
+		view.Set("errorMessage", "error.feed_category_not_found")
+		html.OK(w, r, view.Render("opml_subscriptions"))
+		return
+	}
+
+	if h.store.OPMLSubscriptionURLExists(user.ID, subscriptionForm.URL) {
+		view.Set("errorMessage", "error.opml_subscription_already_exists")
+		html.OK(w, r, view.Render("opml_subscriptions"))
+		return
+	}
+
+	subscription := subscriptionForm.Merge(&model.OPMLSubscription{UserID: user.ID})
+	if err := h.store.CreateOPMLSubscription(subscription); err != nil {
+		logger.Error("[UI:SaveOPMLSubscription] %v", err)
+		view.Set("errorMessage", "error.unable_to_create_opml_subscription")
+		html.OK(w, r, view.Render("opml_subscriptions"))
+		return
+	}
+
+	opml.NewHandler(h.store).Sync(subscription)
+
+	html.Redirect(w, r, route.Path(h.router, "opmlSubscription", "subscriptionID", subscription.ID))
+}
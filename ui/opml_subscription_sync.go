@@ -0,0 +1,31 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/reader/opml"
+)
+
+func (h *handler) syncOPMLSubscription(w http.ResponseWriter, r *http.Request) {
+	subscription, err := h.store.OPMLSubscription(request.UserID(r), request.RouteInt64Param(r, "subscriptionID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if subscription == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	opml.NewHandler(h.store).Sync(subscription)
+
+	html.Redirect(w, r, route.Path(h.router, "opmlSubscription", "subscriptionID", subscription.ID))
+}
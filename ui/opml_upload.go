@@ -5,6 +5,8 @@
 package ui // import "miniflux.app/ui"
 
 import (
+	"fmt"
+	"io"
 	"net/http"
 
 	"miniflux.app/config"
@@ -13,6 +15,7 @@ import (
 	"miniflux.app/http/response/html"
 	"miniflux.app/http/route"
 	"miniflux.app/logger"
+	"miniflux.app/model"
 	"miniflux.app/reader/opml"
 	"miniflux.app/ui/session"
 	"miniflux.app/ui/view"
@@ -53,13 +56,7 @@ func (h *handler) uploadOPML(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if impErr := opml.NewHandler(h.store).Import(user.ID, file); impErr != nil {
-		view.Set("errorMessage", impErr)
-		html.OK(w, r, view.Render("import"))
-		return
-	}
-
-	html.Redirect(w, r, route.Path(h.router, "feeds"))
+	h.importOPML(w, r, view, user.ID, file)
 }
 
 func (h *handler) fetchOPML(w http.ResponseWriter, r *http.Request) {
@@ -96,11 +93,23 @@ func (h *handler) fetchOPML(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if impErr := opml.NewHandler(h.store).Import(user.ID, resp.Body); impErr != nil {
+	h.importOPML(w, r, view, user.ID, resp.Body)
+}
+
+// importOPML imports the subscriptions and shows the outcome of each subscription on the import page.
+func (h *handler) importOPML(w http.ResponseWriter, r *http.Request, view *view.View, userID int64, data io.Reader) {
+	report, impErr := opml.NewHandler(h.store).Import(userID, data)
+	if impErr != nil {
 		view.Set("errorMessage", impErr)
 		html.OK(w, r, view.Render("import"))
 		return
 	}
 
-	html.Redirect(w, r, route.Path(h.router, "feeds"))
+	recordAuditLog(h.store, r, model.AuditActionOPMLImport, userID, "", fmt.Sprintf("created=%d duplicates=%d failures=%d", report.Count(opml.ImportStatusCreated), report.Count(opml.ImportStatusDuplicate), report.Count(opml.ImportStatusFailed)))
+
+	view.Set("report", report)
+	view.Set("createdCount", report.Count(opml.ImportStatusCreated))
+	view.Set("duplicateCount", report.Count(opml.ImportStatusDuplicate))
+	view.Set("failures", report.Failures())
+	html.OK(w, r, view.Render("import"))
 }
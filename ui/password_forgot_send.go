@@ -0,0 +1,62 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/locale"
+	"miniflux.app/logger"
+	"miniflux.app/mail"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+// sendPasswordResetLink always shows the same message, to avoid disclosing which addresses have an account.
+func (h *handler) sendPasswordResetLink(w http.ResponseWriter, r *http.Request) {
+	if !mail.IsEnabled() {
+		html.NotFound(w, r)
+		return
+	}
+
+	forgotPasswordForm := form.NewForgotPasswordForm(r)
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", forgotPasswordForm)
+
+	if err := forgotPasswordForm.Validate(); err != nil {
+		view.Set("errorMessage", err.Error())
+		html.OK(w, r, view.Render("forgot_password"))
+		return
+	}
+
+	user, err := h.store.UserByEmail(forgotPasswordForm.Email)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if user != nil && user.IsActive() {
+		token, err := h.store.CreatePasswordResetToken(user.ID, passwordResetValidity)
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		if err := h.sendPasswordResetEmail(user, token); err != nil {
+			logger.Error("[UI:SendPasswordResetLink] %v", err)
+		} else {
+			logger.Info("[UI:SendPasswordResetLink] [ClientIP=%s] Password reset link sent to user #%d", request.ClientIP(r), user.ID)
+		}
+	}
+
+	sess.NewFlashMessage(locale.NewPrinter(request.UserLanguage(r)).Printf("alert.password_reset_sent"))
+	html.Redirect(w, r, route.Path(h.router, "login"))
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/locale"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+func (h *handler) showResetPasswordPage(w http.ResponseWriter, r *http.Request) {
+	h.renderResetPasswordPage(w, r, false)
+}
+
+func (h *handler) resetPassword(w http.ResponseWriter, r *http.Request) {
+	h.renderResetPasswordPage(w, r, true)
+}
+
+func (h *handler) renderResetPasswordPage(w http.ResponseWriter, r *http.Request, submitted bool) {
+	token := request.RouteStringParam(r, "token")
+	userID, err := h.store.UserIDByPasswordResetToken(token)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if userID == 0 {
+		html.NotFound(w, r)
+		return
+	}
+
+	passwordResetForm := &form.PasswordResetForm{}
+	if submitted {
+		passwordResetForm = form.NewPasswordResetForm(r)
+	}
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("token", token)
+	view.Set("form", passwordResetForm)
+
+	if !submitted {
+		html.OK(w, r, view.Render("reset_password"))
+		return
+	}
+
+	if err := passwordResetForm.Validate(); err != nil {
+		view.Set("errorMessage", err.Error())
+		html.OK(w, r, view.Render("reset_password"))
+		return
+	}
+
+	if err := h.store.ResetPassword(userID, passwordResetForm.Password); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	logger.Info("[UI:ResetPassword] [ClientIP=%s] The password of user #%d has been reset", request.ClientIP(r), userID)
+	recordAuditLog(h.store, r, model.AuditActionPasswordReset, userID, "", "email")
+
+	sess.NewFlashMessage(locale.NewPrinter(request.UserLanguage(r)).Printf("alert.password_reset_done"))
+	html.Redirect(w, r, route.Path(h.router, "login"))
+}
@@ -5,11 +5,17 @@
 package ui // import "miniflux.app/ui"
 
 import (
+	"bytes"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
 	"time"
 
 	"miniflux.app/config"
@@ -18,8 +24,12 @@ import (
 	"miniflux.app/http/response"
 	"miniflux.app/http/response/html"
 	"miniflux.app/logger"
+	"miniflux.app/metric"
+	"miniflux.app/proxy"
 )
 
+const mediaCacheDuration = 72 * time.Hour
+
 func (h *handler) imageProxy(w http.ResponseWriter, r *http.Request) {
 	// If we receive a "If-None-Match" header, we assume the image is already stored in browser cache.
 	if r.Header.Get("If-None-Match") != "" {
@@ -55,42 +65,266 @@ func (h *handler) imageProxy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	imageURL := string(decodedURL)
-	logger.Debug(`[Proxy] Fetching %q`, imageURL)
+	mediaURL := string(decodedURL)
+	etag := crypto.HashFromBytes(decodedURL)
+
+	// The width is ignored when the resizing is disabled, the original image is sent instead.
+	if width := request.QueryIntParam(r, "w", 0); width != 0 && config.Opts.MediaProxyResizeImages() {
+		if !proxy.IsValidImageWidth(width) {
+			html.BadRequest(w, r, errors.New("Invalid image width"))
+			return
+		}
+
+		h.resizedImage(w, r, etag, mediaURL, width)
+		return
+	}
+
+	if h.mediaCache == nil {
+		h.streamMedia(w, r, etag, mediaURL)
+		return
+	}
 
-	req, err := http.NewRequest("GET", imageURL, nil)
+	file, err := h.cachedMedia(etag, mediaURL)
 	if err != nil {
-		html.ServerError(w, r, err)
+		handleMediaError(w, r, mediaURL, err)
+		return
+	}
+
+	if file == nil {
+		// The file was evicted right away because it is larger than the cache.
+		h.streamMedia(w, r, etag, mediaURL)
 		return
 	}
+	defer file.Close()
 
-	// Note: User-Agent HTTP header is omitted to avoid being blocked by bot protection mechanisms.
-	req.Header.Add("Connection", "close")
+	serveMedia(w, r, etag, file.ContentType, file.ModTime, file)
+}
+
+// resizedImage sends a downscaled variant of the image, the original is sent if the image cannot be resized.
+func (h *handler) resizedImage(w http.ResponseWriter, r *http.Request, etag, mediaURL string, width int) {
+	allowJPEG := proxy.AcceptsJPEG(r.Header.Get("Accept"))
+	variantKey := proxy.ImageVariantKey(etag, width, allowJPEG)
+	w.Header().Set("Vary", "Accept")
+
+	if h.mediaCache != nil {
+		file, err := h.mediaCache.Get(variantKey)
+		if err != nil {
+			logger.Error(`[Proxy] %v`, err)
+		}
 
-	clt := &http.Client{
-		Timeout: time.Duration(config.Opts.HTTPClientTimeout()) * time.Second,
+		if file != nil {
+			defer file.Close()
+			countMediaCacheRequest("hit")
+			serveMedia(w, r, variantKey, file.ContentType, file.ModTime, file)
+			return
+		}
+
+		countMediaCacheRequest("miss")
 	}
 
-	resp, err := clt.Do(req)
+	data, contentType, err := h.originalMedia(etag, mediaURL)
 	if err != nil {
-		html.ServerError(w, r, err)
+		handleMediaError(w, r, mediaURL, err)
 		return
 	}
+
+	resizedImage, err := proxy.ResizeImage(data, width, allowJPEG)
+	if err != nil {
+		logger.Debug(`[Proxy] Unable to resize %q: %v`, mediaURL, err)
+		resizedImage = &proxy.ResizedImage{Data: data, ContentType: contentType}
+	}
+
+	if h.mediaCache != nil {
+		err := h.mediaCache.Put(variantKey, resizedImage.ContentType, bytes.NewReader(resizedImage.Data), config.Opts.HTTPClientMaxBodySize())
+		if err != nil {
+			logger.Error(`[Proxy] %v`, err)
+		}
+	}
+
+	serveMedia(w, r, variantKey, resizedImage.ContentType, time.Now(), bytes.NewReader(resizedImage.Data))
+}
+
+// cachedMedia returns the cached copy of a remote file, the file is downloaded on cache miss.
+// A nil file is returned if the file cannot be kept in the cache.
+func (h *handler) cachedMedia(key, mediaURL string) (*proxy.MediaFile, error) {
+	file, err := h.mediaCache.Get(key)
+	if err != nil {
+		logger.Error(`[Proxy] %v`, err)
+	}
+
+	if file != nil {
+		countMediaCacheRequest("hit")
+		return file, nil
+	}
+
+	countMediaCacheRequest("miss")
+
+	if err := h.cacheMedia(key, mediaURL); err != nil {
+		return nil, err
+	}
+
+	return h.mediaCache.Get(key)
+}
+
+// originalMedia returns the content and the content type of a remote file.
+func (h *handler) originalMedia(key, mediaURL string) ([]byte, string, error) {
+	if h.mediaCache != nil {
+		file, err := h.cachedMedia(key, mediaURL)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if file != nil {
+			defer file.Close()
+			data, err := io.ReadAll(file)
+			return data, file.ContentType, err
+		}
+	}
+
+	logger.Debug(`[Proxy] Fetching %q`, mediaURL)
+
+	resp, err := proxy.FetchMedia(mediaURL, "")
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if err := checkMediaResponse(resp, http.StatusOK); err != nil {
+		return nil, "", err
+	}
+
+	maxBodySize := config.Opts.HTTPClientMaxBodySize()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize+1))
+	if err != nil {
+		return nil, "", err
+	}
+
+	if int64(len(data)) > maxBodySize {
+		return nil, "", proxy.ErrMediaTooLarge
+	}
+
+	return data, resp.Header.Get("Content-Type"), nil
+}
+
+func (h *handler) cacheMedia(key, mediaURL string) error {
+	logger.Debug(`[Proxy] Fetching %q`, mediaURL)
+
+	resp, err := proxy.FetchMedia(mediaURL, "")
+	if err != nil {
+		return err
+	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		logger.Error(`[Proxy] Status Code is %d for URL %q`, resp.StatusCode, imageURL)
-		html.NotFound(w, r)
+	if err := checkMediaResponse(resp, http.StatusOK); err != nil {
+		return err
+	}
+
+	return h.mediaCache.Put(key, resp.Header.Get("Content-Type"), resp.Body, config.Opts.HTTPClientMaxBodySize())
+}
+
+// streamMedia sends the remote file to the client without storing it, the Range header is forwarded.
+func (h *handler) streamMedia(w http.ResponseWriter, r *http.Request, etag, mediaURL string) {
+	logger.Debug(`[Proxy] Fetching %q`, mediaURL)
+
+	resp, err := proxy.FetchMedia(mediaURL, r.Header.Get("Range"))
+	if err != nil {
+		handleMediaError(w, r, mediaURL, err)
 		return
 	}
+	defer resp.Body.Close()
 
-	etag := crypto.HashFromBytes(decodedURL)
+	if err := checkMediaResponse(resp, http.StatusOK, http.StatusPartialContent); err != nil {
+		handleMediaError(w, r, mediaURL, err)
+		return
+	}
 
-	response.New(w, r).WithCaching(etag, 72*time.Hour, func(b *response.Builder) {
+	response.New(w, r).WithCaching(etag, mediaCacheDuration, func(b *response.Builder) {
+		b.WithStatus(resp.StatusCode)
 		b.WithHeader("Content-Security-Policy", `default-src 'self'`)
 		b.WithHeader("Content-Type", resp.Header.Get("Content-Type"))
-		b.WithBody(resp.Body)
+		for _, key := range []string{"Accept-Ranges", "Content-Length", "Content-Range"} {
+			if value := resp.Header.Get(key); value != "" {
+				b.WithHeader(key, value)
+			}
+		}
+		b.WithBody(io.LimitReader(resp.Body, config.Opts.HTTPClientMaxBodySize()))
 		b.WithoutCompression()
 		b.Write()
 	})
 }
+
+func checkMediaResponse(resp *http.Response, allowedStatusCodes ...int) error {
+	statusAllowed := false
+	for _, statusCode := range allowedStatusCodes {
+		if resp.StatusCode == statusCode {
+			statusAllowed = true
+		}
+	}
+
+	if !statusAllowed {
+		return fmt.Errorf("status code is %d", resp.StatusCode)
+	}
+
+	if !proxy.IsAllowedMediaType(resp.Header.Get("Content-Type")) {
+		return fmt.Errorf("content type %q is not allowed", resp.Header.Get("Content-Type"))
+	}
+
+	if resp.ContentLength > config.Opts.HTTPClientMaxBodySize() {
+		return proxy.ErrMediaTooLarge
+	}
+
+	return nil
+}
+
+// serveMedia sends the content with the caching headers, ServeContent handles the Range requests used by audio and video players.
+func serveMedia(w http.ResponseWriter, r *http.Request, etag, contentType string, modTime time.Time, content io.ReadSeeker) {
+	header := w.Header()
+	header.Set("ETag", strconv.Quote(etag))
+	header.Set("Cache-Control", "public")
+	header.Set("Expires", time.Now().Add(mediaCacheDuration).Format(time.RFC1123))
+	header.Set("Content-Security-Policy", `default-src 'self'`)
+	header.Set("Content-Type", contentType)
+	header.Set("X-Content-Type-Options", "nosniff")
+
+	http.ServeContent(w, r, "", modTime, content)
+}
+
+func handleMediaError(w http.ResponseWriter, r *http.Request, mediaURL string, err error) {
+	logger.Error(`[Proxy] Unable to proxy %q: %v`, mediaURL, err)
+
+	if errors.Is(err, proxy.ErrPrivateNetwork) {
+		html.Forbidden(w, r)
+		return
+	}
+
+	html.NotFound(w, r)
+}
+
+func countMediaCacheRequest(result string) {
+	if config.Opts.HasMetricsCollector() {
+		metric.MediaProxyCacheRequests.WithLabelValues(result).Inc()
+	}
+}
+
+func newMediaCache() *proxy.MediaCache {
+	if config.Opts.MediaProxyCacheSize() <= 0 {
+		return nil
+	}
+
+	cacheDir := config.Opts.MediaProxyCacheDir()
+	if cacheDir == "" {
+		cacheDir = filepath.Join(os.TempDir(), "miniflux-media-cache")
+	}
+
+	mediaCache, err := proxy.NewMediaCache(
+		cacheDir,
+		int64(config.Opts.MediaProxyCacheSize())*1024*1024,
+		time.Duration(config.Opts.MediaProxyCacheMaxAge())*time.Hour,
+	)
+	if err != nil {
+		logger.Error(`[Proxy] The media cache is disabled: %v`, err)
+		return nil
+	}
+
+	return mediaCache
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+	"net/url"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response"
+	"miniflux.app/http/response/html"
+	"miniflux.app/model"
+	"miniflux.app/syndication"
+)
+
+// publishedFeedMaxEntries is the number of entries included in a published feed.
+const publishedFeedMaxEntries = 50
+
+func (h *handler) showPublishedFeedAtom(w http.ResponseWriter, r *http.Request) {
+	h.writePublishedFeed(w, r, "publishedFeedAtom", syndication.AtomContentType, syndication.Atom)
+}
+
+func (h *handler) showPublishedFeedJSON(w http.ResponseWriter, r *http.Request) {
+	h.writePublishedFeed(w, r, "publishedFeedJSON", syndication.JSONFeedContentType, syndication.JSONFeed)
+}
+
+func (h *handler) writePublishedFeed(w http.ResponseWriter, r *http.Request, routeName, contentType string, generate func(*syndication.Channel) ([]byte, error)) {
+	token := request.RouteStringParam(r, "token")
+	publishedFeed, err := h.store.PublishedFeedByToken(token)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if publishedFeed == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	user, err := h.store.UserByID(publishedFeed.UserID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if user == nil || !user.IsActive() {
+		html.NotFound(w, r)
+		return
+	}
+
+	builder := h.store.NewEntryQueryBuilder(user.ID)
+	builder.WithoutStatus(model.EntryStatusRemoved)
+	siteURL := h.absoluteURL("unread")
+
+	switch publishedFeed.Kind {
+	case model.PublishedFeedKindStarred:
+		builder.WithStarred(true)
+		siteURL = h.absoluteURL("starred")
+	case model.PublishedFeedKindShared:
+		builder.WithShareCodeNotEmpty()
+		siteURL = h.absoluteURL("sharedEntries")
+	case model.PublishedFeedKindCategory:
+		if publishedFeed.CategoryID != nil {
+			builder.WithCategoryID(*publishedFeed.CategoryID)
+			siteURL = h.absoluteURL("categoryEntries", "categoryID", *publishedFeed.CategoryID)
+		}
+	case model.PublishedFeedKindFeed:
+		if publishedFeed.FeedID != nil {
+			builder.WithFeedID(*publishedFeed.FeedID)
+			siteURL = h.absoluteURL("feedEntries", "feedID", *publishedFeed.FeedID)
+		}
+	case model.PublishedFeedKindSearch:
+		siteURL = h.absoluteURL("searchEntries") + "?q=" + url.QueryEscape(publishedFeed.SearchQuery)
+	}
+
+	if publishedFeed.SearchQuery != "" {
+		builder.WithSearchQuery(publishedFeed.SearchQuery)
+	}
+
+	if publishedFeed.UnreadOnly {
+		builder.WithStatus(model.EntryStatusUnread)
+	}
+
+	// Feed readers expect the most recent entries first, even for search results.
+	builder.WithOrder("published_at")
+	builder.WithDirection("desc")
+	builder.WithLimit(publishedFeedMaxEntries)
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	channel := syndication.NewChannel(
+		"urn:miniflux:published:"+publishedFeed.Token,
+		publishedFeed.Title,
+		siteURL,
+		h.absoluteURL(routeName, "token", publishedFeed.Token),
+		publishedFeed.CreatedAt,
+		entries,
+	)
+
+	data, err := generate(channel)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	response.New(w, r).
+		WithHeader("Content-Type", contentType).
+		WithHeader("Last-Modified", channel.Updated.UTC().Format(http.TimeFormat)).
+		WithBody(data).
+		Write()
+}
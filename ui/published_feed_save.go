@@ -0,0 +1,91 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+func (h *handler) savePublishedFeed(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	publishedFeeds, err := h.store.PublishedFeeds(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	categories, err := h.store.Categories(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	feeds, err := h.store.Feeds(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	publishedFeedForm := form.NewPublishedFeedForm(r)
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("publishedFeeds", publishedFeeds)
+	view.Set("categories", categories)
+	view.Set("feeds", feeds)
+	view.Set("form", publishedFeedForm)
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	if err := publishedFeedForm.Validate(); err != nil {
+		view.Set("errorMessage", err.Error())
+		html.OK(w, r, view.Render("published_feeds"))
+		return
+	}
+
+	if publishedFeedForm.Kind == model.PublishedFeedKindCategory && !h.store.CategoryIDExists(user.ID, publishedFeedForm.CategoryID) {
+		view.Set("errorMessage", "error.feed_category_not_found")
+		html.OK(w, r, view.Render("published_feeds"))
+		return
+	}
+
+	if publishedFeedForm.Kind == model.PublishedFeedKindFeed && !h.store.FeedExists(user.ID, publishedFeedForm.FeedID) {
+		view.Set("errorMessage", "error.published_feed_feed_required")
+		html.OK(w, r, view.Render("published_feeds"))
+		return
+	}
+
+	if h.store.PublishedFeedTitleExists(user.ID, publishedFeedForm.Title) {
+		view.Set("errorMessage", "error.published_feed_already_exists")
+		html.OK(w, r, view.Render("published_feeds"))
+		return
+	}
+
+	publishedFeed := publishedFeedForm.Merge(model.NewPublishedFeed(user.ID, publishedFeedForm.Title, publishedFeedForm.Kind))
+	if err := h.store.CreatePublishedFeed(publishedFeed); err != nil {
+		logger.Error("[UI:SavePublishedFeed] %v", err)
+		view.Set("errorMessage", "error.unable_to_create_published_feed")
+		html.OK(w, r, view.Render("published_feeds"))
+		return
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "publishedFeeds"))
+}
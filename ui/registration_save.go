@@ -0,0 +1,77 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/config"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/locale"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+	"miniflux.app/validator"
+)
+
+func (h *handler) saveRegistration(w http.ResponseWriter, r *http.Request) {
+	if !config.Opts.IsRegistrationEnabled() {
+		html.NotFound(w, r)
+		return
+	}
+
+	registrationForm := form.NewRegistrationForm(r)
+
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("form", registrationForm)
+
+	if err := registrationForm.Validate(); err != nil {
+		view.Set("errorMessage", err.Error())
+		html.OK(w, r, view.Render("registration"))
+		return
+	}
+
+	status := model.UserStatusActive
+	if config.Opts.IsRegistrationApprovalRequired() {
+		status = model.UserStatusPending
+	}
+
+	userCreationRequest := &model.UserCreationRequest{
+		Username: registrationForm.Username,
+		Password: registrationForm.Password,
+		Email:    registrationForm.Email,
+		Role:     model.RoleUser,
+		Status:   status,
+	}
+
+	if validationErr := validator.ValidateUserCreationWithPassword(h.store, userCreationRequest); validationErr != nil {
+		view.Set("errorMessage", validationErr.TranslationKey)
+		html.OK(w, r, view.Render("registration"))
+		return
+	}
+
+	if _, err := h.store.CreateUser(userCreationRequest); err != nil {
+		logger.Error("[UI:SaveRegistration] %v", err)
+		view.Set("errorMessage", "error.unable_to_create_user")
+		html.OK(w, r, view.Render("registration"))
+		return
+	}
+
+	logger.Info("[UI:SaveRegistration] [ClientIP=%s] username=%s registered with the status %q", request.ClientIP(r), userCreationRequest.Username, status)
+
+	printer := locale.NewPrinter(request.UserLanguage(r))
+	if status == model.UserStatusPending {
+		sess.NewFlashMessage(printer.Printf("alert.registration_pending"))
+	} else {
+		sess.NewFlashMessage(printer.Printf("alert.registration_done"))
+	}
+
+	html.Redirect(w, r, route.Path(h.router, "login"))
+}
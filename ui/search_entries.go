@@ -10,9 +10,11 @@ import (
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/html"
 	"miniflux.app/http/route"
+	"miniflux.app/integration"
 	"miniflux.app/model"
 	"miniflux.app/ui/session"
 	"miniflux.app/ui/view"
+	"miniflux.app/validator"
 )
 
 func (h *handler) showSearchEntriesPage(w http.ResponseWriter, r *http.Request) {
@@ -24,22 +26,28 @@ func (h *handler) showSearchEntriesPage(w http.ResponseWriter, r *http.Request)
 
 	searchQuery := request.QueryStringParam(r, "q", "")
 	offset := request.QueryIntParam(r, "offset", 0)
-	builder := h.store.NewEntryQueryBuilder(user.ID)
-	builder.WithSearchQuery(searchQuery)
-	builder.WithoutStatus(model.EntryStatusRemoved)
-	builder.WithOffset(offset)
-	builder.WithLimit(user.EntriesPerPage)
+	entries := make(model.Entries, 0)
+	count := 0
 
-	entries, err := builder.GetEntries()
-	if err != nil {
-		html.ServerError(w, r, err)
-		return
-	}
+	validationErr := validator.ValidateSearchQuery(searchQuery)
+	if validationErr == nil {
+		builder := h.store.NewEntryQueryBuilder(user.ID)
+		builder.WithSearchQuery(searchQuery)
+		builder.WithoutStatus(model.EntryStatusRemoved)
+		builder.WithOffset(offset)
+		builder.WithLimit(user.EntriesPerPage)
 
-	count, err := builder.CountEntries()
-	if err != nil {
-		html.ServerError(w, r, err)
-		return
+		entries, err = builder.GetEntries()
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+
+		count, err = builder.CountEntries()
+		if err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
 	}
 
 	sess := session.New(h.store, request.SessionID(r))
@@ -48,6 +56,9 @@ func (h *handler) showSearchEntriesPage(w http.ResponseWriter, r *http.Request)
 	pagination.SearchQuery = searchQuery
 
 	view.Set("searchQuery", searchQuery)
+	if validationErr != nil {
+		view.Set("errorMessage", validationErr.TranslationKey)
+	}
 	view.Set("entries", entries)
 	view.Set("total", count)
 	view.Set("pagination", pagination)
@@ -55,7 +66,7 @@ func (h *handler) showSearchEntriesPage(w http.ResponseWriter, r *http.Request)
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
-	view.Set("hasSaveEntry", h.store.HasSaveEntry(user.ID))
+	view.Set("hasSaveEntry", h.store.HasSaveEntry(user.ID, integration.EntrySenderNames()))
 
 	html.OK(w, r, view.Render("search_entries"))
 }
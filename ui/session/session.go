@@ -9,6 +9,7 @@ import (
 
 	"miniflux.app/crypto"
 	"miniflux.app/logger"
+	"miniflux.app/model"
 	"miniflux.app/storage"
 
 	"github.com/go-webauthn/webauthn/webauthn"
@@ -82,6 +83,23 @@ func (s *Session) SetWebAuthnSessionData(sessionData *webauthn.SessionData) {
 	}
 }
 
+// SetTwoFactorLoginState stores the login waiting for a second factor, a nil state clears it.
+func (s *Session) SetTwoFactorLoginState(state *model.TwoFactorLoginState) {
+	value := ""
+	if state != nil {
+		data, err := json.Marshal(state)
+		if err != nil {
+			logger.Error(err.Error())
+			return
+		}
+		value = string(data)
+	}
+
+	if err := s.store.UpdateAppSessionField(s.sessionID, "two_factor_state", value); err != nil {
+		logger.Error(err.Error())
+	}
+}
+
 // New returns a new session handler.
 func New(store *storage.Storage, sessionID string) *Session {
 	return &Session{store, sessionID}
@@ -5,12 +5,14 @@
 package ui // import "miniflux.app/ui"
 
 import (
+	"fmt"
 	"net/http"
 
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/html"
 	"miniflux.app/http/route"
 	"miniflux.app/logger"
+	"miniflux.app/model"
 )
 
 func (h *handler) removeSession(w http.ResponseWriter, r *http.Request) {
@@ -18,6 +20,8 @@ func (h *handler) removeSession(w http.ResponseWriter, r *http.Request) {
 	err := h.store.RemoveUserSessionByID(request.UserID(r), sessionID)
 	if err != nil {
 		logger.Error("[UI:RemoveSession] %v", err)
+	} else {
+		recordAuditLog(h.store, r, model.AuditActionSessionRemove, request.UserID(r), fmt.Sprintf("session:%d", sessionID), "")
 	}
 
 	html.Redirect(w, r, route.Path(h.router, "sessions"))
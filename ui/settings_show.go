@@ -28,6 +28,7 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 
 	settingsForm := form.SettingsForm{
 		Username:               user.Username,
+		Email:                  user.Email,
 		Theme:                  user.Theme,
 		Language:               user.Language,
 		Timezone:               user.Timezone,
@@ -43,6 +44,8 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 		CJKReadingSpeed:        user.CJKReadingSpeed,
 		DefaultHomePage:        user.DefaultHomePage,
 		CategoriesSortingOrder: user.CategoriesSortingOrder,
+		MarkReadAtEnd:          user.MarkReadAtEnd,
+		EntryScoring:           user.EntryScoring,
 	}
 
 	timezones, err := h.store.Timezones()
@@ -13,6 +13,7 @@ import (
 	"miniflux.app/locale"
 	"miniflux.app/logger"
 	"miniflux.app/model"
+	"miniflux.app/scoring"
 	"miniflux.app/ui/form"
 	"miniflux.app/ui/session"
 	"miniflux.app/ui/view"
@@ -54,6 +55,7 @@ func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request) {
 
 	userModificationRequest := &model.UserModificationRequest{
 		Username:            model.OptionalString(settingsForm.Username),
+		Email:               model.OptionalString(settingsForm.Email),
 		Password:            model.OptionalString(settingsForm.Password),
 		Theme:               model.OptionalString(settingsForm.Theme),
 		Language:            model.OptionalString(settingsForm.Language),
@@ -72,6 +74,7 @@ func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	entryScoringEnabled := loggedUser.EntryScoring
 	err = h.store.UpdateUser(settingsForm.Merge(loggedUser))
 	if err != nil {
 		logger.Error("[UI:UpdateSettings] %v", err)
@@ -80,6 +83,15 @@ func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Rank the entries right away instead of waiting for the scheduler.
+	if loggedUser.EntryScoring && !entryScoringEnabled {
+		go func(userID int64) {
+			if err := scoring.ScoreUserEntries(h.store, userID); err != nil {
+				logger.Error("[UI:UpdateSettings] %v", err)
+			}
+		}(loggedUser.ID)
+	}
+
 	sess.SetLanguage(loggedUser.Language)
 	sess.SetTheme(loggedUser.Theme)
 	sess.NewFlashMessage(locale.NewPrinter(request.UserLanguage(r)).Printf("alert.prefs_saved"))
@@ -204,6 +204,10 @@ function markEntryAsRead(element) {
 // Send the Ajax request to refresh all feeds in the background
 function handleRefreshAllFeeds() {
     let url = document.body.dataset.refreshAllFeedsUrl;
+    if (!url) {
+        return;
+    }
+
     let request = new RequestBuilder(url);
 
     request.withCallback(() => {
@@ -235,6 +239,35 @@ function updateEntriesStatus(entryIDs, status, callback) {
     request.execute();
 }
 
+// Check or uncheck all the checkboxes matching the selector of the "select all" checkbox.
+function toggleSelection(element) {
+    document.querySelectorAll(element.dataset.selectAll).forEach((checkbox) => {
+        checkbox.checked = element.checked;
+    });
+}
+
+// Apply the action chosen in the list view to the checked entries, and reload the page once done.
+function applyEntryBatchAction(element) {
+    let container = element.closest("[data-entry-batch-actions]");
+    let action = container.querySelector("select[data-entry-batch-action]").value;
+    let entryIDs = [];
+
+    document.querySelectorAll(".items input[name=entry_ids]:checked").forEach((checkbox) => {
+        entryIDs.push(parseInt(checkbox.value, 10));
+    });
+
+    if (entryIDs.length === 0) {
+        return;
+    }
+
+    element.textContent = element.dataset.labelLoading;
+
+    let request = new RequestBuilder(document.body.dataset.entriesBatchUrl);
+    request.withBody({entry_ids: entryIDs, action: action});
+    request.withCallback(() => window.location.reload());
+    request.execute();
+}
+
 // Handle save entry from list view and entry view.
 function handleSaveEntry(element) {
     let toasting = !element;
@@ -50,6 +50,8 @@ document.addEventListener("DOMContentLoaded", function () {
     onClick("a[data-toggle-status]", (event) => handleEntryStatus("next", event.target));
     onClick("a[data-save-credential]", (event) => handleSaveCredential(event.target));
     onClick("a[data-credential-authentication]", handleAuthenticateCredential);
+    onClick("input[data-select-all]", (event) => toggleSelection(event.target), true);
+    onClick("button[data-action=applyEntryBatchAction]", (event) => applyEntryBatchAction(event.target));
 
     onClick("a[data-confirm]", (event) => handleConfirmationMessage(event.target, (url, redirectURL) => {
         let request = new RequestBuilder(url);
@@ -86,6 +88,19 @@ document.addEventListener("DOMContentLoaded", function () {
         }
     }
 
+    let readingProgressElement = document.querySelector("section.entry[data-reading-progress-url]");
+    if (readingProgressElement) {
+        let readingProgress = new ReadingProgress(readingProgressElement);
+        readingProgress.start();
+    }
+
+    let offlineElement = document.querySelector("body[data-offline-entries-url]");
+    if (offlineElement && OfflineSync.isSupported()) {
+        let offlineSync = new OfflineSync(offlineElement);
+        offlineSync.synchronize();
+        window.addEventListener("online", () => offlineSync.synchronize());
+    }
+
     window.addEventListener('beforeinstallprompt', (e) => {
         // Prevent Chrome 67 and earlier from automatically showing the prompt.
         e.preventDefault();
@@ -0,0 +1,148 @@
+// OfflineReader displays the entries stored on the device when the server cannot be reached.
+class OfflineReader {
+    constructor(store) {
+        this.store = store;
+        this.labels = document.body.dataset;
+        this.listElement = document.getElementById("offline-entries");
+        this.entryElement = document.getElementById("offline-entry");
+        this.entry = null;
+    }
+
+    listen() {
+        window.addEventListener("hashchange", () => this.render());
+
+        this.entryElement.querySelectorAll("a[data-offline-action]").forEach((element) => {
+            element.onclick = (event) => {
+                event.preventDefault();
+                this.handleAction(element.dataset.offlineAction);
+            };
+        });
+
+        this.render();
+    }
+
+    async render() {
+        let match = window.location.hash.match(/^#entry-(\d+)$/);
+        let entry = match ? await this.store.entry(parseInt(match[1], 10)) : null;
+
+        if (entry) {
+            await this.showEntry(entry);
+        } else {
+            await this.showEntries();
+        }
+
+        await this.showPendingChanges();
+    }
+
+    async showEntries() {
+        let entries = await this.store.entries();
+
+        this.entry = null;
+        this.entryElement.hidden = true;
+        this.listElement.hidden = false;
+        this.listElement.textContent = "";
+        document.getElementById("offline-empty").hidden = entries.length > 0;
+
+        entries.forEach((entry) => {
+            let item = document.createElement("article");
+            item.className = "item entry-item item-status-" + entry.status;
+
+            let header = document.createElement("div");
+            header.className = "item-header";
+
+            let title = document.createElement("span");
+            title.className = "item-title";
+
+            let link = document.createElement("a");
+            link.href = "#entry-" + entry.id;
+            link.textContent = (entry.starred ? "★ " : "") + entry.title;
+
+            title.appendChild(link);
+            header.appendChild(title);
+            item.appendChild(header);
+            item.appendChild(this.buildMeta(entry, "item-meta"));
+            this.listElement.appendChild(item);
+        });
+    }
+
+    async showEntry(entry) {
+        // Opening an entry marks it as read, like in the online reader.
+        if (entry.status === "unread") {
+            await this.store.updateEntry(entry, { status: "read" });
+        }
+
+        this.entry = entry;
+        this.listElement.hidden = true;
+        document.getElementById("offline-empty").hidden = true;
+
+        let link = this.entryElement.querySelector("[data-offline-link]");
+        link.href = entry.url;
+        link.textContent = entry.title;
+
+        let meta = this.entryElement.querySelector("[data-offline-meta]");
+        meta.textContent = "";
+        meta.appendChild(this.buildMeta(entry, "entry-website"));
+
+        // The content was sanitized by the server before being downloaded.
+        this.entryElement.querySelector("[data-offline-content]").innerHTML = entry.content;
+        this.updateActions();
+
+        this.entryElement.hidden = false;
+        window.scrollTo(0, 0);
+    }
+
+    buildMeta(entry, className) {
+        let meta = document.createElement("div");
+        meta.className = className;
+        meta.textContent = entry.feed_title + " - " + new Date(entry.published_at).toLocaleString(document.documentElement.lang);
+        return meta;
+    }
+
+    updateActions() {
+        let statusElement = this.entryElement.querySelector("a[data-offline-action=status]");
+        statusElement.textContent = this.entry.status === "read" ? this.labels.labelUnread : this.labels.labelRead;
+
+        let starElement = this.entryElement.querySelector("a[data-offline-action=star]");
+        starElement.textContent = this.entry.starred ? this.labels.labelUnstar : this.labels.labelStar;
+    }
+
+    async handleAction(action) {
+        switch (action) {
+        case "back":
+            window.location.hash = "";
+            return;
+        case "status":
+            await this.store.updateEntry(this.entry, { status: this.entry.status === "read" ? "unread" : "read" });
+            break;
+        case "star":
+            await this.store.updateEntry(this.entry, { starred: !this.entry.starred });
+            break;
+        }
+
+        this.updateActions();
+        await this.showPendingChanges();
+    }
+
+    async showPendingChanges() {
+        let count = (await this.store.changes()).length;
+        let element = document.getElementById("offline-pending");
+        element.querySelector("strong").textContent = count;
+        element.hidden = count === 0;
+    }
+}
+
+document.addEventListener("DOMContentLoaded", async () => {
+    if (!OfflineSync.isSupported()) {
+        return;
+    }
+
+    let store = await OfflineStore.open();
+    let reader = new OfflineReader(store);
+    reader.listen();
+
+    // Send the pending changes as soon as the connection comes back.
+    window.addEventListener("online", async () => {
+        await new OfflineSync(document.body).synchronize();
+        reader.showPendingChanges();
+    });
+});
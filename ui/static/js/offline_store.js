@@ -0,0 +1,83 @@
+// OfflineStore keeps the entries downloaded for offline reading and the changes made while offline in IndexedDB.
+class OfflineStore {
+    static open() {
+        return new Promise((resolve, reject) => {
+            let request = indexedDB.open("miniflux", 1);
+
+            request.onupgradeneeded = () => {
+                let db = request.result;
+                db.createObjectStore("entries", { keyPath: "id" });
+                db.createObjectStore("changes", { keyPath: "id", autoIncrement: true });
+                db.createObjectStore("settings");
+            };
+
+            request.onsuccess = () => resolve(new OfflineStore(request.result));
+            request.onerror = () => reject(request.error);
+        });
+    }
+
+    constructor(db) {
+        this.db = db;
+    }
+
+    // Run the callback in a transaction and resolve with the result of the returned request once committed.
+    transaction(storeName, mode, callback) {
+        return new Promise((resolve, reject) => {
+            let transaction = this.db.transaction(storeName, mode);
+            let request = callback(transaction.objectStore(storeName));
+
+            transaction.oncomplete = () => resolve(request ? request.result : undefined);
+            transaction.onerror = () => reject(transaction.error);
+            transaction.onabort = () => reject(transaction.error);
+        });
+    }
+
+    async entries() {
+        let entries = await this.transaction("entries", "readonly", (store) => store.getAll());
+        return entries.sort((a, b) => b.published_at.localeCompare(a.published_at));
+    }
+
+    entry(entryID) {
+        return this.transaction("entries", "readonly", (store) => store.get(entryID));
+    }
+
+    putEntry(entry) {
+        return this.transaction("entries", "readwrite", (store) => store.put(entry));
+    }
+
+    replaceEntries(entries) {
+        return this.transaction("entries", "readwrite", (store) => {
+            store.clear();
+            entries.forEach((entry) => store.put(entry));
+        });
+    }
+
+    changes() {
+        return this.transaction("changes", "readonly", (store) => store.getAll());
+    }
+
+    addChange(change) {
+        return this.transaction("changes", "readwrite", (store) => store.add(change));
+    }
+
+    removeChange(changeID) {
+        return this.transaction("changes", "readwrite", (store) => store.delete(changeID));
+    }
+
+    setting(key) {
+        return this.transaction("settings", "readonly", (store) => store.get(key));
+    }
+
+    setSetting(key, value) {
+        return this.transaction("settings", "readwrite", (store) => store.put(value, key));
+    }
+
+    // Update the local copy of an entry and queue the change to replay it on the server.
+    async updateEntry(entry, change) {
+        let changedAt = new Date().toISOString();
+
+        Object.assign(entry, change, { changed_at: changedAt });
+        await this.putEntry(entry);
+        await this.addChange(Object.assign({ entry_ids: [entry.id], changed_at: changedAt }, change));
+    }
+}
@@ -0,0 +1,125 @@
+// OfflineSync replays the changes made offline and downloads the latest unread entries.
+class OfflineSync {
+    // Minimum delay between two downloads of the unread entries.
+    static get DOWNLOAD_INTERVAL() {
+        return 10 * 60 * 1000;
+    }
+
+    static get CACHE_NAME() {
+        return "offline";
+    }
+
+    constructor(element) {
+        this.entriesURL = element.dataset.offlineEntriesUrl;
+        this.apiURL = element.dataset.offlineApiUrl;
+        this.pageURL = element.dataset.offlinePageUrl;
+        this.scriptURL = element.dataset.offlineScriptUrl;
+        this.csrfToken = element.dataset.csrfToken || "";
+    }
+
+    static isSupported() {
+        return "indexedDB" in window && "caches" in window;
+    }
+
+    async synchronize() {
+        if (!navigator.onLine) {
+            return;
+        }
+
+        try {
+            let store = await OfflineStore.open();
+            let pendingChanges = (await store.changes()).length;
+
+            if (!(await this.replay(store))) {
+                return;
+            }
+
+            let downloadedAt = await store.setting("downloadedAt");
+            if (this.entriesURL && (pendingChanges > 0 || !downloadedAt || Date.now() - downloadedAt > OfflineSync.DOWNLOAD_INTERVAL)) {
+                await this.download(store);
+            }
+        } catch (error) {
+            console.error("Offline synchronization failed:", error);
+        }
+    }
+
+    // Send the queued changes in order, the server ignores the ones older than its own modifications.
+    // Returns false when the queue must be kept to try again later.
+    async replay(store) {
+        let changes = await store.changes();
+
+        for (let change of changes) {
+            let body = { entry_ids: change.entry_ids, changed_at: change.changed_at };
+            if (change.status !== undefined) {
+                body.status = change.status;
+            }
+            if (change.starred !== undefined) {
+                body.starred = change.starred;
+            }
+
+            let response = await fetch(this.apiURL, {
+                method: "PUT",
+                credentials: "include",
+                headers: {
+                    "Content-Type": "application/json",
+                    "X-Csrf-Token": this.csrfToken
+                },
+                body: JSON.stringify(body)
+            });
+
+            if (response.status === 401 || response.status >= 500) {
+                return false;
+            }
+
+            // Changes rejected by the server, like removed entries, are dropped as well.
+            await store.removeChange(change.id);
+        }
+
+        return true;
+    }
+
+    async download(store) {
+        let response = await fetch(this.entriesURL, {
+            credentials: "include",
+            headers: { "Accept": "application/json" }
+        });
+
+        if (!response.ok) {
+            return;
+        }
+
+        let data = await response.json();
+        await store.replaceEntries(data.entries);
+        await store.setSetting("downloadedAt", Date.now());
+        await this.updateCache(data.images);
+    }
+
+    // Keep the offline page, its assets and the images of the downloaded entries in the cache of the service worker.
+    async updateCache(images) {
+        let cache = await caches.open(OfflineSync.CACHE_NAME);
+        let stylesheet = document.querySelector("link[rel=stylesheet]");
+        let assets = [this.pageURL, this.scriptURL];
+        if (stylesheet) {
+            assets.push(stylesheet.href);
+        }
+
+        await cache.addAll(assets.filter((url) => url));
+
+        let imageURLs = new Set(images.map((url) => new URL(url, window.location.href).href));
+        for (let request of await cache.keys()) {
+            if (request.url.includes("/proxy/") && !imageURLs.has(request.url)) {
+                await cache.delete(request);
+            }
+        }
+
+        for (let url of imageURLs) {
+            if (!(await cache.match(url))) {
+                try {
+                    await cache.add(url);
+                } catch (error) {
+                    // An image that cannot be downloaded must not prevent caching the others.
+                }
+            }
+        }
+    }
+}
@@ -0,0 +1,79 @@
+// Saves how far the entry has been read, and restores the scroll position when the entry is opened again.
+class ReadingProgress {
+    constructor(element) {
+        this.element = element;
+        this.url = element.dataset.readingProgressUrl;
+        this.markReadAtEnd = element.dataset.markReadAtEnd === "true";
+        this.progress = parseInt(element.dataset.readingProgress, 10) || 0;
+        this.scrollPosition = parseInt(element.dataset.scrollPosition, 10) || 0;
+        this.savedProgress = this.progress;
+        this.savedScrollPosition = this.scrollPosition;
+        this.timer = null;
+    }
+
+    start() {
+        if (this.progress > 0 && this.progress < 100 && this.scrollPosition > 0) {
+            window.scrollTo(0, this.scrollPosition);
+        }
+
+        window.addEventListener("scroll", () => this.onScroll(), {passive: true});
+        document.addEventListener("visibilitychange", () => {
+            if (document.visibilityState === "hidden") {
+                this.save();
+            }
+        });
+
+        // Short entries are read as soon as they are displayed.
+        this.onScroll();
+    }
+
+    onScroll() {
+        let progress = this.computeProgress();
+        if (progress > this.progress) {
+            this.progress = progress;
+        }
+
+        this.scrollPosition = Math.round(window.scrollY);
+
+        if (this.timer !== null) {
+            window.clearTimeout(this.timer);
+        }
+
+        this.timer = window.setTimeout(() => this.save(), 1000);
+    }
+
+    computeProgress() {
+        let content = this.element.querySelector(".entry-content") || this.element;
+        let top = content.getBoundingClientRect().top + window.scrollY;
+        let height = content.offsetHeight;
+        if (height === 0) {
+            return 0;
+        }
+
+        let visibleBottom = window.scrollY + window.innerHeight - top;
+        return Math.max(0, Math.min(100, Math.round(visibleBottom * 100 / height)));
+    }
+
+    save() {
+        this.timer = null;
+        if (this.progress === this.savedProgress && this.scrollPosition === this.savedScrollPosition) {
+            return;
+        }
+
+        let reachedEnd = this.progress === 100 && this.savedProgress < 100;
+        this.savedProgress = this.progress;
+        this.savedScrollPosition = this.scrollPosition;
+
+        let request = new RequestBuilder(this.url);
+        request.withHttpMethod("PUT");
+        request.withBody({reading_progress: this.progress, scroll_position: this.scrollPosition});
+        request.execute();
+
+        if (reachedEnd && this.markReadAtEnd) {
+            let link = this.element.querySelector("a[data-toggle-status]");
+            if (link && link.dataset.value === "unread") {
+                toggleEntryStatus(this.element, false);
+            }
+        }
+    }
+}
@@ -1,7 +1,7 @@
 
 // Incrementing OFFLINE_VERSION will kick off the install event and force
 // previously cached resources to be updated from the network.
-const OFFLINE_VERSION = 1;
+const OFFLINE_VERSION = 2;
 const CACHE_NAME = "offline";
 
 self.addEventListener("install", (event) => {
@@ -22,7 +22,7 @@ self.addEventListener("install", (event) => {
 
 self.addEventListener("fetch", (event) => {
     // We proxify requests through fetch() only if we are offline because it's slower.
-    if (navigator.onLine === false && event.request.mode === "navigate") {
+    if (navigator.onLine === false && event.request.method === "GET") {
         event.respondWith(
             (async () => {
                 try {
@@ -35,8 +35,16 @@ self.addEventListener("fetch", (event) => {
                     // If fetch() returns a valid HTTP response with a response code in
                     // the 4xx or 5xx range, the catch() will NOT be called.
                     const cache = await caches.open(CACHE_NAME);
-                    const cachedResponse = await cache.match(OFFLINE_URL);
-                    return cachedResponse;
+
+                    // Pages are replaced by the offline reader, the other resources like
+                    // the stylesheet, scripts and images of offline entries come from the
+                    // cache filled by the web app.
+                    if (event.request.mode === "navigate") {
+                        return await cache.match(OFFLINE_URL);
+                    }
+
+                    const cachedResponse = await cache.match(event.request);
+                    return cachedResponse || Response.error();
                 }
             })()
         );
@@ -118,20 +118,30 @@ func GenerateJavascriptBundles() error {
 			"js/keyboard_handler.js",
 			"js/request_builder.js",
 			"js/modal_handler.js",
+			"js/reading_progress.js",
+			"js/offline_store.js",
+			"js/offline_sync.js",
 			"js/app.js",
 			"js/bootstrap.js",
 		},
+		"offline": {
+			"js/offline_store.js",
+			"js/offline_sync.js",
+			"js/offline.js",
+		},
 		"service-worker": {
 			"js/service_worker.js",
 		},
 	}
 
 	var prefixes = map[string]string{
-		"app": "(function(){'use strict';",
+		"app":     "(function(){'use strict';",
+		"offline": "(function(){'use strict';",
 	}
 
 	var suffixes = map[string]string{
-		"app": "})();",
+		"app":     "})();",
+		"offline": "})();",
 	}
 
 	JavascriptBundles = make(map[string][]byte)
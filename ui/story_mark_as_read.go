@@ -0,0 +1,30 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/json"
+)
+
+func (h *handler) markClusterAsRead(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	clusterID := request.RouteInt64Param(r, "clusterID")
+
+	if !h.store.ClusterExists(userID, clusterID) {
+		json.NotFound(w, r)
+		return
+	}
+
+	count, err := h.store.MarkClusterAsRead(userID, clusterID)
+	if err != nil {
+		json.ServerError(w, r, err)
+		return
+	}
+
+	json.OK(w, r, count)
+}
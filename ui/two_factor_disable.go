@@ -0,0 +1,61 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/locale"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+	"miniflux.app/ui/session"
+)
+
+func (h *handler) disableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	twoFactor, err := h.store.TwoFactor(userID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !twoFactor.Enabled {
+		html.Redirect(w, r, route.Path(h.router, "twoFactor"))
+		return
+	}
+
+	twoFactorForm := form.NewTwoFactorForm(r)
+	if err := twoFactorForm.Validate(); err != nil {
+		h.renderTwoFactorPage(w, r, err.Error())
+		return
+	}
+
+	valid, err := h.verifyTOTPCode(twoFactor, twoFactorForm.Code)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !valid {
+		h.renderTwoFactorPage(w, r, "error.invalid_two_factor_code")
+		return
+	}
+
+	if err := h.store.DisableTOTP(userID); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	logger.Info("[UI:DisableTwoFactor] Two-factor authentication disabled for user #%d", userID)
+	recordAuditLog(h.store, r, model.AuditActionTwoFactorDisable, userID, "", "")
+
+	sess := session.New(h.store, request.SessionID(r))
+	sess.NewFlashMessage(locale.NewPrinter(request.UserLanguage(r)).Printf("alert.two_factor_disabled"))
+	html.Redirect(w, r, route.Path(h.router, "twoFactor"))
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+	"time"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/totp"
+	"miniflux.app/ui/form"
+)
+
+func (h *handler) enableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	twoFactor, err := h.store.TwoFactor(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if twoFactor.Enabled || twoFactor.Secret == "" {
+		html.Redirect(w, r, route.Path(h.router, "twoFactor"))
+		return
+	}
+
+	twoFactorForm := form.NewTwoFactorForm(r)
+	if err := twoFactorForm.Validate(); err != nil {
+		h.renderTwoFactorPage(w, r, err.Error())
+		return
+	}
+
+	counter, valid := totp.Validate(twoFactor.Secret, twoFactorForm.Code, time.Now(), twoFactor.LastCounter)
+	if !valid {
+		h.renderTwoFactorPage(w, r, "error.invalid_two_factor_code")
+		return
+	}
+
+	recoveryCodes := model.GenerateRecoveryCodes()
+	if err := h.store.EnableTOTP(user.ID, counter, recoveryCodes); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	logger.Info("[UI:EnableTwoFactor] Two-factor authentication enabled for user #%d", user.ID)
+	recordAuditLog(h.store, r, model.AuditActionTwoFactorEnable, user.ID, "user:"+user.Username, "")
+	h.renderRecoveryCodesPage(w, r, user, recoveryCodes)
+}
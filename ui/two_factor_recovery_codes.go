@@ -0,0 +1,59 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/model"
+	"miniflux.app/ui/form"
+)
+
+func (h *handler) regenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	twoFactor, err := h.store.TwoFactor(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !twoFactor.Enabled {
+		html.Redirect(w, r, route.Path(h.router, "twoFactor"))
+		return
+	}
+
+	twoFactorForm := form.NewTwoFactorForm(r)
+	if err := twoFactorForm.Validate(); err != nil {
+		h.renderTwoFactorPage(w, r, err.Error())
+		return
+	}
+
+	valid, err := h.verifyTOTPCode(twoFactor, twoFactorForm.Code)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !valid {
+		h.renderTwoFactorPage(w, r, "error.invalid_two_factor_code")
+		return
+	}
+
+	recoveryCodes := model.GenerateRecoveryCodes()
+	if err := h.store.ReplaceRecoveryCodes(user.ID, recoveryCodes); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	h.renderRecoveryCodesPage(w, r, user, recoveryCodes)
+}
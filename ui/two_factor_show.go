@@ -0,0 +1,96 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+	"time"
+
+	"miniflux.app/config"
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/logger"
+	"miniflux.app/model"
+	"miniflux.app/qrcode"
+	"miniflux.app/totp"
+	"miniflux.app/ui/session"
+	"miniflux.app/ui/view"
+)
+
+const twoFactorIssuer = "Miniflux"
+
+func (h *handler) showTwoFactorPage(w http.ResponseWriter, r *http.Request) {
+	h.renderTwoFactorPage(w, r, "")
+}
+
+func (h *handler) renderTwoFactorPage(w http.ResponseWriter, r *http.Request, errorMessage string) {
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	twoFactor, err := h.store.TwoFactor(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if !twoFactor.Enabled {
+		// The secret is kept until the enrollment is confirmed, reloading the page must not invalidate a scanned code.
+		if twoFactor.Secret == "" {
+			twoFactor.Secret = totp.GenerateSecret()
+			if err := h.store.SetTOTPSecret(user.ID, twoFactor.Secret); err != nil {
+				html.ServerError(w, r, err)
+				return
+			}
+		}
+
+		keyURI := totp.KeyURI(twoFactorIssuer, user.Username, twoFactor.Secret)
+		if code, err := qrcode.Encode(keyURI); err != nil {
+			logger.Error("[UI:TwoFactor] %v", err)
+		} else {
+			view.Set("qrCode", code.SVG())
+		}
+		view.Set("keyURI", keyURI)
+	} else {
+		view.Set("countRecoveryCodes", h.store.CountUnusedRecoveryCodes(user.ID))
+	}
+
+	view.Set("twoFactor", twoFactor)
+	view.Set("enrollmentRequired", twoFactor.IsEnrollmentRequired(config.Opts.IsTwoFactorRequired()))
+	view.Set("errorMessage", errorMessage)
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	html.OK(w, r, view.Render("two_factor"))
+}
+
+func (h *handler) renderRecoveryCodesPage(w http.ResponseWriter, r *http.Request, user *model.User, recoveryCodes []string) {
+	sess := session.New(h.store, request.SessionID(r))
+	view := view.New(h.tpl, r, sess)
+	view.Set("recoveryCodes", recoveryCodes)
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	html.OK(w, r, view.Render("recovery_codes"))
+}
+
+// verifyTOTPCode checks a one-time code and records its counter so it cannot be used again.
+func (h *handler) verifyTOTPCode(twoFactor *model.TwoFactor, code string) (bool, error) {
+	counter, valid := totp.Validate(twoFactor.Secret, code, time.Now(), twoFactor.LastCounter)
+	if !valid {
+		return false, nil
+	}
+
+	return h.store.UseTOTPCounter(twoFactor.UserID, counter)
+}
@@ -24,11 +24,12 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 		logger.Fatal(`Unable to parse templates: %v`, err)
 	}
 
-	handler := &handler{router, store, templateEngine, pool}
+	handler := &handler{router, store, templateEngine, pool, newMediaCache()}
 
 	uiRouter := router.NewRoute().Subrouter()
 	uiRouter.Use(middleware.handleUserSession)
 	uiRouter.Use(middleware.handleAppSession)
+	uiRouter.Use(middleware.handlePermissions)
 	uiRouter.StrictSlash(true)
 
 	// Static assets.
@@ -46,6 +47,9 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 
 	// Unread page.
 	uiRouter.HandleFunc("/mark-all-as-read", handler.markAllAsRead).Name("markAllAsRead").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/stories", handler.showStoriesPage).Name("stories").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/top", handler.showTopPage).Name("top").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/story/{clusterID}/mark-all-as-read", handler.markClusterAsRead).Name("markClusterAsRead").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/unread", handler.showUnreadPage).Name("unread").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/unread/entry/{entryID}", handler.showUnreadEntryPage).Name("unreadEntry").Methods(http.MethodGet)
 
@@ -57,14 +61,24 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	// Bookmark pages.
 	uiRouter.HandleFunc("/starred", handler.showStarredPage).Name("starred").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/starred/entry/{entryID}", handler.showStarredEntryPage).Name("starredEntry").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/in-progress", handler.showInProgressPage).Name("inProgress").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/in-progress/entry/{entryID}", handler.showInProgressEntryPage).Name("inProgressEntry").Methods(http.MethodGet)
 
 	// Search pages.
 	uiRouter.HandleFunc("/search", handler.showSearchEntriesPage).Name("searchEntries").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/search/entry/{entryID}", handler.showSearchEntryPage).Name("searchEntry").Methods(http.MethodGet)
 
+	// Author pages.
+	uiRouter.HandleFunc("/author", handler.showAuthorEntriesPage).Name("authorEntries").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/author/entry/{entryID}", handler.showAuthorEntryPage).Name("authorEntry").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/author/remove", handler.removeAuthorPreference).Name("removeAuthorPreference").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/author/{status:followed|muted}", handler.updateAuthorStatus).Name("updateAuthorStatus").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/authors", handler.showAuthorsPage).Name("authors").Methods(http.MethodGet)
+
 	// Feed listing pages.
 	uiRouter.HandleFunc("/feeds", handler.showFeedsPage).Name("feeds").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/feeds/refresh", handler.refreshAllFeeds).Name("refreshAllFeeds").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/feeds/batch", handler.updateFeedsBatch).Name("updateFeedsBatch").Methods(http.MethodPost)
 
 	// Individual feed pages.
 	uiRouter.HandleFunc("/feed/{feedID}/refresh", handler.refreshFeed).Name("refreshFeed").Methods(http.MethodGet)
@@ -94,6 +108,7 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 
 	// Entry pages.
 	uiRouter.HandleFunc("/entry/status", handler.updateEntriesStatus).Name("updateEntriesStatus").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/entry/batch", handler.updateEntriesBatch).Name("updateEntriesBatch").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/entry/save/{entryID}", handler.saveEntry).Name("saveEntry").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/entry/download/{entryID}", handler.fetchContent).Name("fetchContent").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/proxy/{encodedDigest}/{encodedURL}", handler.imageProxy).Name("proxy").Methods(http.MethodGet)
@@ -105,6 +120,25 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/share/{shareCode}", handler.sharedEntry).Name("sharedEntry").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/shares", handler.sharedEntries).Name("sharedEntries").Methods(http.MethodGet)
 
+	// Published feed pages.
+	uiRouter.HandleFunc("/published-feeds", handler.showPublishedFeedsPage).Name("publishedFeeds").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/published-feeds", handler.savePublishedFeed).Name("savePublishedFeed").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/published-feed/{publishedFeedID}/remove", handler.removePublishedFeed).Name("removePublishedFeed").Methods(http.MethodPost)
+
+	// EPUB export pages.
+	uiRouter.HandleFunc("/epub-exports", handler.showEPUBExportsPage).Name("epubExports").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/epub-exports", handler.saveEPUBExport).Name("saveEPUBExport").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/epub-export/{epubExportID}/download", handler.downloadEPUBExport).Name("downloadEPUBExport").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/epub-export/{epubExportID}/remove", handler.removeEPUBExport).Name("removeEPUBExport").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/export/epub", handler.exportEPUB).Name("exportEPUB").Methods(http.MethodGet)
+
+	// Email digest pages.
+	uiRouter.HandleFunc("/digest", handler.showEmailDigestPage).Name("emailDigest").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/digest", handler.updateEmailDigest).Name("updateEmailDigest").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/digest/preview", handler.previewEmailDigest).Name("previewEmailDigest").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/digest/unsubscribe/{token}", handler.showEmailDigestUnsubscribePage).Name("emailDigestUnsubscribe").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/digest/unsubscribe/{token}", handler.unsubscribeEmailDigest).Name("unsubscribeEmailDigest").Methods(http.MethodPost)
+
 	// User pages.
 	uiRouter.HandleFunc("/users", handler.showUsersPage).Name("users").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/user/create", handler.showCreateUserPage).Name("createUser").Methods(http.MethodGet)
@@ -112,6 +146,14 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/users/{userID}/edit", handler.showEditUserPage).Name("editUser").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/users/{userID}/update", handler.updateUser).Name("updateUser").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/users/{userID}/remove", handler.removeUser).Name("removeUser").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/users/{userID}/approve", handler.approveUser).Name("approveUser").Methods(http.MethodPost)
+
+	// Invitations.
+	uiRouter.HandleFunc("/invitations", handler.showInvitationsPage).Name("invitations").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/invitations", handler.saveInvitation).Name("saveInvitation").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/invitations/{invitationID}/remove", handler.removeInvitation).Name("removeInvitation").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/invitation/{token}", handler.showInvitationPage).Name("showInvitation").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/invitation/{token}", handler.acceptInvitation).Name("acceptInvitation").Methods(http.MethodPost)
 
 	// Settings pages.
 	uiRouter.HandleFunc("/settings", handler.showSettingsPage).Name("settings").Methods(http.MethodGet)
@@ -128,6 +170,15 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/credentials/create", handler.showCreateCredentialPage).Name("createCredential").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/credentials/save", handler.saveCredential).Name("saveCredential").Methods(http.MethodPost)
 
+	// Two-factor authentication pages.
+	uiRouter.HandleFunc("/two-factor", handler.showTwoFactorPage).Name("twoFactor").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/two-factor/enable", handler.enableTwoFactor).Name("enableTwoFactor").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/two-factor/disable", handler.disableTwoFactor).Name("disableTwoFactor").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/two-factor/recovery-codes", handler.regenerateRecoveryCodes).Name("regenerateRecoveryCodes").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/app-passwords", handler.showAppPasswordsPage).Name("appPasswords").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/app-passwords", handler.saveAppPassword).Name("saveAppPassword").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/app-passwords/{appPasswordID}/remove", handler.removeAppPassword).Name("removeAppPassword").Methods(http.MethodPost)
+
 	// Session pages.
 	uiRouter.HandleFunc("/sessions", handler.showSessionsPage).Name("sessions").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/sessions/{sessionID}/remove", handler.removeSession).Name("removeSession").Methods(http.MethodPost)
@@ -143,6 +194,14 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 	uiRouter.HandleFunc("/import", handler.showImportPage).Name("import").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/upload", handler.uploadOPML).Name("uploadOPML").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/fetch", handler.fetchOPML).Name("fetchOPML").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/opml-subscriptions", handler.showOPMLSubscriptionsPage).Name("opmlSubscriptions").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/opml-subscriptions", handler.saveOPMLSubscription).Name("saveOPMLSubscription").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/opml-subscription/{subscriptionID}", handler.showOPMLSubscriptionPage).Name("opmlSubscription").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/opml-subscription/{subscriptionID}/sync", handler.syncOPMLSubscription).Name("syncOPMLSubscription").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/opml-subscription/{subscriptionID}/remove", handler.removeOPMLSubscription).Name("removeOPMLSubscription").Methods(http.MethodPost)
+
+	// Audit log.
+	uiRouter.HandleFunc("/audit-logs", handler.showAuditLogsPage).Name("auditLogs").Methods(http.MethodGet)
 
 	// OAuth2 flow.
 	uiRouter.HandleFunc("/oauth2/{provider}/unlink", handler.oauth2Unlink).Name("oauth2Unlink").Methods(http.MethodGet)
@@ -151,16 +210,32 @@ func Serve(router *mux.Router, store *storage.Storage, pool *worker.Pool) {
 
 	// Offline page
 	uiRouter.HandleFunc("/offline", handler.showOfflinePage).Name("offline").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/offline/entries", handler.showOfflineEntries).Name("offlineEntries").Methods(http.MethodGet)
 
 	// Authentication pages.
 	uiRouter.HandleFunc("/login", handler.checkLogin).Name("checkLogin").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/login/credential", handler.showLoginChallengePage).Name("initChallenge").Methods(http.MethodGet)
 	uiRouter.HandleFunc("/login/credential", handler.showLoginChallengePage).Name("createChallenge").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/login/credential/verify", handler.verifyChallenge).Name("verifyChallenge").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/login/two-factor", handler.showLoginTwoFactorPage).Name("loginTwoFactor").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/login/two-factor", handler.checkLoginTwoFactor).Name("checkLoginTwoFactor").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/register", handler.showRegistrationPage).Name("registration").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/register", handler.saveRegistration).Name("saveRegistration").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/password/forgot", handler.showForgotPasswordPage).Name("forgotPassword").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/password/forgot", handler.sendPasswordResetLink).Name("sendPasswordResetLink").Methods(http.MethodPost)
+	uiRouter.HandleFunc("/password/reset/{token}", handler.showResetPasswordPage).Name("showResetPassword").Methods(http.MethodGet)
+	uiRouter.HandleFunc("/password/reset/{token}", handler.resetPassword).Name("resetPassword").Methods(http.MethodPost)
 	uiRouter.HandleFunc("/logout", handler.logout).Name("logout").Methods(http.MethodGet)
 
 	uiRouter.Handle("/", middleware.handleAuthProxy(http.HandlerFunc(handler.showLoginPage))).Name("login").Methods(http.MethodGet)
 
+	// The identity providers call this endpoint directly, it has neither user session nor CSRF token.
+	router.HandleFunc("/oauth2/{provider}/backchannel-logout", handler.oauth2BackChannelLogout).Name("oauth2BackChannelLogout").Methods(http.MethodPost)
+
+	// Feed readers fetch published feeds without session, the token in the URL is the credential.
+	router.HandleFunc("/published/{token}/atom", handler.showPublishedFeedAtom).Name("publishedFeedAtom").Methods(http.MethodGet)
+	router.HandleFunc("/published/{token}/json", handler.showPublishedFeedJSON).Name("publishedFeedJSON").Methods(http.MethodGet)
+
 	router.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/plain")
 		w.Write([]byte("User-agent: *\nDisallow: /"))
@@ -0,0 +1,47 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/http/request"
+	"miniflux.app/http/response/html"
+	"miniflux.app/http/route"
+	"miniflux.app/model"
+)
+
+func (h *handler) approveUser(w http.ResponseWriter, r *http.Request) {
+	loggedUser, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	selectedUser, err := h.store.UserByID(request.RouteInt64Param(r, "userID"))
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if selectedUser == nil {
+		html.NotFound(w, r)
+		return
+	}
+
+	if !loggedUser.CanManageUser(selectedUser) {
+		html.Forbidden(w, r)
+		return
+	}
+
+	if err := h.store.SetUserStatus(selectedUser.ID, model.UserStatusActive); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	recordAuditLog(h.store, r, model.AuditActionUserUpdate, loggedUser.ID, "user:"+selectedUser.Username, "status="+model.UserStatusActive)
+
+	html.Redirect(w, r, route.Path(h.router, "users"))
+}
@@ -9,6 +9,7 @@ import (
 
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/html"
+	"miniflux.app/model"
 	"miniflux.app/ui/form"
 	"miniflux.app/ui/session"
 	"miniflux.app/ui/view"
@@ -24,12 +25,20 @@ func (h *handler) showCreateUserPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !user.IsAdmin {
+	if !user.CanManageUsers() {
 		html.Forbidden(w, r)
 		return
 	}
 
-	view.Set("form", &form.UserForm{})
+	categories, err := h.store.Categories(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	view.Set("form", &form.UserForm{Role: model.RoleUser})
+	view.Set("roles", model.AssignableRoles(user.Role))
+	view.Set("categories", categories)
 	view.Set("menu", "settings")
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
@@ -9,6 +9,7 @@ import (
 
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/html"
+	"miniflux.app/model"
 	"miniflux.app/ui/form"
 	"miniflux.app/ui/session"
 	"miniflux.app/ui/view"
@@ -25,7 +26,7 @@ func (h *handler) showEditUserPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !user.IsAdmin {
+	if !user.CanManageUsers() {
 		html.Forbidden(w, r)
 		return
 	}
@@ -42,13 +43,47 @@ func (h *handler) showEditUserPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !model.CanAssignRole(user.Role, selectedUser.Role) {
+		html.Forbidden(w, r)
+		return
+	}
+
+	categories, err := h.store.Categories(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	guestCategoryIDs, err := h.store.GuestCategoryIDs(selectedUser.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	twoFactor, err := h.store.TwoFactor(selectedUser.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
 	userForm := &form.UserForm{
-		Username: selectedUser.Username,
-		IsAdmin:  selectedUser.IsAdmin,
+		Username:          selectedUser.Username,
+		Role:              selectedUser.Role,
+		Status:            selectedUser.Status,
+		Email:             selectedUser.Email,
+		GuestCategoryIDs:  guestCategoryIDs,
+		TwoFactorRequired: twoFactor.Required,
+
+		RetentionMaxDays:    selectedUser.RetentionMaxDays,
+		RetentionMaxEntries: selectedUser.RetentionMaxEntries,
 	}
 
 	view.Set("form", userForm)
+	view.Set("roles", model.AssignableRoles(user.Role))
+	view.Set("statuses", model.UserStatuses())
+	view.Set("categories", categories)
 	view.Set("selected_user", selectedUser)
+	view.Set("twoFactor", twoFactor)
 	view.Set("menu", "settings")
 	view.Set("user", user)
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
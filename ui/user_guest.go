@@ -0,0 +1,22 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package ui // import "miniflux.app/ui"
+
+import (
+	"miniflux.app/logger"
+	"miniflux.app/model"
+)
+
+// shareGuestCategories replaces the categories of the owner shared with a guest and subscribes the guest to their feeds.
+func (h *handler) shareGuestCategories(guest, owner *model.User, categoryIDs []int64) {
+	if err := h.store.SetGuestCategories(guest.ID, owner.ID, categoryIDs); err != nil {
+		logger.Error("[UI:GuestCategories] %v", err)
+		return
+	}
+
+	if err := h.store.SyncGuestFeeds(); err != nil {
+		logger.Error("[UI:GuestCategories] %v", err)
+	}
+}
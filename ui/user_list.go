@@ -23,7 +23,7 @@ func (h *handler) showUsersPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !user.IsAdmin {
+	if !user.CanManageUsers() {
 		html.Forbidden(w, r)
 		return
 	}
@@ -11,6 +11,7 @@ import (
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/html"
 	"miniflux.app/http/route"
+	"miniflux.app/model"
 )
 
 func (h *handler) removeUser(w http.ResponseWriter, r *http.Request) {
@@ -20,7 +21,7 @@ func (h *handler) removeUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !loggedUser.IsAdmin {
+	if !loggedUser.CanManageUsers() {
 		html.Forbidden(w, r)
 		return
 	}
@@ -37,6 +38,11 @@ func (h *handler) removeUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !model.CanAssignRole(loggedUser.Role, selectedUser.Role) {
+		html.Forbidden(w, r)
+		return
+	}
+
 	if selectedUser.ID == loggedUser.ID {
 		html.BadRequest(w, r, errors.New("You cannot remove yourself"))
 		return
@@ -47,5 +53,7 @@ func (h *handler) removeUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	recordAuditLog(h.store, r, model.AuditActionUserRemove, loggedUser.ID, "user:"+selectedUser.Username, "")
+
 	html.Redirect(w, r, route.Path(h.router, "users"))
 }
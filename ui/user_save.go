@@ -25,12 +25,22 @@ func (h *handler) saveUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !user.IsAdmin {
+	if !user.CanManageUsers() {
 		html.Forbidden(w, r)
 		return
 	}
 
 	userForm := form.NewUserForm(r)
+	if !model.CanAssignRole(user.Role, userForm.Role) {
+		html.Forbidden(w, r)
+		return
+	}
+
+	categories, err := h.store.Categories(user.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
 
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
@@ -39,6 +49,8 @@ func (h *handler) saveUser(w http.ResponseWriter, r *http.Request) {
 	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
 	view.Set("form", userForm)
+	view.Set("roles", model.AssignableRoles(user.Role))
+	view.Set("categories", categories)
 
 	if err := userForm.ValidateCreation(); err != nil {
 		view.Set("errorMessage", err.Error())
@@ -55,7 +67,8 @@ func (h *handler) saveUser(w http.ResponseWriter, r *http.Request) {
 	userCreationRequest := &model.UserCreationRequest{
 		Username: userForm.Username,
 		Password: userForm.Password,
-		IsAdmin:  userForm.IsAdmin,
+		Role:     userForm.Role,
+		Email:    userForm.Email,
 	}
 
 	if validationErr := validator.ValidateUserCreationWithPassword(h.store, userCreationRequest); validationErr != nil {
@@ -64,12 +77,26 @@ func (h *handler) saveUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if _, err := h.store.CreateUser(userCreationRequest); err != nil {
+	newUser, err := h.store.CreateUser(userCreationRequest)
+	if err != nil {
 		logger.Error("[UI:SaveUser] %v", err)
 		view.Set("errorMessage", "error.unable_to_create_user")
 		html.OK(w, r, view.Render("create_user"))
 		return
 	}
 
+	recordAuditLog(h.store, r, model.AuditActionUserCreate, user.ID, "user:"+newUser.Username, "role="+newUser.Role)
+
+	if userForm.TwoFactorRequired {
+		if err := h.store.SetTwoFactorRequired(newUser.ID, true); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	if newUser.IsGuest() {
+		h.shareGuestCategories(newUser, user, userForm.GuestCategoryIDs)
+	}
+
 	html.Redirect(w, r, route.Path(h.router, "users"))
 }
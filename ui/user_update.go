@@ -5,12 +5,14 @@
 package ui // import "miniflux.app/ui"
 
 import (
+	"fmt"
 	"net/http"
 
 	"miniflux.app/http/request"
 	"miniflux.app/http/response/html"
 	"miniflux.app/http/route"
 	"miniflux.app/logger"
+	"miniflux.app/model"
 	"miniflux.app/ui/form"
 	"miniflux.app/ui/session"
 	"miniflux.app/ui/view"
@@ -23,7 +25,7 @@ func (h *handler) updateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !loggedUser.IsAdmin {
+	if !loggedUser.CanManageUsers() {
 		html.Forbidden(w, r)
 		return
 	}
@@ -41,6 +43,22 @@ func (h *handler) updateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	userForm := form.NewUserForm(r)
+	if !model.CanAssignRole(loggedUser.Role, selectedUser.Role) || !model.CanAssignRole(loggedUser.Role, userForm.Role) {
+		html.Forbidden(w, r)
+		return
+	}
+
+	categories, err := h.store.Categories(loggedUser.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	twoFactor, err := h.store.TwoFactor(selectedUser.ID)
+	if err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
 
 	sess := session.New(h.store, request.SessionID(r))
 	view := view.New(h.tpl, r, sess)
@@ -49,7 +67,11 @@ func (h *handler) updateUser(w http.ResponseWriter, r *http.Request) {
 	view.Set("countUnread", h.store.CountUnreadEntries(loggedUser.ID))
 	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(loggedUser.ID))
 	view.Set("selected_user", selectedUser)
+	view.Set("twoFactor", twoFactor)
 	view.Set("form", userForm)
+	view.Set("roles", model.AssignableRoles(loggedUser.Role))
+	view.Set("statuses", model.UserStatuses())
+	view.Set("categories", categories)
 
 	if err := userForm.ValidateModification(); err != nil {
 		view.Set("errorMessage", err.Error())
@@ -63,6 +85,13 @@ func (h *handler) updateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if userForm.Email != "" && h.store.AnotherUserWithEmailExists(selectedUser.ID, userForm.Email) {
+		view.Set("errorMessage", "error.email_already_used")
+		html.OK(w, r, view.Render("edit_user"))
+		return
+	}
+
+	previousStatus := selectedUser.Status
 	userForm.Merge(selectedUser)
 	if err := h.store.UpdateUser(selectedUser); err != nil {
 		logger.Error("[UI:UpdateUser] %v", err)
@@ -71,5 +100,28 @@ func (h *handler) updateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if selectedUser.Status != previousStatus {
+		if err := h.store.SetUserStatus(selectedUser.ID, selectedUser.Status); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	if err := h.store.SetTwoFactorRequired(selectedUser.ID, userForm.TwoFactorRequired); err != nil {
+		html.ServerError(w, r, err)
+		return
+	}
+
+	if userForm.ResetTwoFactor && twoFactor.Enabled {
+		logger.Info("[UI:UpdateUser] Two-factor authentication of user #%d reset by user #%d", selectedUser.ID, loggedUser.ID)
+		if err := h.store.DisableTOTP(selectedUser.ID); err != nil {
+			html.ServerError(w, r, err)
+			return
+		}
+	}
+
+	h.shareGuestCategories(selectedUser, loggedUser, userForm.GuestCategoryIDs)
+	recordAuditLog(h.store, r, model.AuditActionUserUpdate, loggedUser.ID, "user:"+selectedUser.Username, fmt.Sprintf("role=%s status=%s", selectedUser.Role, selectedUser.Status))
+
 	html.Redirect(w, r, route.Path(h.router, "users"))
 }
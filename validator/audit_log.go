@@ -0,0 +1,25 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package validator // import "miniflux.app/validator"
+
+import (
+	"fmt"
+	"strings"
+
+	"miniflux.app/model"
+)
+
+// ValidateAuditLogFilter makes sure the audit log filter is valid.
+func ValidateAuditLogFilter(filter *model.AuditLogFilter) error {
+	if filter.Action != "" && !model.IsValidAuditAction(filter.Action) {
+		return fmt.Errorf(`Invalid audit log action, valid actions are: "%s"`, strings.Join(model.AuditActions(), `", "`))
+	}
+
+	if filter.Source != "" && !model.IsValidAuditSource(filter.Source) {
+		return fmt.Errorf(`Invalid audit log source, valid sources are: "%s"`, strings.Join(model.AuditSources(), `", "`))
+	}
+
+	return ValidateRange(filter.Offset, filter.Limit)
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package validator // import "miniflux.app/validator"
+
+import (
+	"miniflux.app/model"
+	"miniflux.app/storage"
+)
+
+// ValidateFeedBatchRequest validates an action applied to several feeds, all of them must belong to the user.
+func ValidateFeedBatchRequest(store *storage.Storage, userID int64, request *model.FeedBatchRequest) *ValidationError {
+	if validationErr := validateFeedBatchAction(request); validationErr != nil {
+		return validationErr
+	}
+
+	if request.Action == model.FeedBatchActionMove && !store.CategoryIDExists(userID, request.CategoryID) {
+		return NewValidationError("error.feed_category_not_found")
+	}
+
+	if !store.FeedIDsExist(userID, request.FeedIDs) {
+		return NewValidationError("error.batch_feed_not_found")
+	}
+
+	return nil
+}
+
+// ValidateEntryBatchRequest validates an action applied to several entries.
+func ValidateEntryBatchRequest(request *model.EntryBatchRequest) *ValidationError {
+	if validationErr := validateBatchSelection(len(request.EntryIDs)); validationErr != nil {
+		return validationErr
+	}
+
+	for _, action := range model.EntryBatchActions() {
+		if request.Action == action {
+			return nil
+		}
+	}
+
+	return NewValidationError("error.batch_invalid_action")
+}
+
+func validateFeedBatchAction(request *model.FeedBatchRequest) *ValidationError {
+	if validationErr := validateBatchSelection(len(request.FeedIDs)); validationErr != nil {
+		return validationErr
+	}
+
+	switch request.Action {
+	case model.FeedBatchActionEnable, model.FeedBatchActionDisable, model.FeedBatchActionRemove,
+		model.FeedBatchActionRefresh, model.FeedBatchActionSetUserAgent:
+	case model.FeedBatchActionMove:
+		if request.CategoryID <= 0 {
+			return NewValidationError("error.feed_category_not_found")
+		}
+	case model.FeedBatchActionApplyRules:
+		if request.ScraperRules == nil && request.RewriteRules == nil && request.BlocklistRules == nil && request.KeeplistRules == nil {
+			return NewValidationError("error.batch_rules_required")
+		}
+
+		if request.BlocklistRules != nil && !IsValidRegex(*request.BlocklistRules) {
+			return NewValidationError("error.feed_invalid_blocklist_rule")
+		}
+
+		if request.KeeplistRules != nil && !IsValidRegex(*request.KeeplistRules) {
+			return NewValidationError("error.feed_invalid_keeplist_rule")
+		}
+	default:
+		return NewValidationError("error.batch_invalid_action")
+	}
+
+	return nil
+}
+
+func validateBatchSelection(count int) *ValidationError {
+	if count == 0 {
+		return NewValidationError("error.batch_empty_selection")
+	}
+
+	if count > model.MaxBatchSize {
+		return NewValidationError("error.batch_too_many_items")
+	}
+
+	return nil
+}
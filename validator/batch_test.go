@@ -0,0 +1,59 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package validator // import "miniflux.app/validator"
+
+import (
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestValidateFeedBatchAction(t *testing.T) {
+	validRule := `(?i)sponsored`
+	invalidRule := `[a-z`
+
+	scenarios := []struct {
+		request        model.FeedBatchRequest
+		translationKey string
+	}{
+		{model.FeedBatchRequest{FeedIDs: []int64{1, 2}, Action: model.FeedBatchActionDisable}, ""},
+		{model.FeedBatchRequest{FeedIDs: []int64{1}, Action: model.FeedBatchActionSetUserAgent}, ""},
+		{model.FeedBatchRequest{FeedIDs: []int64{1}, Action: model.FeedBatchActionMove, CategoryID: 3}, ""},
+		{model.FeedBatchRequest{FeedIDs: []int64{1}, Action: model.FeedBatchActionApplyRules, BlocklistRules: &validRule}, ""},
+		{model.FeedBatchRequest{Action: model.FeedBatchActionRefresh}, "error.batch_empty_selection"},
+		{model.FeedBatchRequest{FeedIDs: make([]int64, model.MaxBatchSize+1), Action: model.FeedBatchActionRefresh}, "error.batch_too_many_items"},
+		{model.FeedBatchRequest{FeedIDs: []int64{1}, Action: "archive"}, "error.batch_invalid_action"},
+		{model.FeedBatchRequest{FeedIDs: []int64{1}, Action: model.FeedBatchActionMove}, "error.feed_category_not_found"},
+		{model.FeedBatchRequest{FeedIDs: []int64{1}, Action: model.FeedBatchActionApplyRules}, "error.batch_rules_required"},
+		{model.FeedBatchRequest{FeedIDs: []int64{1}, Action: model.FeedBatchActionApplyRules, KeeplistRules: &invalidRule}, "error.feed_invalid_keeplist_rule"},
+	}
+
+	for _, scenario := range scenarios {
+		validationErr := validateFeedBatchAction(&scenario.request)
+		if scenario.translationKey == "" && validationErr != nil {
+			t.Errorf(`The action %q should be valid, got %q`, scenario.request.Action, validationErr.TranslationKey)
+		}
+
+		if scenario.translationKey != "" && (validationErr == nil || validationErr.TranslationKey != scenario.translationKey) {
+			t.Errorf(`The action %q should be rejected with %q, got %v`, scenario.request.Action, scenario.translationKey, validationErr)
+		}
+	}
+}
+
+func TestValidateEntryBatchRequest(t *testing.T) {
+	for _, action := range model.EntryBatchActions() {
+		if err := ValidateEntryBatchRequest(&model.EntryBatchRequest{EntryIDs: []int64{1}, Action: action}); err != nil {
+			t.Errorf(`The action %q should be valid`, action)
+		}
+	}
+
+	if err := ValidateEntryBatchRequest(&model.EntryBatchRequest{Action: model.EntryBatchActionStar}); err == nil || err.TranslationKey != "error.batch_empty_selection" {
+		t.Error(`An empty selection should be rejected`)
+	}
+
+	if err := ValidateEntryBatchRequest(&model.EntryBatchRequest{EntryIDs: []int64{1}, Action: "remove"}); err == nil || err.TranslationKey != "error.batch_invalid_action" {
+		t.Error(`An unknown action should be rejected`)
+	}
+}
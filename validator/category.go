@@ -19,7 +19,7 @@ func ValidateCategoryCreation(store *storage.Storage, userID int64, request *mod
 		return NewValidationError("error.category_already_exists")
 	}
 
-	return nil
+	return validateRetentionPolicy(request.RetentionMode, request.RetentionValue)
 }
 
 // ValidateCategoryModification validates category modification.
@@ -32,5 +32,5 @@ func ValidateCategoryModification(store *storage.Storage, userID, categoryID int
 		return NewValidationError("error.category_already_exists")
 	}
 
-	return nil
+	return validateRetentionPolicy(request.RetentionMode, request.RetentionValue)
 }
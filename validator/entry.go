@@ -19,6 +19,36 @@ func ValidateEntriesStatusUpdateRequest(request *model.EntriesStatusUpdateReques
 	return ValidateEntryStatus(request.Status)
 }
 
+// ValidateEntriesUpdateRequest validates a status or bookmark update for a list of entries sent to the API.
+func ValidateEntriesUpdateRequest(request *model.EntriesStatusUpdateRequest) error {
+	if len(request.EntryIDs) == 0 {
+		return fmt.Errorf(`The list of entries cannot be empty`)
+	}
+
+	if request.Status == "" && request.Starred == nil {
+		return fmt.Errorf(`The status or the starred flag must be updated`)
+	}
+
+	if request.Status != "" {
+		return ValidateEntryStatus(request.Status)
+	}
+
+	return nil
+}
+
+// ValidateEntryReadingProgressRequest makes sure the percentage and the scroll position are in range.
+func ValidateEntryReadingProgressRequest(request *model.EntryReadingProgressRequest) error {
+	if request.ReadingProgress < 0 || request.ReadingProgress > 100 {
+		return fmt.Errorf(`The reading progress must be between 0 and 100`)
+	}
+
+	if request.ScrollPosition < 0 {
+		return fmt.Errorf(`The scroll position cannot be negative`)
+	}
+
+	return nil
+}
+
 // ValidateEntryStatus makes sure the entry status is valid.
 func ValidateEntryStatus(status string) error {
 	switch status {
@@ -32,9 +62,9 @@ func ValidateEntryStatus(status string) error {
 // ValidateEntryOrder makes sure the sorting order is valid.
 func ValidateEntryOrder(order string) error {
 	switch order {
-	case "id", "status", "changed_at", "published_at", "created_at", "category_title", "category_id", "title", "author":
+	case "id", "status", "changed_at", "published_at", "created_at", "category_title", "category_id", "title", "author", "score":
 		return nil
 	}
 
-	return fmt.Errorf(`Invalid entry order, valid order values are: "id", "status", "changed_at", "published_at", "created_at", "category_title", "category_id", "title", "author"`)
+	return fmt.Errorf(`Invalid entry order, valid order values are: "id", "status", "changed_at", "published_at", "created_at", "category_title", "category_id", "title", "author", "score"`)
 }
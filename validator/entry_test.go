@@ -35,6 +35,33 @@ func TestValidateEntriesStatusUpdateRequest(t *testing.T) {
 	}
 }
 
+func TestValidateEntriesUpdateRequest(t *testing.T) {
+	starred := true
+	err := ValidateEntriesUpdateRequest(&model.EntriesStatusUpdateRequest{
+		EntryIDs: []int64{int64(123)},
+		Starred:  &starred,
+	})
+	if err != nil {
+		t.Error(`A bookmark update without status should be accepted`)
+	}
+
+	err = ValidateEntriesUpdateRequest(&model.EntriesStatusUpdateRequest{
+		EntryIDs: []int64{int64(123)},
+	})
+	if err == nil {
+		t.Error(`A request without any change is not valid`)
+	}
+
+	err = ValidateEntriesUpdateRequest(&model.EntriesStatusUpdateRequest{
+		EntryIDs: []int64{int64(123)},
+		Status:   "invalid",
+		Starred:  &starred,
+	})
+	if err == nil {
+		t.Error(`Only a valid status should be accepted`)
+	}
+}
+
 func TestValidateEntryStatus(t *testing.T) {
 	for _, status := range []string{model.EntryStatusRead, model.EntryStatusUnread, model.EntryStatusRemoved} {
 		if err := ValidateEntryStatus(status); err != nil {
@@ -48,7 +75,7 @@ func TestValidateEntryStatus(t *testing.T) {
 }
 
 func TestValidateEntryOrder(t *testing.T) {
-	for _, status := range []string{"id", "status", "changed_at", "published_at", "created_at", "category_title", "category_id"} {
+	for _, status := range []string{"id", "status", "changed_at", "published_at", "created_at", "category_title", "category_id", "score"} {
 		if err := ValidateEntryOrder(status); err != nil {
 			t.Error(`A valid order should not generate any error`)
 		}
@@ -58,3 +85,21 @@ func TestValidateEntryOrder(t *testing.T) {
 		t.Error(`An invalid order should generate a error`)
 	}
 }
+
+func TestValidateEntryReadingProgressRequest(t *testing.T) {
+	scenarios := map[*model.EntryReadingProgressRequest]bool{
+		{ReadingProgress: 0, ScrollPosition: 0}:     true,
+		{ReadingProgress: 42, ScrollPosition: 1200}: true,
+		{ReadingProgress: 100, ScrollPosition: 0}:   true,
+		{ReadingProgress: -1, ScrollPosition: 0}:    false,
+		{ReadingProgress: 101, ScrollPosition: 0}:   false,
+		{ReadingProgress: 50, ScrollPosition: -10}:  false,
+	}
+
+	for request, expected := range scenarios {
+		result := ValidateEntryReadingProgressRequest(request) == nil
+		if result != expected {
+			t.Errorf(`Unexpected result for %+v, got %v instead of %v`, *request, result, expected)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package validator // import "miniflux.app/validator"
+
+import (
+	"miniflux.app/model"
+	"miniflux.app/storage"
+)
+
+// ValidateEPUBExportSelection makes sure the entries selected by an export exist.
+func ValidateEPUBExportSelection(store *storage.Storage, userID int64, export *model.EPUBExport) *ValidationError {
+	if !model.IsValidEPUBExportKind(export.Kind) {
+		return NewValidationError("error.epub_export_invalid_kind")
+	}
+
+	if export.Kind == model.EPUBExportKindCategory && (export.CategoryID == nil || !store.CategoryIDExists(userID, *export.CategoryID)) {
+		return NewValidationError("error.feed_category_not_found")
+	}
+
+	if export.Kind == model.EPUBExportKindSearch && export.SearchQuery == "" {
+		return NewValidationError("error.epub_export_search_required")
+	}
+
+	if validationErr := ValidateSearchQuery(export.SearchQuery); validationErr != nil {
+		return validationErr
+	}
+
+	return nil
+}
@@ -84,5 +84,5 @@ func ValidateFeedModification(store *storage.Storage, userID int64, request *mod
 		}
 	}
 
-	return nil
+	return validateRetentionPolicy(request.RetentionMode, request.RetentionValue)
 }
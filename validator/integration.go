@@ -0,0 +1,21 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package validator // import "miniflux.app/validator"
+
+import (
+	"fmt"
+
+	"miniflux.app/model"
+)
+
+// ValidateIntegrationDeliveryStatus makes sure the delivery status is valid.
+func ValidateIntegrationDeliveryStatus(status string) error {
+	switch status {
+	case model.DeliveryStatusSuccess, model.DeliveryStatusRetrying, model.DeliveryStatusFailed:
+		return nil
+	}
+
+	return fmt.Errorf(`Invalid delivery status, valid status values are: "%s", "%s" and "%s"`, model.DeliveryStatusSuccess, model.DeliveryStatusRetrying, model.DeliveryStatusFailed)
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package validator // import "miniflux.app/validator"
+
+import "miniflux.app/model"
+
+// validateRetentionPolicy validates the retention policy of a feed or a category, the fields not given are not changed.
+// The modes keeping a number of days or of entries require a positive value.
+func validateRetentionPolicy(mode *string, value *int) *ValidationError {
+	if mode != nil {
+		if !model.IsValidRetentionMode(*mode) {
+			return NewValidationError("error.retention_invalid_mode")
+		}
+
+		policy := model.RetentionPolicy{RetentionMode: *mode}
+		if policy.HasValue() && (value == nil || *value <= 0) {
+			return NewValidationError("error.retention_value_required")
+		}
+	}
+
+	if value != nil && *value < 0 {
+		return NewValidationError("error.retention_value_required")
+	}
+
+	return nil
+}
+
+func validateRetentionCap(value int) *ValidationError {
+	if value < 0 {
+		return NewValidationError("error.retention_invalid_cap")
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package validator // import "miniflux.app/validator"
+
+import (
+	"testing"
+
+	"miniflux.app/model"
+)
+
+func TestValidateRetentionPolicy(t *testing.T) {
+	optionalString := func(value string) *string { return &value }
+	optionalInt := func(value int) *int { return &value }
+
+	scenarios := []struct {
+		mode           *string
+		value          *int
+		translationKey string
+	}{
+		{nil, nil, ""},
+		{optionalString(model.RetentionModeDefault), nil, ""},
+		{optionalString(model.RetentionModeNever), optionalInt(0), ""},
+		{optionalString(model.RetentionModeDays), optionalInt(30), ""},
+		{optionalString(model.RetentionModeCount), optionalInt(100), ""},
+		{nil, optionalInt(10), ""},
+		{optionalString("forever"), nil, "error.retention_invalid_mode"},
+		{optionalString(model.RetentionModeDays), nil, "error.retention_value_required"},
+		{optionalString(model.RetentionModeCount), optionalInt(0), "error.retention_value_required"},
+		{nil, optionalInt(-1), "error.retention_value_required"},
+	}
+
+	for _, scenario := range scenarios {
+		validationErr := validateRetentionPolicy(scenario.mode, scenario.value)
+		if scenario.translationKey == "" && validationErr != nil {
+			t.Errorf(`The policy %v/%v should be valid, got %q`, scenario.mode, scenario.value, validationErr.TranslationKey)
+		}
+
+		if scenario.translationKey != "" && (validationErr == nil || validationErr.TranslationKey != scenario.translationKey) {
+			t.Errorf(`The policy %v/%v should be rejected with %q, got %v`, scenario.mode, scenario.value, scenario.translationKey, validationErr)
+		}
+	}
+}
+
+func TestValidateRetentionCap(t *testing.T) {
+	if err := validateRetentionCap(0); err != nil {
+		t.Error(`A cap of zero means unlimited and should be valid`)
+	}
+
+	if err := validateRetentionCap(-5); err == nil || err.TranslationKey != "error.retention_invalid_cap" {
+		t.Error(`A negative cap should be rejected`)
+	}
+}
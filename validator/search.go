@@ -0,0 +1,18 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package validator // import "miniflux.app/validator"
+
+import (
+	"miniflux.app/search"
+)
+
+// ValidateSearchQuery makes sure the operators of a search query have valid values.
+func ValidateSearchQuery(query string) *ValidationError {
+	if _, err := search.Parse(query); err != nil {
+		return NewValidationError("error.invalid_search_query")
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+// Copyright 2023 Frédéric Guillot. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package validator // import "miniflux.app/validator"
+
+import "testing"
+
+func TestValidateSearchQuery(t *testing.T) {
+	for _, query := range []string{"", "golang", `title:"go 1.22" -beta after:2024-01-01 is:starred`} {
+		if err := ValidateSearchQuery(query); err != nil {
+			t.Errorf(`The query %q should be valid`, query)
+		}
+	}
+
+	for _, query := range []string{"after:yesterday", "is:pinned", "has:video"} {
+		if err := ValidateSearchQuery(query); err == nil {
+			t.Errorf(`The query %q should be invalid`, query)
+		}
+	}
+}
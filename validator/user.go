@@ -24,6 +24,20 @@ func ValidateUserCreationWithPassword(store *storage.Storage, request *model.Use
 		return err
 	}
 
+	if err := validateRole(request.UserRole()); err != nil {
+		return err
+	}
+
+	if err := validateStatus(request.UserStatus()); err != nil {
+		return err
+	}
+
+	if request.Email != "" {
+		if err := validateEmail(store, 0, request.Email); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -43,6 +57,24 @@ func ValidateUserModification(store *storage.Storage, userID int64, changes *mod
 		}
 	}
 
+	if changes.Role != nil {
+		if err := validateRole(*changes.Role); err != nil {
+			return err
+		}
+	}
+
+	if changes.Status != nil {
+		if err := validateStatus(*changes.Status); err != nil {
+			return err
+		}
+	}
+
+	if changes.Email != nil && *changes.Email != "" {
+		if err := validateEmail(store, userID, *changes.Email); err != nil {
+			return err
+		}
+	}
+
 	if changes.Theme != nil {
 		if err := validateTheme(*changes.Theme); err != nil {
 			return err
@@ -97,6 +129,18 @@ func ValidateUserModification(store *storage.Storage, userID int64, changes *mod
 		}
 	}
 
+	if changes.RetentionMaxDays != nil {
+		if err := validateRetentionCap(*changes.RetentionMaxDays); err != nil {
+			return err
+		}
+	}
+
+	if changes.RetentionMaxEntries != nil {
+		if err := validateRetentionCap(*changes.RetentionMaxEntries); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -114,6 +158,41 @@ func validatePassword(password string) *ValidationError {
 	return nil
 }
 
+func validateRole(role string) *ValidationError {
+	if !model.IsValidRole(role) {
+		return NewValidationError("error.invalid_role")
+	}
+	return nil
+}
+
+func validateStatus(status string) *ValidationError {
+	if !IsValidUserStatus(status) {
+		return NewValidationError("error.invalid_user_status")
+	}
+	return nil
+}
+
+// IsValidUserStatus returns true if the status is a known user status.
+func IsValidUserStatus(status string) bool {
+	for _, userStatus := range model.UserStatuses() {
+		if status == userStatus {
+			return true
+		}
+	}
+	return false
+}
+
+func validateEmail(store *storage.Storage, userID int64, email string) *ValidationError {
+	if !IsValidEmail(email) {
+		return NewValidationError("error.invalid_email")
+	}
+
+	if store.AnotherUserWithEmailExists(userID, email) {
+		return NewValidationError("error.email_already_used")
+	}
+	return nil
+}
+
 func validateTheme(theme string) *ValidationError {
 	themes := model.Themes()
 	if _, found := themes[theme]; !found {
@@ -7,6 +7,7 @@ package validator // import "miniflux.app/validator"
 import (
 	"errors"
 	"fmt"
+	"net/mail"
 	"net/url"
 	"regexp"
 
@@ -65,3 +66,9 @@ func IsValidURL(absoluteURL string) bool {
 	_, err := url.ParseRequestURI(absoluteURL)
 	return err == nil
 }
+
+// IsValidEmail verifies if the provided value is a bare email address.
+func IsValidEmail(email string) bool {
+	address, err := mail.ParseAddress(email)
+	return err == nil && address.Address == email
+}
@@ -4,7 +4,11 @@
 
 package validator // import "miniflux.app/validator"
 
-import "testing"
+import (
+	"testing"
+
+	"miniflux.app/model"
+)
 
 func TestIsValidURL(t *testing.T) {
 	scenarios := map[string]bool{
@@ -60,3 +64,42 @@ func TestIsValidRegex(t *testing.T) {
 		}
 	}
 }
+
+func TestIsValidEmail(t *testing.T) {
+	scenarios := map[string]bool{
+		"bob@example.org":        true,
+		"Bob <bob@example.org>":  false,
+		"bob":                    false,
+		"":                       false,
+		"bob@example.org, alice": false,
+	}
+
+	for email, expected := range scenarios {
+		if result := IsValidEmail(email); result != expected {
+			t.Errorf(`Unexpected result for %q, got %v instead of %v`, email, result, expected)
+		}
+	}
+}
+
+func TestValidateAuditLogFilter(t *testing.T) {
+	scenarios := []struct {
+		filter model.AuditLogFilter
+		valid  bool
+	}{
+		{model.AuditLogFilter{Limit: 100}, true},
+		{model.AuditLogFilter{Action: model.AuditActionLogin, Source: model.AuditSourceAPI}, true},
+		{model.AuditLogFilter{Action: "user.explode"}, false},
+		{model.AuditLogFilter{Source: "cron"}, false},
+		{model.AuditLogFilter{Offset: -1}, false},
+	}
+
+	for _, scenario := range scenarios {
+		err := ValidateAuditLogFilter(&scenario.filter)
+		if scenario.valid && err != nil {
+			t.Errorf(`The filter %+v should be valid, got %v`, scenario.filter, err)
+		}
+		if !scenario.valid && err == nil {
+			t.Errorf(`The filter %+v should be invalid`, scenario.filter)
+		}
+	}
+}